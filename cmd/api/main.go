@@ -2,20 +2,49 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	appservice "upwork-test/internal/application/service"
 	"upwork-test/internal/application/usecase"
 	httpserver "upwork-test/internal/delivery/http"
+	"upwork-test/internal/delivery/http/middleware"
+	authrepository "upwork-test/internal/domain/auth/repository"
 	"upwork-test/internal/domain/auth/service"
+	authvalueobject "upwork-test/internal/domain/auth/valueobject"
+	marketservice "upwork-test/internal/domain/market/service"
+	marketvalueobject "upwork-test/internal/domain/market/valueobject"
 	ratelimitservice "upwork-test/internal/domain/ratelimit/service"
+	ratelimitvalueobject "upwork-test/internal/domain/ratelimit/valueobject"
 	"upwork-test/internal/infrastructure/cache"
 	"upwork-test/internal/infrastructure/config"
 	"upwork-test/internal/infrastructure/kalshi"
 	"upwork-test/internal/infrastructure/ratelimit"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// searchSyncInterval is how often the in-process search index checks Redis for new documents
+	searchSyncInterval = 10 * time.Second
+
+	// Upstream fetches for batch lookup cache misses, shared by all batches in this process
+	batchFetchRate        = 10 // requests per second
+	batchFetchConcurrency = 8  // requests in flight per batch
+
+	// revocationCacheTTL is how long this process caches token revocation lookups, and so how
+	// long a revocation made by another process can take to apply here
+	revocationCacheTTL = 5 * time.Second
+
+	// signingKeySyncInterval is how often the signing keys are reloaded and rotated when due
+	signingKeySyncInterval = time.Minute
+
+	// schemaReportInterval is how often this process's schema report is shared
+	schemaReportInterval = time.Minute
 )
 
 func main() {
@@ -28,6 +57,14 @@ func main() {
 	fmt.Printf("Starting Kalshi Aggregation API on port %s (mode: %s)\n",
 		cfg.Server.Port, cfg.Server.GinMode)
 
+	if err := cfg.ValidateSecrets(); err != nil {
+		fmt.Printf("Refusing to start: %v\n", err)
+		os.Exit(1)
+	}
+	if cfg.Server.CursorSecretGenerated {
+		fmt.Printf("Warning: CURSOR_SECRET is not set; pagination cursors are signed with a random secret and only this process accepts them until it restarts\n")
+	}
+
 	redisClient, err := cache.NewRedisClient(
 		cfg.Redis.Addr(),
 		cfg.Redis.Password,
@@ -41,28 +78,180 @@ func main() {
 
 	fmt.Printf("Connected to Redis at %s\n", cfg.Redis.Addr())
 
-	tokenService := service.NewTokenService(cfg.JWT.Secret, cfg.JWT.Expiration)
-	fmt.Printf("Token service initialized (expiration: %s)\n", cfg.JWT.Expiration.String())
+	signingAlgorithm, err := authvalueobject.NewSigningAlgorithm(cfg.JWT.SigningAlgorithm)
+	if err != nil {
+		fmt.Printf("Invalid JWT configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	tokenService := service.NewTokenService(cfg.JWT.Expiration)
+	signingKeyRepo, err := cache.NewSigningKeyRepository(redisClient, cfg.JWT.KeyEncryptionKey)
+	if err != nil {
+		fmt.Printf("Invalid JWT configuration: %v\n", err)
+		os.Exit(1)
+	}
+	if cfg.JWT.KeyEncryptionKey == nil {
+		fmt.Printf("Warning: JWT_KEY_ENCRYPTION_KEY is not set; signing keys are stored in Redis unencrypted\n")
+	}
+	if cfg.JWT.MigratePlaintextKeys {
+		signingKeyRepo.MigratePlaintextKeys()
+		fmt.Printf("Warning: JWT_KEY_ENCRYPTION_MIGRATE is set; unencrypted signing keys are accepted and encrypted. Unset it once they are\n")
+	}
+	signingKeyRotator := appservice.NewSigningKeyRotator(signingKeyRepo, tokenService, signingAlgorithm, cfg.JWT.KeyRotation)
+	if err := signingKeyRotator.Init(context.Background()); err != nil {
+		fmt.Printf("Failed to load signing keys: %v\n", err)
+		os.Exit(1)
+	}
+	refreshTokenRepo := cache.NewRefreshTokenRepository(redisClient)
+	tokenIssuer := appservice.NewTokenIssuer(tokenService, refreshTokenRepo, cfg.JWT.RefreshExpiration)
+	fmt.Printf("Token service initialized (%s, key rotation: %s, access: %s, refresh: %s)\n", signingAlgorithm, cfg.JWT.KeyRotation.String(), cfg.JWT.Expiration.String(), cfg.JWT.RefreshExpiration.String())
+	revocationChecker := service.NewRevocationChecker(cache.NewTokenRevocationRepository(redisClient), revocationCacheTTL)
 
-	rateLimitRepo := ratelimit.NewRedisRateLimiter(redisClient)
+	// Tiers are configured before clients are seeded, as every client must name a known tier
+	rateLimitTiers, err := config.LoadRateLimitTiers(cfg.RateLimit)
+	if err == nil {
+		err = ratelimitvalueobject.ConfigureTiers(rateLimitTiers)
+	}
+	if err != nil {
+		fmt.Printf("Invalid rate limit configuration: %v\n", err)
+		os.Exit(1)
+	}
+	for _, tier := range rateLimitTiers {
+		fmt.Printf("Rate limit tier %s\n", tier)
+	}
+
+	clientRepo := cache.NewClientRepository(redisClient)
+	keyHasher := service.NewKeyHasher()
+	// Before seeding, so clients seeded this boot with an empty scope list on purpose keep none.
+	// The seed file grants the default scopes to clients listed without any.
+	migrated, err := cache.MigrateClientScopes(context.Background(), redisClient)
+	if err != nil {
+		fmt.Printf("Failed to migrate API client scopes: %v\n", err)
+		os.Exit(1)
+	}
+	if migrated > 0 {
+		fmt.Printf("Granted default scopes to %d API clients registered without any\n", migrated)
+	}
+
+	if cfg.Auth.ClientsFile != "" {
+		seeded, err := seedClients(context.Background(), clientRepo, keyHasher, cfg.Auth.ClientsFile, cfg.Kalshi.APIKey)
+		if err != nil {
+			fmt.Printf("Failed to seed API clients: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Seeded %d API clients from %s\n", seeded, cfg.Auth.ClientsFile)
+	}
+
+	rateLimitAlgorithm, err := ratelimitvalueobject.NewRateLimitAlgorithm(cfg.RateLimit.Algorithm)
+	if err != nil {
+		fmt.Printf("Invalid rate limit configuration: %v\n", err)
+		os.Exit(1)
+	}
+	rateLimitRepo, err := ratelimit.NewRedisRateLimiter(redisClient, rateLimitAlgorithm)
+	if err != nil {
+		fmt.Printf("Failed to create rate limiter: %v\n", err)
+		os.Exit(1)
+	}
+	if err := rateLimitRepo.LoadScript(context.Background()); err != nil {
+		// Not fatal: the script is sent with the first request instead
+		fmt.Printf("Warning: %v\n", err)
+	}
 	rateLimiter := ratelimitservice.NewRateLimiter(rateLimitRepo)
-	fmt.Println("Rate limiter initialized")
+	fmt.Printf("Rate limiter initialized (algorithm: %s)\n", rateLimitAlgorithm)
+
+	routeCosts, err := config.LoadRouteCosts(cfg.RateLimit.RoutesFile)
+	if err != nil {
+		fmt.Printf("Invalid rate limit configuration: %v\n", err)
+		os.Exit(1)
+	}
+	routeCostRules := make([]middleware.RouteCostRule, len(routeCosts))
+	for i, route := range routeCosts {
+		routeCostRules[i] = middleware.RouteCostRule{
+			Route:     route.Route,
+			Class:     route.Class,
+			Cost:      route.Cost,
+			BatchSize: route.BatchSize,
+		}
+	}
+	rateLimitCosts, err := middleware.NewRouteCosts(routeCostRules, rateLimitTiers, rateLimitAlgorithm)
+	if err != nil {
+		fmt.Printf("Invalid rate limit configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	kalshiTransport, err := kalshi.NewFixtureTransport(
+		kalshi.FixtureMode(cfg.Kalshi.FixtureMode),
+		cfg.Kalshi.FixtureDir,
+		nil,
+	)
+	if err != nil {
+		fmt.Printf("Failed to configure Kalshi transport: %v\n", err)
+		os.Exit(1)
+	}
+	if cfg.Kalshi.FixtureMode != "" {
+		fmt.Printf("Kalshi fixtures enabled (mode: %s, dir: %s)\n", cfg.Kalshi.FixtureMode, cfg.Kalshi.FixtureDir)
+	}
 
-	kalshiClient := kalshi.NewClient(cfg.Kalshi.BaseURL, cfg.Kalshi.APIKey)
-	fmt.Println("Kalshi API client initialized")
+	kalshiClient := kalshi.NewClientWithTransport(cfg.Kalshi.BaseURL, cfg.Kalshi.APIKey, kalshiTransport)
+	schemaMonitor := kalshi.NewSchemaMonitor(cfg.Kalshi.StrictDecoding)
+	kalshiClient.SetSchemaMonitor(schemaMonitor)
+	fmt.Printf("Kalshi API client initialized (strict decoding: %t)\n", cfg.Kalshi.StrictDecoding)
 
 	marketRepo := cache.NewMarketRepository(redisClient, kalshiClient)
 	fmt.Println("Market repository initialized")
 
-	categoryRepo := cache.NewCategoryRepository(redisClient, kalshiClient, marketRepo)
+	activityRepo := cache.NewActivityRepository(redisClient)
+	categoryRepo := cache.NewCategoryRepository(redisClient, kalshiClient, marketRepo, activityRepo)
 	fmt.Println("Category repository initialized")
 
-	listMarketsUseCase := usecase.NewListMarkets(marketRepo)
-	getMarketDetailsUseCase := usecase.NewGetMarketDetails(marketRepo)
+	eventRepo := cache.NewEventRepository(redisClient, kalshiClient)
+
+	listMarketsUseCase := usecase.NewListMarkets(marketRepo, appservice.NewCursorCodec(cfg.Server.CursorSecret))
+	getMarketDetailsUseCase := usecase.NewGetMarketDetails(marketRepo, eventRepo)
 	getCategoryOverviewUseCase := usecase.NewGetCategoryOverview(categoryRepo)
+	schemaReportRepo := cache.NewSchemaReportRepository(redisClient)
+	schemaSource := appservice.DiagnosticsSource("api")
+	schemaReportPublisher := appservice.NewSchemaReportPublisher(schemaMonitor, schemaReportRepo, schemaSource, schemaReportInterval)
+	getSchemaDiagnosticsUseCase := usecase.NewGetSchemaDiagnostics(schemaMonitor, schemaReportRepo, schemaSource)
+	getMarketSettlementUseCase := usecase.NewGetMarketSettlement(marketRepo, cache.NewSettlementRepository(redisClient))
+	getStatusHistoryUseCase := usecase.NewGetStatusHistory(cache.NewStatusHistoryRepository(redisClient))
+	feeSchedule, err := marketvalueobject.NewFeeSchedule(cfg.Fees.TakerRate, cfg.Fees.MakerRate)
+	if err != nil {
+		fmt.Printf("Invalid fee configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	probabilityEstimator := marketservice.NewProbabilityEstimator()
+	getRangedGroupUseCase := usecase.NewGetRangedGroup(marketRepo, probabilityEstimator)
+	distributionAnalyzer := marketservice.NewDistributionAnalyzer(probabilityEstimator, feeSchedule)
+	getEventDistributionUseCase := usecase.NewGetEventDistribution(eventRepo, distributionAnalyzer)
+	getMarketQuoteUseCase := usecase.NewGetMarketQuote(marketRepo, marketservice.NewQuoteCalculator(feeSchedule))
+	getMoversUseCase := usecase.NewGetMovers(categoryRepo, cache.NewMoversRepository(redisClient))
+	searchIndex := marketservice.NewSearchIndex()
+	searchIndexSync := appservice.NewSearchIndexSync(cache.NewSearchRepository(redisClient), searchIndex, searchSyncInterval)
+	searchMarketsUseCase := usecase.NewSearchMarkets(searchIndex, searchIndexSync)
+
+	batchThrottle := rate.NewLimiter(rate.Limit(batchFetchRate), batchFetchConcurrency)
+	getMarketsBatchUseCase := usecase.NewGetMarketsBatch(marketRepo, batchThrottle, batchFetchConcurrency)
+	authenticateUseCase := usecase.NewAuthenticate(tokenIssuer, clientRepo, keyHasher)
+	refreshAccessTokenUseCase := usecase.NewRefreshAccessToken(tokenIssuer, refreshTokenRepo, clientRepo, revocationChecker)
+	revokeTokenUseCase := usecase.NewRevokeToken(tokenIssuer, tokenService, revocationChecker, refreshTokenRepo)
+	getJWKSUseCase := usecase.NewGetJWKS(tokenService)
+	issueClientCredentialsTokenUseCase := usecase.NewIssueClientCredentialsToken(tokenService, clientRepo, keyHasher)
+	introspectTokenUseCase := usecase.NewIntrospectToken(tokenService, revocationChecker, clientRepo, keyHasher)
+	manageClientsUseCase := usecase.NewManageClients(clientRepo, cache.NewAuditRepository(redisClient), keyHasher, revocationChecker)
 	fmt.Println("Use cases initialized")
 
-	server := httpserver.NewServer(cfg, redisClient, tokenService, rateLimiter, listMarketsUseCase, getMarketDetailsUseCase, getCategoryOverviewUseCase)
+	server := httpserver.NewServer(cfg, redisClient, tokenService, revocationChecker, clientRepo, rateLimiter, rateLimitCosts, listMarketsUseCase, getMarketDetailsUseCase, getCategoryOverviewUseCase, getSchemaDiagnosticsUseCase, getMarketSettlementUseCase, getStatusHistoryUseCase, getRangedGroupUseCase, getEventDistributionUseCase, getMarketQuoteUseCase, getMoversUseCase, searchMarketsUseCase, getMarketsBatchUseCase, authenticateUseCase, refreshAccessTokenUseCase, revokeTokenUseCase, manageClientsUseCase, getJWKSUseCase, issueClientCredentialsTokenUseCase, introspectTokenUseCase)
+	if err := server.CheckRateLimitRoutes(); err != nil {
+		fmt.Printf("Invalid rate limit configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	go syncSigningKeys(backgroundCtx, signingKeyRotator)
+	go schemaReportPublisher.Run(backgroundCtx)
 
 	go func() {
 		if err := server.Start(); err != nil && err != http.ErrServerClosed {
@@ -87,3 +276,57 @@ func main() {
 
 	fmt.Println("Server exited gracefully")
 }
+
+// syncSigningKeys keeps the signing keys current until ctx is done. A failed sync keeps
+// the loaded keys, which stay usable well past the next attempt.
+func syncSigningKeys(ctx context.Context, rotator *appservice.SigningKeyRotator) {
+	ticker := time.NewTicker(signingKeySyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := rotator.Sync(ctx); err != nil {
+				fmt.Printf("Error syncing signing keys: %v\n", err)
+			}
+		}
+	}
+}
+
+// seedClients registers the clients of a seed file that are not in Redis yet, so changes
+// made through the registry since are kept. It refuses any client whose key is the
+// upstream Kalshi key, which must never double as a client credential.
+func seedClients(ctx context.Context, clientRepo authrepository.ClientRepository, keyHasher *service.KeyHasher, path string, upstreamAPIKey string) (int, error) {
+	clients, err := config.LoadClientSeeds(path)
+	if err != nil {
+		return 0, err
+	}
+
+	seeded := 0
+	for _, client := range clients {
+		if client.Tier == ratelimitvalueobject.TierUnauthenticated {
+			return seeded, fmt.Errorf("client %s: the %s tier cannot be assigned to a client", client.ID, client.Tier)
+		}
+		if _, err := ratelimitvalueobject.NewRateLimitTier(client.Tier); err != nil {
+			return seeded, fmt.Errorf("client %s: %w", client.ID, err)
+		}
+		for _, key := range client.Keys {
+			if keyHasher.Verify(upstreamAPIKey, key.Hash) {
+				return seeded, fmt.Errorf("client %s: key %s is the Kalshi API key", client.ID, key.ID)
+			}
+		}
+
+		err := clientRepo.Create(ctx, client)
+		if errors.Is(err, authrepository.ErrClientExists) {
+			continue
+		}
+		if err != nil {
+			return seeded, err
+		}
+		seeded++
+	}
+
+	return seeded, nil
+}
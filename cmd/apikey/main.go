@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"upwork-test/internal/domain/auth/service"
+	"upwork-test/internal/domain/auth/valueobject"
+)
+
+// apikey generates a client API key and prints it with the seed file entry registering it.
+// The key itself is shown only here; the seed entry holds its hash.
+func main() {
+	clientID := flag.String("client", "", "client ID (required)")
+	name := flag.String("name", "", "client display name")
+	tier := flag.String("tier", "authenticated", "rate limit tier")
+	scopes := flag.String("scopes", strings.Join(valueobject.DefaultScopes(), ","), "comma-separated scopes")
+	flag.Parse()
+
+	if *clientID == "" {
+		fmt.Fprintln(os.Stderr, "usage: apikey -client <id> [-name <name>] [-tier <tier>] [-scopes <a,b>]")
+		os.Exit(2)
+	}
+
+	apiKey, err := valueobject.GenerateAPIKey()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to generate API key: %v\n", err)
+		os.Exit(1)
+	}
+
+	hash, err := service.NewKeyHasher().Hash(apiKey.Value())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to hash API key: %v\n", err)
+		os.Exit(1)
+	}
+
+	scopeList := []string{}
+	for _, scope := range strings.Split(*scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope == "" {
+			continue
+		}
+		if err := valueobject.ValidateScope(scope); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid scopes: %v\n", err)
+			os.Exit(2)
+		}
+		scopeList = append(scopeList, scope)
+	}
+
+	type seedKey struct {
+		ID   string `json:"id"`
+		Hash string `json:"hash"`
+	}
+	entry, _ := json.MarshalIndent(struct {
+		ID     string    `json:"id"`
+		Name   string    `json:"name"`
+		Tier   string    `json:"tier"`
+		Scopes []string  `json:"scopes"`
+		Status string    `json:"status"`
+		Keys   []seedKey `json:"keys"`
+	}{
+		ID:     *clientID,
+		Name:   *name,
+		Tier:   *tier,
+		Scopes: scopeList,
+		Status: "active",
+		Keys:   []seedKey{{ID: apiKey.ID(), Hash: hash}},
+	}, "", "  ")
+
+	fmt.Printf("API key (store it now, it is not shown again):\n%s\n\n", apiKey.Value())
+	fmt.Printf("Seed file entry (add to \"clients\" in AUTH_CLIENTS_FILE):\n%s\n", entry)
+}
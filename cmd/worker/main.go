@@ -9,6 +9,7 @@ import (
 	"syscall"
 	"time"
 	"upwork-test/internal/application/service"
+	categoryservice "upwork-test/internal/domain/category/service"
 	"upwork-test/internal/infrastructure/cache"
 	"upwork-test/internal/infrastructure/config"
 	"upwork-test/internal/infrastructure/kalshi"
@@ -20,6 +21,20 @@ const (
 	maxWorkers      = 5
 	kalshiRateLimit = 80 // requests per minute (80% of assumed 100 req/min limit)
 	hotMarketsCount = 20
+
+	// priceSnapshotInterval is how often market prices are recorded for the 1h and 6h movers windows
+	priceSnapshotInterval = 5 * time.Minute
+
+	// activityInterval is how often category activity metrics are recomputed
+	activityInterval = 15 * time.Minute
+	// maxTradeFetchesPerCategory caps the markets whose trades are fetched per category and run
+	maxTradeFetchesPerCategory = 40
+
+	// searchIndexInterval is how often market search documents are rebuilt
+	searchIndexInterval = 10 * time.Minute
+
+	// schemaReportInterval is how often this process's schema report is shared
+	schemaReportInterval = time.Minute
 )
 
 func main() {
@@ -43,14 +58,69 @@ func main() {
 	defer redisClient.Close()
 	fmt.Printf("Connected to Redis at %s\n", cfg.Redis.Addr())
 
-	kalshiClient := kalshi.NewClient(cfg.Kalshi.BaseURL, cfg.Kalshi.APIKey)
+	if err := cache.MigrateCache(context.Background(), redisClient); err != nil {
+		fmt.Printf("Failed to migrate cache: %v\n", err)
+		os.Exit(1)
+	}
+
+	kalshiTransport, err := kalshi.NewFixtureTransport(
+		kalshi.FixtureMode(cfg.Kalshi.FixtureMode),
+		cfg.Kalshi.FixtureDir,
+		nil,
+	)
+	if err != nil {
+		fmt.Printf("Failed to configure Kalshi transport: %v\n", err)
+		os.Exit(1)
+	}
+	if cfg.Kalshi.FixtureMode != "" {
+		fmt.Printf("Kalshi fixtures enabled (mode: %s, dir: %s)\n", cfg.Kalshi.FixtureMode, cfg.Kalshi.FixtureDir)
+	}
+
+	kalshiClient := kalshi.NewClientWithTransport(cfg.Kalshi.BaseURL, cfg.Kalshi.APIKey, kalshiTransport)
+	schemaMonitor := kalshi.NewSchemaMonitor(cfg.Kalshi.StrictDecoding)
+	kalshiClient.SetSchemaMonitor(schemaMonitor)
+
+	// Every request to Kalshi, including each page of a listing, waits for the limiter
+	limiter := rate.NewLimiter(rate.Every(time.Minute/kalshiRateLimit), kalshiRateLimit)
+	kalshiClient.SetRateLimiter(limiter)
 
 	marketRepo := cache.NewMarketRepository(redisClient, kalshiClient)
-	categoryRepo := cache.NewCategoryRepository(redisClient, kalshiClient, marketRepo)
+	activityRepo := cache.NewActivityRepository(redisClient)
+	categoryRepo := cache.NewCategoryRepository(redisClient, kalshiClient, marketRepo, activityRepo)
 
 	cacheWarmer := service.NewCacheWarmer(marketRepo, categoryRepo)
+	statusTracker := service.NewStatusTracker(marketRepo, categoryRepo, cache.NewStatusHistoryRepository(redisClient))
+	settlementDetector := service.NewSettlementDetector(
+		marketRepo,
+		categoryRepo,
+		cache.NewSettlementRepository(redisClient),
+		cache.NewEventPublisher(redisClient),
+	)
+	priceSnapshotter := service.NewPriceSnapshotter(
+		marketRepo,
+		categoryRepo,
+		cache.NewPriceSnapshotRepository(redisClient),
+		cache.NewMoversRepository(redisClient),
+		categoryservice.NewMoversRanker(),
+	)
+	schemaReportPublisher := service.NewSchemaReportPublisher(schemaMonitor, cache.NewSchemaReportRepository(redisClient), service.DiagnosticsSource("worker"), schemaReportInterval)
 
-	limiter := rate.NewLimiter(rate.Every(time.Minute/kalshiRateLimit), kalshiRateLimit)
+	activityPipeline := service.NewActivityPipeline(
+		marketRepo,
+		categoryRepo,
+		cache.NewTradeWindowRepository(redisClient),
+		activityRepo,
+		categoryservice.NewActivityAnalyzer(),
+		maxTradeFetchesPerCategory,
+	)
+
+	searchIndexer := service.NewSearchIndexer(
+		marketRepo,
+		categoryRepo,
+		cache.NewEventRepository(redisClient, kalshiClient),
+		cache.NewSeriesRepository(redisClient, kalshiClient),
+		cache.NewSearchRepository(redisClient),
+	)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -67,12 +137,10 @@ func main() {
 		defer ticker.Stop()
 
 		fmt.Println("Initial hot markets warm-up...")
-		if err := limiter.Wait(ctx); err == nil {
-			if err := cacheWarmer.WarmHotMarkets(ctx, hotMarketsCount); err != nil {
-				fmt.Printf("Error warming hot markets: %v\n", err)
-			} else {
-				fmt.Println("Hot markets warmed successfully")
-			}
+		if err := cacheWarmer.WarmHotMarkets(ctx, hotMarketsCount); err != nil {
+			fmt.Printf("Error warming hot markets: %v\n", err)
+		} else {
+			fmt.Println("Hot markets warmed successfully")
 		}
 
 		for {
@@ -81,10 +149,8 @@ func main() {
 				return
 			case <-ticker.C:
 				fmt.Printf("[%s] Warming hot markets...\n", time.Now().Format(time.RFC3339))
-				if err := limiter.Wait(ctx); err == nil {
-					if err := cacheWarmer.WarmHotMarkets(ctx, hotMarketsCount); err != nil {
-						fmt.Printf("Error warming hot markets: %v\n", err)
-					}
+				if err := cacheWarmer.WarmHotMarkets(ctx, hotMarketsCount); err != nil {
+					fmt.Printf("Error warming hot markets: %v\n", err)
 				}
 			}
 		}
@@ -97,12 +163,10 @@ func main() {
 		defer ticker.Stop()
 
 		fmt.Println("Initial category overviews warm-up...")
-		if err := limiter.Wait(ctx); err == nil {
-			if err := cacheWarmer.WarmCategoryOverviews(ctx); err != nil {
-				fmt.Printf("Error warming category overviews: %v\n", err)
-			} else {
-				fmt.Println("Category overviews warmed successfully")
-			}
+		if err := cacheWarmer.WarmCategoryOverviews(ctx); err != nil {
+			fmt.Printf("Error warming category overviews: %v\n", err)
+		} else {
+			fmt.Println("Category overviews warmed successfully")
 		}
 
 		for {
@@ -111,10 +175,8 @@ func main() {
 				return
 			case <-ticker.C:
 				fmt.Printf("[%s] Warming category overviews...\n", time.Now().Format(time.RFC3339))
-				if err := limiter.Wait(ctx); err == nil {
-					if err := cacheWarmer.WarmCategoryOverviews(ctx); err != nil {
-						fmt.Printf("Error warming category overviews: %v\n", err)
-					}
+				if err := cacheWarmer.WarmCategoryOverviews(ctx); err != nil {
+					fmt.Printf("Error warming category overviews: %v\n", err)
 				}
 			}
 		}
@@ -127,29 +189,152 @@ func main() {
 		defer ticker.Stop()
 
 		fmt.Println("Initial category list warm-up...")
-		if err := limiter.Wait(ctx); err == nil {
-			if err := cacheWarmer.WarmCategoryLists(ctx); err != nil {
-				fmt.Printf("Error warming category lists: %v\n", err)
+		if err := cacheWarmer.WarmCategoryLists(ctx); err != nil {
+			fmt.Printf("Error warming category lists: %v\n", err)
+		} else {
+			fmt.Println("Category lists warmed successfully")
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				fmt.Printf("[%s] Warming category lists...\n", time.Now().Format(time.RFC3339))
+				if err := cacheWarmer.WarmCategoryLists(ctx); err != nil {
+					fmt.Printf("Error warming category lists: %v\n", err)
+				}
+			}
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(2 * time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				fmt.Printf("[%s] Tracking market status transitions...\n", time.Now().Format(time.RFC3339))
+				if err := statusTracker.TrackCategories(ctx); err != nil {
+					fmt.Printf("Error tracking market statuses: %v\n", err)
+				}
+			}
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(5 * time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				fmt.Printf("[%s] Detecting settled markets...\n", time.Now().Format(time.RFC3339))
+				detected, err := settlementDetector.DetectSettlements(ctx)
+				if err != nil {
+					fmt.Printf("Error detecting settlements: %v\n", err)
+				} else if detected > 0 {
+					fmt.Printf("Detected %d new or amended settlements\n", detected)
+				}
+			}
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(priceSnapshotInterval)
+		defer ticker.Stop()
+
+		capture := func() {
+			recorded, err := priceSnapshotter.Capture(ctx)
+			if err != nil {
+				fmt.Printf("Error capturing price snapshot: %v\n", err)
 			} else {
-				fmt.Println("Category lists warmed successfully")
+				fmt.Printf("Captured prices for %d markets\n", recorded)
 			}
 		}
 
+		capture()
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				fmt.Printf("[%s] Warming category lists...\n", time.Now().Format(time.RFC3339))
-				if err := limiter.Wait(ctx); err == nil {
-					if err := cacheWarmer.WarmCategoryLists(ctx); err != nil {
-						fmt.Printf("Error warming category lists: %v\n", err)
-					}
+				fmt.Printf("[%s] Capturing market price snapshot...\n", time.Now().Format(time.RFC3339))
+				capture()
+			}
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(activityInterval)
+		defer ticker.Stop()
+
+		fmt.Println("Initial category activity computation...")
+		if err := activityPipeline.Run(ctx); err != nil {
+			fmt.Printf("Error computing category activity: %v\n", err)
+		} else {
+			fmt.Println("Category activity computed successfully")
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				fmt.Printf("[%s] Computing category activity...\n", time.Now().Format(time.RFC3339))
+				if err := activityPipeline.Run(ctx); err != nil {
+					fmt.Printf("Error computing category activity: %v\n", err)
 				}
 			}
 		}
 	}()
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(searchIndexInterval)
+		defer ticker.Stop()
+
+		reindex := func() {
+			upserted, removed, err := searchIndexer.Reindex(ctx)
+			if err != nil {
+				fmt.Printf("Error rebuilding search index: %v\n", err)
+			} else if upserted > 0 || removed > 0 {
+				fmt.Printf("Search index updated (%d upserted, %d removed)\n", upserted, removed)
+			}
+		}
+
+		reindex()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				fmt.Printf("[%s] Rebuilding search index...\n", time.Now().Format(time.RFC3339))
+				reindex()
+			}
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		schemaReportPublisher.Run(ctx)
+	}()
+
 	fmt.Println("Cache warmer workers started successfully")
 
 	<-quit
@@ -7,11 +7,24 @@ type AuthRequest struct {
 	APIKey string `json:"api_key"`
 }
 
-// TokenResponse represents a JWT token response
+// RefreshRequest represents a request to exchange a refresh token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RevokeRequest represents a request to revoke a token
+type RevokeRequest struct {
+	Token         string `json:"token"`
+	TokenTypeHint string `json:"token_type_hint"`
+}
+
+// TokenResponse represents a JWT token response, with a refresh token when one was issued
 type TokenResponse struct {
-	Token     string    `json:"token"`
-	ExpiresAt time.Time `json:"expires_at"`
-	TokenType string    `json:"token_type"`
+	Token            string    `json:"token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	TokenType        string    `json:"token_type"`
+	RefreshToken     string    `json:"refresh_token,omitempty"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at,omitempty"`
 }
 
 // NewTokenResponse creates a new TokenResponse
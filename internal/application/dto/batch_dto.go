@@ -0,0 +1,21 @@
+package dto
+
+// MarketBatchDTO represents the results of a batch market lookup, in request order
+type MarketBatchDTO struct {
+	Results []MarketBatchItemDTO `json:"results"`
+	Found   int                  `json:"found"`
+	Failed  int                  `json:"failed"`
+}
+
+// MarketBatchItemDTO represents the lookup of one ticker; exactly one of Market and Error is set
+type MarketBatchItemDTO struct {
+	Ticker string             `json:"ticker"`
+	Market *MarketDetailDTO   `json:"market,omitempty"`
+	Error  *BatchItemErrorDTO `json:"error,omitempty"`
+}
+
+// BatchItemErrorDTO describes why one ticker of a batch could not be returned
+type BatchItemErrorDTO struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
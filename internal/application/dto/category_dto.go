@@ -15,11 +15,33 @@ type CategoryDTO struct {
 
 // CategoryOverviewDTO represents category overview metrics.
 type CategoryOverviewDTO struct {
-	CategoryName     string    `json:"category_name"`
-	TotalMarkets     int       `json:"total_markets"`
-	TotalVolume24h   int64     `json:"total_volume_24h"`
-	AverageLiquidity float64   `json:"average_liquidity"`
-	ActiveTraders24h int       `json:"active_traders_24h"`
-	ComputedAt       time.Time `json:"computed_at"`
-	ExpiresAt        time.Time `json:"expires_at"`
+	CategoryName     string           `json:"category_name"`
+	TotalMarkets     int              `json:"total_markets"`
+	TotalVolume24h   int64            `json:"total_volume_24h"`
+	AverageLiquidity float64          `json:"average_liquidity"`
+	Trades24h        int              `json:"trades_24h"`
+	PriceChanges24h  []MarketMoverDTO `json:"price_changes_24h"`
+	Movers           *MoversDTO       `json:"movers,omitempty"`
+	Activity         *ActivityDTO     `json:"activity,omitempty"`
+	ComputedAt       time.Time        `json:"computed_at"`
+	ExpiresAt        time.Time        `json:"expires_at"`
+}
+
+// ActivityDTO represents trading activity across every open market in a category.
+// Spreads and notional amounts are in cents.
+type ActivityDTO struct {
+	Window               string    `json:"window"`
+	TotalMarkets         int       `json:"total_markets"`
+	TotalVolume24h       int64     `json:"total_volume_24h"`
+	AverageLiquidity     float64   `json:"average_liquidity"`
+	TotalOpenInterest    int64     `json:"total_open_interest"`
+	MedianSpread         float64   `json:"median_spread"`
+	TradeCount           int       `json:"trade_count"` // Distinct trade IDs
+	ContractsTraded      int64     `json:"contracts_traded"`
+	NotionalTraded       float64   `json:"notional_traded"`
+	MarketsWithTrades    int       `json:"markets_with_trades"`
+	MarketsWithVolume    int       `json:"markets_with_volume"`
+	MarketsTradesTracked int       `json:"markets_trades_tracked"`
+	TradeCoverage        float64   `json:"trade_coverage"`
+	ComputedAt           time.Time `json:"computed_at"`
 }
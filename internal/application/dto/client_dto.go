@@ -0,0 +1,56 @@
+package dto
+
+import "time"
+
+// ClientDTO represents a registered API client. Keys are listed by masked prefix only.
+type ClientDTO struct {
+	ID        string         `json:"id"`
+	Name      string         `json:"name"`
+	Tier      string         `json:"tier"`
+	Scopes    []string       `json:"scopes"`
+	Status    string         `json:"status"`
+	Keys      []ClientKeyDTO `json:"keys"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// ClientKeyDTO represents one API key of a client
+type ClientKeyDTO struct {
+	ID        string    `json:"id"`
+	Prefix    string    `json:"prefix"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+// CreateClientDTO represents a request to register an API client
+type CreateClientDTO struct {
+	ID     string   `json:"id"`
+	Name   string   `json:"name"`
+	Tier   string   `json:"tier"`
+	Scopes []string `json:"scopes"`
+}
+
+// IssuedKeyDTO represents a newly issued API key. The key is not stored and cannot be shown again.
+type IssuedKeyDTO struct {
+	Client *ClientDTO `json:"client"`
+	KeyID  string     `json:"key_id"`
+	APIKey string     `json:"api_key"`
+}
+
+// AuditActorDTO identifies who made a registry change and the request it came from
+type AuditActorDTO struct {
+	ClientID string `json:"client_id"`
+	TraceID  string `json:"trace_id"`
+}
+
+// AuditEntryDTO represents one audit log entry
+type AuditEntryDTO struct {
+	ID       string    `json:"id"`
+	Time     time.Time `json:"time"`
+	Actor    string    `json:"actor"`
+	Action   string    `json:"action"`
+	ClientID string    `json:"client_id"`
+	KeyID    string    `json:"key_id,omitempty"`
+	Detail   string    `json:"detail,omitempty"`
+	TraceID  string    `json:"trace_id,omitempty"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+// SchemaDiagnosticsDTO represents upstream schema drift across all reporting processes.
+type SchemaDiagnosticsDTO struct {
+	DriftDetected bool               `json:"drift_detected"`
+	Reports       []*SchemaReportDTO `json:"reports"`
+}
+
+// SchemaReportDTO represents the schema diagnostics collected by one process.
+type SchemaReportDTO struct {
+	Source         string             `json:"source"`
+	StrictDecoding bool               `json:"strict_decoding"`
+	DriftDetected  bool               `json:"drift_detected"`
+	Endpoints      []EndpointDriftDTO `json:"endpoints"`
+	Fallbacks      map[string]int64   `json:"fallbacks"`
+	OutOfRange     map[string]int64   `json:"out_of_range"`
+	StartedAt      time.Time          `json:"started_at"`
+	LastDriftAt    time.Time          `json:"last_drift_at,omitempty"`
+	GeneratedAt    time.Time          `json:"generated_at"`
+}
+
+// EndpointDriftDTO represents drift counters for one upstream endpoint.
+type EndpointDriftDTO struct {
+	Endpoint      string           `json:"endpoint"`
+	Responses     int64            `json:"responses"`
+	DecodeErrors  int64            `json:"decode_errors"`
+	UnknownFields map[string]int64 `json:"unknown_fields"`
+	MissingFields map[string]int64 `json:"missing_fields"`
+}
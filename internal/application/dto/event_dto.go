@@ -0,0 +1,44 @@
+package dto
+
+// EventDistributionDTO represents the implied probability distribution across an event's outcomes
+type EventDistributionDTO struct {
+	EventTicker       string       `json:"event_ticker"`
+	Title             string       `json:"title"`
+	Category          string       `json:"category"`
+	MutuallyExclusive bool         `json:"mutually_exclusive"`
+	Outcomes          []OutcomeDTO `json:"outcomes"`
+	TotalProbability  float64      `json:"total_probability"`
+	Overround         float64      `json:"overround"`
+	AllYes            BasketDTO    `json:"all_yes"`
+	AllNo             BasketDTO    `json:"all_no"`
+	Arbitrage         bool         `json:"arbitrage"`
+}
+
+// OutcomeDTO represents one outcome's share of an event distribution
+type OutcomeDTO struct {
+	Ticker                string     `json:"ticker"`
+	Title                 string     `json:"title"`
+	Status                string     `json:"status"`
+	Strike                *StrikeDTO `json:"strike,omitempty"`
+	YesBid                float64    `json:"yes_bid"`
+	YesAsk                float64    `json:"yes_ask"`
+	NoAsk                 float64    `json:"no_ask"`
+	LastPrice             float64    `json:"last_price"`
+	ImpliedProbability    float64    `json:"implied_probability"`
+	NormalizedProbability float64    `json:"normalized_probability"`
+	PriceSource           string     `json:"price_source"`
+}
+
+// BasketDTO represents the price of buying one side of every outcome, in cents
+type BasketDTO struct {
+	Side      string  `json:"side"`
+	Contracts int64   `json:"contracts"`
+	Available bool    `json:"available"`
+	Cost      float64 `json:"cost"`
+	Fees      int64   `json:"fees"`
+	NetCost   float64 `json:"net_cost"`
+	Payout    float64 `json:"payout"`
+	Edge      float64 `json:"edge"`
+	BelowPar  bool    `json:"below_par"`
+	AbovePar  bool    `json:"above_par"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+// JWKDTO represents a public signing key as a JSON Web Key (RFC 7517)
+type JWKDTO struct {
+	KeyType   string
+	KeyID     string
+	Algorithm string
+	// RSA modulus and exponent
+	N string
+	E string
+	// EC curve and coordinates
+	Curve string
+	X     string
+	Y     string
+}
+
+// JWKSetDTO represents the public keys access tokens can be verified with
+type JWKSetDTO struct {
+	Keys []*JWKDTO
+}
@@ -11,13 +11,30 @@ type MarketDTO struct {
 	Category        string    `json:"category"`
 	CloseDate       time.Time `json:"close_date"`
 	SettlementRules string    `json:"settlement_rules,omitempty"`
-	YesPrice        int       `json:"yes_price"`
-	NoPrice         int       `json:"no_price"`
+	YesPrice        float64   `json:"yes_price"`
+	NoPrice         float64   `json:"no_price"`
 	Status          string    `json:"status"`
 	Volume24h       int64     `json:"volume_24h"`
 	LastUpdated     time.Time `json:"last_updated"`
 }
 
+// MarketListQueryDTO represents a category market listing request.
+// Prices are decimal cents as given by the client.
+type MarketListQueryDTO struct {
+	Page         int
+	Limit        int
+	Status       string
+	Sort         string
+	Order        string
+	MinPrice     string
+	MaxPrice     string
+	ClosesAfter  time.Time
+	ClosesBefore time.Time
+	MinLiquidity int64
+	EventTicker  string
+	Cursor       string // Opaque token from a previous page; takes precedence over Page
+}
+
 // PaginationDTO represents pagination metadata.
 type PaginationDTO struct {
 	Page       int    `json:"page"`
@@ -26,6 +43,8 @@ type PaginationDTO struct {
 	TotalPages int    `json:"total_pages"`
 	NextURL    string `json:"next_url,omitempty"`
 	PrevURL    string `json:"prev_url,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 // MarketListDTO represents a paginated list of markets.
@@ -36,24 +55,38 @@ type MarketListDTO struct {
 
 // MarketDetailDTO represents comprehensive market information with aggregated data
 type MarketDetailDTO struct {
-	Ticker       string        `json:"ticker"`
-	Title        string        `json:"title"`
-	Category     string        `json:"category"`
-	OpenTime     time.Time     `json:"open_time"`
-	CloseTime    time.Time     `json:"close_time"`
-	Status       string        `json:"status"`
-	YesAsk       int64         `json:"yes_ask"`
-	YesBid       int64         `json:"yes_bid"`
-	NoAsk        int64         `json:"no_ask"`
-	NoBid        int64         `json:"no_bid"`
-	LastPrice    int64         `json:"last_price"`
-	Volume       int64         `json:"volume"`
-	Volume24h    int64         `json:"volume_24h"`
-	Liquidity    int64         `json:"liquidity"`
-	OrderBook    *OrderBookDTO `json:"order_book,omitempty"`
-	RecentTrades []TradeDTO    `json:"recent_trades,omitempty"`
-	IsPartial    bool          `json:"is_partial"`
-	Errors       []string      `json:"errors,omitempty"`
+	Ticker            string         `json:"ticker"`
+	EventTicker       string         `json:"event_ticker,omitempty"`
+	RangedGroupTicker string         `json:"ranged_group_ticker,omitempty"`
+	Title             string         `json:"title"`
+	Category          string         `json:"category"`
+	OpenTime          time.Time      `json:"open_time"`
+	CloseTime         time.Time      `json:"close_time"`
+	Status            string         `json:"status"`
+	YesAsk            float64        `json:"yes_ask"`
+	YesBid            float64        `json:"yes_bid"`
+	NoAsk             float64        `json:"no_ask"`
+	NoBid             float64        `json:"no_bid"`
+	LastPrice         float64        `json:"last_price"`
+	Volume            int64          `json:"volume"`
+	Volume24h         int64          `json:"volume_24h"`
+	Liquidity         int64          `json:"liquidity"`
+	Strike            *StrikeDTO     `json:"strike,omitempty"`
+	OrderBook         *OrderBookDTO  `json:"order_book,omitempty"`
+	RecentTrades      []TradeDTO     `json:"recent_trades,omitempty"`
+	Candles           []CandleDTO    `json:"candles,omitempty"`
+	Event             *EventRefDTO   `json:"event,omitempty"`
+	Settlement        *SettlementDTO `json:"settlement,omitempty"`
+	IsPartial         bool           `json:"is_partial"`
+	Errors            []string       `json:"errors,omitempty"`
+}
+
+// MarketDetailOptionsDTO selects the resources embedded in market details.
+// A nil Include embeds the order book and trades, as before includes existed.
+type MarketDetailOptionsDTO struct {
+	Include      []string // orderbook, trades, candles, event
+	TradesLimit  int
+	CandlePeriod string
 }
 
 // OrderBookDTO represents an order book snapshot
@@ -66,15 +99,94 @@ type OrderBookDTO struct {
 
 // OrderLevelDTO represents a price level in the order book
 type OrderLevelDTO struct {
-	Price    int64 `json:"price"`
-	Quantity int   `json:"quantity"`
+	Price    float64 `json:"price"`
+	Quantity int     `json:"quantity"`
 }
 
 // TradeDTO represents a historical trade
 type TradeDTO struct {
 	TradeID   string    `json:"trade_id"`
-	Price     int64     `json:"price"`
+	Price     float64   `json:"price"`
 	Quantity  int       `json:"quantity"`
 	Side      string    `json:"side"`
 	Timestamp time.Time `json:"timestamp"`
 }
+
+// CandleDTO represents one candlestick period, in cents. Traded prices are nil when nothing traded.
+type CandleDTO struct {
+	PeriodEnd    time.Time `json:"period_end"`
+	Open         *float64  `json:"open"`
+	High         *float64  `json:"high"`
+	Low          *float64  `json:"low"`
+	Close        *float64  `json:"close"`
+	YesBidClose  float64   `json:"yes_bid_close"`
+	YesAskClose  float64   `json:"yes_ask_close"`
+	Volume       int64     `json:"volume"`
+	OpenInterest int64     `json:"open_interest"`
+}
+
+// EventRefDTO summarizes the event a market belongs to
+type EventRefDTO struct {
+	EventTicker       string `json:"event_ticker"`
+	SeriesTicker      string `json:"series_ticker"`
+	Title             string `json:"title"`
+	SubTitle          string `json:"sub_title,omitempty"`
+	Category          string `json:"category"`
+	MutuallyExclusive bool   `json:"mutually_exclusive"`
+	MarketCount       int    `json:"market_count"`
+}
+
+// SettlementDTO represents the resolution of a market
+type SettlementDTO struct {
+	Ticker          string    `json:"ticker"`
+	Result          string    `json:"result"`
+	SettlementValue float64   `json:"settlement_value"`
+	ExpirationValue string    `json:"expiration_value,omitempty"`
+	SettledAt       time.Time `json:"settled_at"`
+}
+
+// StatusHistoryDTO represents the observed status transitions of a market, newest first
+type StatusHistoryDTO struct {
+	Ticker      string                `json:"ticker"`
+	Transitions []StatusTransitionDTO `json:"transitions"`
+}
+
+// StatusTransitionDTO represents one observed change in a market's status
+type StatusTransitionDTO struct {
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	Expected   bool      `json:"expected"`
+	ObservedAt time.Time `json:"observed_at"`
+}
+
+// StrikeDTO represents the threshold or range a scalar/bracket market resolves against
+type StrikeDTO struct {
+	Type       string   `json:"type"`
+	Floor      *float64 `json:"floor,omitempty"`
+	Cap        *float64 `json:"cap,omitempty"`
+	Functional string   `json:"functional,omitempty"`
+	Label      string   `json:"label"`
+}
+
+// RangedGroupDTO represents the bracket markets of a range group ordered by strike
+type RangedGroupDTO struct {
+	GroupTicker      string       `json:"group_ticker"`
+	EventTicker      string       `json:"event_ticker"`
+	Brackets         []BracketDTO `json:"brackets"`
+	TotalProbability float64      `json:"total_probability"`
+}
+
+// BracketDTO represents one bracket market with its share of the implied distribution
+type BracketDTO struct {
+	Ticker                string     `json:"ticker"`
+	Title                 string     `json:"title"`
+	Status                string     `json:"status"`
+	Strike                *StrikeDTO `json:"strike,omitempty"`
+	YesBid                float64    `json:"yes_bid"`
+	YesAsk                float64    `json:"yes_ask"`
+	LastPrice             float64    `json:"last_price"`
+	Volume24h             int64      `json:"volume_24h"`
+	ImpliedProbability    float64    `json:"implied_probability"`
+	NormalizedProbability float64    `json:"normalized_probability"`
+	PriceSource           string     `json:"price_source"`
+}
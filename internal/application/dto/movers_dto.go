@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+)
+
+// MarketMoverDTO represents a market's price movement and activity over a window, in cents
+type MarketMoverDTO struct {
+	Ticker             string   `json:"ticker"`
+	Title              string   `json:"title"`
+	Category           string   `json:"category"`
+	LastPrice          float64  `json:"last_price"`
+	PreviousPrice      *float64 `json:"previous_price,omitempty"`
+	PriceChange        *float64 `json:"price_change,omitempty"`
+	PriceChangePercent *float64 `json:"price_change_percent,omitempty"`
+	Volume24h          int64    `json:"volume_24h"`
+	Spread             *float64 `json:"spread,omitempty"`
+}
+
+// MoversDTO represents the ranked market movers for a window
+type MoversDTO struct {
+	Window         string           `json:"window"`
+	Category       string           `json:"category,omitempty"`
+	MarketsScanned int              `json:"markets_scanned"`
+	BaselineAt     time.Time        `json:"baseline_at"`
+	Gainers        []MarketMoverDTO `json:"gainers"`
+	Losers         []MarketMoverDTO `json:"losers"`
+	HighestVolume  []MarketMoverDTO `json:"highest_volume"`
+	WidestSpreads  []MarketMoverDTO `json:"widest_spreads"`
+	ComputedAt     time.Time        `json:"computed_at"`
+}
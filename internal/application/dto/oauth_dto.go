@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+// ClientCredentials identifies an OAuth client: its registry ID and, as its secret, one of its API keys
+type ClientCredentials struct {
+	ClientID     string
+	ClientSecret string
+}
+
+// ClientCredentialsRequest represents an OAuth client credentials grant (RFC 6749 section 4.4)
+type ClientCredentialsRequest struct {
+	Client ClientCredentials
+	// Scopes requested; all of the client's scopes when empty
+	Scopes []string
+}
+
+// AccessTokenDTO represents an access token issued without a refresh token
+type AccessTokenDTO struct {
+	AccessToken string
+	ExpiresAt   time.Time
+	Scopes      []string
+}
+
+// IntrospectionRequest represents a token introspection request (RFC 7662)
+type IntrospectionRequest struct {
+	Client        ClientCredentials
+	Token         string
+	TokenTypeHint string
+}
+
+// IntrospectionDTO represents the state of an introspected token. Only Active is set for
+// inactive tokens.
+type IntrospectionDTO struct {
+	Active    bool
+	Scopes    []string
+	ClientID  string
+	TokenID   string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// QuoteRequest represents a request to price a hypothetical order
+type QuoteRequest struct {
+	Ticker      string   `json:"ticker"`
+	Side        string   `json:"side"`
+	Quantity    int64    `json:"quantity"`
+	Liquidity   string   `json:"liquidity,omitempty"`
+	LimitPrice  *float64 `json:"limit_price,omitempty"` // In cents, may include fractions of a cent
+	Probability *float64 `json:"probability,omitempty"`
+}
+
+// QuoteDTO represents the cost and payoff of an order, in cents
+type QuoteDTO struct {
+	Ticker               string     `json:"ticker"`
+	Side                 string     `json:"side"`
+	Liquidity            string     `json:"liquidity"`
+	Requested            int64      `json:"requested"`
+	Filled               int64      `json:"filled"`
+	FullyFilled          bool       `json:"fully_filled"`
+	Fills                []FillDTO  `json:"fills"`
+	AveragePrice         float64    `json:"average_price"`
+	GrossCost            float64    `json:"gross_cost"`
+	Fees                 int64      `json:"fees"`
+	TotalCost            float64    `json:"total_cost"`
+	MaxPayout            float64    `json:"max_payout"`
+	MaxProfit            float64    `json:"max_profit"`
+	BreakEvenProbability float64    `json:"break_even_probability"`
+	ExpectedValue        *float64   `json:"expected_value,omitempty"`
+	OrderBookTimestamp   *time.Time `json:"order_book_timestamp,omitempty"`
+}
+
+// FillDTO represents the part of an order executed at one price level
+type FillDTO struct {
+	Price    float64 `json:"price"`
+	Quantity int64   `json:"quantity"`
+	Fee      int64   `json:"fee"`
+}
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+)
+
+// SearchQueryDTO represents a market search request
+type SearchQueryDTO struct {
+	Query        string
+	Category     string
+	Status       string
+	ClosesBefore time.Time
+	MinVolume    int64
+	Sort         string
+	Limit        int
+}
+
+// SearchResultsDTO represents ranked market search results
+type SearchResultsDTO struct {
+	Query        string         `json:"query"`
+	Results      []SearchHitDTO `json:"results"`
+	Total        int            `json:"total"`
+	IndexVersion int64          `json:"index_version"`
+	IndexSize    int            `json:"index_size"`
+}
+
+// SearchHitDTO represents a market matching a search, in cents
+type SearchHitDTO struct {
+	Ticker      string    `json:"ticker"`
+	Title       string    `json:"title"`
+	Subtitle    string    `json:"subtitle,omitempty"`
+	EventTicker string    `json:"event_ticker,omitempty"`
+	EventTitle  string    `json:"event_title,omitempty"`
+	SeriesTitle string    `json:"series_title,omitempty"`
+	Category    string    `json:"category"`
+	Status      string    `json:"status"`
+	CloseTime   time.Time `json:"close_time"`
+	Volume24h   int64     `json:"volume_24h"`
+	Liquidity   int64     `json:"liquidity"`
+	LastPrice   float64   `json:"last_price"`
+	Score       float64   `json:"score"`
+}
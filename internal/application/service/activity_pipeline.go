@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+	"upwork-test/internal/domain/category/repository"
+	categoryservice "upwork-test/internal/domain/category/service"
+	"upwork-test/internal/domain/category/valueobject"
+	"upwork-test/internal/domain/market/entity"
+	marketrepo "upwork-test/internal/domain/market/repository"
+)
+
+const (
+	// activityWindow is the period activity metrics cover
+	activityWindow = 24 * time.Hour
+	// watermarkOverlap re-fetches the tail of the previous fetch to catch late-published trades.
+	// Duplicates are dropped by trade ID.
+	watermarkOverlap = 1 * time.Minute
+)
+
+// Throttle paces upstream calls; *rate.Limiter satisfies it
+type Throttle interface {
+	Wait(ctx context.Context) error
+}
+
+// ActivityPipeline computes category activity metrics from the full market set and
+// a rolling window of trades, fetching only trades newer than each market's watermark.
+// Upstream calls are paced by the Kalshi client, one token per page.
+type ActivityPipeline struct {
+	marketRepo      marketrepo.MarketRepository
+	categoryRepo    repository.CategoryRepository
+	tradeRepo       marketrepo.TradeWindowRepository
+	activityRepo    repository.ActivityRepository
+	analyzer        *categoryservice.ActivityAnalyzer
+	maxTradeFetches int
+}
+
+// NewActivityPipeline creates a new activity pipeline.
+// maxTradeFetches caps the markets whose trades are fetched per category and run;
+// the stalest markets are fetched first so coverage catches up over successive runs.
+func NewActivityPipeline(
+	marketRepo marketrepo.MarketRepository,
+	categoryRepo repository.CategoryRepository,
+	tradeRepo marketrepo.TradeWindowRepository,
+	activityRepo repository.ActivityRepository,
+	analyzer *categoryservice.ActivityAnalyzer,
+	maxTradeFetches int,
+) *ActivityPipeline {
+	return &ActivityPipeline{
+		marketRepo:      marketRepo,
+		categoryRepo:    categoryRepo,
+		tradeRepo:       tradeRepo,
+		activityRepo:    activityRepo,
+		analyzer:        analyzer,
+		maxTradeFetches: maxTradeFetches,
+	}
+}
+
+// Run updates activity metrics and overviews for all categories
+func (ap *ActivityPipeline) Run(ctx context.Context) error {
+	categories, err := ap.categoryRepo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	for _, cat := range categories {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err := ap.RunCategory(ctx, cat.Name); err != nil {
+			fmt.Printf("Warning: failed to compute activity for category %s: %v\n", cat.Name.String(), err)
+			continue
+		}
+
+		if _, err := ap.categoryRepo.RefreshOverview(ctx, cat.Name.String()); err != nil {
+			fmt.Printf("Warning: failed to refresh overview for category %s: %v\n", cat.Name.String(), err)
+		}
+	}
+
+	return nil
+}
+
+// RunCategory fetches new trades for a category's markets and recomputes its activity metrics
+func (ap *ActivityPipeline) RunCategory(ctx context.Context, category valueobject.CategoryName) error {
+	name := category.String()
+
+	markets, err := ap.marketRepo.ListAllByCategory(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to list markets: %w", err)
+	}
+
+	watermarks, err := ap.tradeRepo.GetWatermarks(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	windowStart := time.Now().Add(-activityWindow)
+	updated := make(map[string]time.Time)
+	incomplete := make(map[string]bool)
+
+	for _, market := range ap.tradeFetchQueue(markets, watermarks) {
+		ticker := market.Ticker.String()
+
+		since := windowStart
+		if watermark, ok := watermarks[ticker]; ok && watermark.After(since) {
+			since = watermark.Add(-watermarkOverlap)
+		}
+
+		fetchedAt := time.Now()
+		trades, truncated, err := ap.marketRepo.GetTradesSince(ctx, ticker, since)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			fmt.Printf("Warning: failed to fetch trades for %s: %v\n", ticker, err)
+			continue
+		}
+
+		if _, err := ap.tradeRepo.Add(ctx, name, trades); err != nil {
+			return err
+		}
+
+		// The watermark only moves up to the newest trade received, never past trades not fetched
+		updated[ticker] = fetchedAt
+		if len(trades) > 0 {
+			updated[ticker] = newestTrade(trades)
+		}
+		if truncated {
+			fmt.Printf("Warning: trades for %s since %s were truncated; metrics undercount it\n", ticker, since.Format(time.RFC3339))
+			incomplete[ticker] = true
+		}
+	}
+
+	if err := ap.tradeRepo.SetWatermarks(ctx, name, updated); err != nil {
+		return err
+	}
+	if err := ap.tradeRepo.Prune(ctx, name, windowStart); err != nil {
+		return err
+	}
+
+	trades, err := ap.tradeRepo.ListSince(ctx, name, windowStart)
+	if err != nil {
+		return err
+	}
+
+	tracked := make(map[string]bool, len(watermarks)+len(updated))
+	for ticker, watermark := range watermarks {
+		if watermark.After(windowStart) {
+			tracked[ticker] = true
+		}
+	}
+	for ticker := range updated {
+		tracked[ticker] = true
+	}
+	for ticker := range incomplete {
+		delete(tracked, ticker)
+	}
+
+	activity := ap.analyzer.Analyze(category, activityWindow, markets, trades, tracked)
+	return ap.activityRepo.Save(ctx, activity)
+}
+
+// tradeFetchQueue returns the open markets with 24h volume whose trades should be fetched this run,
+// never-fetched markets first, then by oldest watermark
+func (ap *ActivityPipeline) tradeFetchQueue(markets []*entity.Market, watermarks map[string]time.Time) []*entity.Market {
+	queue := make([]*entity.Market, 0, len(markets))
+	for _, market := range markets {
+		if market.IsOpen() && market.Volume24h > 0 {
+			queue = append(queue, market)
+		}
+	}
+
+	sort.SliceStable(queue, func(i, j int) bool {
+		wi, iok := watermarks[queue[i].Ticker.String()]
+		wj, jok := watermarks[queue[j].Ticker.String()]
+		if iok != jok {
+			return !iok
+		}
+		if !wi.Equal(wj) {
+			return wi.Before(wj)
+		}
+		return queue[i].Volume24h > queue[j].Volume24h
+	})
+
+	if ap.maxTradeFetches > 0 && len(queue) > ap.maxTradeFetches {
+		queue = queue[:ap.maxTradeFetches]
+	}
+
+	return queue
+}
+
+// newestTrade returns the creation time of the most recent trade
+func newestTrade(trades []*entity.Trade) time.Time {
+	var newest time.Time
+	for _, trade := range trades {
+		if trade.Timestamp.After(newest) {
+			newest = trade.Timestamp
+		}
+	}
+	return newest
+}
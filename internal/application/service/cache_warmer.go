@@ -40,7 +40,7 @@ func (cw *CacheWarmer) WarmHotMarkets(ctx context.Context, topN int) error {
 	var allMarkets []marketWithVolume
 
 	for _, cat := range categories {
-		markets, _, err := cw.marketRepo.ListByCategory(ctx, cat.Name.String(), 1, 200, "")
+		markets, _, err := cw.marketRepo.ListByCategory(ctx, cat.Name.String(), 1, 200, marketrepo.MarketQuery{})
 		if err != nil {
 			fmt.Printf("Warning: failed to get markets for category %s: %v\n", cat.Name.String(), err)
 			continue
@@ -107,7 +107,7 @@ func (cw *CacheWarmer) WarmCategoryLists(ctx context.Context) error {
 
 // WarmMarketsByCategory refreshes market list cache for a specific category
 func (cw *CacheWarmer) WarmMarketsByCategory(ctx context.Context, category string) error {
-	_, _, err := cw.marketRepo.ListByCategory(ctx, category, 1, 200, "")
+	_, _, err := cw.marketRepo.ListByCategory(ctx, category, 1, 200, marketrepo.MarketQuery{})
 	if err != nil {
 		return fmt.Errorf("failed to warm markets for category %s: %w", category, err)
 	}
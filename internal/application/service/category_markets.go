@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"upwork-test/internal/domain/market/entity"
+	marketrepo "upwork-test/internal/domain/market/repository"
+)
+
+// categoryPageSize is the number of markets read per page of a category listing
+const categoryPageSize = 1000
+
+// forEachMarketPage calls fn with every page of a category's market listing, stopping at the first error
+func forEachMarketPage(ctx context.Context, marketRepo marketrepo.MarketRepository, category string, fn func(markets []*entity.Market) error) error {
+	for page := 1; ; page++ {
+		markets, total, err := marketRepo.ListByCategory(ctx, category, page, categoryPageSize, marketrepo.MarketQuery{})
+		if err != nil {
+			return fmt.Errorf("failed to get markets: %w", err)
+		}
+
+		if err := fn(markets); err != nil {
+			return err
+		}
+
+		if len(markets) < categoryPageSize || page*categoryPageSize >= total {
+			return nil
+		}
+	}
+}
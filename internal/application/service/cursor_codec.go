@@ -0,0 +1,94 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/repository"
+)
+
+// cursorSignatureSize is the number of HMAC-SHA256 bytes kept in a token
+const cursorSignatureSize = 16
+
+var (
+	// ErrInvalidCursor is returned when a cursor token is malformed, tampered with or issued for another listing
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// cursorPayload is the signed content of a cursor token
+type cursorPayload struct {
+	Version int64                `json:"s"`
+	SortKey entity.MarketSortKey `json:"k"`
+	Ticker  string               `json:"t"`
+	Before  bool                 `json:"b,omitempty"`
+}
+
+// CursorCodec encodes listing cursors as opaque, signed tokens. Each token is bound to a
+// scope (the listing and its filters) so it cannot be replayed against a different listing.
+type CursorCodec struct {
+	secret []byte
+}
+
+// NewCursorCodec creates a new cursor codec signing with the given secret.
+func NewCursorCodec(secret string) *CursorCodec {
+	return &CursorCodec{
+		secret: []byte(secret),
+	}
+}
+
+// Encode returns the token for a cursor within a scope.
+func (c *CursorCodec) Encode(cursor *repository.MarketCursor, scope string) string {
+	payload, _ := json.Marshal(cursorPayload{
+		Version: cursor.Version,
+		SortKey: cursor.SortKey,
+		Ticker:  cursor.Ticker,
+		Before:  cursor.Before,
+	})
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(encoded, scope))
+}
+
+// Decode verifies a token against a scope and returns its cursor.
+func (c *CursorCodec) Decode(token string, scope string) (*repository.MarketCursor, error) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found {
+		return nil, ErrInvalidCursor
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, c.sign(encoded, scope)) {
+		return nil, ErrInvalidCursor
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil || payload.Ticker == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	return &repository.MarketCursor{
+		Version: payload.Version,
+		SortKey: payload.SortKey,
+		Ticker:  payload.Ticker,
+		Before:  payload.Before,
+	}, nil
+}
+
+// sign computes the truncated HMAC of an encoded payload within a scope.
+func (c *CursorCodec) sign(encoded string, scope string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(scope))
+	mac.Write([]byte{0})
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)[:cursorSignatureSize]
+}
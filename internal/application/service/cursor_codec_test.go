@@ -0,0 +1,117 @@
+package service
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCursorScope = "economics?sort=volume_24h"
+
+func TestCursorCodecRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor repository.MarketCursor
+	}{
+		{
+			name:   "sorted position",
+			cursor: repository.MarketCursor{Version: 1733011200000000000, SortKey: entity.MarketSortKey{Value: 4200}, Ticker: "KXHIGHNY-24DEC31-B45"},
+		},
+		{
+			name:   "backwards from a missing key",
+			cursor: repository.MarketCursor{Version: 7, SortKey: entity.MarketSortKey{Missing: true}, Ticker: "KXCPIYOY-24NOV-T2.7", Before: true},
+		},
+		{
+			name:   "unsorted position",
+			cursor: repository.MarketCursor{Ticker: "KXFEDDECISION-24DEC-C25"},
+		},
+		{
+			name:   "negative key",
+			cursor: repository.MarketCursor{Version: 1, SortKey: entity.MarketSortKey{Value: -1}, Ticker: "KXA"},
+		},
+	}
+
+	codec := NewCursorCodec("test-secret")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := codec.Encode(&tt.cursor, testCursorScope)
+			assert.NotContains(t, token, tt.cursor.Ticker, "tokens are opaque")
+
+			decoded, err := codec.Decode(token, testCursorScope)
+			require.NoError(t, err)
+			assert.Equal(t, tt.cursor, *decoded)
+		})
+	}
+}
+
+func TestCursorCodecRejects(t *testing.T) {
+	codec := NewCursorCodec("test-secret")
+	cursor := &repository.MarketCursor{Version: 1, SortKey: entity.MarketSortKey{Value: 300}, Ticker: "KXB"}
+	token := codec.Encode(cursor, testCursorScope)
+	encoded, signature, _ := strings.Cut(token, ".")
+
+	// forged re-signs a payload with the wrong secret, as a client guessing it would
+	forged := func(payload string) string {
+		encodedPayload := base64.RawURLEncoding.EncodeToString([]byte(payload))
+		return encodedPayload + "." + base64.RawURLEncoding.EncodeToString(NewCursorCodec("guess").sign(encodedPayload, testCursorScope))
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		scope string
+	}{
+		{name: "other category", token: token, scope: "climate?sort=volume_24h"},
+		{name: "other filters", token: token, scope: "economics?sort=price"},
+		{name: "no filters", token: token, scope: "economics?"},
+		{name: "other secret", token: NewCursorCodec("other-secret").Encode(cursor, testCursorScope), scope: testCursorScope},
+		{
+			name:  "tampered payload",
+			token: base64.RawURLEncoding.EncodeToString([]byte(`{"s":1,"k":{"v":0},"t":"KXB"}`)) + "." + signature,
+			scope: testCursorScope,
+		},
+		{name: "tampered signature", token: encoded + "." + base64.RawURLEncoding.EncodeToString(make([]byte, cursorSignatureSize)), scope: testCursorScope},
+		{name: "truncated signature", token: encoded + "." + signature[:len(signature)-2], scope: testCursorScope},
+		{name: "forged payload", token: forged(`{"s":1,"k":{"v":300},"t":"KXB"}`), scope: testCursorScope},
+		{name: "no signature", token: encoded, scope: testCursorScope},
+		{name: "signature not base64", token: encoded + ".!!!", scope: testCursorScope},
+		{name: "empty", token: "", scope: testCursorScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := codec.Decode(tt.token, tt.scope)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
+
+func TestCursorCodecRejectsSignedGarbage(t *testing.T) {
+	codec := NewCursorCodec("test-secret")
+
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{name: "not JSON", payload: "KXB"},
+		{name: "no ticker", payload: `{"s":1,"k":{"v":300}}`},
+		{name: "wrong types", payload: `{"s":"one","t":"KXB"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := base64.RawURLEncoding.EncodeToString([]byte(tt.payload))
+			token := encoded + "." + base64.RawURLEncoding.EncodeToString(codec.sign(encoded, testCursorScope))
+
+			_, err := codec.Decode(token, testCursorScope)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"upwork-test/internal/domain/category/repository"
+	categoryservice "upwork-test/internal/domain/category/service"
+	"upwork-test/internal/domain/category/valueobject"
+	"upwork-test/internal/domain/market/entity"
+	marketrepo "upwork-test/internal/domain/market/repository"
+)
+
+const (
+	// MaxMoversLimit is the length of the ranked movers lists; requests may ask for fewer
+	MaxMoversLimit = 50
+
+	// moversSnapshotTolerance is how far before the window start a price snapshot may have been taken
+	moversSnapshotTolerance = 15 * time.Minute
+)
+
+// moverWindows are the windows movers are ranked over on every capture
+var moverWindows = []valueobject.MoverWindow{
+	valueobject.MoverWindow1h,
+	valueobject.MoverWindow6h,
+	valueobject.MoverWindow24h,
+}
+
+// PriceSnapshotter records the last price of every listed market so price changes
+// can be measured over windows shorter than Kalshi's previous-day prices, and ranks
+// the movers of every window from the same listing so the API never scans markets itself
+type PriceSnapshotter struct {
+	marketRepo   marketrepo.MarketRepository
+	categoryRepo repository.CategoryRepository
+	snapshotRepo marketrepo.PriceSnapshotRepository
+	moversRepo   repository.MoversRepository
+	ranker       *categoryservice.MoversRanker
+}
+
+// NewPriceSnapshotter creates a new price snapshotter
+func NewPriceSnapshotter(
+	marketRepo marketrepo.MarketRepository,
+	categoryRepo repository.CategoryRepository,
+	snapshotRepo marketrepo.PriceSnapshotRepository,
+	moversRepo repository.MoversRepository,
+	ranker *categoryservice.MoversRanker,
+) *PriceSnapshotter {
+	return &PriceSnapshotter{
+		marketRepo:   marketRepo,
+		categoryRepo: categoryRepo,
+		snapshotRepo: snapshotRepo,
+		moversRepo:   moversRepo,
+		ranker:       ranker,
+	}
+}
+
+// Capture snapshots the last prices of markets in all categories, then ranks their movers.
+// Returns the number of markets recorded.
+func (ps *PriceSnapshotter) Capture(ctx context.Context) (int, error) {
+	categories, err := ps.categoryRepo.GetAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	snapshot := entity.NewPriceSnapshot()
+	listed := make(map[string][]*entity.Market, len(categories))
+	for _, cat := range categories {
+		var markets []*entity.Market
+		err := forEachMarketPage(ctx, ps.marketRepo, cat.Name.String(), func(page []*entity.Market) error {
+			markets = append(markets, page...)
+			return nil
+		})
+		if err != nil {
+			fmt.Printf("Warning: failed to get markets for category %s: %v\n", cat.Name.String(), err)
+			continue
+		}
+		snapshot.Record(markets)
+		listed[cat.Name.String()] = markets
+	}
+
+	if err := ps.snapshotRepo.Save(ctx, snapshot); err != nil {
+		return 0, err
+	}
+
+	for _, window := range moverWindows {
+		if err := ps.rankMovers(ctx, window, listed); err != nil {
+			fmt.Printf("Warning: failed to rank %s movers: %v\n", window, err)
+		}
+	}
+
+	return len(snapshot.Prices), nil
+}
+
+// rankMovers ranks the movers of a window in each category and across all of them.
+// Windows without a price snapshot old enough are skipped until the history exists.
+func (ps *PriceSnapshotter) rankMovers(ctx context.Context, window valueobject.MoverWindow, listed map[string][]*entity.Market) error {
+	baselineAt := time.Now().Add(-window.Duration())
+	baseline := categoryservice.PreviousDayBaseline
+
+	if !window.UsesPreviousDayPrices() {
+		snapshot, err := ps.snapshotRepo.GetAt(ctx, baselineAt, moversSnapshotTolerance)
+		if err != nil {
+			if errors.Is(err, marketrepo.ErrSnapshotNotFound) {
+				return nil
+			}
+			return fmt.Errorf("failed to get price snapshot: %w", err)
+		}
+		baselineAt = snapshot.TakenAt
+		baseline = categoryservice.SnapshotBaseline(snapshot.Prices)
+	}
+
+	var all []*entity.Market
+	for category, markets := range listed {
+		movers := ps.ranker.Rank(window, baselineAt, markets, baseline, MaxMoversLimit)
+		if err := ps.moversRepo.Save(ctx, category, movers); err != nil {
+			return err
+		}
+		all = append(all, markets...)
+	}
+
+	return ps.moversRepo.Save(ctx, "", ps.ranker.Rank(window, baselineAt, all, baseline, MaxMoversLimit))
+}
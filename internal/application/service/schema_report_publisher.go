@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"upwork-test/internal/domain/diagnostics/entity"
+	"upwork-test/internal/domain/diagnostics/repository"
+)
+
+// SchemaReporter produces a snapshot of the schema diagnostics collected in this process
+type SchemaReporter interface {
+	Report(source string) *entity.SchemaReport
+}
+
+// SchemaReportPublisher periodically shares this process's schema report, so the diagnostics
+// endpoint of any API process can show the drift seen by all of them.
+type SchemaReportPublisher struct {
+	reporter   SchemaReporter
+	reportRepo repository.SchemaReportRepository
+	source     string
+	interval   time.Duration
+}
+
+// NewSchemaReportPublisher creates a new schema report publisher. The report is published
+// under source every interval.
+func NewSchemaReportPublisher(reporter SchemaReporter, reportRepo repository.SchemaReportRepository, source string, interval time.Duration) *SchemaReportPublisher {
+	return &SchemaReportPublisher{
+		reporter:   reporter,
+		reportRepo: reportRepo,
+		source:     source,
+		interval:   interval,
+	}
+}
+
+// Run publishes the report until ctx is done, once at start and then every interval
+func (p *SchemaReportPublisher) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		report := p.reporter.Report(p.source)
+		if report.HasDrift() {
+			fmt.Printf("[%s] Kalshi schema drift detected, see /api/v1/admin/diagnostics/schema\n", time.Now().Format(time.RFC3339))
+		}
+		if err := p.reportRepo.Save(ctx, report); err != nil {
+			fmt.Printf("Error publishing schema report: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DiagnosticsSource identifies this process in shared diagnostics by its role and host
+func DiagnosticsSource(role string) string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return role
+	}
+	return role + "@" + hostname
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	marketrepo "upwork-test/internal/domain/market/repository"
+	marketservice "upwork-test/internal/domain/market/service"
+)
+
+// SearchIndexSync keeps an in-process search index in step with the shared search repository.
+// The first sync loads every document; later syncs apply only the tickers changed since the
+// version the index holds.
+type SearchIndexSync struct {
+	searchRepo marketrepo.SearchRepository
+	index      *marketservice.SearchIndex
+	interval   time.Duration
+
+	mu       sync.Mutex
+	version  int64
+	syncedAt time.Time
+}
+
+// NewSearchIndexSync creates a new search index sync that checks for changes at most once per interval
+func NewSearchIndexSync(
+	searchRepo marketrepo.SearchRepository,
+	index *marketservice.SearchIndex,
+	interval time.Duration,
+) *SearchIndexSync {
+	return &SearchIndexSync{
+		searchRepo: searchRepo,
+		index:      index,
+		interval:   interval,
+	}
+}
+
+// EnsureFresh syncs the index if it has not been checked within the interval.
+// Concurrent callers wait for a single sync rather than each querying Redis.
+func (s *SearchIndexSync) EnsureFresh(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.syncedAt) < s.interval {
+		return nil
+	}
+
+	if err := s.sync(ctx); err != nil {
+		return err
+	}
+
+	s.syncedAt = time.Now()
+	return nil
+}
+
+// Version returns the repository version the index reflects
+func (s *SearchIndexSync) Version() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.version
+}
+
+// sync applies repository changes to the index. Caller must hold mu.
+func (s *SearchIndexSync) sync(ctx context.Context) error {
+	version, err := s.searchRepo.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	if version == s.version {
+		return nil
+	}
+
+	// First load, or the repository was reset underneath us
+	if s.version == 0 || version < s.version {
+		return s.reload(ctx)
+	}
+
+	changed, err := s.searchRepo.ChangedSince(ctx, s.version)
+	if errors.Is(err, marketrepo.ErrSearchChangesPruned) {
+		// Fell further behind than the repository keeps changes for
+		return s.reload(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	docs, err := s.searchRepo.GetMany(ctx, changed)
+	if err != nil {
+		return err
+	}
+
+	present := make(map[string]bool, len(docs))
+	for _, doc := range docs {
+		present[doc.Ticker] = true
+	}
+
+	var removed []string
+	for _, ticker := range changed {
+		if !present[ticker] {
+			removed = append(removed, ticker)
+		}
+	}
+
+	s.index.Put(docs...)
+	s.index.Remove(removed...)
+	s.version = version
+
+	if len(changed) > 0 {
+		fmt.Printf("Search index synced to version %d (%d changed)\n", version, len(changed))
+	}
+
+	return nil
+}
+
+// reload replaces the index with every document. Caller must hold mu.
+func (s *SearchIndexSync) reload(ctx context.Context) error {
+	docs, version, err := s.searchRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	s.index.Replace(docs)
+	s.version = version
+	return nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"upwork-test/internal/domain/category/repository"
+	"upwork-test/internal/domain/market/entity"
+	marketrepo "upwork-test/internal/domain/market/repository"
+)
+
+// SearchIndexer builds market search documents and publishes the ones that changed
+// since the last run to the shared search repository
+type SearchIndexer struct {
+	marketRepo   marketrepo.MarketRepository
+	categoryRepo repository.CategoryRepository
+	eventRepo    marketrepo.EventRepository
+	seriesRepo   marketrepo.SeriesRepository
+	searchRepo   marketrepo.SearchRepository
+}
+
+// NewSearchIndexer creates a new search indexer
+func NewSearchIndexer(
+	marketRepo marketrepo.MarketRepository,
+	categoryRepo repository.CategoryRepository,
+	eventRepo marketrepo.EventRepository,
+	seriesRepo marketrepo.SeriesRepository,
+	searchRepo marketrepo.SearchRepository,
+) *SearchIndexer {
+	return &SearchIndexer{
+		marketRepo:   marketRepo,
+		categoryRepo: categoryRepo,
+		eventRepo:    eventRepo,
+		seriesRepo:   seriesRepo,
+		searchRepo:   searchRepo,
+	}
+}
+
+// Reindex rebuilds documents for every listed market and applies the differences.
+// Documents are only removed when every category was fetched, so an upstream error
+// never empties part of the index.
+// Returns the number of documents upserted and removed.
+func (si *SearchIndexer) Reindex(ctx context.Context) (upserted int, removed int, err error) {
+	categories, err := si.categoryRepo.GetAll(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	events := make(map[string]*entity.Event)
+	if openEvents, err := si.eventRepo.ListByStatus(ctx, "open"); err != nil {
+		fmt.Printf("Warning: failed to get events for search: %v\n", err)
+	} else {
+		for _, event := range openEvents {
+			events[event.EventTicker] = event
+		}
+	}
+
+	docs := make(map[string]*entity.SearchDocument)
+	complete := true
+
+	for _, cat := range categories {
+		name := cat.Name.String()
+
+		series := make(map[string]*entity.Series)
+		if categorySeries, err := si.seriesRepo.ListByCategory(ctx, name); err != nil {
+			fmt.Printf("Warning: failed to get series for category %s: %v\n", name, err)
+		} else {
+			for _, s := range categorySeries {
+				series[s.Ticker] = s
+			}
+		}
+
+		markets, err := si.categoryMarkets(ctx, name)
+		if err != nil {
+			fmt.Printf("Warning: failed to get markets for category %s: %v\n", name, err)
+			complete = false
+			continue
+		}
+
+		for _, market := range markets {
+			event := events[market.EventTicker]
+			var marketSeries *entity.Series
+			if event != nil {
+				marketSeries = series[event.SeriesTicker]
+			}
+			docs[market.Ticker.String()] = entity.NewSearchDocument(market, event, marketSeries)
+		}
+	}
+
+	fingerprints, err := si.searchRepo.Fingerprints(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var upserts []*entity.SearchDocument
+	for ticker, doc := range docs {
+		if fingerprints[ticker] != doc.Fingerprint() {
+			upserts = append(upserts, doc)
+		}
+	}
+
+	var removals []string
+	if complete {
+		for ticker := range fingerprints {
+			if _, ok := docs[ticker]; !ok {
+				removals = append(removals, ticker)
+			}
+		}
+	}
+
+	if _, err := si.searchRepo.Apply(ctx, upserts, removals); err != nil {
+		return 0, 0, err
+	}
+
+	return len(upserts), len(removals), nil
+}
+
+// categoryMarkets returns every open market in a category plus the recently listed markets
+// of any status, so closed and settled markets remain searchable while they are listed
+func (si *SearchIndexer) categoryMarkets(ctx context.Context, category string) ([]*entity.Market, error) {
+	open, err := si.marketRepo.ListAllByCategory(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	listed, _, err := si.marketRepo.ListByCategory(ctx, category, 1, 1000, marketrepo.MarketQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(open))
+	markets := make([]*entity.Market, 0, len(open)+len(listed))
+	for _, market := range append(open, listed...) {
+		if seen[market.Ticker.String()] {
+			continue
+		}
+		seen[market.Ticker.String()] = true
+		markets = append(markets, market)
+	}
+
+	return markets, nil
+}
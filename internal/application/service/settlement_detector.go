@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"upwork-test/internal/domain/category/repository"
+	"upwork-test/internal/domain/market/entity"
+	marketrepo "upwork-test/internal/domain/market/repository"
+)
+
+// SettlementPublisher defines the interface for emitting settlement events to downstream consumers
+type SettlementPublisher interface {
+	PublishSettlement(ctx context.Context, settlement *entity.Settlement) error
+}
+
+// SettlementDetector finds newly settled or amended settlements, stores them permanently and emits events
+type SettlementDetector struct {
+	marketRepo     marketrepo.MarketRepository
+	categoryRepo   repository.CategoryRepository
+	settlementRepo marketrepo.SettlementRepository
+	publisher      SettlementPublisher
+}
+
+// NewSettlementDetector creates a new settlement detector
+func NewSettlementDetector(
+	marketRepo marketrepo.MarketRepository,
+	categoryRepo repository.CategoryRepository,
+	settlementRepo marketrepo.SettlementRepository,
+	publisher SettlementPublisher,
+) *SettlementDetector {
+	return &SettlementDetector{
+		marketRepo:     marketRepo,
+		categoryRepo:   categoryRepo,
+		settlementRepo: settlementRepo,
+		publisher:      publisher,
+	}
+}
+
+// DetectSettlements scans every category for settled markets that have not been seen before,
+// or whose settlement changed since. Returns the number of new or changed settlements.
+func (sd *SettlementDetector) DetectSettlements(ctx context.Context) (int, error) {
+	categories, err := sd.categoryRepo.GetAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	detected := 0
+	for _, cat := range categories {
+		markets, _, err := sd.marketRepo.ListByCategory(ctx, cat.Name.String(), 1, 1000, marketrepo.MarketQuery{})
+		if err != nil {
+			fmt.Printf("Warning: failed to get markets for category %s: %v\n", cat.Name.String(), err)
+			continue
+		}
+
+		for _, market := range markets {
+			if !market.IsSettled() {
+				continue
+			}
+
+			changed, err := sd.settlementRepo.Save(ctx, market)
+			if err != nil {
+				fmt.Printf("Warning: failed to store settlement for %s: %v\n", market.Ticker.String(), err)
+				continue
+			}
+			if !changed {
+				continue
+			}
+
+			detected++
+			if err := sd.publisher.PublishSettlement(ctx, market.Settlement); err != nil {
+				fmt.Printf("Warning: failed to publish settlement for %s: %v\n", market.Ticker.String(), err)
+			}
+		}
+	}
+
+	return detected, nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/repository"
+	authservice "upwork-test/internal/domain/auth/service"
+	"upwork-test/internal/domain/auth/valueobject"
+)
+
+const (
+	// signingKeyPublishAhead is how long a key is published before it signs, so every API
+	// process and every verifier caching the JWKS knows it by then
+	signingKeyPublishAhead = 15 * time.Minute
+	// signingKeyExpiryMargin keeps a retired key published a little past its last token's
+	// expiry, for verifiers whose clocks run behind
+	signingKeyExpiryMargin = 5 * time.Minute
+	// signingKeyLockTTL bounds how long a crashed process can block rotation
+	signingKeyLockTTL = 30 * time.Second
+)
+
+// SigningKeyRotator keeps the token service's key set in step with the signing keys shared
+// by all API processes, and rotates them on schedule: each key signs for the rotation
+// period, its successor is published ahead of time, and keys are deleted once every token
+// they signed has expired.
+type SigningKeyRotator struct {
+	keyRepo      repository.SigningKeyRepository
+	tokenService *authservice.TokenService
+	algorithm    valueobject.SigningAlgorithm
+	rotation     time.Duration
+}
+
+// NewSigningKeyRotator creates a new signing key rotator. New keys use algorithm and sign for rotation.
+func NewSigningKeyRotator(
+	keyRepo repository.SigningKeyRepository,
+	tokenService *authservice.TokenService,
+	algorithm valueobject.SigningAlgorithm,
+	rotation time.Duration,
+) *SigningKeyRotator {
+	return &SigningKeyRotator{
+		keyRepo:      keyRepo,
+		tokenService: tokenService,
+		algorithm:    algorithm,
+		rotation:     rotation,
+	}
+}
+
+// Init loads the key set, creating the first key if there is none. When another process
+// is creating it, Init waits for that key rather than adding its own.
+func (r *SigningKeyRotator) Init(ctx context.Context) error {
+	deadline := time.Now().Add(signingKeyLockTTL)
+	for {
+		if err := r.Sync(ctx); err != nil {
+			return err
+		}
+		if r.tokenService.CanSign() {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return authservice.ErrNoSigningKey
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// Sync reloads the key set, adding the next key when the current one is about to retire
+// and deleting expired keys. It must run well within signingKeyPublishAhead.
+func (r *SigningKeyRotator) Sync(ctx context.Context) error {
+	keys, err := r.keyRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if r.needsKey(keys, now) {
+		if keys, err = r.rotate(ctx, keys, now); err != nil {
+			return err
+		}
+	}
+
+	current := make([]*entity.SigningKey, 0, len(keys))
+	for _, key := range keys {
+		if !key.IsExpired(now) {
+			current = append(current, key)
+			continue
+		}
+		if err := r.keyRepo.Delete(ctx, key.ID); err != nil {
+			fmt.Printf("Warning: failed to delete expired signing key %s: %v\n", key.ID, err)
+		}
+	}
+
+	return r.tokenService.SetKeys(current)
+}
+
+// rotate adds the next key unless another process holds the lock, returning the new key set
+func (r *SigningKeyRotator) rotate(ctx context.Context, keys []*entity.SigningKey, now time.Time) ([]*entity.SigningKey, error) {
+	lockToken, acquired, err := r.keyRepo.AcquireRotationLock(ctx, signingKeyLockTTL)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		// Another process is adding the key; a later sync loads it
+		return keys, nil
+	}
+	defer func() {
+		if err := r.keyRepo.ReleaseRotationLock(ctx, lockToken); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+	}()
+
+	// The previous holder may have added the key since the list was read
+	if keys, err = r.keyRepo.List(ctx); err != nil {
+		return nil, err
+	}
+	if !r.needsKey(keys, now) {
+		return keys, nil
+	}
+
+	// The successor takes over when the current key retires; without a current key,
+	// tokens cannot be issued at all, so a new key activates at once
+	activatesAt := now
+	if canSign(keys, now) {
+		activatesAt = latestKey(keys).RetiresAt
+	}
+	retiresAt := activatesAt.Add(r.rotation)
+	expiresAt := retiresAt.Add(r.tokenService.Expiration() + signingKeyExpiryMargin)
+
+	key, err := entity.GenerateSigningKey(r.algorithm, now, activatesAt, retiresAt, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.keyRepo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Created %s signing key %s, active from %s until %s\n", key.Algorithm, key.ID, activatesAt.Format(time.RFC3339), retiresAt.Format(time.RFC3339))
+	return append(keys, key), nil
+}
+
+// needsKey checks if no key can sign now, or the last key retires within the publish-ahead window
+func (r *SigningKeyRotator) needsKey(keys []*entity.SigningKey, now time.Time) bool {
+	if !canSign(keys, now) {
+		return true
+	}
+	return !now.Add(signingKeyPublishAhead).Before(latestKey(keys).RetiresAt)
+}
+
+// canSign checks if any key can sign now
+func canSign(keys []*entity.SigningKey, now time.Time) bool {
+	for _, key := range keys {
+		if key.CanSign(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// latestKey returns the key that retires last, or nil
+func latestKey(keys []*entity.SigningKey) *entity.SigningKey {
+	var latest *entity.SigningKey
+	for _, key := range keys {
+		if latest == nil || key.RetiresAt.After(latest.RetiresAt) {
+			latest = key
+		}
+	}
+	return latest
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"upwork-test/internal/domain/category/repository"
+	"upwork-test/internal/domain/market/entity"
+	marketrepo "upwork-test/internal/domain/market/repository"
+)
+
+// StatusTracker records market status transitions observed by the worker
+type StatusTracker struct {
+	marketRepo   marketrepo.MarketRepository
+	categoryRepo repository.CategoryRepository
+	historyRepo  marketrepo.StatusHistoryRepository
+}
+
+// NewStatusTracker creates a new status tracker
+func NewStatusTracker(
+	marketRepo marketrepo.MarketRepository,
+	categoryRepo repository.CategoryRepository,
+	historyRepo marketrepo.StatusHistoryRepository,
+) *StatusTracker {
+	return &StatusTracker{
+		marketRepo:   marketRepo,
+		categoryRepo: categoryRepo,
+		historyRepo:  historyRepo,
+	}
+}
+
+// TrackCategories observes the status of every listed market in all categories
+func (st *StatusTracker) TrackCategories(ctx context.Context) error {
+	categories, err := st.categoryRepo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	for _, cat := range categories {
+		err := forEachMarketPage(ctx, st.marketRepo, cat.Name.String(), func(markets []*entity.Market) error {
+			_, err := st.Observe(ctx, markets)
+			return err
+		})
+		if err != nil {
+			fmt.Printf("Warning: failed to track statuses for category %s: %v\n", cat.Name.String(), err)
+		}
+	}
+
+	return nil
+}
+
+// Observe compares each market's status with the last one seen and records any transition.
+// Returns the transitions that were recorded.
+func (st *StatusTracker) Observe(ctx context.Context, markets []*entity.Market) ([]*entity.StatusTransition, error) {
+	var transitions []*entity.StatusTransition
+
+	for _, market := range markets {
+		// An unknown status says nothing of the lifecycle; the last known one is kept
+		if !market.Status.IsValid() {
+			continue
+		}
+
+		previous, found, err := st.historyRepo.SwapStatus(ctx, market.Ticker.String(), market.Status)
+		if err != nil {
+			return transitions, err
+		}
+
+		// First sighting or no change
+		if !found || previous == market.Status {
+			continue
+		}
+
+		transition := entity.NewStatusTransition(market.Ticker, previous, market.Status)
+		if err := st.historyRepo.AppendTransition(ctx, transition); err != nil {
+			return transitions, err
+		}
+
+		if !transition.Expected {
+			fmt.Printf("Warning: unexpected status transition for %s: %s -> %s\n", market.Ticker.String(), previous, market.Status)
+		}
+
+		transitions = append(transitions, transition)
+	}
+
+	return transitions, nil
+}
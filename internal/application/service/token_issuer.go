@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/repository"
+	authservice "upwork-test/internal/domain/auth/service"
+	"upwork-test/internal/domain/auth/valueobject"
+
+	"github.com/google/uuid"
+)
+
+// TokenIssuer issues short-lived access tokens paired with single-use refresh tokens.
+// The refresh tokens issued from one authentication form a family: each refresh
+// consumes a token and issues its replacement in the same family.
+type TokenIssuer struct {
+	tokenService *authservice.TokenService
+	refreshRepo  repository.RefreshTokenRepository
+	refreshTTL   time.Duration
+}
+
+// NewTokenIssuer creates a new token issuer. Refresh tokens expire refreshTTL after issue.
+func NewTokenIssuer(tokenService *authservice.TokenService, refreshRepo repository.RefreshTokenRepository, refreshTTL time.Duration) *TokenIssuer {
+	return &TokenIssuer{
+		tokenService: tokenService,
+		refreshRepo:  refreshRepo,
+		refreshTTL:   refreshTTL,
+	}
+}
+
+// RefreshTTL returns how long a refresh token can be exchanged
+func (i *TokenIssuer) RefreshTTL() time.Duration {
+	return i.refreshTTL
+}
+
+// Issue issues an access token granting a client's scopes and tier, and the first refresh
+// token of a new family
+func (i *TokenIssuer) Issue(ctx context.Context, client *entity.Client) (*dto.TokenResponse, error) {
+	refreshToken, record, err := i.newRefreshToken(client.ID, uuid.NewString())
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := i.tokenPair(client, refreshToken, record)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.refreshRepo.Create(ctx, record); err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return response, nil
+}
+
+// Rotate consumes a refresh token and issues a new access token granting the client's current
+// scopes and tier with the token's replacement. It fails with the repository's reuse or
+// revocation errors if the token cannot be consumed.
+func (i *TokenIssuer) Rotate(ctx context.Context, consumed *entity.RefreshToken, client *entity.Client) (*dto.TokenResponse, error) {
+	refreshToken, record, err := i.newRefreshToken(consumed.ClientID, consumed.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign first so a consumed token is never lost to a signing failure
+	response, err := i.tokenPair(client, refreshToken, record)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.refreshRepo.Consume(ctx, consumed.ID, record); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (i *TokenIssuer) newRefreshToken(clientID string, familyID string) (valueobject.RefreshToken, *entity.RefreshToken, error) {
+	refreshToken, err := valueobject.GenerateRefreshToken()
+	if err != nil {
+		return valueobject.RefreshToken{}, nil, err
+	}
+
+	now := time.Now()
+	return refreshToken, &entity.RefreshToken{
+		ID:        refreshToken.Hash(),
+		FamilyID:  familyID,
+		ClientID:  clientID,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(i.refreshTTL),
+	}, nil
+}
+
+func (i *TokenIssuer) tokenPair(client *entity.Client, refreshToken valueobject.RefreshToken, record *entity.RefreshToken) (*dto.TokenResponse, error) {
+	token, err := i.tokenService.GenerateToken(client.ID, client.Scopes, client.Tier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	response := dto.NewTokenResponse(token.String(), token.ExpiresAt())
+	response.RefreshToken = refreshToken.String()
+	response.RefreshExpiresAt = record.ExpiresAt
+	return response, nil
+}
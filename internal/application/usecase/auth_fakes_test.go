@@ -0,0 +1,425 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	appservice "upwork-test/internal/application/service"
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/repository"
+	"upwork-test/internal/domain/auth/service"
+	"upwork-test/internal/domain/auth/valueobject"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testKeyHasher is shared by every test, since hashing is deliberately slow
+var testKeyHasher = service.NewKeyHasher()
+
+// fakeClientRepository is an in-memory client registry. Clients are copied in and out
+// through JSON, so callers cannot change stored clients except through the repository.
+type fakeClientRepository struct {
+	mu      sync.Mutex
+	clients map[string][]byte
+	err     error // Returned by every call when set
+	// conflicts is the number of coming updates that lose a race: the change runs on the
+	// stored client but is discarded, and runs again, as when another writer got there first
+	conflicts int
+}
+
+func newFakeClientRepository() *fakeClientRepository {
+	return &fakeClientRepository{clients: make(map[string][]byte)}
+}
+
+func (r *fakeClientRepository) GetByID(_ context.Context, clientID string) (*entity.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.load(clientID)
+}
+
+func (r *fakeClientRepository) GetByKeyID(_ context.Context, keyID string) (*entity.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	for clientID := range r.clients {
+		client, _ := r.load(clientID)
+		if client.FindKey(keyID) != nil {
+			return client, nil
+		}
+	}
+	return nil, repository.ErrClientNotFound
+}
+
+func (r *fakeClientRepository) List(_ context.Context) ([]*entity.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	clients := make([]*entity.Client, 0, len(r.clients))
+	for clientID := range r.clients {
+		client, _ := r.load(clientID)
+		clients = append(clients, client)
+	}
+	return clients, nil
+}
+
+func (r *fakeClientRepository) Create(_ context.Context, client *entity.Client) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+	if _, ok := r.clients[client.ID]; ok {
+		return repository.ErrClientExists
+	}
+	return r.store(client)
+}
+
+func (r *fakeClientRepository) Save(_ context.Context, client *entity.Client) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+	return r.store(client)
+}
+
+func (r *fakeClientRepository) Update(_ context.Context, clientID string, update repository.ClientUpdate) (*entity.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	for ; r.conflicts > 0; r.conflicts-- {
+		lost, err := r.load(clientID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := update(lost); err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := r.load(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := update(client)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		if err := r.store(client); err != nil {
+			return nil, err
+		}
+	}
+	return client, nil
+}
+
+func (r *fakeClientRepository) Delete(_ context.Context, clientID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+	if _, ok := r.clients[clientID]; !ok {
+		return repository.ErrClientNotFound
+	}
+	delete(r.clients, clientID)
+	return nil
+}
+
+// load returns a copy of a stored client. Caller must hold mu.
+func (r *fakeClientRepository) load(clientID string) (*entity.Client, error) {
+	data, ok := r.clients[clientID]
+	if !ok {
+		return nil, repository.ErrClientNotFound
+	}
+
+	var client entity.Client
+	if err := json.Unmarshal(data, &client); err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+// store saves a copy of a client, refusing key IDs held by another client. Caller must hold mu.
+func (r *fakeClientRepository) store(client *entity.Client) error {
+	for clientID := range r.clients {
+		if clientID == client.ID {
+			continue
+		}
+		other, _ := r.load(clientID)
+		for _, key := range client.Keys {
+			if other.FindKey(key.ID) != nil {
+				return repository.ErrKeyIDConflict
+			}
+		}
+	}
+
+	data, err := json.Marshal(client)
+	if err != nil {
+		return err
+	}
+	r.clients[client.ID] = data
+	return nil
+}
+
+// fakeAuditRepository records audit entries in memory
+type fakeAuditRepository struct {
+	mu      sync.Mutex
+	entries []*entity.AuditEntry
+}
+
+func (r *fakeAuditRepository) Append(_ context.Context, entry *entity.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *fakeAuditRepository) ListRecent(_ context.Context, limit int) ([]*entity.AuditEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recent := make([]*entity.AuditEntry, 0, limit)
+	for i := len(r.entries) - 1; i >= 0 && len(recent) < limit; i-- {
+		recent = append(recent, r.entries[i])
+	}
+	return recent, nil
+}
+
+// actions returns the audited actions in order
+func (r *fakeAuditRepository) actions() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	actions := make([]string, len(r.entries))
+	for i, entry := range r.entries {
+		actions[i] = entry.Action
+	}
+	return actions
+}
+
+// fakeRefreshTokenRepository keeps refresh tokens and revoked families in memory
+type fakeRefreshTokenRepository struct {
+	mu              sync.Mutex
+	tokens          map[string]entity.RefreshToken
+	revokedFamilies map[string]bool
+	// beforeConsume runs once at the start of the next Consume, to race it
+	beforeConsume func()
+}
+
+func newFakeRefreshTokenRepository() *fakeRefreshTokenRepository {
+	return &fakeRefreshTokenRepository{
+		tokens:          make(map[string]entity.RefreshToken),
+		revokedFamilies: make(map[string]bool),
+	}
+}
+
+func (r *fakeRefreshTokenRepository) Get(_ context.Context, tokenID string) (*entity.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.tokens[tokenID]
+	if !ok {
+		return nil, repository.ErrRefreshTokenNotFound
+	}
+	return &token, nil
+}
+
+func (r *fakeRefreshTokenRepository) Create(_ context.Context, token *entity.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens[token.ID] = *token
+	return nil
+}
+
+func (r *fakeRefreshTokenRepository) Consume(_ context.Context, tokenID string, replacement *entity.RefreshToken) error {
+	if race := r.beforeConsume; race != nil {
+		r.beforeConsume = nil
+		race()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.tokens[tokenID]
+	if !ok {
+		return repository.ErrRefreshTokenNotFound
+	}
+	if token.IsUsed() {
+		return repository.ErrRefreshTokenReused
+	}
+	if r.revokedFamilies[token.FamilyID] {
+		return repository.ErrTokenFamilyRevoked
+	}
+
+	token.UsedAt = time.Now()
+	r.tokens[tokenID] = token
+	r.tokens[replacement.ID] = *replacement
+	return nil
+}
+
+func (r *fakeRefreshTokenRepository) RevokeFamily(_ context.Context, familyID string, _ time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.revokedFamilies[familyID] = true
+	return nil
+}
+
+// isFamilyRevoked checks if a family was revoked
+func (r *fakeRefreshTokenRepository) isFamilyRevoked(familyID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.revokedFamilies[familyID]
+}
+
+// fakeTokenRevocationRepository keeps the access token denylist and client watermarks in memory
+type fakeTokenRevocationRepository struct {
+	mu         sync.Mutex
+	denylist   map[string]time.Duration
+	watermarks map[string]time.Time
+	err        error // Returned by lookups when set
+	setErr     error // Returned by SetClientWatermark when set
+
+	watermarkWrites int
+}
+
+func newFakeTokenRevocationRepository() *fakeTokenRevocationRepository {
+	return &fakeTokenRevocationRepository{
+		denylist:   make(map[string]time.Duration),
+		watermarks: make(map[string]time.Time),
+	}
+}
+
+func (r *fakeTokenRevocationRepository) RevokeToken(_ context.Context, tokenID string, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.denylist[tokenID] = ttl
+	return nil
+}
+
+func (r *fakeTokenRevocationRepository) IsTokenRevoked(_ context.Context, tokenID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return false, r.err
+	}
+	_, ok := r.denylist[tokenID]
+	return ok, nil
+}
+
+func (r *fakeTokenRevocationRepository) SetClientWatermark(_ context.Context, clientID string, issuedBefore time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.setErr != nil {
+		return r.setErr
+	}
+	r.watermarkWrites++
+	r.watermarks[clientID] = issuedBefore
+	return nil
+}
+
+func (r *fakeTokenRevocationRepository) GetClientWatermark(_ context.Context, clientID string) (time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return time.Time{}, r.err
+	}
+	return r.watermarks[clientID], nil
+}
+
+// newTestSigningKey returns an ES256 key that is active now
+func newTestSigningKey(t *testing.T) *entity.SigningKey {
+	t.Helper()
+
+	now := time.Now()
+	key, err := entity.GenerateSigningKey(valueobject.SigningAlgorithmES256, now, now.Add(-time.Minute), now.Add(time.Hour), now.Add(2*time.Hour))
+	require.NoError(t, err)
+	return key
+}
+
+// newTestTokenService returns a token service issuing 15-minute tokens with one active key
+func newTestTokenService(t *testing.T) *service.TokenService {
+	t.Helper()
+
+	tokenService := service.NewTokenService(15 * time.Minute)
+	require.NoError(t, tokenService.SetKeys([]*entity.SigningKey{newTestSigningKey(t)}))
+	return tokenService
+}
+
+// authFixture wires the auth use cases to in-memory repositories
+type authFixture struct {
+	clients     *fakeClientRepository
+	refresh     *fakeRefreshTokenRepository
+	revocations *fakeTokenRevocationRepository
+
+	tokenService      *service.TokenService
+	tokenIssuer       *appservice.TokenIssuer
+	revocationChecker *service.RevocationChecker
+}
+
+func newAuthFixture(t *testing.T) *authFixture {
+	t.Helper()
+
+	f := &authFixture{
+		clients:      newFakeClientRepository(),
+		refresh:      newFakeRefreshTokenRepository(),
+		revocations:  newFakeTokenRevocationRepository(),
+		tokenService: newTestTokenService(t),
+	}
+	f.tokenIssuer = appservice.NewTokenIssuer(f.tokenService, f.refresh, 24*time.Hour)
+	// Caching is disabled so every check reads the repository
+	f.revocationChecker = service.NewRevocationChecker(f.revocations, 0)
+	return f
+}
+
+// addClient registers a client with one API key and returns the key
+func (f *authFixture) addClient(t *testing.T, clientID string, status entity.ClientStatus, scopes ...string) string {
+	t.Helper()
+
+	apiKey, err := valueobject.GenerateAPIKey()
+	require.NoError(t, err)
+	hash, err := testKeyHasher.Hash(apiKey.Value())
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, f.clients.Create(context.Background(), &entity.Client{
+		ID:     clientID,
+		Name:   clientID,
+		Tier:   "authenticated",
+		Scopes: scopes,
+		Status: status,
+		Keys: []entity.ClientKey{{
+			ID: apiKey.ID(), Prefix: apiKey.Masked(), Hash: hash, CreatedAt: now,
+		}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}))
+	return apiKey.Value()
+}
@@ -1,10 +1,14 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
 	"upwork-test/internal/application/dto"
+	appservice "upwork-test/internal/application/service"
 	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/repository"
 	"upwork-test/internal/domain/auth/service"
 	"upwork-test/internal/domain/auth/valueobject"
 )
@@ -12,40 +16,75 @@ import (
 var (
 	// ErrInvalidAPIKey is returned when API key is invalid
 	ErrInvalidAPIKey = errors.New("invalid API key")
+	// ErrClientDisabled is returned when the API key belongs to a disabled client
+	ErrClientDisabled = errors.New("client disabled")
 )
 
-// Authenticate use case handles user authentication
+// Authenticate use case handles client authentication against the client registry
 type Authenticate struct {
-	tokenService *service.TokenService
-	validAPIKey string
+	tokenIssuer *appservice.TokenIssuer
+	clientRepo  repository.ClientRepository
+	keyHasher   *service.KeyHasher
 }
 
 // NewAuthenticate creates a new Authenticate use case
-func NewAuthenticate(tokenService *service.TokenService, validAPIKey string) *Authenticate {
+func NewAuthenticate(tokenIssuer *appservice.TokenIssuer, clientRepo repository.ClientRepository, keyHasher *service.KeyHasher) *Authenticate {
 	return &Authenticate{
-		tokenService: tokenService,
-		validAPIKey:  validAPIKey,
+		tokenIssuer: tokenIssuer,
+		clientRepo:  clientRepo,
+		keyHasher:   keyHasher,
 	}
 }
 
-// Execute authenticates a user and returns a JWT token
-func (uc *Authenticate) Execute(request *dto.AuthRequest) (*dto.TokenResponse, error) {
-	credentials, err := valueobject.NewCredentials(request.APIKey)
+// Execute authenticates a client by API key and returns an access token issued to that
+// client with its scopes and tier, and the first refresh token of a new session
+func (uc *Authenticate) Execute(ctx context.Context, request *dto.AuthRequest) (*dto.TokenResponse, error) {
+	client, err := verifyAPIKey(ctx, uc.clientRepo, uc.keyHasher, request.APIKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if !client.IsActive() {
+		return nil, ErrClientDisabled
+	}
+
+	return uc.tokenIssuer.Issue(ctx, client)
+}
+
+// verifyAPIKey finds the client holding an API key, failing with ErrInvalidAPIKey unless the
+// key is registered, unexpired and matches its hash. The client may be disabled.
+// Unknown keys are verified against a dummy hash, so the time taken to reject a key does not
+// reveal whether its ID is registered.
+func verifyAPIKey(ctx context.Context, clientRepo repository.ClientRepository, keyHasher *service.KeyHasher, rawKey string) (*entity.Client, error) {
+	credentials, err := valueobject.NewCredentials(rawKey)
 	if err != nil {
 		return nil, ErrInvalidAPIKey
 	}
 
-	if credentials.APIKey() != uc.validAPIKey {
+	apiKey, err := valueobject.ParseAPIKey(credentials)
+	if err != nil {
 		return nil, ErrInvalidAPIKey
 	}
 
-	user := entity.NewUser("default-user", credentials)
-	user.UpdateLastLogin()
+	client, err := clientRepo.GetByKeyID(ctx, apiKey.ID())
+	if err != nil && !errors.Is(err, repository.ErrClientNotFound) {
+		return nil, fmt.Errorf("failed to look up client: %w", err)
+	}
 
-	token, err := uc.tokenService.GenerateToken(user.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate token: %w", err)
+	var key *entity.ClientKey
+	if client != nil {
+		key = client.FindKey(apiKey.ID())
+	}
+
+	hash := keyHasher.DummyHash()
+	if key != nil {
+		hash = key.Hash
+	}
+	matches := keyHasher.Verify(apiKey.Value(), hash)
+
+	if key == nil || !matches || key.IsExpired(time.Now()) {
+		return nil, ErrInvalidAPIKey
 	}
 
-	return dto.NewTokenResponse(token.String(), token.ExpiresAt()), nil
+	return client, nil
 }
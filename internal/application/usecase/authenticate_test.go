@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/valueobject"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticate(t *testing.T) {
+	f := newAuthFixture(t)
+	ctx := context.Background()
+
+	activeKey := f.addClient(t, "team-a", entity.ClientStatusActive, valueobject.ScopeMarketsRead)
+	disabledKey := f.addClient(t, "team-b", entity.ClientStatusDisabled, valueobject.ScopeMarketsRead)
+	expiredKey := f.addClient(t, "team-c", entity.ClientStatusActive, valueobject.ScopeMarketsRead)
+
+	_, err := f.clients.Update(ctx, "team-c", func(client *entity.Client) (bool, error) {
+		client.ExpireKeys(time.Now().Add(-time.Minute))
+		return true, nil
+	})
+	require.NoError(t, err)
+
+	unknown, err := valueobject.GenerateAPIKey()
+	require.NoError(t, err)
+
+	// Same key ID as team-a's key, with another secret
+	keyID, _, _ := strings.Cut(strings.TrimPrefix(activeKey, valueobject.APIKeyPrefix+"_"), "_")
+	wrongSecret := valueobject.APIKeyPrefix + "_" + keyID + "_" + strings.Repeat("A", 43)
+
+	tests := []struct {
+		name       string
+		apiKey     string
+		wantErr    error
+		wantClient string
+	}{
+		{name: "active client", apiKey: activeKey, wantClient: "team-a"},
+		{name: "surrounding whitespace", apiKey: "  " + activeKey + "\n", wantClient: "team-a"},
+		{name: "disabled client", apiKey: disabledKey, wantErr: ErrClientDisabled},
+		{name: "expired key", apiKey: expiredKey, wantErr: ErrInvalidAPIKey},
+		{name: "unknown key", apiKey: unknown.Value(), wantErr: ErrInvalidAPIKey},
+		{name: "wrong secret", apiKey: wrongSecret, wantErr: ErrInvalidAPIKey},
+		{name: "upstream Kalshi key", apiKey: "0c4f7e9a-5b1d-4e2a-9f3c-8d6b2a1e7f40", wantErr: ErrInvalidAPIKey},
+		{name: "malformed key ID", apiKey: valueobject.APIKeyPrefix + "_zz_secret", wantErr: ErrInvalidAPIKey},
+		{name: "empty", apiKey: "", wantErr: ErrInvalidAPIKey},
+	}
+
+	uc := NewAuthenticate(f.tokenIssuer, f.clients, testKeyHasher)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := uc.Execute(ctx, &dto.AuthRequest{APIKey: tt.apiKey})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, response)
+				return
+			}
+			require.NoError(t, err)
+
+			token, err := f.tokenService.ValidateToken(response.Token)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantClient, token.UserID())
+			assert.Equal(t, []string{valueobject.ScopeMarketsRead}, token.Scopes())
+			assert.Equal(t, "authenticated", token.Tier())
+			assert.NotEmpty(t, token.ID())
+
+			refreshToken, err := valueobject.NewRefreshToken(response.RefreshToken)
+			require.NoError(t, err)
+			record, err := f.refresh.Get(ctx, refreshToken.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantClient, record.ClientID)
+		})
+	}
+}
+
+func TestAuthenticateRegistryUnavailable(t *testing.T) {
+	f := newAuthFixture(t)
+	apiKey := f.addClient(t, "team-a", entity.ClientStatusActive)
+	f.clients.err = errors.New("connection refused")
+
+	_, err := NewAuthenticate(f.tokenIssuer, f.clients, testKeyHasher).Execute(context.Background(), &dto.AuthRequest{APIKey: apiKey})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidAPIKey, "a registry outage is not a bad key")
+}
@@ -45,13 +45,45 @@ func (uc *GetCategoryOverview) Execute(ctx context.Context, categoryName string)
 
 // overviewToDTO converts a CategoryOverview entity to DTO.
 func (uc *GetCategoryOverview) overviewToDTO(overview *entity.CategoryOverview) *dto.CategoryOverviewDTO {
+	var movers *dto.MoversDTO
+	if overview.Movers != nil {
+		movers = moversToDTO(overview.Movers, overview.CategoryName.String(), overview.TotalMarkets)
+	}
+
 	return &dto.CategoryOverviewDTO{
 		CategoryName:     overview.CategoryName.String(),
 		TotalMarkets:     overview.TotalMarkets,
 		TotalVolume24h:   overview.TotalVolume24h,
 		AverageLiquidity: overview.AverageLiquidity,
-		ActiveTraders24h: overview.ActiveTraders24h,
+		Trades24h:        overview.Trades24h,
+		PriceChanges24h:  moverListToDTO(overview.PriceChanges24h),
+		Movers:           movers,
+		Activity:         activityToDTO(overview.Activity),
 		ComputedAt:       overview.ComputedAt,
 		ExpiresAt:        overview.ExpiresAt,
 	}
 }
+
+// activityToDTO converts category activity metrics to DTO.
+func activityToDTO(activity *entity.CategoryActivity) *dto.ActivityDTO {
+	if activity == nil {
+		return nil
+	}
+
+	return &dto.ActivityDTO{
+		Window:               activity.Window.String(),
+		TotalMarkets:         activity.TotalMarkets,
+		TotalVolume24h:       activity.TotalVolume24h,
+		AverageLiquidity:     activity.AverageLiquidity,
+		TotalOpenInterest:    activity.TotalOpenInterest,
+		MedianSpread:         activity.MedianSpread,
+		TradeCount:           activity.TradeCount,
+		ContractsTraded:      activity.ContractsTraded,
+		NotionalTraded:       activity.NotionalTraded,
+		MarketsWithTrades:    activity.MarketsWithTrades,
+		MarketsWithVolume:    activity.MarketsWithVolume,
+		MarketsTradesTracked: activity.MarketsTradesTracked,
+		TradeCoverage:        activity.TradeCoverage(),
+		ComputedAt:           activity.ComputedAt,
+	}
+}
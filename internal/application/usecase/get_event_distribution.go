@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/domain/market/repository"
+	"upwork-test/internal/domain/market/service"
+	"upwork-test/internal/domain/market/valueobject"
+)
+
+const (
+	// defaultBasketContracts sizes basket quotes when the caller does not
+	defaultBasketContracts = 100
+)
+
+var (
+	// ErrEventNotFound is returned when the event does not exist
+	ErrEventNotFound = errors.New("event not found")
+)
+
+// GetEventDistribution computes the implied distribution and basket arbitrage checks for an event
+type GetEventDistribution struct {
+	eventRepo repository.EventRepository
+	analyzer  *service.DistributionAnalyzer
+}
+
+// NewGetEventDistribution creates a new GetEventDistribution use case
+func NewGetEventDistribution(eventRepo repository.EventRepository, analyzer *service.DistributionAnalyzer) *GetEventDistribution {
+	return &GetEventDistribution{
+		eventRepo: eventRepo,
+		analyzer:  analyzer,
+	}
+}
+
+// Execute returns the distribution of an event; contracts sizes the basket quotes (0 uses the default)
+func (uc *GetEventDistribution) Execute(ctx context.Context, eventTickerStr string, contracts int64) (*dto.EventDistributionDTO, error) {
+	eventTicker, err := valueobject.NewTicker(eventTickerStr)
+	if err != nil || eventTicker.IsEmpty() {
+		return nil, ErrInvalidTicker
+	}
+
+	if contracts == 0 {
+		contracts = defaultBasketContracts
+	}
+
+	event, err := uc.eventRepo.GetByTicker(ctx, eventTicker.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrEventNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+	if len(event.Markets) == 0 {
+		return nil, ErrEventNotFound
+	}
+
+	distribution := uc.analyzer.Analyze(event, contracts)
+
+	result := &dto.EventDistributionDTO{
+		EventTicker:       event.EventTicker,
+		Title:             event.Title,
+		Category:          event.Category,
+		MutuallyExclusive: event.MutuallyExclusive,
+		Outcomes:          make([]dto.OutcomeDTO, len(distribution.Outcomes)),
+		TotalProbability:  distribution.TotalProbability,
+		Overround:         distribution.Overround,
+		AllYes:            basketToDTO(distribution.AllYes),
+		AllNo:             basketToDTO(distribution.AllNo),
+		Arbitrage:         distribution.HasArbitrage(),
+	}
+
+	for i, outcome := range distribution.Outcomes {
+		market := outcome.Market
+		result.Outcomes[i] = dto.OutcomeDTO{
+			Ticker:                market.Ticker.String(),
+			Title:                 market.Title,
+			Status:                string(market.Status),
+			YesBid:                market.YesBid.ExactCents(),
+			YesAsk:                market.YesAsk.ExactCents(),
+			NoAsk:                 market.NoAsk.ExactCents(),
+			LastPrice:             market.LastPrice.ExactCents(),
+			ImpliedProbability:    outcome.Implied.Probability,
+			NormalizedProbability: outcome.Normalized,
+			PriceSource:           string(outcome.Implied.Source),
+		}
+		if market.HasStrike() {
+			result.Outcomes[i].Strike = strikeToDTO(market.Strike)
+		}
+	}
+
+	return result, nil
+}
+
+// basketToDTO converts a basket quote to DTO
+func basketToDTO(quote service.BasketQuote) dto.BasketDTO {
+	return dto.BasketDTO{
+		Side:      string(quote.Side),
+		Contracts: quote.Contracts,
+		Available: quote.Available,
+		Cost:      unitsToCents(quote.Cost),
+		Fees:      quote.Fees / valueobject.PriceScale,
+		NetCost:   unitsToCents(quote.NetCost),
+		Payout:    unitsToCents(quote.Payout),
+		Edge:      unitsToCents(quote.Edge),
+		BelowPar:  quote.BelowPar,
+		AbovePar:  quote.AbovePar,
+	}
+}
+
+// unitsToCents converts an amount in fixed-point price units to cents
+func unitsToCents(units int64) float64 {
+	return float64(units) / valueobject.PriceScale
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/service"
+)
+
+// GetJWKS use case publishes the public keys of the token signing key set, so other
+// services can verify access tokens without calling this API
+type GetJWKS struct {
+	tokenService *service.TokenService
+}
+
+// NewGetJWKS creates a new GetJWKS use case
+func NewGetJWKS(tokenService *service.TokenService) *GetJWKS {
+	return &GetJWKS{
+		tokenService: tokenService,
+	}
+}
+
+// Execute returns the current key set, including keys published ahead of their activation
+func (uc *GetJWKS) Execute() (*dto.JWKSetDTO, error) {
+	publicKeys := uc.tokenService.PublicKeys()
+
+	result := &dto.JWKSetDTO{
+		Keys: make([]*dto.JWKDTO, 0, len(publicKeys)),
+	}
+	for _, publicKey := range publicKeys {
+		jwk, err := publicKeyToJWK(publicKey)
+		if err != nil {
+			return nil, err
+		}
+		result.Keys = append(result.Keys, jwk)
+	}
+	return result, nil
+}
+
+// publicKeyToJWK encodes a public key as in RFC 7518 section 6
+func publicKeyToJWK(publicKey entity.PublicKey) (*dto.JWKDTO, error) {
+	jwk := &dto.JWKDTO{
+		KeyID:     publicKey.ID,
+		Algorithm: publicKey.Algorithm.String(),
+	}
+
+	switch key := publicKey.Key.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	case *ecdsa.PublicKey:
+		ecdhKey, err := key.ECDH()
+		if err != nil {
+			return nil, fmt.Errorf("signing key %s: %w", publicKey.ID, err)
+		}
+
+		// Uncompressed point: 0x04, then X and Y padded to the curve size
+		point := ecdhKey.Bytes()
+		size := (len(point) - 1) / 2
+		jwk.KeyType = "EC"
+		jwk.Curve = key.Curve.Params().Name
+		jwk.X = base64.RawURLEncoding.EncodeToString(point[1 : 1+size])
+		jwk.Y = base64.RawURLEncoding.EncodeToString(point[1+size:])
+	default:
+		return nil, fmt.Errorf("signing key %s: unsupported key type %T", publicKey.ID, publicKey.Key)
+	}
+
+	return jwk, nil
+}
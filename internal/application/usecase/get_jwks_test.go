@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/service"
+	"upwork-test/internal/domain/auth/valueobject"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetJWKSKeySet(t *testing.T) {
+	now := time.Now()
+	generate := func(algorithm valueobject.SigningAlgorithm, activatesAt time.Time, retiresAt time.Time, expiresAt time.Time) *entity.SigningKey {
+		t.Helper()
+		key, err := entity.GenerateSigningKey(algorithm, now, activatesAt, retiresAt, expiresAt)
+		require.NoError(t, err)
+		return key
+	}
+
+	// A retired key still verifying tokens, the active key, its published successor, and
+	// a key whose tokens have all expired
+	retired := generate(valueobject.SigningAlgorithmRS256, now.Add(-2*time.Hour), now.Add(-time.Hour), now.Add(time.Hour))
+	active := generate(valueobject.SigningAlgorithmES256, now.Add(-time.Hour), now.Add(15*time.Minute), now.Add(2*time.Hour))
+	next := generate(valueobject.SigningAlgorithmES256, now.Add(15*time.Minute), now.Add(time.Hour), now.Add(3*time.Hour))
+	expired := generate(valueobject.SigningAlgorithmES256, now.Add(-3*time.Hour), now.Add(-2*time.Hour), now.Add(-time.Minute))
+
+	tokenService := service.NewTokenService(15 * time.Minute)
+	require.NoError(t, tokenService.SetKeys([]*entity.SigningKey{expired, next, retired, active}))
+
+	jwks, err := NewGetJWKS(tokenService).Execute()
+	require.NoError(t, err)
+
+	kids := make(map[string]string, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		kids[jwk.KeyID] = jwk.KeyType
+	}
+	assert.Equal(t, map[string]string{retired.ID: "RSA", active.ID: "EC", next.ID: "EC"}, kids)
+
+	// Issued tokens name a published key
+	token, err := tokenService.GenerateToken("team-a", nil, "authenticated")
+	require.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(token.String(), &valueobject.Claims{})
+	require.NoError(t, err)
+	assert.Equal(t, active.ID, parsed.Header["kid"])
+}
@@ -3,6 +3,8 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"sync"
 	"upwork-test/internal/application/dto"
 	"upwork-test/internal/domain/market/entity"
@@ -11,11 +13,27 @@ import (
 	"upwork-test/internal/domain/market/valueobject"
 )
 
+const (
+	// defaultTradesLimit is the number of recent trades embedded when no limit is given
+	defaultTradesLimit = 100
+	maxTradesLimit     = 100
+)
+
+// Resources that can be embedded in market details
+const (
+	IncludeOrderBook = "orderbook"
+	IncludeTrades    = "trades"
+	IncludeCandles   = "candles"
+	IncludeEvent     = "event"
+)
+
 var (
 	// ErrMarketNotFound is returned when the market does not exist
 	ErrMarketNotFound = errors.New("market not found")
 	// ErrInvalidTicker is returned when the ticker is invalid
 	ErrInvalidTicker = errors.New("invalid ticker")
+	// ErrInvalidDetailOptions is returned when includes, the trades limit or the candle period are invalid
+	ErrInvalidDetailOptions = errors.New("invalid market detail options")
 )
 
 // MarketRepositoryExtended extends MarketRepository with aggregation methods
@@ -32,24 +50,58 @@ type MarketRepositoryExtended interface {
 // GetMarketDetails retrieves comprehensive market information with concurrent aggregation
 type GetMarketDetails struct {
 	repo       MarketRepositoryExtended
+	eventRepo  repository.EventRepository
 	aggregator *service.MarketAggregator
 }
 
 // NewGetMarketDetails creates a new GetMarketDetails use case
-func NewGetMarketDetails(repo MarketRepositoryExtended) *GetMarketDetails {
+func NewGetMarketDetails(repo MarketRepositoryExtended, eventRepo repository.EventRepository) *GetMarketDetails {
 	return &GetMarketDetails{
 		repo:       repo,
+		eventRepo:  eventRepo,
 		aggregator: service.NewMarketAggregator(),
 	}
 }
 
-// Execute retrieves market details with concurrent fan-out for order book and trades
-func (uc *GetMarketDetails) Execute(ctx context.Context, tickerStr string) (*dto.MarketDetailDTO, error) {
+// detailIncludes records which resources a market detail request embeds
+type detailIncludes struct {
+	orderBook bool
+	trades    bool
+	candles   bool
+	event     bool
+}
+
+// Execute retrieves market details, fetching only the embedded resources that were requested.
+// Metadata, order book and trades are fetched concurrently; the event and candlesticks need
+// the market's event ticker and are fetched once the metadata is known.
+func (uc *GetMarketDetails) Execute(ctx context.Context, tickerStr string, options *dto.MarketDetailOptionsDTO) (*dto.MarketDetailDTO, error) {
 	ticker, err := valueobject.NewTicker(tickerStr)
 	if err != nil {
 		return nil, ErrInvalidTicker
 	}
 
+	if options == nil {
+		options = &dto.MarketDetailOptionsDTO{}
+	}
+
+	includes, err := uc.resolveIncludes(options.Include)
+	if err != nil {
+		return nil, err
+	}
+
+	tradesLimit := options.TradesLimit
+	if tradesLimit == 0 {
+		tradesLimit = defaultTradesLimit
+	}
+	if tradesLimit < 1 || tradesLimit > maxTradesLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidDetailOptions, maxTradesLimit)
+	}
+
+	candlePeriod, err := valueobject.NewCandlePeriod(options.CandlePeriod)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDetailOptions, err)
+	}
+
 	var wg sync.WaitGroup
 	var market *entity.Market
 	var orderBook *entity.OrderBook
@@ -62,17 +114,21 @@ func (uc *GetMarketDetails) Execute(ctx context.Context, tickerStr string) (*dto
 		market, marketErr = uc.repo.GetByTicker(ctx, ticker.String())
 	}()
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		orderBook, orderBookErr = uc.repo.GetOrderBook(ctx, ticker.String())
-	}()
+	if includes.orderBook {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			orderBook, orderBookErr = uc.repo.GetOrderBook(ctx, ticker.String())
+		}()
+	}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		trades, tradesErr = uc.repo.GetRecentTrades(ctx, ticker.String(), 100)
-	}()
+	if includes.trades {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			trades, tradesErr = uc.repo.GetRecentTrades(ctx, ticker.String(), tradesLimit)
+		}()
+	}
 
 	wg.Wait()
 
@@ -87,8 +143,97 @@ func (uc *GetMarketDetails) Execute(ctx context.Context, tickerStr string) (*dto
 	if aggregated == nil {
 		return nil, ErrMarketNotFound
 	}
+	// Components that were not requested are not missing
+	aggregated.IsPartial = (includes.orderBook && !aggregated.HasOrderBook()) || (includes.trades && !aggregated.HasTrades())
+
+	result := uc.toDTO(aggregated, orderBookErr, tradesErr)
+
+	if includes.event || includes.candles {
+		uc.embedEventResources(ctx, market, includes, candlePeriod, result)
+	}
 
-	return uc.toDTO(aggregated, orderBookErr, tradesErr), nil
+	return result, nil
+}
+
+// resolveIncludes validates the requested includes; nil keeps the original order book and trades.
+func (uc *GetMarketDetails) resolveIncludes(include []string) (detailIncludes, error) {
+	if include == nil {
+		return detailIncludes{orderBook: true, trades: true}, nil
+	}
+
+	var includes detailIncludes
+	for _, name := range include {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "":
+		case IncludeOrderBook:
+			includes.orderBook = true
+		case IncludeTrades:
+			includes.trades = true
+		case IncludeCandles:
+			includes.candles = true
+		case IncludeEvent:
+			includes.event = true
+		default:
+			return detailIncludes{}, fmt.Errorf("%w: unknown include %s (expected orderbook, trades, candles or event)", ErrInvalidDetailOptions, name)
+		}
+	}
+	return includes, nil
+}
+
+// embedEventResources adds the market's event and candlesticks to the result. Candlesticks are
+// served per series, so they are looked up through the event. Failures make the result partial.
+func (uc *GetMarketDetails) embedEventResources(
+	ctx context.Context,
+	market *entity.Market,
+	includes detailIncludes,
+	candlePeriod valueobject.CandlePeriod,
+	result *dto.MarketDetailDTO,
+) {
+	fail := func(component string, err error) {
+		result.IsPartial = true
+		result.Errors = append(result.Errors, component+": "+err.Error())
+	}
+
+	failAll := func(err error) {
+		if includes.event {
+			fail("event", err)
+		}
+		if includes.candles {
+			fail("candles", err)
+		}
+	}
+
+	if market.EventTicker == "" {
+		failAll(errors.New("market has no event"))
+		return
+	}
+
+	event, err := uc.eventRepo.GetByTicker(ctx, market.EventTicker)
+	if err != nil {
+		failAll(err)
+		return
+	}
+
+	if includes.event {
+		result.Event = &dto.EventRefDTO{
+			EventTicker:       event.EventTicker,
+			SeriesTicker:      event.SeriesTicker,
+			Title:             event.Title,
+			SubTitle:          event.SubTitle,
+			Category:          event.Category,
+			MutuallyExclusive: event.MutuallyExclusive,
+			MarketCount:       len(event.Markets),
+		}
+	}
+
+	if includes.candles {
+		candles, err := uc.repo.GetCandlesticks(ctx, event.SeriesTicker, market.Ticker.String(), candlePeriod)
+		if err != nil {
+			fail("candles", err)
+			return
+		}
+		result.Candles = uc.convertCandles(candles)
+	}
 }
 
 // toDTO converts aggregated market to DTO
@@ -96,25 +241,8 @@ func (uc *GetMarketDetails) toDTO(
 	aggregated *service.AggregatedMarket,
 	orderBookErr, tradesErr error,
 ) *dto.MarketDetailDTO {
-	market := aggregated.Market
-
-	result := &dto.MarketDetailDTO{
-		Ticker:    market.Ticker.String(),
-		Title:     market.Title,
-		Category:  market.Category,
-		OpenTime:  market.OpenTime,
-		CloseTime: market.CloseTime,
-		Status:    string(market.Status),
-		YesAsk:    market.YesAsk.Value(),
-		YesBid:    market.YesBid.Value(),
-		NoAsk:     market.NoAsk.Value(),
-		NoBid:     market.NoBid.Value(),
-		LastPrice: market.LastPrice.Value(),
-		Volume:    market.Volume,
-		Volume24h: market.Volume24h,
-		Liquidity: market.Liquidity,
-		IsPartial: aggregated.IsPartial,
-	}
+	result := marketDetailToDTO(aggregated.Market)
+	result.IsPartial = aggregated.IsPartial
 
 	if aggregated.HasOrderBook() {
 		ob := aggregated.OrderBook
@@ -137,25 +265,76 @@ func (uc *GetMarketDetails) toDTO(
 	return result
 }
 
+// marketDetailToDTO converts a market's own fields to a detail DTO, without order book or trades
+func marketDetailToDTO(market *entity.Market) *dto.MarketDetailDTO {
+	result := &dto.MarketDetailDTO{
+		Ticker:            market.Ticker.String(),
+		EventTicker:       market.EventTicker,
+		RangedGroupTicker: market.RangedGroupTicker,
+		Title:             market.Title,
+		Category:          market.Category,
+		OpenTime:          market.OpenTime,
+		CloseTime:         market.CloseTime,
+		Status:            string(market.Status),
+		YesAsk:            market.YesAsk.ExactCents(),
+		YesBid:            market.YesBid.ExactCents(),
+		NoAsk:             market.NoAsk.ExactCents(),
+		NoBid:             market.NoBid.ExactCents(),
+		LastPrice:         market.LastPrice.ExactCents(),
+		Volume:            market.Volume,
+		Volume24h:         market.Volume24h,
+		Liquidity:         market.Liquidity,
+	}
+
+	if market.HasStrike() {
+		result.Strike = strikeToDTO(market.Strike)
+	}
+
+	if market.IsSettled() {
+		result.Settlement = settlementToDTO(market.Settlement)
+	}
+
+	return result
+}
+
 // convertOrderLevels converts domain order levels to DTOs
 func (uc *GetMarketDetails) convertOrderLevels(levels []entity.OrderLevel) []dto.OrderLevelDTO {
 	result := make([]dto.OrderLevelDTO, len(levels))
 	for i, level := range levels {
 		result[i] = dto.OrderLevelDTO{
-			Price:    level.Price.Value(),
+			Price:    level.Price.ExactCents(),
 			Quantity: level.Quantity,
 		}
 	}
 	return result
 }
 
+// convertCandles converts domain candlesticks to DTOs
+func (uc *GetMarketDetails) convertCandles(candles []*entity.Candlestick) []dto.CandleDTO {
+	result := make([]dto.CandleDTO, len(candles))
+	for i, candle := range candles {
+		result[i] = dto.CandleDTO{
+			PeriodEnd:    candle.PeriodEnd,
+			YesBidClose:  candle.YesBidClose.ExactCents(),
+			YesAskClose:  candle.YesAskClose.ExactCents(),
+			Volume:       candle.Volume,
+			OpenInterest: candle.OpenInterest,
+		}
+		if candle.HasTrades {
+			open, high, low, closePrice := candle.Open.ExactCents(), candle.High.ExactCents(), candle.Low.ExactCents(), candle.Close.ExactCents()
+			result[i].Open, result[i].High, result[i].Low, result[i].Close = &open, &high, &low, &closePrice
+		}
+	}
+	return result
+}
+
 // convertTrades converts domain trades to DTOs
 func (uc *GetMarketDetails) convertTrades(trades []*entity.Trade) []dto.TradeDTO {
 	result := make([]dto.TradeDTO, len(trades))
 	for i, trade := range trades {
 		result[i] = dto.TradeDTO{
 			TradeID:   trade.TradeID,
-			Price:     trade.Price.Value(),
+			Price:     trade.Price.ExactCents(),
 			Quantity:  trade.Quantity,
 			Side:      string(trade.Side),
 			Timestamp: trade.Timestamp,
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/repository"
+	"upwork-test/internal/domain/market/service"
+	"upwork-test/internal/domain/market/valueobject"
+)
+
+var (
+	// ErrInvalidQuoteRequest is returned when the order to price is malformed
+	ErrInvalidQuoteRequest = errors.New("invalid quote request")
+	// ErrMarketNotTradable is returned when quoting a market that is not open for trading
+	ErrMarketNotTradable = errors.New("market not tradable")
+)
+
+// GetMarketQuote prices a hypothetical order against the current order book, including fees
+type GetMarketQuote struct {
+	repo       repository.MarketRepository
+	calculator *service.QuoteCalculator
+}
+
+// NewGetMarketQuote creates a new GetMarketQuote use case
+func NewGetMarketQuote(repo repository.MarketRepository, calculator *service.QuoteCalculator) *GetMarketQuote {
+	return &GetMarketQuote{
+		repo:       repo,
+		calculator: calculator,
+	}
+}
+
+// Execute returns the quote for an order
+func (uc *GetMarketQuote) Execute(ctx context.Context, req *dto.QuoteRequest) (*dto.QuoteDTO, error) {
+	ticker, err := valueobject.NewTicker(req.Ticker)
+	if err != nil || ticker.IsEmpty() {
+		return nil, ErrInvalidTicker
+	}
+
+	params := service.QuoteParams{
+		Side:      service.QuoteSide(req.Side),
+		Quantity:  req.Quantity,
+		Liquidity: service.Liquidity(req.Liquidity),
+	}
+	if req.LimitPrice != nil {
+		limit, err := valueobject.ParseCents(strconv.FormatFloat(*req.LimitPrice, 'f', -1, 64))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuoteRequest, err)
+		}
+		params.LimitPrice = &limit
+	}
+	if req.Probability != nil && (*req.Probability < 0 || *req.Probability > 1) {
+		return nil, fmt.Errorf("%w: probability must be between 0 and 1", ErrInvalidQuoteRequest)
+	}
+
+	market, err := uc.repo.GetByTicker(ctx, ticker.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrMarketNotFound
+		}
+		return nil, err
+	}
+	if !market.IsOpen() {
+		return nil, ErrMarketNotTradable
+	}
+
+	var orderBook *entity.OrderBook
+	if params.Liquidity != service.LiquidityMaker {
+		orderBook, err = uc.repo.GetOrderBook(ctx, ticker.String())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	quote, err := uc.calculator.Calculate(orderBook, params)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidQuote) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuoteRequest, err)
+		}
+		return nil, err
+	}
+
+	result := &dto.QuoteDTO{
+		Ticker:               ticker.String(),
+		Side:                 string(quote.Side),
+		Liquidity:            string(quote.Liquidity),
+		Requested:            quote.Requested,
+		Filled:               quote.Filled,
+		FullyFilled:          quote.IsFullyFilled(),
+		Fills:                make([]dto.FillDTO, len(quote.Fills)),
+		AveragePrice:         quote.AveragePrice,
+		GrossCost:            unitsToCents(quote.GrossCost),
+		Fees:                 quote.Fees / valueobject.PriceScale,
+		TotalCost:            unitsToCents(quote.TotalCost),
+		MaxPayout:            unitsToCents(quote.MaxPayout),
+		MaxProfit:            unitsToCents(quote.MaxProfit),
+		BreakEvenProbability: quote.BreakEvenProbability,
+	}
+
+	for i, fill := range quote.Fills {
+		result.Fills[i] = dto.FillDTO{
+			Price:    fill.Price.ExactCents(),
+			Quantity: fill.Quantity,
+			Fee:      fill.Fee / valueobject.PriceScale,
+		}
+	}
+
+	if req.Probability != nil {
+		ev := quote.ExpectedValue(*req.Probability) / valueobject.PriceScale
+		result.ExpectedValue = &ev
+	}
+
+	if orderBook != nil {
+		timestamp := orderBook.Timestamp
+		result.OrderBookTimestamp = &timestamp
+	}
+
+	return result, nil
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/repository"
+	"upwork-test/internal/domain/market/valueobject"
+)
+
+var (
+	// ErrMarketNotSettled is returned when the market has no settlement result yet
+	ErrMarketNotSettled = errors.New("market not settled")
+)
+
+// GetMarketSettlement retrieves the settlement result of a market
+type GetMarketSettlement struct {
+	marketRepo     repository.MarketRepository
+	settlementRepo repository.SettlementRepository
+}
+
+// NewGetMarketSettlement creates a new GetMarketSettlement use case
+func NewGetMarketSettlement(marketRepo repository.MarketRepository, settlementRepo repository.SettlementRepository) *GetMarketSettlement {
+	return &GetMarketSettlement{
+		marketRepo:     marketRepo,
+		settlementRepo: settlementRepo,
+	}
+}
+
+// Execute returns the stored settlement, falling back to the live market for settlements the worker has not seen yet
+func (uc *GetMarketSettlement) Execute(ctx context.Context, tickerStr string) (*dto.SettlementDTO, error) {
+	ticker, err := valueobject.NewTicker(tickerStr)
+	if err != nil || ticker.IsEmpty() {
+		return nil, ErrInvalidTicker
+	}
+
+	market, err := uc.settlementRepo.Get(ctx, ticker.String())
+	if err != nil {
+		if !errors.Is(err, repository.ErrSettlementNotFound) {
+			return nil, err
+		}
+
+		market, err = uc.marketRepo.GetByTicker(ctx, ticker.String())
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, ErrMarketNotFound
+			}
+			return nil, err
+		}
+	}
+
+	if !market.IsSettled() {
+		return nil, ErrMarketNotSettled
+	}
+
+	return settlementToDTO(market.Settlement), nil
+}
+
+// settlementToDTO converts a Settlement entity to DTO
+func settlementToDTO(settlement *entity.Settlement) *dto.SettlementDTO {
+	return &dto.SettlementDTO{
+		Ticker:          settlement.Ticker.String(),
+		Result:          string(settlement.Result),
+		SettlementValue: settlement.SettlementValue.ExactCents(),
+		ExpirationValue: settlement.ExpirationValue,
+		SettledAt:       settlement.SettledAt,
+	}
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/application/service"
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/repository"
+)
+
+// MaxBatchSize is the maximum number of tickers in one batch lookup
+const MaxBatchSize = 100
+
+// Per-ticker error codes of a batch lookup
+const (
+	BatchErrorInvalidTicker = "invalid_ticker"
+	BatchErrorNotFound      = "not_found"
+	BatchErrorUpstream      = "upstream_error"
+)
+
+var (
+	// ErrInvalidBatchRequest is returned when a batch lookup has no tickers or too many
+	ErrInvalidBatchRequest = errors.New("invalid batch request")
+)
+
+// GetMarketsBatch use case looks up many markets at once. Cached markets are read in a
+// single round trip; the rest are fetched upstream concurrently, paced by a throttle.
+type GetMarketsBatch struct {
+	marketRepo     repository.MarketRepository
+	throttle       service.Throttle
+	maxConcurrency int
+}
+
+// NewGetMarketsBatch creates a new GetMarketsBatch use case.
+// maxConcurrency caps the upstream fetches in flight for one batch.
+func NewGetMarketsBatch(marketRepo repository.MarketRepository, throttle service.Throttle, maxConcurrency int) *GetMarketsBatch {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	return &GetMarketsBatch{
+		marketRepo:     marketRepo,
+		throttle:       throttle,
+		maxConcurrency: maxConcurrency,
+	}
+}
+
+// Execute looks up every ticker, reporting failures per ticker rather than failing the batch.
+// Duplicate tickers are looked up once and reported once.
+func (uc *GetMarketsBatch) Execute(ctx context.Context, tickers []string) (*dto.MarketBatchDTO, error) {
+	if len(tickers) == 0 {
+		return nil, fmt.Errorf("%w: at least one ticker is required", ErrInvalidBatchRequest)
+	}
+	if len(tickers) > MaxBatchSize {
+		return nil, fmt.Errorf("%w: at most %d tickers are allowed", ErrInvalidBatchRequest, MaxBatchSize)
+	}
+
+	ordered := make([]string, 0, len(tickers))
+	seen := make(map[string]bool, len(tickers))
+	for _, ticker := range tickers {
+		ticker = strings.TrimSpace(ticker)
+		if !seen[ticker] {
+			seen[ticker] = true
+			ordered = append(ordered, ticker)
+		}
+	}
+
+	lookup := make([]string, 0, len(ordered))
+	for _, ticker := range ordered {
+		if ticker != "" {
+			lookup = append(lookup, ticker)
+		}
+	}
+
+	found, missing, err := uc.marketRepo.GetMultiple(ctx, lookup)
+	if err != nil {
+		// The cache is an optimization; fall back to fetching every ticker
+		fmt.Printf("Warning: batch cache lookup failed: %v\n", err)
+		found, missing = map[string]*entity.Market{}, lookup
+	}
+
+	failures := uc.fetchMissing(ctx, missing, found)
+
+	result := &dto.MarketBatchDTO{
+		Results: make([]dto.MarketBatchItemDTO, len(ordered)),
+	}
+	for i, ticker := range ordered {
+		item := dto.MarketBatchItemDTO{Ticker: ticker}
+
+		switch {
+		case ticker == "":
+			item.Error = &dto.BatchItemErrorDTO{Code: BatchErrorInvalidTicker, Message: "ticker is empty"}
+		case found[ticker] != nil:
+			item.Market = marketDetailToDTO(found[ticker])
+		case errors.Is(failures[ticker], repository.ErrNotFound):
+			item.Error = &dto.BatchItemErrorDTO{Code: BatchErrorNotFound, Message: "market not found"}
+		default:
+			message := "market could not be fetched"
+			if failures[ticker] != nil {
+				message = failures[ticker].Error()
+			}
+			item.Error = &dto.BatchItemErrorDTO{Code: BatchErrorUpstream, Message: message}
+		}
+
+		if item.Error != nil {
+			result.Failed++
+		} else {
+			result.Found++
+		}
+		result.Results[i] = item
+	}
+
+	return result, nil
+}
+
+// fetchMissing fetches uncached markets into found with bounded concurrency, returning the failures.
+func (uc *GetMarketsBatch) fetchMissing(ctx context.Context, missing []string, found map[string]*entity.Market) map[string]error {
+	failures := make(map[string]error)
+	if len(missing) == 0 {
+		return failures
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, uc.maxConcurrency)
+
+	for _, ticker := range missing {
+		wg.Add(1)
+		go func(ticker string) {
+			defer wg.Done()
+
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			var market *entity.Market
+			err := uc.throttle.Wait(ctx)
+			if err == nil {
+				market, err = uc.marketRepo.GetByTicker(ctx, ticker)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures[ticker] = err
+				return
+			}
+			found[ticker] = market
+		}(ticker)
+	}
+
+	wg.Wait()
+	return failures
+}
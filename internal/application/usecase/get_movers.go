@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"upwork-test/internal/application/dto"
+	appservice "upwork-test/internal/application/service"
+	"upwork-test/internal/domain/category/entity"
+	"upwork-test/internal/domain/category/repository"
+	"upwork-test/internal/domain/category/valueobject"
+)
+
+const (
+	defaultMoversLimit = 10
+	maxMoversLimit     = appservice.MaxMoversLimit
+)
+
+var (
+	// ErrInvalidMoversRequest is returned when the movers window or limit is invalid
+	ErrInvalidMoversRequest = errors.New("invalid movers request")
+	// ErrMoversWindowUnavailable is returned when no movers have been ranked for the window yet
+	ErrMoversWindowUnavailable = errors.New("price history for window not available")
+)
+
+// GetMovers use case returns the top market movers across one or all categories,
+// as ranked by the worker on every price snapshot.
+type GetMovers struct {
+	categoryRepo repository.CategoryRepository
+	moversRepo   repository.MoversRepository
+}
+
+// NewGetMovers creates a new GetMovers use case.
+func NewGetMovers(
+	categoryRepo repository.CategoryRepository,
+	moversRepo repository.MoversRepository,
+) *GetMovers {
+	return &GetMovers{
+		categoryRepo: categoryRepo,
+		moversRepo:   moversRepo,
+	}
+}
+
+// Execute returns gainers, losers, highest volume and widest spreads over a window.
+// An empty category covers every category; limit 0 uses the default.
+func (uc *GetMovers) Execute(ctx context.Context, category string, window string, limit int) (*dto.MoversDTO, error) {
+	moverWindow, err := valueobject.NewMoverWindow(window)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMoversRequest, err)
+	}
+
+	if limit == 0 {
+		limit = defaultMoversLimit
+	}
+	if limit < 1 || limit > maxMoversLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidMoversRequest, maxMoversLimit)
+	}
+
+	var categoryName string
+	if category != "" {
+		cat, err := uc.categoryRepo.GetByName(ctx, category)
+		if err != nil {
+			if errors.Is(err, repository.ErrCategoryNotFound) {
+				return nil, ErrCategoryNotFound
+			}
+			return nil, fmt.Errorf("failed to get category: %w", err)
+		}
+		categoryName = cat.Name.String()
+	}
+
+	movers, err := uc.moversRepo.Get(ctx, categoryName, moverWindow)
+	if err != nil {
+		if errors.Is(err, repository.ErrMoversNotFound) {
+			return nil, ErrMoversWindowUnavailable
+		}
+		return nil, err
+	}
+
+	return moversToDTO(movers.Top(limit), categoryName, movers.MarketsScanned), nil
+}
+
+// moversToDTO converts ranked movers to DTO.
+func moversToDTO(movers *entity.Movers, category string, marketsScanned int) *dto.MoversDTO {
+	return &dto.MoversDTO{
+		Window:         movers.Window.String(),
+		Category:       category,
+		MarketsScanned: marketsScanned,
+		BaselineAt:     movers.BaselineAt,
+		Gainers:        moverListToDTO(movers.Gainers),
+		Losers:         moverListToDTO(movers.Losers),
+		HighestVolume:  moverListToDTO(movers.HighestVolume),
+		WidestSpreads:  moverListToDTO(movers.WidestSpreads),
+		ComputedAt:     movers.ComputedAt,
+	}
+}
+
+// moverListToDTO converts a list of market movers to DTOs.
+func moverListToDTO(movers []entity.MarketMover) []dto.MarketMoverDTO {
+	result := make([]dto.MarketMoverDTO, len(movers))
+	for i, mover := range movers {
+		result[i] = dto.MarketMoverDTO{
+			Ticker:             mover.Ticker,
+			Title:              mover.Title,
+			Category:           mover.Category,
+			LastPrice:          mover.LastPrice,
+			PreviousPrice:      mover.PreviousPrice,
+			PriceChange:        mover.PriceChange,
+			PriceChangePercent: mover.PriceChangePercent,
+			Volume24h:          mover.Volume24h,
+			Spread:             mover.Spread,
+		}
+	}
+	return result
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/repository"
+	"upwork-test/internal/domain/market/service"
+	"upwork-test/internal/domain/market/valueobject"
+)
+
+var (
+	// ErrRangedGroupNotFound is returned when no markets belong to the range group
+	ErrRangedGroupNotFound = errors.New("ranged group not found")
+)
+
+// GetRangedGroup retrieves the bracket markets of a range group with their implied distribution
+type GetRangedGroup struct {
+	marketRepo repository.MarketRepository
+	estimator  *service.ProbabilityEstimator
+}
+
+// NewGetRangedGroup creates a new GetRangedGroup use case
+func NewGetRangedGroup(marketRepo repository.MarketRepository, estimator *service.ProbabilityEstimator) *GetRangedGroup {
+	return &GetRangedGroup{
+		marketRepo: marketRepo,
+		estimator:  estimator,
+	}
+}
+
+// Execute returns the brackets of a range group ordered by strike
+func (uc *GetRangedGroup) Execute(ctx context.Context, groupTickerStr string) (*dto.RangedGroupDTO, error) {
+	groupTicker, err := valueobject.NewTicker(groupTickerStr)
+	if err != nil || groupTicker.IsEmpty() {
+		return nil, ErrInvalidTicker
+	}
+
+	markets, err := uc.marketRepo.ListByRangedGroup(ctx, groupTicker.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrRangedGroupNotFound
+		}
+		return nil, err
+	}
+	if len(markets) == 0 {
+		return nil, ErrRangedGroupNotFound
+	}
+
+	sortByStrike(markets)
+
+	implied := make([]service.ImpliedProbability, len(markets))
+	probabilities := make([]float64, len(markets))
+	for i, market := range markets {
+		implied[i] = uc.estimator.Estimate(market)
+		probabilities[i] = implied[i].Probability
+	}
+	normalized, total := uc.estimator.Normalize(probabilities)
+
+	result := &dto.RangedGroupDTO{
+		GroupTicker:      groupTicker.String(),
+		EventTicker:      markets[0].EventTicker,
+		Brackets:         make([]dto.BracketDTO, len(markets)),
+		TotalProbability: total,
+	}
+	if markets[0].RangedGroupTicker != "" {
+		result.GroupTicker = markets[0].RangedGroupTicker
+	}
+
+	for i, market := range markets {
+		result.Brackets[i] = dto.BracketDTO{
+			Ticker:                market.Ticker.String(),
+			Title:                 market.Title,
+			Status:                string(market.Status),
+			YesBid:                market.YesBid.ExactCents(),
+			YesAsk:                market.YesAsk.ExactCents(),
+			LastPrice:             market.LastPrice.ExactCents(),
+			Volume24h:             market.Volume24h,
+			ImpliedProbability:    implied[i].Probability,
+			NormalizedProbability: normalized[i],
+			PriceSource:           string(implied[i].Source),
+		}
+		if market.HasStrike() {
+			result.Brackets[i].Strike = strikeToDTO(market.Strike)
+		}
+	}
+
+	return result, nil
+}
+
+// sortByStrike orders markets by strike, placing markets without a strike last
+func sortByStrike(markets []*entity.Market) {
+	sort.SliceStable(markets, func(i, j int) bool {
+		a, b := markets[i], markets[j]
+		if a.HasStrike() != b.HasStrike() {
+			return a.HasStrike()
+		}
+		if a.HasStrike() && !a.Strike.Equals(*b.Strike) {
+			return a.Strike.Less(*b.Strike)
+		}
+		return a.Ticker.String() < b.Ticker.String()
+	})
+}
+
+// strikeToDTO converts a Strike value object to DTO
+func strikeToDTO(strike *valueobject.Strike) *dto.StrikeDTO {
+	result := &dto.StrikeDTO{
+		Type:       string(strike.Type()),
+		Functional: strike.Functional(),
+		Label:      strike.Label(),
+	}
+	if floor, ok := strike.Floor(); ok {
+		result.Floor = &floor
+	}
+	if cap, ok := strike.Cap(); ok {
+		result.Cap = &cap
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"upwork-test/internal/application/dto"
+	appservice "upwork-test/internal/application/service"
+	"upwork-test/internal/domain/diagnostics/entity"
+	"upwork-test/internal/domain/diagnostics/repository"
+)
+
+// GetSchemaDiagnostics use case reports upstream schema drift seen by the API and worker.
+type GetSchemaDiagnostics struct {
+	reporter   appservice.SchemaReporter
+	reportRepo repository.SchemaReportRepository
+	source     string
+}
+
+// NewGetSchemaDiagnostics creates a new GetSchemaDiagnostics use case.
+// source identifies this process in the shared report store, which it publishes to separately.
+func NewGetSchemaDiagnostics(reporter appservice.SchemaReporter, reportRepo repository.SchemaReportRepository, source string) *GetSchemaDiagnostics {
+	return &GetSchemaDiagnostics{
+		reporter:   reporter,
+		reportRepo: reportRepo,
+		source:     source,
+	}
+}
+
+// Execute returns the latest report from every process, with this process's current report
+// in place of the one it last published. It only reads.
+func (uc *GetSchemaDiagnostics) Execute(ctx context.Context) (*dto.SchemaDiagnosticsDTO, error) {
+	local := uc.reporter.Report(uc.source)
+
+	published, err := uc.reportRepo.List(ctx)
+	if err != nil {
+		// Still answer with what this process knows
+		fmt.Printf("Warning: failed to list schema reports: %v\n", err)
+	}
+
+	reports := []*entity.SchemaReport{local}
+	for _, report := range published {
+		if report.Source != uc.source {
+			reports = append(reports, report)
+		}
+	}
+
+	result := &dto.SchemaDiagnosticsDTO{
+		Reports: make([]*dto.SchemaReportDTO, len(reports)),
+	}
+	for i, report := range reports {
+		result.Reports[i] = uc.reportToDTO(report)
+		if result.Reports[i].DriftDetected {
+			result.DriftDetected = true
+		}
+	}
+
+	return result, nil
+}
+
+// reportToDTO converts a SchemaReport entity to DTO.
+func (uc *GetSchemaDiagnostics) reportToDTO(report *entity.SchemaReport) *dto.SchemaReportDTO {
+	endpoints := make([]dto.EndpointDriftDTO, len(report.Endpoints))
+	for i, endpoint := range report.Endpoints {
+		endpoints[i] = dto.EndpointDriftDTO{
+			Endpoint:      endpoint.Endpoint,
+			Responses:     endpoint.Responses,
+			DecodeErrors:  endpoint.DecodeErrors,
+			UnknownFields: endpoint.UnknownFields,
+			MissingFields: endpoint.MissingFields,
+		}
+	}
+
+	return &dto.SchemaReportDTO{
+		Source:         report.Source,
+		StrictDecoding: report.StrictDecoding,
+		DriftDetected:  report.HasDrift(),
+		Endpoints:      endpoints,
+		Fallbacks:      report.Fallbacks,
+		OutOfRange:     report.OutOfRange,
+		StartedAt:      report.StartedAt,
+		LastDriftAt:    report.LastDriftAt,
+		GeneratedAt:    report.GeneratedAt,
+	}
+}
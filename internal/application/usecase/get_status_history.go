@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/domain/market/repository"
+	"upwork-test/internal/domain/market/valueobject"
+)
+
+const (
+	// statusHistoryDefaultLimit is the number of transitions returned when no limit is given
+	statusHistoryDefaultLimit = 20
+	// statusHistoryMaxLimit matches the number of transitions kept per market
+	statusHistoryMaxLimit = 100
+)
+
+// GetStatusHistory retrieves the status transitions the worker observed for a market
+type GetStatusHistory struct {
+	historyRepo repository.StatusHistoryRepository
+}
+
+// NewGetStatusHistory creates a new GetStatusHistory use case
+func NewGetStatusHistory(historyRepo repository.StatusHistoryRepository) *GetStatusHistory {
+	return &GetStatusHistory{
+		historyRepo: historyRepo,
+	}
+}
+
+// Execute returns the most recent transitions of a market, newest first. A market the worker
+// has never seen change status has an empty history.
+func (uc *GetStatusHistory) Execute(ctx context.Context, tickerStr string, limit int) (*dto.StatusHistoryDTO, error) {
+	ticker, err := valueobject.NewTicker(tickerStr)
+	if err != nil || ticker.IsEmpty() {
+		return nil, ErrInvalidTicker
+	}
+
+	if limit <= 0 {
+		limit = statusHistoryDefaultLimit
+	}
+	if limit > statusHistoryMaxLimit {
+		limit = statusHistoryMaxLimit
+	}
+
+	transitions, err := uc.historyRepo.GetHistory(ctx, ticker.String(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.StatusHistoryDTO{
+		Ticker:      ticker.String(),
+		Transitions: make([]dto.StatusTransitionDTO, 0, len(transitions)),
+	}
+	for _, transition := range transitions {
+		result.Transitions = append(result.Transitions, dto.StatusTransitionDTO{
+			From:       transition.From.String(),
+			To:         transition.To.String(),
+			Expected:   transition.Expected,
+			ObservedAt: transition.ObservedAt,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/domain/auth/repository"
+	"upwork-test/internal/domain/auth/service"
+	"upwork-test/internal/domain/auth/valueobject"
+)
+
+// ErrInsufficientScope is returned when an authenticated client lacks the scope an operation requires
+var ErrInsufficientScope = errors.New("insufficient scope")
+
+// IntrospectToken use case reports whether an access token is active and what it grants
+// (RFC 7662), for gateways that cannot verify tokens themselves. Callers authenticate as
+// OAuth clients holding the tokens:introspect scope.
+type IntrospectToken struct {
+	tokenService      *service.TokenService
+	revocationChecker *service.RevocationChecker
+	clientRepo        repository.ClientRepository
+	keyHasher         *service.KeyHasher
+}
+
+// NewIntrospectToken creates a new IntrospectToken use case
+func NewIntrospectToken(tokenService *service.TokenService, revocationChecker *service.RevocationChecker, clientRepo repository.ClientRepository, keyHasher *service.KeyHasher) *IntrospectToken {
+	return &IntrospectToken{
+		tokenService:      tokenService,
+		revocationChecker: revocationChecker,
+		clientRepo:        clientRepo,
+		keyHasher:         keyHasher,
+	}
+}
+
+// Execute introspects a token. Refresh tokens, and access tokens that are malformed,
+// expired or revoked, are all reported only as inactive.
+func (uc *IntrospectToken) Execute(ctx context.Context, request *dto.IntrospectionRequest) (*dto.IntrospectionDTO, error) {
+	caller, err := authenticateOAuthClient(ctx, uc.clientRepo, uc.keyHasher, request.Client)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(caller.Scopes, valueobject.ScopeTokensIntrospect) {
+		return nil, fmt.Errorf("%w: %s", ErrInsufficientScope, valueobject.ScopeTokensIntrospect)
+	}
+
+	token, err := uc.tokenService.ValidateToken(request.Token)
+	if err != nil {
+		return &dto.IntrospectionDTO{Active: false}, nil
+	}
+
+	// As in the Auth middleware, a token is not reported active if the denylist is unreachable
+	revoked, err := uc.revocationChecker.IsRevoked(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	if revoked {
+		return &dto.IntrospectionDTO{Active: false}, nil
+	}
+
+	return &dto.IntrospectionDTO{
+		Active:    true,
+		Scopes:    token.Scopes(),
+		ClientID:  token.UserID(),
+		TokenID:   token.ID(),
+		IssuedAt:  token.IssuedAt(),
+		ExpiresAt: token.ExpiresAt(),
+	}, nil
+}
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/service"
+	"upwork-test/internal/domain/auth/valueobject"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntrospectToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      func(t *testing.T, f *authFixture, session *dto.TokenResponse) string
+		wantActive bool
+	}{
+		{
+			name:       "active access token",
+			token:      func(t *testing.T, f *authFixture, session *dto.TokenResponse) string { return session.Token },
+			wantActive: true,
+		},
+		{
+			name: "revoked access token",
+			token: func(t *testing.T, f *authFixture, session *dto.TokenResponse) string {
+				uc := NewRevokeToken(f.tokenIssuer, f.tokenService, f.revocationChecker, f.refresh)
+				require.NoError(t, uc.Execute(context.Background(), &dto.RevokeRequest{Token: session.Token}))
+				return session.Token
+			},
+		},
+		{
+			name: "client tokens revoked",
+			token: func(t *testing.T, f *authFixture, session *dto.TokenResponse) string {
+				f.revocations.watermarks["team-a"] = time.Now().Add(time.Second)
+				return session.Token
+			},
+		},
+		{
+			name: "expired access token",
+			token: func(t *testing.T, f *authFixture, session *dto.TokenResponse) string {
+				// Signed with the fixture's key, but already expired
+				key := newTestSigningKey(t)
+				require.NoError(t, f.tokenService.SetKeys([]*entity.SigningKey{key}))
+				expired := service.NewTokenService(-time.Minute)
+				require.NoError(t, expired.SetKeys([]*entity.SigningKey{key}))
+
+				token, err := expired.GenerateToken("team-a", []string{valueobject.ScopeMarketsRead}, "authenticated")
+				require.NoError(t, err)
+				return token.String()
+			},
+		},
+		{
+			name: "access token signed by an unknown key",
+			token: func(t *testing.T, f *authFixture, session *dto.TokenResponse) string {
+				token, err := newTestTokenService(t).GenerateToken("team-a", nil, "authenticated")
+				require.NoError(t, err)
+				return token.String()
+			},
+		},
+		{
+			name:  "refresh token",
+			token: func(t *testing.T, f *authFixture, session *dto.TokenResponse) string { return session.RefreshToken },
+		},
+		{
+			name:  "garbage",
+			token: func(t *testing.T, f *authFixture, session *dto.TokenResponse) string { return "not-a-token" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAuthFixture(t)
+			gatewayKey := f.addClient(t, "gateway", entity.ClientStatusActive, valueobject.ScopeTokensIntrospect)
+			session := f.login(t, f.addClient(t, "team-a", entity.ClientStatusActive, valueobject.ScopeMarketsRead))
+
+			uc := NewIntrospectToken(f.tokenService, f.revocationChecker, f.clients, testKeyHasher)
+			result, err := uc.Execute(context.Background(), &dto.IntrospectionRequest{
+				Client: dto.ClientCredentials{ClientID: "gateway", ClientSecret: gatewayKey},
+				Token:  tt.token(t, f, session),
+			})
+			require.NoError(t, err)
+
+			if !tt.wantActive {
+				// Inactive tokens disclose nothing else
+				assert.Equal(t, &dto.IntrospectionDTO{Active: false}, result)
+				return
+			}
+			access, err := f.tokenService.ValidateToken(session.Token)
+			require.NoError(t, err)
+			assert.True(t, result.Active)
+			assert.Equal(t, "team-a", result.ClientID)
+			assert.Equal(t, []string{valueobject.ScopeMarketsRead}, result.Scopes)
+			assert.Equal(t, access.ID(), result.TokenID)
+			assert.Equal(t, access.ExpiresAt(), result.ExpiresAt)
+		})
+	}
+}
+
+func TestIntrospectTokenCaller(t *testing.T) {
+	tests := []struct {
+		name    string
+		caller  func(t *testing.T, f *authFixture) dto.ClientCredentials
+		wantErr error
+	}{
+		{
+			name: "caller without the introspection scope",
+			caller: func(t *testing.T, f *authFixture) dto.ClientCredentials {
+				apiKey := f.addClient(t, "reader", entity.ClientStatusActive, valueobject.ScopeMarketsRead)
+				return dto.ClientCredentials{ClientID: "reader", ClientSecret: apiKey}
+			},
+			wantErr: ErrInsufficientScope,
+		},
+		{
+			name: "disabled caller",
+			caller: func(t *testing.T, f *authFixture) dto.ClientCredentials {
+				apiKey := f.addClient(t, "gateway", entity.ClientStatusDisabled, valueobject.ScopeTokensIntrospect)
+				return dto.ClientCredentials{ClientID: "gateway", ClientSecret: apiKey}
+			},
+			wantErr: ErrClientDisabled,
+		},
+		{
+			name: "key of another client",
+			caller: func(t *testing.T, f *authFixture) dto.ClientCredentials {
+				f.addClient(t, "gateway", entity.ClientStatusActive, valueobject.ScopeTokensIntrospect)
+				apiKey := f.addClient(t, "reader", entity.ClientStatusActive, valueobject.ScopeMarketsRead)
+				return dto.ClientCredentials{ClientID: "gateway", ClientSecret: apiKey}
+			},
+			wantErr: ErrInvalidClientCredentials,
+		},
+		{
+			name: "unknown key",
+			caller: func(t *testing.T, f *authFixture) dto.ClientCredentials {
+				apiKey, err := valueobject.GenerateAPIKey()
+				require.NoError(t, err)
+				return dto.ClientCredentials{ClientID: "gateway", ClientSecret: apiKey.Value()}
+			},
+			wantErr: ErrInvalidClientCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAuthFixture(t)
+			session := f.login(t, f.addClient(t, "team-a", entity.ClientStatusActive, valueobject.ScopeMarketsRead))
+
+			uc := NewIntrospectToken(f.tokenService, f.revocationChecker, f.clients, testKeyHasher)
+			_, err := uc.Execute(context.Background(), &dto.IntrospectionRequest{
+				Client: tt.caller(t, f),
+				Token:  session.Token,
+			})
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestIntrospectTokenRevocationUnavailable(t *testing.T) {
+	f := newAuthFixture(t)
+	gatewayKey := f.addClient(t, "gateway", entity.ClientStatusActive, valueobject.ScopeTokensIntrospect)
+	session := f.login(t, f.addClient(t, "team-a", entity.ClientStatusActive, valueobject.ScopeMarketsRead))
+
+	// A token whose revocation cannot be checked is not reported active
+	f.revocations.err = errors.New("connection refused")
+	uc := NewIntrospectToken(f.tokenService, f.revocationChecker, f.clients, testKeyHasher)
+	result, err := uc.Execute(context.Background(), &dto.IntrospectionRequest{
+		Client: dto.ClientCredentials{ClientID: "gateway", ClientSecret: gatewayKey},
+		Token:  session.Token,
+	})
+	assert.ErrorIs(t, err, f.revocations.err)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/repository"
+	"upwork-test/internal/domain/auth/service"
+)
+
+var (
+	// ErrInvalidClientCredentials is returned when an OAuth client fails to authenticate
+	ErrInvalidClientCredentials = errors.New("invalid client credentials")
+	// ErrInvalidScope is returned when a client requests a scope it was not granted
+	ErrInvalidScope = errors.New("invalid scope")
+)
+
+// IssueClientCredentialsToken use case implements the OAuth 2.0 client credentials grant:
+// a client authenticates with its ID and an API key, and receives an access token for the
+// scopes it requests, without a refresh token
+type IssueClientCredentialsToken struct {
+	tokenService *service.TokenService
+	clientRepo   repository.ClientRepository
+	keyHasher    *service.KeyHasher
+}
+
+// NewIssueClientCredentialsToken creates a new IssueClientCredentialsToken use case
+func NewIssueClientCredentialsToken(tokenService *service.TokenService, clientRepo repository.ClientRepository, keyHasher *service.KeyHasher) *IssueClientCredentialsToken {
+	return &IssueClientCredentialsToken{
+		tokenService: tokenService,
+		clientRepo:   clientRepo,
+		keyHasher:    keyHasher,
+	}
+}
+
+// Execute authenticates the client and issues an access token
+func (uc *IssueClientCredentialsToken) Execute(ctx context.Context, request *dto.ClientCredentialsRequest) (*dto.AccessTokenDTO, error) {
+	client, err := authenticateOAuthClient(ctx, uc.clientRepo, uc.keyHasher, request.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := client.Scopes
+	if len(request.Scopes) > 0 {
+		for _, scope := range request.Scopes {
+			if !slices.Contains(client.Scopes, scope) {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidScope, scope)
+			}
+		}
+		scopes = request.Scopes
+	}
+
+	token, err := uc.tokenService.GenerateToken(client.ID, scopes, client.Tier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return &dto.AccessTokenDTO{
+		AccessToken: token.String(),
+		ExpiresAt:   token.ExpiresAt(),
+		Scopes:      token.Scopes(),
+	}, nil
+}
+
+// authenticateOAuthClient verifies that the secret is an API key of the named client, and
+// that the client is active
+func authenticateOAuthClient(ctx context.Context, clientRepo repository.ClientRepository, keyHasher *service.KeyHasher, credentials dto.ClientCredentials) (*entity.Client, error) {
+	client, err := verifyAPIKey(ctx, clientRepo, keyHasher, credentials.ClientSecret)
+	if errors.Is(err, ErrInvalidAPIKey) {
+		return nil, ErrInvalidClientCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if client.ID != credentials.ClientID {
+		return nil, ErrInvalidClientCredentials
+	}
+	if !client.IsActive() {
+		return nil, ErrClientDisabled
+	}
+
+	return client, nil
+}
@@ -5,9 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 	"upwork-test/internal/application/dto"
+	"upwork-test/internal/application/service"
 	"upwork-test/internal/domain/market/entity"
 	"upwork-test/internal/domain/market/repository"
+	"upwork-test/internal/domain/market/valueobject"
 )
 
 var (
@@ -15,64 +21,94 @@ var (
 	ErrInvalidPage = errors.New("invalid page number")
 	// ErrInvalidLimit is returned when limit is invalid
 	ErrInvalidLimit = errors.New("invalid limit")
+	// ErrInvalidListQuery is returned when market listing filters or sort are invalid
+	ErrInvalidListQuery = errors.New("invalid market list query")
+	// ErrInvalidCursor is returned when a pagination cursor is malformed or belongs to another listing
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrCursorExpired is returned when a pagination cursor's position no longer exists
+	ErrCursorExpired = errors.New("cursor expired")
 	// ErrCategoryNotFound is returned when category is not found
 	ErrCategoryNotFound = errors.New("category not found")
 )
 
 // ListMarkets use case retrieves paginated markets for a category.
 type ListMarkets struct {
-	marketRepo repository.MarketRepository
+	marketRepo  repository.MarketRepository
+	cursorCodec *service.CursorCodec
 }
 
 // NewListMarkets creates a new ListMarkets use case.
-func NewListMarkets(marketRepo repository.MarketRepository) *ListMarkets {
+func NewListMarkets(marketRepo repository.MarketRepository, cursorCodec *service.CursorCodec) *ListMarkets {
 	return &ListMarkets{
-		marketRepo: marketRepo,
+		marketRepo:  marketRepo,
+		cursorCodec: cursorCodec,
 	}
 }
 
-// Execute retrieves markets for a category with pagination.
-func (uc *ListMarkets) Execute(ctx context.Context, category string, page int, limit int, status string) (*dto.MarketListDTO, error) {
-	if page < 1 {
+// Execute retrieves the markets of a category matching the query, one page at a time.
+// Pages are selected by cursor when one is given and by page number otherwise; the
+// returned links always use cursors, which do not skip or repeat markets when the
+// list is refreshed between requests.
+func (uc *ListMarkets) Execute(ctx context.Context, category string, query *dto.MarketListQueryDTO) (*dto.MarketListDTO, error) {
+	page, limit := query.Page, query.Limit
+	if query.Cursor == "" && page < 1 {
 		return nil, ErrInvalidPage
 	}
 	if limit < 1 || limit > 100 {
 		return nil, ErrInvalidLimit
 	}
 
-	markets, total, err := uc.marketRepo.ListByCategory(ctx, category, page, limit, status)
+	marketQuery, err := uc.buildQuery(query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list markets: %w", err)
-	}
-
-	marketDTOs := make([]*dto.MarketDTO, len(markets))
-	for i, market := range markets {
-		marketDTOs[i] = uc.marketToDTO(market)
+		return nil, err
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+	params := uc.listParams(query)
+	scope := category + "?" + params.Encode()
 
-	var nextURL, prevURL string
-	if page < totalPages {
-		nextURL = fmt.Sprintf("/api/v1/categories/%s/markets?page=%d&limit=%d", category, page+1, limit)
-		if status != "" {
-			nextURL += fmt.Sprintf("&status=%s", status)
+	pageRequest := repository.MarketPageRequest{
+		Query: marketQuery,
+		Limit: limit,
+	}
+	if query.Cursor != "" {
+		cursor, err := uc.cursorCodec.Decode(query.Cursor, scope)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
 		}
+		pageRequest.Cursor = cursor
+	} else {
+		pageRequest.Offset = (page - 1) * limit
 	}
-	if page > 1 {
-		prevURL = fmt.Sprintf("/api/v1/categories/%s/markets?page=%d&limit=%d", category, page-1, limit)
-		if status != "" {
-			prevURL += fmt.Sprintf("&status=%s", status)
+
+	result, err := uc.marketRepo.ListPageByCategory(ctx, category, pageRequest)
+	if err != nil {
+		if errors.Is(err, repository.ErrCursorExpired) {
+			return nil, fmt.Errorf("%w: %v", ErrCursorExpired, err)
 		}
+		return nil, fmt.Errorf("failed to list markets: %w", err)
+	}
+
+	marketDTOs := make([]*dto.MarketDTO, len(result.Markets))
+	for i, market := range result.Markets {
+		marketDTOs[i] = uc.marketToDTO(market)
 	}
 
 	pagination := &dto.PaginationDTO{
-		Page:       page,
+		Page:       result.Offset/limit + 1,
 		Limit:      limit,
-		Total:      total,
-		TotalPages: totalPages,
-		NextURL:    nextURL,
-		PrevURL:    prevURL,
+		Total:      result.Total,
+		TotalPages: int(math.Ceil(float64(result.Total) / float64(limit))),
+	}
+
+	if count := len(result.Markets); count > 0 {
+		if result.Offset+count < result.Total {
+			pagination.NextCursor = uc.encodeCursor(result, result.Markets[count-1], marketQuery, false, scope)
+			pagination.NextURL = uc.pageURL(category, params, limit, pagination.NextCursor)
+		}
+		if result.Offset > 0 {
+			pagination.PrevCursor = uc.encodeCursor(result, result.Markets[0], marketQuery, true, scope)
+			pagination.PrevURL = uc.pageURL(category, params, limit, pagination.PrevCursor)
+		}
 	}
 
 	return &dto.MarketListDTO{
@@ -81,6 +117,108 @@ func (uc *ListMarkets) Execute(ctx context.Context, category string, page int, l
 	}, nil
 }
 
+// encodeCursor returns the token for the position of a market on the page.
+func (uc *ListMarkets) encodeCursor(page *repository.MarketPage, market *entity.Market, query repository.MarketQuery, before bool, scope string) string {
+	return uc.cursorCodec.Encode(&repository.MarketCursor{
+		Version: page.Version,
+		SortKey: market.SortKey(query.Sort.Field),
+		Ticker:  market.Ticker.String(),
+		Before:  before,
+	}, scope)
+}
+
+// buildQuery validates the listing parameters and converts them to a repository query.
+func (uc *ListMarkets) buildQuery(query *dto.MarketListQueryDTO) (repository.MarketQuery, error) {
+	var filter entity.MarketFilter
+
+	if query.Status != "" {
+		status, err := entity.ParseMarketStatus(query.Status)
+		if err != nil {
+			return repository.MarketQuery{}, fmt.Errorf("%w: %v", ErrInvalidListQuery, err)
+		}
+		filter.Status = status
+	}
+
+	if query.MinPrice != "" {
+		price, err := valueobject.ParseCents(query.MinPrice)
+		if err != nil {
+			return repository.MarketQuery{}, fmt.Errorf("%w: min_price: %v", ErrInvalidListQuery, err)
+		}
+		filter.MinPrice = &price
+	}
+	if query.MaxPrice != "" {
+		price, err := valueobject.ParseCents(query.MaxPrice)
+		if err != nil {
+			return repository.MarketQuery{}, fmt.Errorf("%w: max_price: %v", ErrInvalidListQuery, err)
+		}
+		filter.MaxPrice = &price
+	}
+	if filter.MinPrice != nil && filter.MaxPrice != nil && filter.MinPrice.Units() > filter.MaxPrice.Units() {
+		return repository.MarketQuery{}, fmt.Errorf("%w: min_price exceeds max_price", ErrInvalidListQuery)
+	}
+
+	if !query.ClosesAfter.IsZero() && !query.ClosesBefore.IsZero() && !query.ClosesAfter.Before(query.ClosesBefore) {
+		return repository.MarketQuery{}, fmt.Errorf("%w: closes_after must be before closes_before", ErrInvalidListQuery)
+	}
+	filter.ClosesAfter = query.ClosesAfter
+	filter.ClosesBefore = query.ClosesBefore
+
+	if query.MinLiquidity < 0 {
+		return repository.MarketQuery{}, fmt.Errorf("%w: min_liquidity cannot be negative", ErrInvalidListQuery)
+	}
+	filter.MinLiquidity = query.MinLiquidity
+	filter.EventTicker = strings.TrimSpace(query.EventTicker)
+
+	order, err := valueobject.NewMarketSort(query.Sort, query.Order)
+	if err != nil {
+		return repository.MarketQuery{}, fmt.Errorf("%w: %v", ErrInvalidListQuery, err)
+	}
+
+	return repository.MarketQuery{Filter: filter, Sort: order}, nil
+}
+
+// listParams returns the filter and sort parameters of a listing, which every page shares.
+func (uc *ListMarkets) listParams(query *dto.MarketListQueryDTO) url.Values {
+	params := url.Values{}
+
+	optional := map[string]string{
+		"status":       query.Status,
+		"sort":         query.Sort,
+		"order":        query.Order,
+		"min_price":    query.MinPrice,
+		"max_price":    query.MaxPrice,
+		"event_ticker": query.EventTicker,
+	}
+	for key, value := range optional {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+	if !query.ClosesAfter.IsZero() {
+		params.Set("closes_after", query.ClosesAfter.Format(time.RFC3339))
+	}
+	if !query.ClosesBefore.IsZero() {
+		params.Set("closes_before", query.ClosesBefore.Format(time.RFC3339))
+	}
+	if query.MinLiquidity > 0 {
+		params.Set("min_liquidity", strconv.FormatInt(query.MinLiquidity, 10))
+	}
+
+	return params
+}
+
+// pageURL builds the link to the page at a cursor, preserving every listing parameter.
+func (uc *ListMarkets) pageURL(category string, params url.Values, limit int, cursor string) string {
+	link := url.Values{}
+	for key, values := range params {
+		link[key] = values
+	}
+	link.Set("limit", strconv.Itoa(limit))
+	link.Set("cursor", cursor)
+
+	return fmt.Sprintf("/api/v1/categories/%s/markets?%s", url.PathEscape(category), link.Encode())
+}
+
 // marketToDTO converts a market entity to DTO.
 func (uc *ListMarkets) marketToDTO(market *entity.Market) *dto.MarketDTO {
 	return &dto.MarketDTO{
@@ -88,8 +226,8 @@ func (uc *ListMarkets) marketToDTO(market *entity.Market) *dto.MarketDTO {
 		Title:       market.Title,
 		Category:    market.Category,
 		CloseDate:   market.CloseTime,
-		YesPrice:    int(market.LastPrice.Value()), // Using LastPrice as yes price for now
-		NoPrice:     100 - int(market.LastPrice.Value()),
+		YesPrice:    market.LastPrice.ExactCents(), // Using LastPrice as yes price for now
+		NoPrice:     market.LastPrice.Complement().ExactCents(),
 		Status:      string(market.Status),
 		Volume24h:   market.Volume24h,
 		LastUpdated: market.LastUpdated,
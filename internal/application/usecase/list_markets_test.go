@@ -0,0 +1,278 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/application/service"
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/repository"
+	"upwork-test/internal/domain/market/valueobject"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var listNow = time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+
+// fakeMarketRepository serves one category listing from memory. Pages are located by
+// offset or by the cursor's ticker, which must still be listed.
+type fakeMarketRepository struct {
+	repository.MarketRepository
+
+	markets []*entity.Market
+	version int64
+	lastReq repository.MarketPageRequest
+}
+
+func (r *fakeMarketRepository) ListPageByCategory(_ context.Context, _ string, request repository.MarketPageRequest) (*repository.MarketPage, error) {
+	r.lastReq = request
+
+	markets := entity.FilterMarkets(slices.Clone(r.markets), request.Query.Filter)
+	entity.SortMarkets(markets, request.Query.Sort)
+	total := len(markets)
+
+	start, end := min(request.Offset, total), min(request.Offset+request.Limit, total)
+	if cursor := request.Cursor; cursor != nil {
+		index := slices.IndexFunc(markets, func(market *entity.Market) bool {
+			return market.Ticker.String() == cursor.Ticker
+		})
+		if index < 0 {
+			return nil, repository.ErrCursorExpired
+		}
+		if cursor.Before {
+			start, end = max(index-request.Limit, 0), index
+		} else {
+			start, end = index+1, min(index+1+request.Limit, total)
+		}
+	}
+
+	return &repository.MarketPage{Markets: markets[start:end], Offset: start, Total: total, Version: r.version}, nil
+}
+
+// listedMarket returns a market with a last price, volume, liquidity and YES spread in cents;
+// a zero spread leaves the market without a bid and ask
+func listedMarket(t *testing.T, ticker, eventTicker string, status entity.MarketStatus, closesInDays int, price, volume, liquidity, spread int64) *entity.Market {
+	t.Helper()
+
+	tickerVO, err := valueobject.NewTicker(ticker)
+	require.NoError(t, err)
+
+	market := entity.NewMarket(tickerVO, ticker, "Economics", listNow, listNow.AddDate(0, 0, closesInDays), status)
+	market.EventTicker = eventTicker
+	market.Volume24h = volume
+	market.Liquidity = liquidity
+
+	market.LastPrice, err = valueobject.NewPrice(price)
+	require.NoError(t, err)
+	if spread > 0 {
+		market.YesBid, err = valueobject.NewPrice(price - 1)
+		require.NoError(t, err)
+		market.YesAsk, err = valueobject.NewPrice(price - 1 + spread)
+		require.NoError(t, err)
+	}
+	return market
+}
+
+func newListMarketsFixture(t *testing.T) (*ListMarkets, *fakeMarketRepository) {
+	t.Helper()
+
+	repo := &fakeMarketRepository{
+		version: 1,
+		markets: []*entity.Market{
+			listedMarket(t, "KXA", "EV1", entity.MarketStatusOpen, 1, 30, 100, 500, 4),
+			listedMarket(t, "KXB", "EV2", entity.MarketStatusActive, 3, 60, 300, 200, 2),
+			listedMarket(t, "KXC", "EV1", entity.MarketStatusClosed, 2, 45, 200, 900, 6),
+			listedMarket(t, "KXD", "EV2", entity.MarketStatusOpen, 4, 80, 50, 100, 0),
+		},
+	}
+	return NewListMarkets(repo, service.NewCursorCodec("test-secret")), repo
+}
+
+// listedTickers returns the tickers of a listing in order
+func listedTickers(list *dto.MarketListDTO) []string {
+	tickers := make([]string, 0, len(list.Markets))
+	for _, market := range list.Markets {
+		tickers = append(tickers, market.Ticker)
+	}
+	return tickers
+}
+
+func TestListMarketsFiltersAndSorts(t *testing.T) {
+	tests := []struct {
+		name  string
+		query dto.MarketListQueryDTO
+		want  []string
+	}{
+		{name: "upstream order", want: []string{"KXA", "KXB", "KXC", "KXD"}},
+		{name: "open matches active", query: dto.MarketListQueryDTO{Status: "open"}, want: []string{"KXA", "KXB", "KXD"}},
+		{name: "price range is inclusive", query: dto.MarketListQueryDTO{MinPrice: "45", MaxPrice: "60"}, want: []string{"KXB", "KXC"}},
+		{name: "sub-cent price bound", query: dto.MarketListQueryDTO{MinPrice: "44.5"}, want: []string{"KXB", "KXC", "KXD"}},
+		{
+			name:  "close window",
+			query: dto.MarketListQueryDTO{ClosesAfter: listNow.AddDate(0, 0, 2), ClosesBefore: listNow.AddDate(0, 0, 4)},
+			want:  []string{"KXB", "KXC"},
+		},
+		{name: "minimum liquidity", query: dto.MarketListQueryDTO{MinLiquidity: 500}, want: []string{"KXA", "KXC"}},
+		{name: "event ticker is case-insensitive", query: dto.MarketListQueryDTO{EventTicker: " ev1 "}, want: []string{"KXA", "KXC"}},
+		{name: "volume defaults to descending", query: dto.MarketListQueryDTO{Sort: "volume_24h"}, want: []string{"KXB", "KXC", "KXA", "KXD"}},
+		{name: "close time defaults to ascending", query: dto.MarketListQueryDTO{Sort: "close_time"}, want: []string{"KXA", "KXC", "KXB", "KXD"}},
+		{name: "explicit direction", query: dto.MarketListQueryDTO{Sort: "PRICE", Order: "asc"}, want: []string{"KXA", "KXC", "KXB", "KXD"}},
+		{name: "liquidity", query: dto.MarketListQueryDTO{Sort: "liquidity"}, want: []string{"KXC", "KXA", "KXB", "KXD"}},
+		{name: "missing spread sorts last ascending", query: dto.MarketListQueryDTO{Sort: "spread"}, want: []string{"KXB", "KXA", "KXC", "KXD"}},
+		{name: "missing spread sorts last descending", query: dto.MarketListQueryDTO{Sort: "spread", Order: "desc"}, want: []string{"KXC", "KXA", "KXB", "KXD"}},
+		{
+			name:  "filters then sorts",
+			query: dto.MarketListQueryDTO{Status: "open", Sort: "price"},
+			want:  []string{"KXD", "KXB", "KXA"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, _ := newListMarketsFixture(t)
+
+			query := tt.query
+			query.Page, query.Limit = 1, 10
+			list, err := uc.Execute(context.Background(), "economics", &query)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, listedTickers(list))
+			assert.Equal(t, len(tt.want), list.Pagination.Total)
+		})
+	}
+}
+
+func TestListMarketsPageNumbers(t *testing.T) {
+	uc, repo := newListMarketsFixture(t)
+
+	list, err := uc.Execute(context.Background(), "economics", &dto.MarketListQueryDTO{Page: 2, Limit: 3, Sort: "volume_24h"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, repo.lastReq.Offset)
+	assert.Nil(t, repo.lastReq.Cursor)
+	assert.Equal(t, []string{"KXD"}, listedTickers(list))
+	assert.Equal(t, dto.PaginationDTO{
+		Page: 2, Limit: 3, Total: 4, TotalPages: 2,
+		PrevURL:    list.Pagination.PrevURL,
+		PrevCursor: list.Pagination.PrevCursor,
+	}, *list.Pagination)
+	assert.NotEmpty(t, list.Pagination.PrevCursor)
+	assert.Contains(t, list.Pagination.PrevURL, "sort=volume_24h")
+}
+
+func TestListMarketsInvalidQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   dto.MarketListQueryDTO
+		wantErr error
+	}{
+		{name: "page zero", query: dto.MarketListQueryDTO{Page: 0, Limit: 10}, wantErr: ErrInvalidPage},
+		{name: "limit zero", query: dto.MarketListQueryDTO{Page: 1}, wantErr: ErrInvalidLimit},
+		{name: "limit above 100", query: dto.MarketListQueryDTO{Page: 1, Limit: 101}, wantErr: ErrInvalidLimit},
+		{name: "unknown status", query: dto.MarketListQueryDTO{Page: 1, Limit: 10, Status: "pending"}, wantErr: ErrInvalidListQuery},
+		{name: "price above 100", query: dto.MarketListQueryDTO{Page: 1, Limit: 10, MaxPrice: "101"}, wantErr: ErrInvalidListQuery},
+		{name: "price not a number", query: dto.MarketListQueryDTO{Page: 1, Limit: 10, MinPrice: "cheap"}, wantErr: ErrInvalidListQuery},
+		{name: "inverted price range", query: dto.MarketListQueryDTO{Page: 1, Limit: 10, MinPrice: "60", MaxPrice: "40"}, wantErr: ErrInvalidListQuery},
+		{
+			name:    "empty close window",
+			query:   dto.MarketListQueryDTO{Page: 1, Limit: 10, ClosesAfter: listNow, ClosesBefore: listNow},
+			wantErr: ErrInvalidListQuery,
+		},
+		{name: "negative liquidity", query: dto.MarketListQueryDTO{Page: 1, Limit: 10, MinLiquidity: -1}, wantErr: ErrInvalidListQuery},
+		{name: "unknown sort", query: dto.MarketListQueryDTO{Page: 1, Limit: 10, Sort: "popularity"}, wantErr: ErrInvalidListQuery},
+		{name: "unknown order", query: dto.MarketListQueryDTO{Page: 1, Limit: 10, Sort: "price", Order: "up"}, wantErr: ErrInvalidListQuery},
+		{name: "order without sort", query: dto.MarketListQueryDTO{Page: 1, Limit: 10, Order: "asc"}, wantErr: ErrInvalidListQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, _ := newListMarketsFixture(t)
+
+			_, err := uc.Execute(context.Background(), "economics", &tt.query)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestListMarketsCursorPagination(t *testing.T) {
+	uc, repo := newListMarketsFixture(t)
+	ctx := context.Background()
+
+	query := dto.MarketListQueryDTO{Page: 1, Limit: 2, Sort: "volume_24h", Status: "open"}
+	first, err := uc.Execute(ctx, "economics", &query)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"KXB", "KXA"}, listedTickers(first))
+	assert.Empty(t, first.Pagination.PrevCursor)
+	require.NotEmpty(t, first.Pagination.NextCursor)
+	assert.Contains(t, first.Pagination.NextURL, "/api/v1/categories/economics/markets?")
+	assert.Contains(t, first.Pagination.NextURL, "status=open")
+	assert.Contains(t, first.Pagination.NextURL, "limit=2")
+
+	query.Cursor = first.Pagination.NextCursor
+	second, err := uc.Execute(ctx, "economics", &query)
+	require.NoError(t, err)
+	require.NotNil(t, repo.lastReq.Cursor)
+	assert.Equal(t, "KXA", repo.lastReq.Cursor.Ticker)
+	assert.Equal(t, int64(1), repo.lastReq.Cursor.Version)
+	assert.Equal(t, int64(100), repo.lastReq.Cursor.SortKey.Value)
+	assert.Equal(t, []string{"KXD"}, listedTickers(second))
+	assert.Equal(t, 2, second.Pagination.Page)
+	assert.Empty(t, second.Pagination.NextCursor)
+	require.NotEmpty(t, second.Pagination.PrevCursor)
+
+	query.Cursor = second.Pagination.PrevCursor
+	back, err := uc.Execute(ctx, "economics", &query)
+	require.NoError(t, err)
+	assert.True(t, repo.lastReq.Cursor.Before)
+	assert.Equal(t, []string{"KXB", "KXA"}, listedTickers(back))
+}
+
+func TestListMarketsCursorErrors(t *testing.T) {
+	ctx := context.Background()
+
+	uc, repo := newListMarketsFixture(t)
+	query := dto.MarketListQueryDTO{Page: 1, Limit: 2, Status: "open"}
+	first, err := uc.Execute(ctx, "economics", &query)
+	require.NoError(t, err)
+	cursor := first.Pagination.NextCursor
+	require.NotEmpty(t, cursor)
+
+	tests := []struct {
+		name     string
+		category string
+		query    dto.MarketListQueryDTO
+		wantErr  error
+	}{
+		{name: "other category", category: "climate", query: dto.MarketListQueryDTO{Limit: 2, Status: "open", Cursor: cursor}, wantErr: ErrInvalidCursor},
+		{name: "other filters", category: "economics", query: dto.MarketListQueryDTO{Limit: 2, Status: "closed", Cursor: cursor}, wantErr: ErrInvalidCursor},
+		{name: "added sort", category: "economics", query: dto.MarketListQueryDTO{Limit: 2, Status: "open", Sort: "price", Cursor: cursor}, wantErr: ErrInvalidCursor},
+		{name: "tampered", category: "economics", query: dto.MarketListQueryDTO{Limit: 2, Status: "open", Cursor: "x" + cursor}, wantErr: ErrInvalidCursor},
+		{name: "limit is not part of the scope", category: "economics", query: dto.MarketListQueryDTO{Limit: 5, Status: "open", Cursor: cursor}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Execute(ctx, tt.category, &tt.query)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("market no longer listed", func(t *testing.T) {
+		// The page ended at KXB, which the unsorted listing no longer holds
+		repo.markets = slices.DeleteFunc(slices.Clone(repo.markets), func(market *entity.Market) bool {
+			return market.Ticker.String() == "KXB"
+		})
+
+		_, err := uc.Execute(ctx, "economics", &dto.MarketListQueryDTO{Limit: 2, Status: "open", Cursor: cursor})
+		assert.ErrorIs(t, err, ErrCursorExpired)
+		assert.NotErrorIs(t, err, ErrInvalidCursor)
+	})
+}
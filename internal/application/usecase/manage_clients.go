@@ -0,0 +1,438 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/repository"
+	"upwork-test/internal/domain/auth/service"
+	"upwork-test/internal/domain/auth/valueobject"
+	ratelimitvalueobject "upwork-test/internal/domain/ratelimit/valueobject"
+)
+
+const (
+	// DefaultKeyOverlap is how long the previous keys of a client keep working after a rotation
+	DefaultKeyOverlap = 24 * time.Hour
+	// MaxKeyOverlap caps the overlap window of a rotation
+	MaxKeyOverlap = 7 * 24 * time.Hour
+
+	maxAuditEntries = 500
+)
+
+var (
+	// ErrInvalidClient is returned when a client registration or change is invalid
+	ErrInvalidClient = errors.New("invalid client")
+	// ErrClientExists is returned when registering a client ID that is taken
+	ErrClientExists = errors.New("client already exists")
+	// ErrClientNotFound is returned when no client is registered with an ID
+	ErrClientNotFound = errors.New("client not found")
+	// ErrKeyNotFound is returned when a client holds no key with an ID
+	ErrKeyNotFound = errors.New("API key not found")
+)
+
+// ManageClients use case administers the API client registry: registering clients, issuing,
+// rotating and revoking their keys, assigning their rate limit tiers, revoking their tokens,
+// and disabling or deleting them.
+// Every change is audited.
+type ManageClients struct {
+	clientRepo        repository.ClientRepository
+	auditRepo         repository.AuditRepository
+	keyHasher         *service.KeyHasher
+	revocationChecker *service.RevocationChecker
+}
+
+// NewManageClients creates a new ManageClients use case
+func NewManageClients(clientRepo repository.ClientRepository, auditRepo repository.AuditRepository, keyHasher *service.KeyHasher, revocationChecker *service.RevocationChecker) *ManageClients {
+	return &ManageClients{
+		clientRepo:        clientRepo,
+		auditRepo:         auditRepo,
+		keyHasher:         keyHasher,
+		revocationChecker: revocationChecker,
+	}
+}
+
+// Create registers a client with a first API key, returned once in the result
+func (uc *ManageClients) Create(ctx context.Context, actor dto.AuditActorDTO, request *dto.CreateClientDTO) (*dto.IssuedKeyDTO, error) {
+	clientID := strings.TrimSpace(request.ID)
+	if err := entity.ValidateClientID(clientID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidClient, err)
+	}
+
+	tier := request.Tier
+	if tier == "" {
+		tier = ratelimitvalueobject.TierAuthenticated
+	}
+	if err := validateClientTier(tier); err != nil {
+		return nil, err
+	}
+
+	scopes := request.Scopes
+	if scopes == nil {
+		scopes = valueobject.DefaultScopes()
+	}
+	scopes, err := normalizeScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	client := &entity.Client{
+		ID:        clientID,
+		Name:      strings.TrimSpace(request.Name),
+		Tier:      tier,
+		Scopes:    scopes,
+		Status:    entity.ClientStatusActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	apiKey, key, err := uc.issueKey(now)
+	if err != nil {
+		return nil, err
+	}
+	client.AddKey(key)
+
+	if err := uc.clientRepo.Create(ctx, client); err != nil {
+		if errors.Is(err, repository.ErrClientExists) {
+			return nil, ErrClientExists
+		}
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	uc.audit(ctx, actor, entity.AuditClientCreated, client.ID, apiKey.ID(), fmt.Sprintf("tier=%s scopes=%s", tier, strings.Join(scopes, ",")))
+
+	return &dto.IssuedKeyDTO{
+		Client: clientToDTO(client),
+		KeyID:  apiKey.ID(),
+		APIKey: apiKey.Value(),
+	}, nil
+}
+
+// List returns every registered client
+func (uc *ManageClients) List(ctx context.Context) ([]*dto.ClientDTO, error) {
+	clients, err := uc.clientRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clients: %w", err)
+	}
+
+	result := make([]*dto.ClientDTO, len(clients))
+	for i, client := range clients {
+		result[i] = clientToDTO(client)
+	}
+	return result, nil
+}
+
+// RotateKey issues a new key for a client. Its previous keys keep working for the overlap
+// window so deployments can switch over; keys already past their expiry are dropped.
+func (uc *ManageClients) RotateKey(ctx context.Context, actor dto.AuditActorDTO, clientID string, overlap time.Duration) (*dto.IssuedKeyDTO, error) {
+	if overlap < 0 || overlap > MaxKeyOverlap {
+		return nil, fmt.Errorf("%w: overlap must be between 0 and %s", ErrInvalidClient, MaxKeyOverlap)
+	}
+
+	now := time.Now()
+	apiKey, key, err := uc.issueKey(now)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := uc.update(ctx, clientID, func(client *entity.Client) (bool, error) {
+		client.PruneExpiredKeys(now)
+		client.ExpireKeys(now.Add(overlap))
+		client.AddKey(key)
+		client.UpdatedAt = now
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.audit(ctx, actor, entity.AuditKeyRotated, client.ID, apiKey.ID(), fmt.Sprintf("overlap=%s", overlap))
+
+	return &dto.IssuedKeyDTO{
+		Client: clientToDTO(client),
+		KeyID:  apiKey.ID(),
+		APIKey: apiKey.Value(),
+	}, nil
+}
+
+// RevokeKey deletes one key of a client immediately
+func (uc *ManageClients) RevokeKey(ctx context.Context, actor dto.AuditActorDTO, clientID string, keyID string) (*dto.ClientDTO, error) {
+	client, err := uc.update(ctx, clientID, func(client *entity.Client) (bool, error) {
+		if !client.RemoveKey(keyID) {
+			return false, ErrKeyNotFound
+		}
+		client.UpdatedAt = time.Now()
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.audit(ctx, actor, entity.AuditKeyRevoked, client.ID, keyID, "")
+	return clientToDTO(client), nil
+}
+
+// RevokeTokens invalidates every access and refresh token issued to a client so far. The
+// client's keys keep working, so it can authenticate again.
+func (uc *ManageClients) RevokeTokens(ctx context.Context, actor dto.AuditActorDTO, clientID string) error {
+	client, err := uc.getClient(ctx, clientID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.revokeTokens(ctx, client.ID, time.Now()); err != nil {
+		return err
+	}
+
+	uc.audit(ctx, actor, entity.AuditTokensRevoked, client.ID, "", "")
+	return nil
+}
+
+// SetTier assigns a client to a rate limit tier. Access tokens carry the tier, so it applies
+// to the tokens issued from then on, at the latest once the current ones expire.
+func (uc *ManageClients) SetTier(ctx context.Context, actor dto.AuditActorDTO, clientID string, tier string) (*dto.ClientDTO, error) {
+	if err := validateClientTier(tier); err != nil {
+		return nil, err
+	}
+
+	var previous string
+	client, err := uc.update(ctx, clientID, func(client *entity.Client) (bool, error) {
+		previous = client.Tier
+		if client.Tier == tier {
+			return false, nil
+		}
+		client.Tier = tier
+		client.UpdatedAt = time.Now()
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if previous != tier {
+		uc.audit(ctx, actor, entity.AuditTierChanged, client.ID, "", fmt.Sprintf("tier=%s previous=%s", tier, previous))
+	}
+
+	return clientToDTO(client), nil
+}
+
+// SetStatus enables or disables a client. Disabled clients keep their keys but cannot
+// authenticate, and the tokens issued to them are revoked once the change is stored.
+// Disabling a client already disabled revokes its tokens again, so retrying after a
+// failed revocation completes it.
+func (uc *ManageClients) SetStatus(ctx context.Context, actor dto.AuditActorDTO, clientID string, status entity.ClientStatus) (*dto.ClientDTO, error) {
+	var changed bool
+	client, err := uc.update(ctx, clientID, func(client *entity.Client) (bool, error) {
+		changed = client.Status != status
+		if !changed {
+			return false, nil
+		}
+
+		client.Status = status
+		client.UpdatedAt = time.Now()
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if changed {
+		action := entity.AuditClientEnabled
+		if status == entity.ClientStatusDisabled {
+			action = entity.AuditClientDisabled
+		}
+		uc.audit(ctx, actor, action, client.ID, "", "")
+	}
+
+	if status == entity.ClientStatusDisabled {
+		if err := uc.revokeTokens(ctx, client.ID, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	return clientToDTO(client), nil
+}
+
+// Delete removes a client and all its keys, and revokes the tokens issued to it
+func (uc *ManageClients) Delete(ctx context.Context, actor dto.AuditActorDTO, clientID string) error {
+	if _, err := uc.getClient(ctx, clientID); err != nil {
+		return err
+	}
+
+	// Revoked first, so its tokens never carry over to a client registered again under the same ID
+	if err := uc.revokeTokens(ctx, clientID, time.Now()); err != nil {
+		return err
+	}
+
+	if err := uc.clientRepo.Delete(ctx, clientID); err != nil {
+		if errors.Is(err, repository.ErrClientNotFound) {
+			return ErrClientNotFound
+		}
+		return fmt.Errorf("failed to delete client: %w", err)
+	}
+
+	uc.audit(ctx, actor, entity.AuditClientDeleted, clientID, "", "")
+	return nil
+}
+
+// AuditLog returns the most recent registry changes, newest first
+func (uc *ManageClients) AuditLog(ctx context.Context, limit int) ([]*dto.AuditEntryDTO, error) {
+	if limit < 1 || limit > maxAuditEntries {
+		limit = maxAuditEntries
+	}
+
+	entries, err := uc.auditRepo.ListRecent(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+
+	result := make([]*dto.AuditEntryDTO, len(entries))
+	for i, entry := range entries {
+		result[i] = &dto.AuditEntryDTO{
+			ID:       entry.ID,
+			Time:     entry.Time,
+			Actor:    entry.Actor,
+			Action:   entry.Action,
+			ClientID: entry.ClientID,
+			KeyID:    entry.KeyID,
+			Detail:   entry.Detail,
+			TraceID:  entry.TraceID,
+		}
+	}
+	return result, nil
+}
+
+// issueKey generates a key and the hashed record of it a client holds
+func (uc *ManageClients) issueKey(now time.Time) (valueobject.APIKey, entity.ClientKey, error) {
+	apiKey, err := valueobject.GenerateAPIKey()
+	if err != nil {
+		return valueobject.APIKey{}, entity.ClientKey{}, err
+	}
+
+	hash, err := uc.keyHasher.Hash(apiKey.Value())
+	if err != nil {
+		return valueobject.APIKey{}, entity.ClientKey{}, fmt.Errorf("failed to hash API key: %w", err)
+	}
+
+	return apiKey, entity.ClientKey{
+		ID:        apiKey.ID(),
+		Prefix:    apiKey.Masked(),
+		Hash:      hash,
+		CreatedAt: now,
+	}, nil
+}
+
+func (uc *ManageClients) revokeTokens(ctx context.Context, clientID string, issuedBefore time.Time) error {
+	if err := uc.revocationChecker.RevokeClientTokens(ctx, clientID, issuedBefore); err != nil {
+		return fmt.Errorf("failed to revoke client tokens: %w", err)
+	}
+	return nil
+}
+
+func (uc *ManageClients) getClient(ctx context.Context, clientID string) (*entity.Client, error) {
+	client, err := uc.clientRepo.GetByID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, repository.ErrClientNotFound) {
+			return nil, ErrClientNotFound
+		}
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+	return client, nil
+}
+
+// update applies a change to a client atomically. The change reads the stored client and
+// may run more than once, if the client is changed concurrently before it is written.
+func (uc *ManageClients) update(ctx context.Context, clientID string, update repository.ClientUpdate) (*entity.Client, error) {
+	client, err := uc.clientRepo.Update(ctx, clientID, update)
+	switch {
+	case errors.Is(err, repository.ErrClientNotFound):
+		return nil, ErrClientNotFound
+	case errors.Is(err, ErrKeyNotFound):
+		return nil, err
+	case err != nil:
+		return nil, fmt.Errorf("failed to save client: %w", err)
+	}
+	return client, nil
+}
+
+// audit records a change. The change has already been made, so a failure to record it is
+// logged rather than returned.
+func (uc *ManageClients) audit(ctx context.Context, actor dto.AuditActorDTO, action string, clientID string, keyID string, detail string) {
+	entry := &entity.AuditEntry{
+		Time:     time.Now(),
+		Actor:    actor.ClientID,
+		Action:   action,
+		ClientID: clientID,
+		KeyID:    keyID,
+		Detail:   detail,
+		TraceID:  actor.TraceID,
+	}
+
+	if err := uc.auditRepo.Append(ctx, entry); err != nil {
+		fmt.Printf("Warning: failed to audit %s of client %s by %s: %v\n", action, clientID, actor.ClientID, err)
+	}
+}
+
+// validateClientTier checks that a tier is configured. Anonymous requests have their own tier,
+// which no client can be assigned.
+func validateClientTier(tier string) error {
+	if tier == ratelimitvalueobject.TierUnauthenticated {
+		return fmt.Errorf("%w: the %s tier cannot be assigned to a client", ErrInvalidClient, tier)
+	}
+	if _, err := ratelimitvalueobject.NewRateLimitTier(tier); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidClient, err)
+	}
+	return nil
+}
+
+// normalizeScopes trims, validates and de-duplicates scopes, preserving their order
+func normalizeScopes(scopes []string) ([]string, error) {
+	normalized := make([]string, 0, len(scopes))
+	seen := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope == "" {
+			return nil, fmt.Errorf("%w: scopes cannot be empty", ErrInvalidClient)
+		}
+		if err := valueobject.ValidateScope(scope); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidClient, err)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
+
+func clientToDTO(client *entity.Client) *dto.ClientDTO {
+	keys := make([]dto.ClientKeyDTO, len(client.Keys))
+	for i, key := range client.Keys {
+		keys[i] = dto.ClientKeyDTO{
+			ID:        key.ID,
+			Prefix:    key.Prefix,
+			CreatedAt: key.CreatedAt,
+			ExpiresAt: key.ExpiresAt,
+		}
+	}
+
+	scopes := client.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return &dto.ClientDTO{
+		ID:        client.ID,
+		Name:      client.Name,
+		Tier:      client.Tier,
+		Scopes:    scopes,
+		Status:    string(client.Status),
+		Keys:      keys,
+		CreatedAt: client.CreatedAt,
+		UpdatedAt: client.UpdatedAt,
+	}
+}
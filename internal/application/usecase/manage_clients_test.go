@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/domain/auth/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManageClientsSetStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		initial       entity.ClientStatus
+		status        entity.ClientStatus
+		conflicts     int
+		wantActions   []string
+		wantRevoked   bool
+		wantWatermark int
+	}{
+		{
+			name:    "disable",
+			initial: entity.ClientStatusActive, status: entity.ClientStatusDisabled,
+			wantActions: []string{entity.AuditClientDisabled}, wantRevoked: true, wantWatermark: 1,
+		},
+		{
+			name:    "disable after losing a race revokes once",
+			initial: entity.ClientStatusActive, status: entity.ClientStatusDisabled, conflicts: 2,
+			wantActions: []string{entity.AuditClientDisabled}, wantRevoked: true, wantWatermark: 1,
+		},
+		{
+			name:    "disable again revokes again",
+			initial: entity.ClientStatusDisabled, status: entity.ClientStatusDisabled,
+			wantActions: []string{}, wantRevoked: true, wantWatermark: 1,
+		},
+		{
+			name:    "enable",
+			initial: entity.ClientStatusDisabled, status: entity.ClientStatusActive,
+			wantActions: []string{entity.AuditClientEnabled},
+		},
+		{
+			name:    "enable an active client",
+			initial: entity.ClientStatusActive, status: entity.ClientStatusActive,
+			wantActions: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAuthFixture(t)
+			ctx := context.Background()
+			f.addClient(t, "team-a", tt.initial)
+
+			audit := &fakeAuditRepository{}
+			uc := NewManageClients(f.clients, audit, testKeyHasher, f.revocationChecker)
+			f.clients.conflicts = tt.conflicts
+
+			before := time.Now()
+			client, err := uc.SetStatus(ctx, dto.AuditActorDTO{ClientID: "admin"}, "team-a", tt.status)
+			require.NoError(t, err)
+			after := time.Now()
+			assert.Equal(t, string(tt.status), client.Status)
+
+			stored, err := f.clients.GetByID(ctx, "team-a")
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, stored.Status)
+
+			assert.Equal(t, tt.wantActions, audit.actions())
+			assert.Equal(t, tt.wantWatermark, f.revocations.watermarkWrites)
+
+			// Tokens issued before the second the status changed in are revoked
+			watermark := f.revocations.watermarks["team-a"]
+			if tt.wantRevoked {
+				assert.False(t, watermark.Before(before.Truncate(time.Second)))
+				assert.False(t, watermark.After(after))
+			} else {
+				assert.True(t, watermark.IsZero())
+			}
+		})
+	}
+}
+
+func TestManageClientsSetStatusRevocationFailure(t *testing.T) {
+	f := newAuthFixture(t)
+	ctx := context.Background()
+	f.addClient(t, "team-a", entity.ClientStatusActive)
+
+	audit := &fakeAuditRepository{}
+	uc := NewManageClients(f.clients, audit, testKeyHasher, f.revocationChecker)
+
+	f.revocations.setErr = errors.New("connection refused")
+	_, err := uc.SetStatus(ctx, dto.AuditActorDTO{ClientID: "admin"}, "team-a", entity.ClientStatusDisabled)
+	require.Error(t, err)
+
+	// The client is disabled and the change audited, but its tokens are not yet revoked
+	stored, err := f.clients.GetByID(ctx, "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, entity.ClientStatusDisabled, stored.Status)
+	assert.Equal(t, []string{entity.AuditClientDisabled}, audit.actions())
+	assert.Zero(t, f.revocations.watermarkWrites)
+
+	// Retrying completes the revocation
+	f.revocations.setErr = nil
+	_, err = uc.SetStatus(ctx, dto.AuditActorDTO{ClientID: "admin"}, "team-a", entity.ClientStatusDisabled)
+	require.NoError(t, err)
+	assert.Equal(t, 1, f.revocations.watermarkWrites)
+	assert.Equal(t, []string{entity.AuditClientDisabled}, audit.actions())
+}
+
+func TestManageClientsSetStatusUnknownClient(t *testing.T) {
+	f := newAuthFixture(t)
+	uc := NewManageClients(f.clients, &fakeAuditRepository{}, testKeyHasher, f.revocationChecker)
+
+	_, err := uc.SetStatus(context.Background(), dto.AuditActorDTO{ClientID: "admin"}, "nobody", entity.ClientStatusDisabled)
+	assert.ErrorIs(t, err, ErrClientNotFound)
+	assert.Zero(t, f.revocations.watermarkWrites)
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"upwork-test/internal/application/dto"
+	appservice "upwork-test/internal/application/service"
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/repository"
+	"upwork-test/internal/domain/auth/service"
+	"upwork-test/internal/domain/auth/valueobject"
+)
+
+var (
+	// ErrInvalidRefreshToken is returned when a refresh token is unknown, expired or revoked
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused is returned when an already exchanged refresh token is presented again
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+)
+
+// RefreshAccessToken use case exchanges a refresh token for a new access token and a
+// replacement refresh token. A refresh token works once: presenting it again means it
+// was copied, so every token of its family is revoked and the session must authenticate
+// with its API key again.
+type RefreshAccessToken struct {
+	tokenIssuer       *appservice.TokenIssuer
+	refreshRepo       repository.RefreshTokenRepository
+	clientRepo        repository.ClientRepository
+	revocationChecker *service.RevocationChecker
+}
+
+// NewRefreshAccessToken creates a new RefreshAccessToken use case
+func NewRefreshAccessToken(tokenIssuer *appservice.TokenIssuer, refreshRepo repository.RefreshTokenRepository, clientRepo repository.ClientRepository, revocationChecker *service.RevocationChecker) *RefreshAccessToken {
+	return &RefreshAccessToken{
+		tokenIssuer:       tokenIssuer,
+		refreshRepo:       refreshRepo,
+		clientRepo:        clientRepo,
+		revocationChecker: revocationChecker,
+	}
+}
+
+// Execute exchanges a refresh token
+func (uc *RefreshAccessToken) Execute(ctx context.Context, request *dto.RefreshRequest) (*dto.TokenResponse, error) {
+	refreshToken, err := valueobject.NewRefreshToken(request.RefreshToken)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	record, err := uc.refreshRepo.Get(ctx, refreshToken.Hash())
+	if err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if record.IsExpired(time.Now()) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if record.IsUsed() {
+		return nil, uc.revokeReusedFamily(ctx, record)
+	}
+
+	// Revoking a client's tokens also ends the sessions started before
+	issuedBefore, err := uc.revocationChecker.ClientWatermark(ctx, record.ClientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	if record.IssuedAt.Before(issuedBefore) {
+		uc.revokeFamily(ctx, record.FamilyID)
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Clients disabled or deleted since the session started lose it
+	client, err := uc.clientRepo.GetByID(ctx, record.ClientID)
+	if err != nil && !errors.Is(err, repository.ErrClientNotFound) {
+		return nil, fmt.Errorf("failed to look up client: %w", err)
+	}
+	if client == nil || !client.IsActive() {
+		uc.revokeFamily(ctx, record.FamilyID)
+		if client != nil {
+			return nil, ErrClientDisabled
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Scopes and tier are read from the registry, so changes apply from the next refresh
+	response, err := uc.tokenIssuer.Rotate(ctx, record, client)
+	switch {
+	case errors.Is(err, repository.ErrRefreshTokenReused):
+		return nil, uc.revokeReusedFamily(ctx, record)
+	case errors.Is(err, repository.ErrRefreshTokenNotFound), errors.Is(err, repository.ErrTokenFamilyRevoked):
+		return nil, ErrInvalidRefreshToken
+	case err != nil:
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	return response, nil
+}
+
+// revokeReusedFamily revokes the family of a token presented after being exchanged
+func (uc *RefreshAccessToken) revokeReusedFamily(ctx context.Context, record *entity.RefreshToken) error {
+	fmt.Printf("Warning: refresh token reuse detected for client %s, revoking token family %s\n", record.ClientID, record.FamilyID)
+	uc.revokeFamily(ctx, record.FamilyID)
+	return ErrRefreshTokenReused
+}
+
+func (uc *RefreshAccessToken) revokeFamily(ctx context.Context, familyID string) {
+	if err := uc.refreshRepo.RevokeFamily(ctx, familyID, uc.tokenIssuer.RefreshTTL()); err != nil {
+		fmt.Printf("Warning: failed to revoke refresh token family %s: %v\n", familyID, err)
+	}
+}
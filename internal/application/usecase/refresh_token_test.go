@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/valueobject"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// refreshRecord returns the stored record of a refresh token
+func (f *authFixture) refreshRecord(t *testing.T, refreshToken string) *entity.RefreshToken {
+	t.Helper()
+
+	token, err := valueobject.NewRefreshToken(refreshToken)
+	require.NoError(t, err)
+	record, err := f.refresh.Get(context.Background(), token.Hash())
+	require.NoError(t, err)
+	return record
+}
+
+// login authenticates a client and returns its first token pair
+func (f *authFixture) login(t *testing.T, apiKey string) *dto.TokenResponse {
+	t.Helper()
+
+	response, err := NewAuthenticate(f.tokenIssuer, f.clients, testKeyHasher).Execute(context.Background(), &dto.AuthRequest{APIKey: apiKey})
+	require.NoError(t, err)
+	return response
+}
+
+func TestRefreshAccessTokenRotates(t *testing.T) {
+	f := newAuthFixture(t)
+	ctx := context.Background()
+	apiKey := f.addClient(t, "team-a", entity.ClientStatusActive, valueobject.ScopeMarketsRead)
+	first := f.login(t, apiKey)
+
+	// Scopes changed since login apply from the next refresh
+	_, err := f.clients.Update(ctx, "team-a", func(client *entity.Client) (bool, error) {
+		client.Scopes = []string{valueobject.ScopeMarketsRead, valueobject.ScopeStreamSubscribe}
+		return true, nil
+	})
+	require.NoError(t, err)
+
+	uc := NewRefreshAccessToken(f.tokenIssuer, f.refresh, f.clients, f.revocationChecker)
+	second, err := uc.Execute(ctx, &dto.RefreshRequest{RefreshToken: first.RefreshToken})
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+	assert.Equal(t, f.refreshRecord(t, first.RefreshToken).FamilyID, f.refreshRecord(t, second.RefreshToken).FamilyID)
+	assert.True(t, f.refreshRecord(t, first.RefreshToken).IsUsed())
+	assert.False(t, f.refreshRecord(t, second.RefreshToken).IsUsed())
+
+	token, err := f.tokenService.ValidateToken(second.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", token.UserID())
+	assert.Equal(t, []string{valueobject.ScopeMarketsRead, valueobject.ScopeStreamSubscribe}, token.Scopes())
+
+	// The replacement works once in turn
+	third, err := uc.Execute(ctx, &dto.RefreshRequest{RefreshToken: second.RefreshToken})
+	require.NoError(t, err)
+	assert.NotEmpty(t, third.Token)
+}
+
+func TestRefreshAccessTokenFamilyRevocation(t *testing.T) {
+	tests := []struct {
+		name string
+		// present sets up the session and returns the refresh token to exchange
+		present       func(t *testing.T, f *authFixture, uc *RefreshAccessToken, session *dto.TokenResponse) string
+		wantErr       error
+		wantFamilyEnd bool
+	}{
+		{
+			name: "reused token",
+			present: func(t *testing.T, f *authFixture, uc *RefreshAccessToken, session *dto.TokenResponse) string {
+				_, err := uc.Execute(context.Background(), &dto.RefreshRequest{RefreshToken: session.RefreshToken})
+				require.NoError(t, err)
+				return session.RefreshToken
+			},
+			wantErr:       ErrRefreshTokenReused,
+			wantFamilyEnd: true,
+		},
+		{
+			name: "token consumed concurrently",
+			present: func(t *testing.T, f *authFixture, uc *RefreshAccessToken, session *dto.TokenResponse) string {
+				// Another exchange consumes it after the use case read it as unused
+				record := f.refreshRecord(t, session.RefreshToken)
+				f.refresh.beforeConsume = func() {
+					replacement := *record
+					replacement.ID = "concurrent"
+					require.NoError(t, f.refresh.Consume(context.Background(), record.ID, &replacement))
+				}
+				return session.RefreshToken
+			},
+			wantErr:       ErrRefreshTokenReused,
+			wantFamilyEnd: true,
+		},
+		{
+			name: "client disabled",
+			present: func(t *testing.T, f *authFixture, uc *RefreshAccessToken, session *dto.TokenResponse) string {
+				_, err := f.clients.Update(context.Background(), "team-a", func(client *entity.Client) (bool, error) {
+					client.Status = entity.ClientStatusDisabled
+					return true, nil
+				})
+				require.NoError(t, err)
+				return session.RefreshToken
+			},
+			wantErr:       ErrClientDisabled,
+			wantFamilyEnd: true,
+		},
+		{
+			name: "client deleted",
+			present: func(t *testing.T, f *authFixture, uc *RefreshAccessToken, session *dto.TokenResponse) string {
+				require.NoError(t, f.clients.Delete(context.Background(), "team-a"))
+				return session.RefreshToken
+			},
+			wantErr:       ErrInvalidRefreshToken,
+			wantFamilyEnd: true,
+		},
+		{
+			name: "client tokens revoked",
+			present: func(t *testing.T, f *authFixture, uc *RefreshAccessToken, session *dto.TokenResponse) string {
+				require.NoError(t, f.revocations.SetClientWatermark(context.Background(), "team-a", time.Now().Add(time.Second)))
+				return session.RefreshToken
+			},
+			wantErr:       ErrInvalidRefreshToken,
+			wantFamilyEnd: true,
+		},
+		{
+			name: "family already revoked",
+			present: func(t *testing.T, f *authFixture, uc *RefreshAccessToken, session *dto.TokenResponse) string {
+				record := f.refreshRecord(t, session.RefreshToken)
+				require.NoError(t, f.refresh.RevokeFamily(context.Background(), record.FamilyID, time.Hour))
+				return session.RefreshToken
+			},
+			wantErr:       ErrInvalidRefreshToken,
+			wantFamilyEnd: true,
+		},
+		{
+			name: "expired token",
+			present: func(t *testing.T, f *authFixture, uc *RefreshAccessToken, session *dto.TokenResponse) string {
+				record := f.refreshRecord(t, session.RefreshToken)
+				record.ExpiresAt = time.Now().Add(-time.Second)
+				require.NoError(t, f.refresh.Create(context.Background(), record))
+				return session.RefreshToken
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "unknown token",
+			present: func(t *testing.T, f *authFixture, uc *RefreshAccessToken, session *dto.TokenResponse) string {
+				token, err := valueobject.GenerateRefreshToken()
+				require.NoError(t, err)
+				return token.String()
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "access token presented as refresh token",
+			present: func(t *testing.T, f *authFixture, uc *RefreshAccessToken, session *dto.TokenResponse) string {
+				return session.Token
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAuthFixture(t)
+			ctx := context.Background()
+			session := f.login(t, f.addClient(t, "team-a", entity.ClientStatusActive))
+			familyID := f.refreshRecord(t, session.RefreshToken).FamilyID
+
+			uc := NewRefreshAccessToken(f.tokenIssuer, f.refresh, f.clients, f.revocationChecker)
+			presented := tt.present(t, f, uc, session)
+
+			response, err := uc.Execute(ctx, &dto.RefreshRequest{RefreshToken: presented})
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, response)
+			assert.Equal(t, tt.wantFamilyEnd, f.refresh.isFamilyRevoked(familyID))
+		})
+	}
+}
+
+func TestRefreshAccessTokenReuseEndsTheSession(t *testing.T) {
+	f := newAuthFixture(t)
+	ctx := context.Background()
+	apiKey := f.addClient(t, "team-a", entity.ClientStatusActive)
+	session := f.login(t, apiKey)
+	uc := NewRefreshAccessToken(f.tokenIssuer, f.refresh, f.clients, f.revocationChecker)
+
+	// The legitimate holder refreshed; an attacker then replays the copied token
+	rotated, err := uc.Execute(ctx, &dto.RefreshRequest{RefreshToken: session.RefreshToken})
+	require.NoError(t, err)
+	_, err = uc.Execute(ctx, &dto.RefreshRequest{RefreshToken: session.RefreshToken})
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	// Neither can continue the session
+	_, err = uc.Execute(ctx, &dto.RefreshRequest{RefreshToken: rotated.RefreshToken})
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	// Other sessions of the client are unaffected
+	other := f.login(t, apiKey)
+	_, err = uc.Execute(ctx, &dto.RefreshRequest{RefreshToken: other.RefreshToken})
+	assert.NoError(t, err)
+}
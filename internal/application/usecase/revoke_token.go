@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"upwork-test/internal/application/dto"
+	appservice "upwork-test/internal/application/service"
+	"upwork-test/internal/domain/auth/repository"
+	"upwork-test/internal/domain/auth/service"
+	"upwork-test/internal/domain/auth/valueobject"
+)
+
+// RevokeToken use case revokes an access token, or ends a session by revoking a refresh
+// token and every token of its family. As in RFC 7009, revoking an unknown or already
+// invalid token succeeds, so the response reveals nothing about the token.
+type RevokeToken struct {
+	tokenIssuer       *appservice.TokenIssuer
+	tokenService      *service.TokenService
+	revocationChecker *service.RevocationChecker
+	refreshRepo       repository.RefreshTokenRepository
+}
+
+// NewRevokeToken creates a new RevokeToken use case
+func NewRevokeToken(tokenIssuer *appservice.TokenIssuer, tokenService *service.TokenService, revocationChecker *service.RevocationChecker, refreshRepo repository.RefreshTokenRepository) *RevokeToken {
+	return &RevokeToken{
+		tokenIssuer:       tokenIssuer,
+		tokenService:      tokenService,
+		revocationChecker: revocationChecker,
+		refreshRepo:       refreshRepo,
+	}
+}
+
+// Execute revokes a token. The two token types are told apart by their format, so the
+// type hint is not needed.
+func (uc *RevokeToken) Execute(ctx context.Context, request *dto.RevokeRequest) error {
+	refreshToken, err := valueobject.NewRefreshToken(request.Token)
+	if err != nil {
+		return uc.revokeAccessToken(ctx, request.Token)
+	}
+
+	record, err := uc.refreshRepo.Get(ctx, refreshToken.Hash())
+	if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	if err := uc.refreshRepo.RevokeFamily(ctx, record.FamilyID, uc.tokenIssuer.RefreshTTL()); err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// revokeAccessToken denylists a valid access token until it expires
+func (uc *RevokeToken) revokeAccessToken(ctx context.Context, tokenString string) error {
+	token, err := uc.tokenService.ValidateToken(tokenString)
+	if err != nil {
+		// Invalid or expired tokens need no revocation
+		return nil
+	}
+
+	if err := uc.revocationChecker.RevokeToken(ctx, token); err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/valueobject"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokeToken(t *testing.T) {
+	tests := []struct {
+		name string
+		// token picks the token to revoke from a fresh session
+		token           func(t *testing.T, f *authFixture, session *dto.TokenResponse) string
+		wantAccessEnded bool
+		wantFamilyEnded bool
+	}{
+		{
+			name:            "access token",
+			token:           func(t *testing.T, f *authFixture, session *dto.TokenResponse) string { return session.Token },
+			wantAccessEnded: true,
+		},
+		{
+			name:            "refresh token",
+			token:           func(t *testing.T, f *authFixture, session *dto.TokenResponse) string { return session.RefreshToken },
+			wantFamilyEnded: true,
+		},
+		{
+			name: "refresh token already exchanged",
+			token: func(t *testing.T, f *authFixture, session *dto.TokenResponse) string {
+				uc := NewRefreshAccessToken(f.tokenIssuer, f.refresh, f.clients, f.revocationChecker)
+				_, err := uc.Execute(context.Background(), &dto.RefreshRequest{RefreshToken: session.RefreshToken})
+				require.NoError(t, err)
+				return session.RefreshToken
+			},
+			wantFamilyEnded: true,
+		},
+		{
+			name: "unknown refresh token",
+			token: func(t *testing.T, f *authFixture, session *dto.TokenResponse) string {
+				token, err := valueobject.GenerateRefreshToken()
+				require.NoError(t, err)
+				return token.String()
+			},
+		},
+		{
+			name: "access token signed by an unknown key",
+			token: func(t *testing.T, f *authFixture, session *dto.TokenResponse) string {
+				token, err := newTestTokenService(t).GenerateToken("team-a", nil, "authenticated")
+				require.NoError(t, err)
+				return token.String()
+			},
+		},
+		{
+			name:  "garbage",
+			token: func(t *testing.T, f *authFixture, session *dto.TokenResponse) string { return "not-a-token" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAuthFixture(t)
+			ctx := context.Background()
+			session := f.login(t, f.addClient(t, "team-a", entity.ClientStatusActive))
+			familyID := f.refreshRecord(t, session.RefreshToken).FamilyID
+
+			uc := NewRevokeToken(f.tokenIssuer, f.tokenService, f.revocationChecker, f.refresh)
+			// Unknown and invalid tokens succeed too, as in RFC 7009
+			require.NoError(t, uc.Execute(ctx, &dto.RevokeRequest{Token: tt.token(t, f, session)}))
+
+			access, err := f.tokenService.ValidateToken(session.Token)
+			require.NoError(t, err)
+			revoked, err := f.revocationChecker.IsRevoked(ctx, access)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAccessEnded, revoked, "access token revoked")
+			assert.Equal(t, tt.wantFamilyEnded, f.refresh.isFamilyRevoked(familyID), "refresh token family revoked")
+		})
+	}
+}
+
+func TestRevokeTokenDenylistsUntilExpiry(t *testing.T) {
+	f := newAuthFixture(t)
+	session := f.login(t, f.addClient(t, "team-a", entity.ClientStatusActive))
+
+	uc := NewRevokeToken(f.tokenIssuer, f.tokenService, f.revocationChecker, f.refresh)
+	require.NoError(t, uc.Execute(context.Background(), &dto.RevokeRequest{Token: session.Token, TokenTypeHint: "refresh_token"}))
+
+	token, err := f.tokenService.ValidateToken(session.Token)
+	require.NoError(t, err)
+	require.Contains(t, f.revocations.denylist, token.ID())
+	assert.InDelta(t, time.Until(token.ExpiresAt()).Seconds(), f.revocations.denylist[token.ID()].Seconds(), 1)
+
+	// Revoking the access token leaves the session's refresh token working
+	_, err = NewRefreshAccessToken(f.tokenIssuer, f.refresh, f.clients, f.revocationChecker).
+		Execute(context.Background(), &dto.RefreshRequest{RefreshToken: session.RefreshToken})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/application/service"
+	"upwork-test/internal/domain/market/entity"
+	marketservice "upwork-test/internal/domain/market/service"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+var (
+	// ErrInvalidSearchQuery is returned when search parameters are invalid
+	ErrInvalidSearchQuery = errors.New("invalid search query")
+	// ErrSearchUnavailable is returned when the search index has never been loaded
+	ErrSearchUnavailable = errors.New("search index unavailable")
+)
+
+// SearchMarkets use case finds markets by text and filters.
+type SearchMarkets struct {
+	index *marketservice.SearchIndex
+	sync  *service.SearchIndexSync
+}
+
+// NewSearchMarkets creates a new SearchMarkets use case.
+func NewSearchMarkets(index *marketservice.SearchIndex, sync *service.SearchIndexSync) *SearchMarkets {
+	return &SearchMarkets{
+		index: index,
+		sync:  sync,
+	}
+}
+
+// Execute searches the index, catching it up with the worker's latest documents first.
+// If the catch-up fails the current index is searched, unless it was never loaded.
+func (uc *SearchMarkets) Execute(ctx context.Context, query *dto.SearchQueryDTO) (*dto.SearchResultsDTO, error) {
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+	if limit < 1 || limit > maxSearchLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidSearchQuery, maxSearchLimit)
+	}
+
+	var status entity.MarketStatus
+	if query.Status != "" {
+		parsed, err := entity.ParseMarketStatus(query.Status)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSearchQuery, err)
+		}
+		status = parsed
+	}
+
+	sort := marketservice.SearchSort(query.Sort)
+	switch sort {
+	case "", marketservice.SearchSortRelevance, marketservice.SearchSortVolume, marketservice.SearchSortCloseTime:
+	default:
+		return nil, fmt.Errorf("%w: unknown sort %s", ErrInvalidSearchQuery, query.Sort)
+	}
+
+	if err := uc.sync.EnsureFresh(ctx); err != nil {
+		if uc.index.Len() == 0 {
+			return nil, fmt.Errorf("%w: %v", ErrSearchUnavailable, err)
+		}
+		fmt.Printf("Warning: serving stale search index: %v\n", err)
+	}
+
+	results, total := uc.index.Search(marketservice.SearchQuery{
+		Text:         query.Query,
+		Category:     query.Category,
+		Status:       status,
+		ClosesBefore: query.ClosesBefore,
+		MinVolume:    query.MinVolume,
+		Sort:         sort,
+		Limit:        limit,
+	})
+
+	hits := make([]dto.SearchHitDTO, len(results))
+	for i, result := range results {
+		doc := result.Document
+		hits[i] = dto.SearchHitDTO{
+			Ticker:      doc.Ticker,
+			Title:       doc.Title,
+			Subtitle:    doc.Subtitle,
+			EventTicker: doc.EventTicker,
+			EventTitle:  doc.EventTitle,
+			SeriesTitle: doc.SeriesTitle,
+			Category:    doc.Category,
+			Status:      doc.Status.String(),
+			CloseTime:   doc.CloseTime,
+			Volume24h:   doc.Volume24h,
+			Liquidity:   doc.Liquidity,
+			LastPrice:   doc.LastPrice.ExactCents(),
+			Score:       result.Score,
+		}
+	}
+
+	return &dto.SearchResultsDTO{
+		Query:        query.Query,
+		Results:      hits,
+		Total:        total,
+		IndexVersion: uc.sync.Version(),
+		IndexSize:    uc.index.Len(),
+	}, nil
+}
@@ -12,12 +12,20 @@ import (
 )
 
 type AuthHandler struct {
-	authenticateUseCase *usecase.Authenticate
+	authenticateUseCase       *usecase.Authenticate
+	refreshAccessTokenUseCase *usecase.RefreshAccessToken
+	revokeTokenUseCase        *usecase.RevokeToken
 }
 
-func NewAuthHandler(authenticateUseCase *usecase.Authenticate) *AuthHandler {
+func NewAuthHandler(
+	authenticateUseCase *usecase.Authenticate,
+	refreshAccessTokenUseCase *usecase.RefreshAccessToken,
+	revokeTokenUseCase *usecase.RevokeToken,
+) *AuthHandler {
 	return &AuthHandler{
-		authenticateUseCase: authenticateUseCase,
+		authenticateUseCase:       authenticateUseCase,
+		refreshAccessTokenUseCase: refreshAccessTokenUseCase,
+		revokeTokenUseCase:        revokeTokenUseCase,
 	}
 }
 
@@ -34,7 +42,7 @@ func (h *AuthHandler) IssueToken(c *gin.Context) {
 		return
 	}
 
-	tokenResponse, err := h.authenticateUseCase.Execute(&dto.AuthRequest{
+	tokenResponse, err := h.authenticateUseCase.Execute(c.Request.Context(), &dto.AuthRequest{
 		APIKey: req.APIKey,
 	})
 
@@ -48,6 +56,101 @@ func (h *AuthHandler) IssueToken(c *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, usecase.ErrClientDisabled) {
+			c.JSON(http.StatusForbidden, response.NewErrorResponse(
+				http.StatusForbidden,
+				"API client is disabled",
+				traceID.(string),
+			))
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse(
+			http.StatusInternalServerError,
+			"Internal server error",
+			traceID.(string),
+		))
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, response.FromTokenResponseDTO(tokenResponse))
+}
+
+func (h *AuthHandler) RefreshToken(c *gin.Context) {
+	traceID, _ := c.Get("trace_id")
+
+	var req request.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+			http.StatusBadRequest,
+			"Invalid request body",
+			traceID.(string),
+		))
+		return
+	}
+
+	tokenResponse, err := h.refreshAccessTokenUseCase.Execute(c.Request.Context(), &dto.RefreshRequest{
+		RefreshToken: req.RefreshToken,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidRefreshToken) {
+			c.JSON(http.StatusUnauthorized, response.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Invalid refresh token",
+				traceID.(string),
+			))
+			return
+		}
+
+		if errors.Is(err, usecase.ErrRefreshTokenReused) {
+			c.JSON(http.StatusUnauthorized, response.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Refresh token already used; the session has been revoked",
+				traceID.(string),
+			))
+			return
+		}
+
+		if errors.Is(err, usecase.ErrClientDisabled) {
+			c.JSON(http.StatusForbidden, response.NewErrorResponse(
+				http.StatusForbidden,
+				"API client is disabled",
+				traceID.(string),
+			))
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse(
+			http.StatusInternalServerError,
+			"Internal server error",
+			traceID.(string),
+		))
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, response.FromTokenResponseDTO(tokenResponse))
+}
+
+func (h *AuthHandler) RevokeToken(c *gin.Context) {
+	traceID, _ := c.Get("trace_id")
+
+	var req request.RevokeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+			http.StatusBadRequest,
+			"Invalid request body",
+			traceID.(string),
+		))
+		return
+	}
+
+	err := h.revokeTokenUseCase.Execute(c.Request.Context(), &dto.RevokeRequest{
+		Token:         req.Token,
+		TokenTypeHint: req.TokenTypeHint,
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.NewErrorResponse(
 			http.StatusInternalServerError,
 			"Internal server error",
@@ -56,8 +159,5 @@ func (h *AuthHandler) IssueToken(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, response.NewTokenResponse(
-		tokenResponse.Token,
-		tokenResponse.ExpiresAt,
-	))
+	c.Status(http.StatusOK)
 }
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"upwork-test/internal/application/usecase"
+	"upwork-test/internal/delivery/http/request"
+	"upwork-test/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type BatchHandler struct {
+	getMarketsBatchUseCase *usecase.GetMarketsBatch
+}
+
+func NewBatchHandler(
+	getMarketsBatchUseCase *usecase.GetMarketsBatch,
+) *BatchHandler {
+	return &BatchHandler{
+		getMarketsBatchUseCase: getMarketsBatchUseCase,
+	}
+}
+
+func (h *BatchHandler) GetMarkets(c *gin.Context) {
+	traceID, _ := c.Get("trace_id")
+
+	// The rate limiter may have read the body already, to weigh the request
+	var req request.BatchMarketsRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+			http.StatusBadRequest,
+			"Invalid request body",
+			traceID.(string),
+		))
+		return
+	}
+
+	result, err := h.getMarketsBatchUseCase.Execute(c.Request.Context(), req.Tickers)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidBatchRequest) {
+			c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+				http.StatusBadRequest,
+				err.Error(),
+				traceID.(string),
+			))
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse(
+			http.StatusInternalServerError,
+			"Failed to look up markets",
+			traceID.(string),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.FromMarketBatchDTO(result, req.Fields))
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/application/usecase"
+	"upwork-test/internal/delivery/http/request"
+	"upwork-test/internal/delivery/http/response"
+	"upwork-test/internal/domain/auth/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ClientHandler struct {
+	manageClientsUseCase *usecase.ManageClients
+}
+
+func NewClientHandler(
+	manageClientsUseCase *usecase.ManageClients,
+) *ClientHandler {
+	return &ClientHandler{
+		manageClientsUseCase: manageClientsUseCase,
+	}
+}
+
+func (h *ClientHandler) CreateClient(c *gin.Context) {
+	traceID, _ := c.Get("trace_id")
+
+	var req request.CreateClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+			http.StatusBadRequest,
+			"Invalid request body",
+			traceID.(string),
+		))
+		return
+	}
+
+	result, err := h.manageClientsUseCase.Create(c.Request.Context(), auditActor(c), &dto.CreateClientDTO{
+		ID:     req.ID,
+		Name:   req.Name,
+		Tier:   req.Tier,
+		Scopes: req.Scopes,
+	})
+	if err != nil {
+		writeClientError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusCreated, response.FromIssuedKeyDTO(result))
+}
+
+func (h *ClientHandler) ListClients(c *gin.Context) {
+	result, err := h.manageClientsUseCase.List(c.Request.Context())
+	if err != nil {
+		writeClientError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, response.FromClientDTOs(result))
+}
+
+func (h *ClientHandler) RotateKey(c *gin.Context) {
+	traceID, _ := c.Get("trace_id")
+
+	// The body is optional
+	var req request.RotateKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+			http.StatusBadRequest,
+			"Invalid request body",
+			traceID.(string),
+		))
+		return
+	}
+
+	overlap := usecase.DefaultKeyOverlap
+	if req.OverlapSeconds != nil {
+		overlap = time.Duration(*req.OverlapSeconds) * time.Second
+	}
+
+	result, err := h.manageClientsUseCase.RotateKey(c.Request.Context(), auditActor(c), c.Param("client_id"), overlap)
+	if err != nil {
+		writeClientError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusCreated, response.FromIssuedKeyDTO(result))
+}
+
+func (h *ClientHandler) RevokeKey(c *gin.Context) {
+	result, err := h.manageClientsUseCase.RevokeKey(c.Request.Context(), auditActor(c), c.Param("client_id"), c.Param("key_id"))
+	if err != nil {
+		writeClientError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.FromClientDTO(result))
+}
+
+func (h *ClientHandler) SetTier(c *gin.Context) {
+	traceID, _ := c.Get("trace_id")
+
+	var req request.SetTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+			http.StatusBadRequest,
+			"Invalid request body",
+			traceID.(string),
+		))
+		return
+	}
+
+	result, err := h.manageClientsUseCase.SetTier(c.Request.Context(), auditActor(c), c.Param("client_id"), req.Tier)
+	if err != nil {
+		writeClientError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.FromClientDTO(result))
+}
+
+func (h *ClientHandler) DisableClient(c *gin.Context) {
+	h.setStatus(c, entity.ClientStatusDisabled)
+}
+
+func (h *ClientHandler) EnableClient(c *gin.Context) {
+	h.setStatus(c, entity.ClientStatusActive)
+}
+
+func (h *ClientHandler) RevokeClientTokens(c *gin.Context) {
+	if err := h.manageClientsUseCase.RevokeTokens(c.Request.Context(), auditActor(c), c.Param("client_id")); err != nil {
+		writeClientError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ClientHandler) DeleteClient(c *gin.Context) {
+	if err := h.manageClientsUseCase.Delete(c.Request.Context(), auditActor(c), c.Param("client_id")); err != nil {
+		writeClientError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ClientHandler) GetAuditLog(c *gin.Context) {
+	traceID, _ := c.Get("trace_id")
+
+	var req request.AuditLogRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+			http.StatusBadRequest,
+			"Invalid query parameters",
+			traceID.(string),
+		))
+		return
+	}
+
+	result, err := h.manageClientsUseCase.AuditLog(c.Request.Context(), req.Limit)
+	if err != nil {
+		writeClientError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, response.FromAuditEntryDTOs(result))
+}
+
+func (h *ClientHandler) setStatus(c *gin.Context, status entity.ClientStatus) {
+	result, err := h.manageClientsUseCase.SetStatus(c.Request.Context(), auditActor(c), c.Param("client_id"), status)
+	if err != nil {
+		writeClientError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.FromClientDTO(result))
+}
+
+// auditActor identifies the administrator making a change from the authenticated request
+func auditActor(c *gin.Context) dto.AuditActorDTO {
+	return dto.AuditActorDTO{
+		ClientID: c.GetString("user_id"),
+		TraceID:  c.GetString("trace_id"),
+	}
+}
+
+// writeClientError maps a client registry error to its response
+func writeClientError(c *gin.Context, err error) {
+	traceID, _ := c.Get("trace_id")
+
+	status, message := http.StatusInternalServerError, "Internal server error"
+	switch {
+	case errors.Is(err, usecase.ErrInvalidClient):
+		status, message = http.StatusBadRequest, err.Error()
+	case errors.Is(err, usecase.ErrClientNotFound):
+		status, message = http.StatusNotFound, "Client not found"
+	case errors.Is(err, usecase.ErrKeyNotFound):
+		status, message = http.StatusNotFound, "API key not found"
+	case errors.Is(err, usecase.ErrClientExists):
+		status, message = http.StatusConflict, "Client already exists"
+	}
+
+	c.JSON(status, response.NewErrorResponse(status, message, traceID.(string)))
+}
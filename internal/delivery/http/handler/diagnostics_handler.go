@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+
+	"upwork-test/internal/application/usecase"
+	"upwork-test/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DiagnosticsHandler struct {
+	getSchemaDiagnosticsUseCase *usecase.GetSchemaDiagnostics
+}
+
+func NewDiagnosticsHandler(
+	getSchemaDiagnosticsUseCase *usecase.GetSchemaDiagnostics,
+) *DiagnosticsHandler {
+	return &DiagnosticsHandler{
+		getSchemaDiagnosticsUseCase: getSchemaDiagnosticsUseCase,
+	}
+}
+
+func (h *DiagnosticsHandler) GetSchemaDiagnostics(c *gin.Context) {
+	traceID, _ := c.Get("trace_id")
+
+	result, err := h.getSchemaDiagnosticsUseCase.Execute(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse(
+			http.StatusInternalServerError,
+			"Failed to get schema diagnostics",
+			traceID.(string),
+		))
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, response.FromSchemaDiagnosticsDTO(result))
+}
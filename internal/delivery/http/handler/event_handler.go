@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"upwork-test/internal/application/usecase"
+	"upwork-test/internal/delivery/http/request"
+	"upwork-test/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EventHandler struct {
+	getEventDistributionUseCase *usecase.GetEventDistribution
+}
+
+func NewEventHandler(
+	getEventDistributionUseCase *usecase.GetEventDistribution,
+) *EventHandler {
+	return &EventHandler{
+		getEventDistributionUseCase: getEventDistributionUseCase,
+	}
+}
+
+func (h *EventHandler) GetDistribution(c *gin.Context) {
+	traceID, _ := c.Get("trace_id")
+
+	var req request.EventDistributionRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+			http.StatusBadRequest,
+			"Invalid event ticker",
+			traceID.(string),
+		))
+		return
+	}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+			http.StatusBadRequest,
+			"Invalid query parameters",
+			traceID.(string),
+		))
+		return
+	}
+
+	result, err := h.getEventDistributionUseCase.Execute(c.Request.Context(), req.EventTicker, req.Contracts)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidTicker) {
+			c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+				http.StatusBadRequest,
+				"Invalid event ticker",
+				traceID.(string),
+			))
+			return
+		}
+
+		if errors.Is(err, usecase.ErrEventNotFound) {
+			c.JSON(http.StatusNotFound, response.NewErrorResponse(
+				http.StatusNotFound,
+				"Event not found",
+				traceID.(string),
+			))
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse(
+			http.StatusInternalServerError,
+			"Internal server error",
+			traceID.(string),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.FromEventDistributionDTO(result))
+}
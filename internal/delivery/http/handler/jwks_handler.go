@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"upwork-test/internal/application/usecase"
+	"upwork-test/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// jwksMaxAge is how long verifiers may cache the key set. Keys are published well ahead
+// of their activation, so a cached set always holds the key of a fresh token.
+const jwksMaxAge = "public, max-age=300"
+
+type JWKSHandler struct {
+	getJWKSUseCase *usecase.GetJWKS
+}
+
+func NewJWKSHandler(getJWKSUseCase *usecase.GetJWKS) *JWKSHandler {
+	return &JWKSHandler{
+		getJWKSUseCase: getJWKSUseCase,
+	}
+}
+
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	traceID, _ := c.Get("trace_id")
+
+	result, err := h.getJWKSUseCase.Execute()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse(
+			http.StatusInternalServerError,
+			"Failed to get signing keys",
+			traceID.(string),
+		))
+		return
+	}
+
+	c.Header("Cache-Control", jwksMaxAge)
+	c.JSON(http.StatusOK, response.FromJWKSetDTO(result))
+}
@@ -3,7 +3,9 @@ package handler
 import (
 	"errors"
 	"net/http"
+	"strings"
 
+	"upwork-test/internal/application/dto"
 	"upwork-test/internal/application/usecase"
 	"upwork-test/internal/delivery/http/request"
 	"upwork-test/internal/delivery/http/response"
@@ -55,9 +57,23 @@ func (h *MarketHandler) ListMarkets(c *gin.Context) {
 		req.Limit = 20
 	}
 
-	result, err := h.listMarketsUseCase.Execute(c.Request.Context(), req.Category, req.Page, req.Limit, req.Status)
+	result, err := h.listMarketsUseCase.Execute(c.Request.Context(), req.Category, &dto.MarketListQueryDTO{
+		Page:         req.Page,
+		Limit:        req.Limit,
+		Status:       req.Status,
+		Sort:         req.Sort,
+		Order:        req.Order,
+		MinPrice:     req.MinPrice,
+		MaxPrice:     req.MaxPrice,
+		ClosesAfter:  req.ClosesAfter,
+		ClosesBefore: req.ClosesBefore,
+		MinLiquidity: req.MinLiquidity,
+		EventTicker:  req.EventTicker,
+		Cursor:       req.Cursor,
+	})
 	if err != nil {
-		if errors.Is(err, usecase.ErrInvalidPage) || errors.Is(err, usecase.ErrInvalidLimit) {
+		if errors.Is(err, usecase.ErrInvalidPage) || errors.Is(err, usecase.ErrInvalidLimit) || errors.Is(err, usecase.ErrInvalidListQuery) ||
+			errors.Is(err, usecase.ErrInvalidCursor) {
 			c.JSON(http.StatusBadRequest, response.NewErrorResponse(
 				http.StatusBadRequest,
 				err.Error(),
@@ -66,6 +82,15 @@ func (h *MarketHandler) ListMarkets(c *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, usecase.ErrCursorExpired) {
+			c.JSON(http.StatusGone, response.NewErrorResponse(
+				http.StatusGone,
+				"Cursor expired, restart from the first page",
+				traceID.(string),
+			))
+			return
+		}
+
 		if errors.Is(err, usecase.ErrCategoryNotFound) {
 			c.JSON(http.StatusNotFound, response.NewErrorResponse(
 				http.StatusNotFound,
@@ -100,8 +125,53 @@ func (h *MarketHandler) GetMarketDetails(c *gin.Context) {
 		return
 	}
 
-	result, err := h.getMarketDetailsUseCase.Execute(c.Request.Context(), ticker)
+	var req request.GetMarketDetailsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+			http.StatusBadRequest,
+			"Invalid query parameters",
+			traceID.(string),
+		))
+		return
+	}
+
+	fields := splitList(req.Fields)
+	for _, field := range fields {
+		if !response.IsMarketDetailField(field) {
+			c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+				http.StatusBadRequest,
+				"Unknown field: "+field,
+				traceID.(string),
+			))
+			return
+		}
+	}
+
+	options := &dto.MarketDetailOptionsDTO{
+		TradesLimit:  req.Limit,
+		CandlePeriod: req.CandlePeriod,
+	}
+	if req.Include != nil {
+		options.Include = splitList(*req.Include)
+		if options.Include == nil {
+			options.Include = []string{}
+		}
+	} else if len(fields) > 0 {
+		// Clients selecting fields only get the resources they ask for
+		options.Include = []string{}
+	}
+
+	result, err := h.getMarketDetailsUseCase.Execute(c.Request.Context(), ticker, options)
 	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidDetailOptions) {
+			c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+				http.StatusBadRequest,
+				err.Error(),
+				traceID.(string),
+			))
+			return
+		}
+
 		if errors.Is(err, usecase.ErrInvalidTicker) {
 			c.JSON(http.StatusBadRequest, response.NewErrorResponse(
 				http.StatusBadRequest,
@@ -135,5 +205,16 @@ func (h *MarketHandler) GetMarketDetails(c *gin.Context) {
 		statusCode = http.StatusPartialContent
 	}
 
-	c.JSON(statusCode, response.FromMarketDetailDTO(result))
+	c.JSON(statusCode, response.FromMarketDetailDTO(result, fields))
+}
+
+// splitList splits a comma-separated query parameter, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"upwork-test/internal/application/usecase"
+	"upwork-test/internal/delivery/http/request"
+	"upwork-test/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MoversHandler struct {
+	getMoversUseCase *usecase.GetMovers
+}
+
+func NewMoversHandler(
+	getMoversUseCase *usecase.GetMovers,
+) *MoversHandler {
+	return &MoversHandler{
+		getMoversUseCase: getMoversUseCase,
+	}
+}
+
+func (h *MoversHandler) GetMovers(c *gin.Context) {
+	traceID, _ := c.Get("trace_id")
+
+	var req request.GetMoversRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+			http.StatusBadRequest,
+			"Invalid query parameters",
+			traceID.(string),
+		))
+		return
+	}
+
+	result, err := h.getMoversUseCase.Execute(c.Request.Context(), req.Category, req.Window, req.Limit)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidMoversRequest) {
+			c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+				http.StatusBadRequest,
+				"Invalid query parameters",
+				traceID.(string),
+			))
+			return
+		}
+
+		if errors.Is(err, usecase.ErrCategoryNotFound) {
+			c.JSON(http.StatusNotFound, response.NewErrorResponse(
+				http.StatusNotFound,
+				"Category not found",
+				traceID.(string),
+			))
+			return
+		}
+
+		if errors.Is(err, usecase.ErrMoversWindowUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, response.NewErrorResponse(
+				http.StatusServiceUnavailable,
+				"Price history for this window is not available yet",
+				traceID.(string),
+			))
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse(
+			http.StatusInternalServerError,
+			"Failed to get movers",
+			traceID.(string),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.FromMoversDTO(result))
+}
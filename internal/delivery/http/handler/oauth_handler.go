@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/application/usecase"
+	"upwork-test/internal/delivery/http/request"
+	"upwork-test/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const (
+	grantTypeClientCredentials = "client_credentials"
+	oauthRealm                 = `Basic realm="kalshi-api"`
+)
+
+var (
+	errOAuthNoCredentials       = errors.New("client authentication is required")
+	errOAuthConflictingAuthMode = errors.New("client credentials must be sent in only one way")
+)
+
+type OAuthHandler struct {
+	issueClientCredentialsTokenUseCase *usecase.IssueClientCredentialsToken
+	introspectTokenUseCase             *usecase.IntrospectToken
+}
+
+func NewOAuthHandler(
+	issueClientCredentialsTokenUseCase *usecase.IssueClientCredentialsToken,
+	introspectTokenUseCase *usecase.IntrospectToken,
+) *OAuthHandler {
+	return &OAuthHandler{
+		issueClientCredentialsTokenUseCase: issueClientCredentialsTokenUseCase,
+		introspectTokenUseCase:             introspectTokenUseCase,
+	}
+}
+
+func (h *OAuthHandler) IssueToken(c *gin.Context) {
+	var req request.OAuthTokenRequest
+	if err := bindOAuthForm(c, &req); err != nil {
+		writeOAuthError(c, http.StatusBadRequest, response.OAuthErrorInvalidRequest, err.Error())
+		return
+	}
+
+	if req.GrantType == "" {
+		writeOAuthError(c, http.StatusBadRequest, response.OAuthErrorInvalidRequest, "grant_type is required")
+		return
+	}
+	if req.GrantType != grantTypeClientCredentials {
+		writeOAuthError(c, http.StatusBadRequest, response.OAuthErrorUnsupportedGrantType, "Only the client_credentials grant is supported")
+		return
+	}
+
+	credentials, basic, err := oauthClientCredentials(c, req.ClientID, req.ClientSecret)
+	if err != nil {
+		writeOAuthClientError(c, err, basic)
+		return
+	}
+
+	result, err := h.issueClientCredentialsTokenUseCase.Execute(c.Request.Context(), &dto.ClientCredentialsRequest{
+		Client: credentials,
+		Scopes: strings.Fields(req.Scope),
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidScope) {
+			writeOAuthError(c, http.StatusBadRequest, response.OAuthErrorInvalidScope, "The requested scope exceeds the scopes granted to the client")
+			return
+		}
+
+		writeOAuthClientError(c, err, basic)
+		return
+	}
+
+	setOAuthNoStore(c)
+	c.JSON(http.StatusOK, response.FromAccessTokenDTO(result))
+}
+
+func (h *OAuthHandler) IntrospectToken(c *gin.Context) {
+	var req request.IntrospectRequest
+	if err := bindOAuthForm(c, &req); err != nil {
+		writeOAuthError(c, http.StatusBadRequest, response.OAuthErrorInvalidRequest, err.Error())
+		return
+	}
+
+	if req.Token == "" {
+		writeOAuthError(c, http.StatusBadRequest, response.OAuthErrorInvalidRequest, "token is required")
+		return
+	}
+
+	credentials, basic, err := oauthClientCredentials(c, req.ClientID, req.ClientSecret)
+	if err != nil {
+		writeOAuthClientError(c, err, basic)
+		return
+	}
+
+	result, err := h.introspectTokenUseCase.Execute(c.Request.Context(), &dto.IntrospectionRequest{
+		Client:        credentials,
+		Token:         req.Token,
+		TokenTypeHint: req.TokenTypeHint,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInsufficientScope) {
+			writeOAuthError(c, http.StatusForbidden, response.OAuthErrorInsufficientScope, "Introspection requires the tokens:introspect scope")
+			return
+		}
+
+		writeOAuthClientError(c, err, basic)
+		return
+	}
+
+	setOAuthNoStore(c)
+	c.JSON(http.StatusOK, response.FromIntrospectionDTO(result))
+}
+
+// bindOAuthForm binds a form-encoded body. OAuth parameters must not be sent in the query
+// string or repeated (RFC 6749 section 3.2).
+func bindOAuthForm(c *gin.Context, req interface{}) error {
+	if c.ContentType() != binding.MIMEPOSTForm {
+		return fmt.Errorf("content type must be %s", binding.MIMEPOSTForm)
+	}
+
+	if err := c.ShouldBindWith(req, binding.FormPost); err != nil {
+		return errors.New("invalid request body")
+	}
+
+	for name, values := range c.Request.PostForm {
+		if len(values) > 1 {
+			return fmt.Errorf("%s must not be repeated", name)
+		}
+	}
+	return nil
+}
+
+// oauthClientCredentials reads the client's credentials from HTTP Basic authentication,
+// where they are form-encoded (RFC 6749 section 2.3.1), or from the body. It reports whether
+// Basic authentication was attempted.
+func oauthClientCredentials(c *gin.Context, bodyID string, bodySecret string) (dto.ClientCredentials, bool, error) {
+	username, password, basic := c.Request.BasicAuth()
+	if !basic {
+		if bodyID == "" || bodySecret == "" {
+			return dto.ClientCredentials{}, false, errOAuthNoCredentials
+		}
+		return dto.ClientCredentials{ClientID: bodyID, ClientSecret: bodySecret}, false, nil
+	}
+
+	if bodyID != "" || bodySecret != "" {
+		return dto.ClientCredentials{}, true, errOAuthConflictingAuthMode
+	}
+
+	clientID, err := url.QueryUnescape(username)
+	if err != nil {
+		return dto.ClientCredentials{}, true, usecase.ErrInvalidClientCredentials
+	}
+	clientSecret, err := url.QueryUnescape(password)
+	if err != nil {
+		return dto.ClientCredentials{}, true, usecase.ErrInvalidClientCredentials
+	}
+
+	return dto.ClientCredentials{ClientID: clientID, ClientSecret: clientSecret}, true, nil
+}
+
+// writeOAuthClientError answers a failed client authentication, or any other error, in
+// OAuth form
+func writeOAuthClientError(c *gin.Context, err error, basic bool) {
+	switch {
+	case errors.Is(err, errOAuthConflictingAuthMode):
+		writeOAuthError(c, http.StatusBadRequest, response.OAuthErrorInvalidRequest, err.Error())
+	case errors.Is(err, errOAuthNoCredentials),
+		errors.Is(err, usecase.ErrInvalidClientCredentials),
+		errors.Is(err, usecase.ErrClientDisabled):
+		// Clients that tried Basic authentication are told how to retry
+		if basic || errors.Is(err, errOAuthNoCredentials) {
+			c.Header("WWW-Authenticate", oauthRealm)
+		}
+		writeOAuthError(c, http.StatusUnauthorized, response.OAuthErrorInvalidClient, "Client authentication failed")
+	default:
+		writeOAuthError(c, http.StatusInternalServerError, response.OAuthErrorServerError, "Internal server error")
+	}
+}
+
+func writeOAuthError(c *gin.Context, status int, code string, description string) {
+	setOAuthNoStore(c)
+	c.JSON(status, response.NewOAuthErrorResponse(code, description))
+}
+
+// setOAuthNoStore keeps tokens and token metadata out of caches (RFC 6749 section 5.1)
+func setOAuthNoStore(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+}
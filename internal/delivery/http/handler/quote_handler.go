@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/application/usecase"
+	"upwork-test/internal/delivery/http/request"
+	"upwork-test/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type QuoteHandler struct {
+	getMarketQuoteUseCase *usecase.GetMarketQuote
+}
+
+func NewQuoteHandler(
+	getMarketQuoteUseCase *usecase.GetMarketQuote,
+) *QuoteHandler {
+	return &QuoteHandler{
+		getMarketQuoteUseCase: getMarketQuoteUseCase,
+	}
+}
+
+func (h *QuoteHandler) CreateQuote(c *gin.Context) {
+	traceID, _ := c.Get("trace_id")
+
+	var req request.QuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+			http.StatusBadRequest,
+			"Invalid request body",
+			traceID.(string),
+		))
+		return
+	}
+
+	result, err := h.getMarketQuoteUseCase.Execute(c.Request.Context(), &dto.QuoteRequest{
+		Ticker:      c.Param("ticker"),
+		Side:        req.Side,
+		Quantity:    req.Quantity,
+		Liquidity:   req.Liquidity,
+		LimitPrice:  req.LimitPrice,
+		Probability: req.Probability,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidTicker) {
+			c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+				http.StatusBadRequest,
+				"Invalid ticker format",
+				traceID.(string),
+			))
+			return
+		}
+
+		if errors.Is(err, usecase.ErrInvalidQuoteRequest) {
+			c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+				http.StatusBadRequest,
+				err.Error(),
+				traceID.(string),
+			))
+			return
+		}
+
+		if errors.Is(err, usecase.ErrMarketNotFound) {
+			c.JSON(http.StatusNotFound, response.NewErrorResponse(
+				http.StatusNotFound,
+				"Market not found",
+				traceID.(string),
+			))
+			return
+		}
+
+		if errors.Is(err, usecase.ErrMarketNotTradable) {
+			c.JSON(http.StatusConflict, response.NewErrorResponse(
+				http.StatusConflict,
+				"Market is not open for trading",
+				traceID.(string),
+			))
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse(
+			http.StatusInternalServerError,
+			"Internal server error",
+			traceID.(string),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.FromQuoteDTO(result))
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"upwork-test/internal/application/usecase"
+	"upwork-test/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RangedGroupHandler struct {
+	getRangedGroupUseCase *usecase.GetRangedGroup
+}
+
+func NewRangedGroupHandler(
+	getRangedGroupUseCase *usecase.GetRangedGroup,
+) *RangedGroupHandler {
+	return &RangedGroupHandler{
+		getRangedGroupUseCase: getRangedGroupUseCase,
+	}
+}
+
+func (h *RangedGroupHandler) GetRangedGroup(c *gin.Context) {
+	traceID, _ := c.Get("trace_id")
+
+	result, err := h.getRangedGroupUseCase.Execute(c.Request.Context(), c.Param("ticker"))
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidTicker) {
+			c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+				http.StatusBadRequest,
+				"Invalid ticker format",
+				traceID.(string),
+			))
+			return
+		}
+
+		if errors.Is(err, usecase.ErrRangedGroupNotFound) {
+			c.JSON(http.StatusNotFound, response.NewErrorResponse(
+				http.StatusNotFound,
+				"Ranged group not found",
+				traceID.(string),
+			))
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse(
+			http.StatusInternalServerError,
+			"Internal server error",
+			traceID.(string),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.FromRangedGroupDTO(result))
+}
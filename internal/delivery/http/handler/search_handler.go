@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"upwork-test/internal/application/dto"
+	"upwork-test/internal/application/usecase"
+	"upwork-test/internal/delivery/http/request"
+	"upwork-test/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	searchMarketsUseCase *usecase.SearchMarkets
+}
+
+func NewSearchHandler(
+	searchMarketsUseCase *usecase.SearchMarkets,
+) *SearchHandler {
+	return &SearchHandler{
+		searchMarketsUseCase: searchMarketsUseCase,
+	}
+}
+
+func (h *SearchHandler) SearchMarkets(c *gin.Context) {
+	traceID, _ := c.Get("trace_id")
+
+	var req request.SearchMarketsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+			http.StatusBadRequest,
+			"Invalid query parameters",
+			traceID.(string),
+		))
+		return
+	}
+
+	result, err := h.searchMarketsUseCase.Execute(c.Request.Context(), &dto.SearchQueryDTO{
+		Query:        req.Query,
+		Category:     req.Category,
+		Status:       req.Status,
+		ClosesBefore: req.ClosesBefore,
+		MinVolume:    req.MinVolume,
+		Sort:         req.Sort,
+		Limit:        req.Limit,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidSearchQuery) {
+			c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+				http.StatusBadRequest,
+				"Invalid query parameters",
+				traceID.(string),
+			))
+			return
+		}
+
+		if errors.Is(err, usecase.ErrSearchUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, response.NewErrorResponse(
+				http.StatusServiceUnavailable,
+				"Search is temporarily unavailable",
+				traceID.(string),
+			))
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse(
+			http.StatusInternalServerError,
+			"Failed to search markets",
+			traceID.(string),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.FromSearchResultsDTO(result))
+}
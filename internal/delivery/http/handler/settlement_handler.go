@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"upwork-test/internal/application/usecase"
+	"upwork-test/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SettlementHandler struct {
+	getMarketSettlementUseCase *usecase.GetMarketSettlement
+}
+
+func NewSettlementHandler(
+	getMarketSettlementUseCase *usecase.GetMarketSettlement,
+) *SettlementHandler {
+	return &SettlementHandler{
+		getMarketSettlementUseCase: getMarketSettlementUseCase,
+	}
+}
+
+func (h *SettlementHandler) GetSettlement(c *gin.Context) {
+	traceID, _ := c.Get("trace_id")
+
+	result, err := h.getMarketSettlementUseCase.Execute(c.Request.Context(), c.Param("ticker"))
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidTicker) {
+			c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+				http.StatusBadRequest,
+				"Invalid ticker format",
+				traceID.(string),
+			))
+			return
+		}
+
+		if errors.Is(err, usecase.ErrMarketNotFound) {
+			c.JSON(http.StatusNotFound, response.NewErrorResponse(
+				http.StatusNotFound,
+				"Market not found",
+				traceID.(string),
+			))
+			return
+		}
+
+		if errors.Is(err, usecase.ErrMarketNotSettled) {
+			c.JSON(http.StatusNotFound, response.NewErrorResponse(
+				http.StatusNotFound,
+				"Market has not settled",
+				traceID.(string),
+			))
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse(
+			http.StatusInternalServerError,
+			"Internal server error",
+			traceID.(string),
+		))
+		return
+	}
+
+	// Settlements are final
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.JSON(http.StatusOK, response.FromSettlementDTO(result))
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"upwork-test/internal/application/usecase"
+	"upwork-test/internal/delivery/http/request"
+	"upwork-test/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StatusHistoryHandler struct {
+	getStatusHistoryUseCase *usecase.GetStatusHistory
+}
+
+func NewStatusHistoryHandler(
+	getStatusHistoryUseCase *usecase.GetStatusHistory,
+) *StatusHistoryHandler {
+	return &StatusHistoryHandler{
+		getStatusHistoryUseCase: getStatusHistoryUseCase,
+	}
+}
+
+func (h *StatusHistoryHandler) GetStatusHistory(c *gin.Context) {
+	traceID, _ := c.Get("trace_id")
+
+	var req request.GetStatusHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+			http.StatusBadRequest,
+			"Invalid query parameters",
+			traceID.(string),
+		))
+		return
+	}
+
+	result, err := h.getStatusHistoryUseCase.Execute(c.Request.Context(), c.Param("ticker"), req.Limit)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidTicker) {
+			c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+				http.StatusBadRequest,
+				"Invalid ticker format",
+				traceID.(string),
+			))
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse(
+			http.StatusInternalServerError,
+			"Internal server error",
+			traceID.(string),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.FromStatusHistoryDTO(result))
+}
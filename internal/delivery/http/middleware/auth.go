@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -11,8 +12,8 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// Auth returns a middleware that validates JWT tokens
-func Auth(tokenService *service.TokenService) gin.HandlerFunc {
+// Auth returns a middleware that validates JWT tokens and refuses revoked ones
+func Auth(tokenService *service.TokenService, revocationChecker *service.RevocationChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		traceID, _ := c.Get("trace_id")
 
@@ -60,8 +61,30 @@ func Auth(tokenService *service.TokenService) gin.HandlerFunc {
 			return
 		}
 
-		// Store user ID in context
+		// Check revocation. If the denylist is unreachable the request is refused, since a
+		// revoked token must not keep working while Redis is down
+		revoked, err := revocationChecker.IsRevoked(c.Request.Context(), token)
+		if err != nil {
+			fmt.Printf("Warning: token revocation check failed: %v\n", err)
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, response.NewErrorResponse(
+				http.StatusServiceUnavailable,
+				"Token revocation check unavailable",
+				traceID.(string),
+			))
+			return
+		}
+		if revoked {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Token revoked",
+				traceID.(string),
+			))
+			return
+		}
+
+		// Store user ID and granted scopes in context
 		c.Set("user_id", token.UserID())
+		c.Set("scopes", token.Scopes())
 
 		c.Next()
 	}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/service"
+	"upwork-test/internal/domain/auth/valueobject"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTokenRevocationRepository keeps the denylist in memory and fails lookups when err is set
+type fakeTokenRevocationRepository struct {
+	denylist map[string]bool
+	err      error
+}
+
+func (r *fakeTokenRevocationRepository) RevokeToken(_ context.Context, tokenID string, _ time.Duration) error {
+	r.denylist[tokenID] = true
+	return nil
+}
+
+func (r *fakeTokenRevocationRepository) IsTokenRevoked(_ context.Context, tokenID string) (bool, error) {
+	if r.err != nil {
+		return false, r.err
+	}
+	return r.denylist[tokenID], nil
+}
+
+func (r *fakeTokenRevocationRepository) SetClientWatermark(_ context.Context, _ string, _ time.Time) error {
+	return nil
+}
+
+func (r *fakeTokenRevocationRepository) GetClientWatermark(_ context.Context, _ string) (time.Time, error) {
+	if r.err != nil {
+		return time.Time{}, r.err
+	}
+	return time.Time{}, nil
+}
+
+// newTestTokenService returns a token service issuing 15-minute tokens with one active key
+func newTestTokenService(t *testing.T) *service.TokenService {
+	t.Helper()
+
+	now := time.Now()
+	key, err := entity.GenerateSigningKey(valueobject.SigningAlgorithmES256, now, now.Add(-time.Minute), now.Add(time.Hour), now.Add(2*time.Hour))
+	require.NoError(t, err)
+	tokenService := service.NewTokenService(15 * time.Minute)
+	require.NoError(t, tokenService.SetKeys([]*entity.SigningKey{key}))
+	return tokenService
+}
+
+func TestAuthRevocation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tokenService := newTestTokenService(t)
+
+	tests := []struct {
+		name       string
+		revoke     bool
+		lookupErr  error
+		wantStatus int
+	}{
+		{name: "active token", wantStatus: http.StatusOK},
+		{name: "revoked token", revoke: true, wantStatus: http.StatusUnauthorized},
+		{name: "denylist unreachable", lookupErr: errors.New("connection refused"), wantStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeTokenRevocationRepository{denylist: make(map[string]bool)}
+			revocationChecker := service.NewRevocationChecker(repo, 0)
+
+			token, err := tokenService.GenerateToken("team-a", []string{valueobject.ScopeMarketsRead}, "authenticated")
+			require.NoError(t, err)
+			if tt.revoke {
+				require.NoError(t, revocationChecker.RevokeToken(context.Background(), token))
+			}
+			repo.err = tt.lookupErr
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) { c.Set("trace_id", "trace") })
+			router.GET("/", Auth(tokenService, revocationChecker), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+token.String())
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
@@ -1,24 +1,161 @@
 package middleware
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
+	"upwork-test/internal/application/usecase"
+	"upwork-test/internal/delivery/http/request"
+	"upwork-test/internal/domain/auth/repository"
 	"upwork-test/internal/domain/auth/service"
+	authvalueobject "upwork-test/internal/domain/auth/valueobject"
 	ratelimit "upwork-test/internal/domain/ratelimit/service"
 	"upwork-test/internal/domain/ratelimit/valueobject"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
-// RateLimitMiddleware creates a middleware that enforces rate limits.
-func RateLimitMiddleware(limiter *ratelimit.RateLimiter, tokenService *service.TokenService) gin.HandlerFunc {
+// RequestCost returns the rate limit weight of a request
+type RequestCost func(c *gin.Context) int
+
+// RouteCost is how requests to a route are rate limited: the bucket of their class, and their weight
+type RouteCost struct {
+	Class  valueobject.RouteClass
+	Cost   RequestCost
+	Public bool // Served without authentication
+}
+
+// RouteCostRule configures how requests to a route are limited
+type RouteCostRule struct {
+	Route     string // Method and path pattern, e.g. "GET /api/v1/markets/:ticker"
+	Class     valueobject.RouteClass
+	Cost      int // Units per request, or per started batch of BatchSize items
+	BatchSize int // Items per cost unit of a batch route; 0 for a flat cost
+}
+
+// RouteCosts maps routes, keyed by method and path pattern (e.g. "POST /api/v1/markets/batch"),
+// to how their requests are limited. Other routes cost 1 in the read class.
+type RouteCosts map[string]RouteCost
+
+// batchRoute counts the items of requests to a batch route, which holds at most maxItems
+type batchRoute struct {
+	size     func(c *gin.Context) (int, bool)
+	maxItems int
+}
+
+// batchRoutes are the routes that can be weighed by batch size
+var batchRoutes = map[string]batchRoute{
+	http.MethodPost + " /api/v1/markets/batch": {size: batchMarketsSize, maxItems: usecase.MaxBatchSize},
+}
+
+// maxBatchBodyBytes bounds the body of a batch request read to weigh it, before the request
+// is authenticated. A full batch of the longest tickers takes about 10KB.
+const maxBatchBodyBytes = 64 << 10
+
+// publicRoutes are the routes served without authentication. Requests without a valid token,
+// limited under the unauthenticated tier, are refused by Auth on every other route, and the
+// worker tier, meant for background workers, is not used on them either.
+var publicRoutes = map[string]bool{
+	http.MethodGet + " /.well-known/jwks.json":    true,
+	http.MethodPost + " /api/v1/auth/token":       true,
+	http.MethodPost + " /api/v1/auth/refresh":     true,
+	http.MethodPost + " /api/v1/auth/revoke":      true,
+	http.MethodPost + " /api/v1/oauth/token":      true,
+	http.MethodPost + " /api/v1/oauth/introspect": true,
+}
+
+// reachesRoute checks if requests limited under a tier can be served by a route
+func reachesRoute(tier valueobject.RateLimitTier, route string) bool {
+	if publicRoutes[route] {
+		return true
+	}
+	return tier.Name() != valueobject.TierUnauthenticated && tier.Name() != valueobject.TierWorker
+}
+
+// NewRouteCosts builds the route costs of the rules. Only batch routes can be weighed by
+// batch size. A route whose largest request costs more than some tier that reaches it ever
+// admits at once (see RateLimitTier.MaxCost) is refused, as those requests would always be
+// denied.
+func NewRouteCosts(rules []RouteCostRule, tiers []valueobject.RateLimitTier, algorithm valueobject.RateLimitAlgorithm) (RouteCosts, error) {
+	costs := make(RouteCosts, len(rules))
+	for _, rule := range rules {
+		units := rule.Cost
+		cost := func(*gin.Context) int { return units }
+		maxCost := units
+		if rule.BatchSize > 0 {
+			batch, ok := batchRoutes[rule.Route]
+			if !ok {
+				return nil, fmt.Errorf("rate limit route %s is not a batch route", rule.Route)
+			}
+			cost = batchCost(batch.size, units, rule.BatchSize)
+			maxCost = units * max((batch.maxItems+rule.BatchSize-1)/rule.BatchSize, 1)
+		}
+
+		for _, tier := range tiers {
+			if !reachesRoute(tier, rule.Route) {
+				continue
+			}
+			if maxCost > tier.MaxCost(algorithm) {
+				return nil, fmt.Errorf("rate limit route %s costs up to %d, more than the %s tier admits at once (%d)",
+					rule.Route, maxCost, tier.Name(), tier.MaxCost(algorithm))
+			}
+		}
+
+		costs[rule.Route] = RouteCost{Class: rule.Class, Cost: cost, Public: publicRoutes[rule.Route]}
+	}
+	return costs, nil
+}
+
+// CheckRoutes fails if a route cost, or a route known to be public, names a route that is
+// not registered, which would otherwise be silently ignored
+func (rc RouteCosts) CheckRoutes(routes gin.RoutesInfo) error {
+	registered := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		registered[route.Method+" "+route.Path] = true
+	}
+
+	var unknown []string
+	for route := range rc {
+		if !registered[route] {
+			unknown = append(unknown, route)
+		}
+	}
+	for route := range publicRoutes {
+		if _, listed := rc[route]; !listed && !registered[route] {
+			unknown = append(unknown, route)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("rate limit routes not served by the API: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// RateLimitMiddleware creates a middleware that enforces rate limits. Authenticated requests
+// are limited by their client's tier, read from the token or, for tokens issued without one,
+// from the client registry.
+func RateLimitMiddleware(limiter *ratelimit.RateLimiter, tokenService *service.TokenService, revocationChecker *service.RevocationChecker, clientRepo repository.ClientRepository, costs RouteCosts) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Determine user ID and tier
-		userID, tier := getUserIDAndTier(c, tokenService)
+		userID, tier := getUserIDAndTier(c, tokenService, revocationChecker, clientRepo)
+
+		class, cost := valueobject.RouteClassRead, 1
+		if routeCost, ok := costs[c.Request.Method+" "+c.FullPath()]; ok {
+			class = routeCost.Class
+			// Unauthenticated requests to other routes are refused by Auth, before they
+			// cost anything upstream, so they are neither weighed nor read
+			if routeCost.Public || tier.Name() != valueobject.TierUnauthenticated {
+				cost = routeCost.Cost(c)
+			}
+		}
 
 		// Check rate limit
-		allowed, remaining, resetTime, err := limiter.CheckLimit(c.Request.Context(), userID, tier)
+		allowed, remaining, resetTime, err := limiter.CheckLimit(c.Request.Context(), userID, tier, class, cost)
 		if err != nil {
 			// Log error but don't block the request on rate limit check failure
 			// This ensures availability over strict rate limiting
@@ -30,6 +167,7 @@ func RateLimitMiddleware(limiter *ratelimit.RateLimiter, tokenService *service.T
 		c.Header("X-RateLimit-Limit", strconv.Itoa(tier.MaxRequests()))
 		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
 		c.Header("X-RateLimit-Reset", strconv.FormatInt(resetTime.Unix(), 10))
+		c.Header("X-RateLimit-Cost", strconv.Itoa(cost))
 
 		// If rate limit exceeded, return 429
 		if !allowed {
@@ -46,11 +184,36 @@ func RateLimitMiddleware(limiter *ratelimit.RateLimiter, tokenService *service.T
 	}
 }
 
+// batchCost weighs a batch request by its size: units per started group of batchSize items.
+// A request whose items cannot be counted is rejected by the handler, so it costs units.
+func batchCost(size func(c *gin.Context) (int, bool), units int, batchSize int) RequestCost {
+	return func(c *gin.Context) int {
+		items, ok := size(c)
+		if !ok {
+			return units
+		}
+		return units * max((items+batchSize-1)/batchSize, 1)
+	}
+}
+
+// batchMarketsSize counts the tickers of a batch market lookup. The body stays available to
+// the handler through ShouldBindBodyWith; a body over maxBatchBodyBytes is not read, and the
+// handler then rejects it too.
+func batchMarketsSize(c *gin.Context) (int, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBatchBodyBytes)
+
+	var req request.BatchMarketsRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		return 0, false
+	}
+	return len(req.Tickers), true
+}
+
 // getUserIDAndTier extracts user ID and rate limit tier from the request.
 // It attempts to decode the JWT token from the Authorization header to determine
-// if the user is authenticated. This runs before the Auth middleware, so it doesn't
-// rely on context values.
-func getUserIDAndTier(c *gin.Context, tokenService *service.TokenService) (string, valueobject.RateLimitTier) {
+// if the user is authenticated and the token not revoked. This runs before the Auth
+// middleware, so it doesn't rely on context values.
+func getUserIDAndTier(c *gin.Context, tokenService *service.TokenService, revocationChecker *service.RevocationChecker, clientRepo repository.ClientRepository) (string, valueobject.RateLimitTier) {
 	// Try to extract and validate JWT token
 	authHeader := c.GetHeader("Authorization")
 	if authHeader != "" {
@@ -61,10 +224,16 @@ func getUserIDAndTier(c *gin.Context, tokenService *service.TokenService) (strin
 			// Attempt to validate token
 			token, err := tokenService.ValidateToken(tokenString)
 			if err == nil {
-				// Valid token - use authenticated tier
+				// Revoked tokens are limited as anonymous requests; Auth rejects them.
+				// A failed check is treated as not revoked here; Auth then refuses the request.
+				if revoked, _ := revocationChecker.IsRevoked(c.Request.Context(), token); revoked {
+					return c.ClientIP(), valueobject.MustRateLimitTier(valueobject.TierUnauthenticated)
+				}
+
+				// Valid token - use its client's tier
 				userID := token.UserID()
 				c.Set("user_id", userID) // Pre-set for Auth middleware
-				return userID, valueobject.Authenticated
+				return userID, clientTier(c.Request.Context(), token, clientRepo)
 			}
 			// Invalid/expired token - will be caught by Auth middleware later
 			// For rate limiting purposes, treat as unauthenticated
@@ -73,5 +242,25 @@ func getUserIDAndTier(c *gin.Context, tokenService *service.TokenService) (strin
 
 	// For unauthenticated users, use client IP
 	clientIP := c.ClientIP()
-	return clientIP, valueobject.Unauthenticated
+	return clientIP, valueobject.MustRateLimitTier(valueobject.TierUnauthenticated)
+}
+
+// clientTier resolves the tier of a token's client. Tokens issued before tiers were embedded
+// are looked up in the registry. A tier that is no longer configured, or a failed lookup,
+// falls back to the authenticated tier.
+func clientTier(ctx context.Context, token authvalueobject.Token, clientRepo repository.ClientRepository) valueobject.RateLimitTier {
+	tierName := token.Tier()
+	if tierName == "" {
+		client, err := clientRepo.GetByID(ctx, token.UserID())
+		if err != nil {
+			return valueobject.MustRateLimitTier(valueobject.TierAuthenticated)
+		}
+		tierName = client.Tier
+	}
+
+	tier, err := valueobject.NewRateLimitTier(tierName)
+	if err != nil || tierName == valueobject.TierUnauthenticated {
+		return valueobject.MustRateLimitTier(valueobject.TierAuthenticated)
+	}
+	return tier
 }
@@ -0,0 +1,252 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"upwork-test/internal/domain/auth/service"
+	"upwork-test/internal/domain/ratelimit/entity"
+	ratelimit "upwork-test/internal/domain/ratelimit/service"
+	"upwork-test/internal/domain/ratelimit/valueobject"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testMarketRoute = http.MethodGet + " /api/v1/markets/:ticker"
+	testBatchRoute  = http.MethodPost + " /api/v1/markets/batch"
+	testTokenRoute  = http.MethodPost + " /api/v1/auth/token"
+)
+
+// fakeRateLimitRepository allows every request
+type fakeRateLimitRepository struct{}
+
+func (fakeRateLimitRepository) Get(_ context.Context, _ string) (*entity.RateLimit, error) {
+	return nil, nil
+}
+
+func (fakeRateLimitRepository) Save(_ context.Context, _ *entity.RateLimit) error {
+	return nil
+}
+
+func (fakeRateLimitRepository) IncrementAndCheck(_ context.Context, _ string, tier valueobject.RateLimitTier, _ valueobject.RouteClass, cost int) (bool, int, time.Time, error) {
+	return true, tier.MaxRequests() - cost, time.Now().Add(tier.Window()), nil
+}
+
+// testTiers returns the built-in tiers, with low unauthenticated and worker limits, and a
+// client tier of 5 requests admitting bursts of 2
+func testTiers(t *testing.T) []valueobject.RateLimitTier {
+	t.Helper()
+
+	var tiers []valueobject.RateLimitTier
+	for _, definition := range []struct {
+		name         string
+		limit, burst int
+	}{
+		{name: valueobject.TierAuthenticated, limit: 100},
+		{name: valueobject.TierUnauthenticated, limit: 2},
+		{name: valueobject.TierWorker, limit: 2},
+		{name: "partner", limit: 5, burst: 2},
+	} {
+		tier, err := valueobject.DefineRateLimitTier(definition.name, definition.limit, time.Minute, definition.burst)
+		require.NoError(t, err)
+		tiers = append(tiers, tier)
+	}
+	return tiers
+}
+
+func TestNewRouteCostsValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		rule      RouteCostRule
+		algorithm valueobject.RateLimitAlgorithm
+		wantErr   string
+	}{
+		{
+			name: "authenticated route costlier than the unauthenticated and worker limits",
+			rule: RouteCostRule{Route: testMarketRoute, Class: valueobject.RouteClassRead, Cost: 3},
+		},
+		{
+			name:    "authenticated route costlier than a client tier",
+			rule:    RouteCostRule{Route: testMarketRoute, Class: valueobject.RouteClassRead, Cost: 6},
+			wantErr: "more than the partner tier admits at once (5)",
+		},
+		{
+			name:    "public route costlier than the unauthenticated limit",
+			rule:    RouteCostRule{Route: testTokenRoute, Class: valueobject.RouteClassHandshake, Cost: 3},
+			wantErr: "more than the unauthenticated tier admits at once (2)",
+		},
+		{
+			name: "public route within every limit",
+			rule: RouteCostRule{Route: testTokenRoute, Class: valueobject.RouteClassHandshake, Cost: 2},
+		},
+		{
+			name:      "authenticated route costlier than a client tier's burst",
+			rule:      RouteCostRule{Route: testMarketRoute, Class: valueobject.RouteClassRead, Cost: 3},
+			algorithm: valueobject.AlgorithmGCRA,
+			wantErr:   "more than the partner tier admits at once (2)",
+		},
+		{
+			name: "full batch within every client tier",
+			rule: RouteCostRule{Route: testBatchRoute, Class: valueobject.RouteClassRead, Cost: 1, BatchSize: 25},
+		},
+		{
+			name:    "full batch over a client tier",
+			rule:    RouteCostRule{Route: testBatchRoute, Class: valueobject.RouteClassRead, Cost: 1, BatchSize: 10},
+			wantErr: "costs up to 10, more than the partner tier",
+		},
+		{
+			name:    "batch size on a flat route",
+			rule:    RouteCostRule{Route: testMarketRoute, Class: valueobject.RouteClassRead, Cost: 1, BatchSize: 10},
+			wantErr: "is not a batch route",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			algorithm := tt.algorithm
+			if algorithm == "" {
+				algorithm = valueobject.AlgorithmSlidingWindow
+			}
+
+			costs, err := NewRouteCosts([]RouteCostRule{tt.rule}, testTiers(t), algorithm)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				assert.Contains(t, costs, tt.rule.Route)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRouteCostsResolve(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	costs, err := NewRouteCosts([]RouteCostRule{
+		{Route: testMarketRoute, Class: valueobject.RouteClassRead, Cost: 3},
+		{Route: testBatchRoute, Class: valueobject.RouteClassRead, Cost: 2, BatchSize: 10},
+		{Route: testTokenRoute, Class: valueobject.RouteClassHandshake, Cost: 1},
+	}, []valueobject.RateLimitTier{valueobject.MustRateLimitTier(valueobject.TierAuthenticated)}, valueobject.AlgorithmSlidingWindow)
+	require.NoError(t, err)
+
+	assert.Equal(t, valueobject.RouteClassRead, costs[testMarketRoute].Class)
+	assert.False(t, costs[testMarketRoute].Public)
+	assert.Equal(t, valueobject.RouteClassHandshake, costs[testTokenRoute].Class)
+	assert.True(t, costs[testTokenRoute].Public)
+
+	tickers := func(n int) string {
+		quoted := make([]string, n)
+		for i := range quoted {
+			quoted[i] = `"KX-` + strings.Repeat("A", i%5+1) + `"`
+		}
+		return `{"tickers": [` + strings.Join(quoted, ",") + `]}`
+	}
+
+	tests := []struct {
+		name  string
+		route string
+		body  string
+		want  int
+	}{
+		{name: "flat route", route: testMarketRoute, want: 3},
+		{name: "one ticker", route: testBatchRoute, body: tickers(1), want: 2},
+		{name: "a full group of tickers", route: testBatchRoute, body: tickers(10), want: 2},
+		{name: "a started second group", route: testBatchRoute, body: tickers(11), want: 4},
+		{name: "a full batch", route: testBatchRoute, body: tickers(100), want: 20},
+		{name: "no tickers", route: testBatchRoute, body: `{"tickers": []}`, want: 2},
+		{name: "malformed body", route: testBatchRoute, body: `{"tickers": [`, want: 2},
+		{name: "oversized body", route: testBatchRoute, body: `{"tickers": ["` + strings.Repeat("A", maxBatchBodyBytes) + `"]}`, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/markets/batch", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			assert.Equal(t, tt.want, costs[tt.route].Cost(c))
+		})
+	}
+}
+
+func TestRateLimitMiddlewareCost(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tokenService := newTestTokenService(t)
+	revocationChecker := service.NewRevocationChecker(&fakeTokenRevocationRepository{denylist: make(map[string]bool)}, 0)
+	token, err := tokenService.GenerateToken("team-a", nil, valueobject.TierAuthenticated)
+	require.NoError(t, err)
+
+	costs, err := NewRouteCosts([]RouteCostRule{
+		{Route: testMarketRoute, Class: valueobject.RouteClassRead, Cost: 3},
+		{Route: testTokenRoute, Class: valueobject.RouteClassHandshake, Cost: 2},
+	}, testTiers(t), valueobject.AlgorithmSlidingWindow)
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(RateLimitMiddleware(ratelimit.NewRateLimiter(fakeRateLimitRepository{}), tokenService, revocationChecker, nil, costs))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/api/v1/markets/:ticker", ok)
+	router.POST("/api/v1/auth/token", ok)
+	router.GET("/api/v1/movers", ok)
+
+	tests := []struct {
+		name          string
+		method, path  string
+		authenticated bool
+		wantCost      string
+	}{
+		{name: "authenticated request", method: http.MethodGet, path: "/api/v1/markets/KX-A", authenticated: true, wantCost: "3"},
+		// Auth refuses it, so it is not weighed
+		{name: "unauthenticated request to an authenticated route", method: http.MethodGet, path: "/api/v1/markets/KX-A", wantCost: "1"},
+		{name: "unauthenticated request to a public route", method: http.MethodPost, path: "/api/v1/auth/token", wantCost: "2"},
+		{name: "route without a cost", method: http.MethodGet, path: "/api/v1/movers", authenticated: true, wantCost: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.authenticated {
+				req.Header.Set("Authorization", "Bearer "+token.String())
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.wantCost, rec.Header().Get("X-RateLimit-Cost"))
+		})
+	}
+}
+
+func TestRouteCostsCheckRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	for route := range publicRoutes {
+		method, path, _ := strings.Cut(route, " ")
+		router.Handle(method, path, ok)
+	}
+	router.GET("/api/v1/markets/:ticker", ok)
+
+	costs := RouteCosts{testMarketRoute: {Class: valueobject.RouteClassRead}}
+	assert.NoError(t, costs.CheckRoutes(router.Routes()))
+
+	costs["GET /api/v1/market/:ticker"] = RouteCost{Class: valueobject.RouteClassRead}
+	err := costs.CheckRoutes(router.Routes())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GET /api/v1/market/:ticker")
+
+	// A public route that is no longer served is reported too
+	err = RouteCosts{}.CheckRoutes(gin.New().Routes())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), testTokenRoute)
+}
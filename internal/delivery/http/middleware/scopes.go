@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"upwork-test/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireScopes returns a middleware that only lets through tokens granted every one of
+// scopes. It runs after Auth, which stores the token's scopes in the context.
+func RequireScopes(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		traceID, _ := c.Get("trace_id")
+
+		granted := c.GetStringSlice("scopes")
+		for _, scope := range scopes {
+			if !slices.Contains(granted, scope) {
+				c.AbortWithStatusJSON(http.StatusForbidden, response.NewErrorResponse(
+					http.StatusForbidden,
+					"Missing required scope: "+scope,
+					traceID.(string),
+				))
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
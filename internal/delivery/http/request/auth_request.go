@@ -4,3 +4,14 @@ package request
 type AuthRequest struct {
 	APIKey string `json:"api_key" binding:"required"`
 }
+
+// RefreshRequest represents the token refresh request payload
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// RevokeRequest represents the token revocation request payload
+type RevokeRequest struct {
+	Token         string `json:"token" binding:"required"`
+	TokenTypeHint string `json:"token_type_hint" binding:"omitempty,oneof=refresh_token access_token"`
+}
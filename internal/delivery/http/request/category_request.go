@@ -4,3 +4,10 @@ package request
 type GetCategoryOverviewRequest struct {
 	Category string `uri:"category" binding:"required,min=2,max=50"`
 }
+
+// GetMoversRequest represents the query parameters for the top movers.
+type GetMoversRequest struct {
+	Category string `form:"category" binding:"omitempty,min=2,max=50"`
+	Window   string `form:"window" binding:"omitempty,oneof=1h 6h 24h"`
+	Limit    int    `form:"limit" binding:"min=0,max=50"`
+}
@@ -0,0 +1,25 @@
+package request
+
+// CreateClientRequest represents the request payload for registering an API client.
+type CreateClientRequest struct {
+	ID     string   `json:"id" binding:"required"`
+	Name   string   `json:"name" binding:"max=200"`
+	Tier   string   `json:"tier"`
+	Scopes []string `json:"scopes"`
+}
+
+// RotateKeyRequest represents the optional request payload for rotating a client's key.
+type RotateKeyRequest struct {
+	// How long the previous keys keep working; defaults to 24 hours
+	OverlapSeconds *int64 `json:"overlap_seconds" binding:"omitempty,min=0"`
+}
+
+// SetTierRequest represents the request payload for assigning a client's rate limit tier.
+type SetTierRequest struct {
+	Tier string `json:"tier" binding:"required"`
+}
+
+// AuditLogRequest represents the query parameters for the registry audit log.
+type AuditLogRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=500"`
+}
@@ -0,0 +1,7 @@
+package request
+
+// EventDistributionRequest represents the request parameters for an event distribution.
+type EventDistributionRequest struct {
+	EventTicker string `uri:"event_ticker" binding:"required"`
+	Contracts   int64  `form:"contracts" binding:"min=0,max=100000"`
+}
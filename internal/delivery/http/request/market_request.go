@@ -1,9 +1,62 @@
 package request
 
+import (
+	"time"
+)
+
 // ListMarketsRequest represents the request parameters for listing markets.
 type ListMarketsRequest struct {
-	Category string `uri:"category" binding:"required"`
-	Page     int    `form:"page" binding:"min=0"`
-	Limit    int    `form:"limit" binding:"min=0,max=100"`
-	Status   string `form:"status" binding:"omitempty,oneof=open closed settled"`
+	Category     string    `uri:"category" binding:"required"`
+	Page         int       `form:"page" binding:"min=0"`
+	Limit        int       `form:"limit" binding:"min=0,max=100"`
+	Status       string    `form:"status" binding:"omitempty,oneof=initialized unopened open active inactive closed determined disputed amended settled finalized"`
+	Sort         string    `form:"sort" binding:"omitempty,oneof=volume_24h close_time liquidity price spread"`
+	Order        string    `form:"order" binding:"omitempty,oneof=asc desc"`
+	MinPrice     string    `form:"min_price" binding:"max=10"`
+	MaxPrice     string    `form:"max_price" binding:"max=10"`
+	ClosesAfter  time.Time `form:"closes_after" time_format:"2006-01-02T15:04:05Z07:00"`
+	ClosesBefore time.Time `form:"closes_before" time_format:"2006-01-02T15:04:05Z07:00"`
+	MinLiquidity int64     `form:"min_liquidity" binding:"min=0"`
+	EventTicker  string    `form:"event_ticker" binding:"max=100"`
+	Cursor       string    `form:"cursor" binding:"max=512"`
+}
+
+// QuoteRequest represents the request payload for pricing an order.
+type QuoteRequest struct {
+	Side        string   `json:"side" binding:"required,oneof=yes no"`
+	Quantity    int64    `json:"quantity" binding:"required,min=1,max=1000000"`
+	Liquidity   string   `json:"liquidity" binding:"omitempty,oneof=taker maker"`
+	LimitPrice  *float64 `json:"limit_price" binding:"omitempty,gt=0,lt=100"`
+	Probability *float64 `json:"probability" binding:"omitempty,min=0,max=1"`
+}
+
+// SearchMarketsRequest represents the query parameters for searching markets.
+type SearchMarketsRequest struct {
+	Query        string    `form:"q" binding:"max=200"`
+	Category     string    `form:"category" binding:"omitempty,min=2,max=50"`
+	Status       string    `form:"status" binding:"omitempty,oneof=initialized unopened open active inactive closed determined disputed amended settled finalized"`
+	ClosesBefore time.Time `form:"closes_before" time_format:"2006-01-02T15:04:05Z07:00"`
+	MinVolume    int64     `form:"min_volume" binding:"min=0"`
+	Sort         string    `form:"sort" binding:"omitempty,oneof=relevance volume close_time"`
+	Limit        int       `form:"limit" binding:"min=0,max=100"`
+}
+
+// GetMarketDetailsRequest represents the query parameters for market details.
+// Fields and Include are comma-separated lists; a missing include embeds the order book and trades.
+type GetMarketDetailsRequest struct {
+	Fields       string  `form:"fields" binding:"max=500"`
+	Include      *string `form:"include" binding:"omitempty,max=100"`
+	Limit        int     `form:"limit" binding:"min=0,max=100"`
+	CandlePeriod string  `form:"candle_period" binding:"omitempty,oneof=1m 1h 1d"`
+}
+
+// BatchMarketsRequest represents the request body for looking up many markets at once.
+type BatchMarketsRequest struct {
+	Tickers []string `json:"tickers" binding:"required,min=1,max=100,dive,max=100"`
+	Fields  []string `json:"fields" binding:"omitempty,max=20,dive,oneof=ticker event_ticker ranged_group_ticker title category open_time close_time status yes_ask yes_bid no_ask no_bid last_price volume volume_24h liquidity strike settlement"`
+}
+
+// GetStatusHistoryRequest represents the query parameters for a market's status history.
+type GetStatusHistoryRequest struct {
+	Limit int `form:"limit" binding:"min=0,max=100"`
 }
@@ -0,0 +1,18 @@
+package request
+
+// OAuthTokenRequest represents a form-encoded OAuth token request. Client credentials may
+// be sent in the body instead of HTTP Basic authentication.
+type OAuthTokenRequest struct {
+	GrantType    string `form:"grant_type"`
+	Scope        string `form:"scope"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+}
+
+// IntrospectRequest represents a form-encoded token introspection request
+type IntrospectRequest struct {
+	Token         string `form:"token"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientID      string `form:"client_id"`
+	ClientSecret  string `form:"client_secret"`
+}
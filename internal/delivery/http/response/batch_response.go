@@ -0,0 +1,52 @@
+package response
+
+import (
+	"upwork-test/internal/application/dto"
+)
+
+// MarketBatchResponse represents the results of a batch market lookup, in request order
+type MarketBatchResponse struct {
+	Results []MarketBatchItemResponse `json:"results"`
+	Found   int                       `json:"found"`
+	Failed  int                       `json:"failed"`
+}
+
+// MarketBatchItemResponse represents the lookup of one ticker; exactly one of Market and Error is set
+type MarketBatchItemResponse struct {
+	Ticker string              `json:"ticker"`
+	Market interface{}         `json:"market,omitempty"`
+	Error  *BatchErrorResponse `json:"error,omitempty"`
+}
+
+// BatchErrorResponse describes why one ticker of a batch could not be returned
+type BatchErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// FromMarketBatchDTO converts a batch lookup DTO to API response format. When fields are
+// given, each market only carries those fields and its ticker.
+func FromMarketBatchDTO(batchDTO *dto.MarketBatchDTO, fields []string) *MarketBatchResponse {
+	results := make([]MarketBatchItemResponse, len(batchDTO.Results))
+	for i, item := range batchDTO.Results {
+		result := MarketBatchItemResponse{Ticker: item.Ticker}
+
+		if item.Market != nil {
+			result.Market = FromMarketDetailDTO(item.Market, fields)
+		}
+		if item.Error != nil {
+			result.Error = &BatchErrorResponse{
+				Code:    item.Error.Code,
+				Message: item.Error.Message,
+			}
+		}
+
+		results[i] = result
+	}
+
+	return &MarketBatchResponse{
+		Results: results,
+		Found:   batchDTO.Found,
+		Failed:  batchDTO.Failed,
+	}
+}
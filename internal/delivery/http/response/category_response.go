@@ -7,13 +7,35 @@ import (
 
 // CategoryOverviewResponse represents category overview metrics in the API response.
 type CategoryOverviewResponse struct {
-	CategoryName     string    `json:"category_name"`
-	TotalMarkets     int       `json:"total_markets"`
-	TotalVolume24h   int64     `json:"total_volume_24h"`
-	AverageLiquidity float64   `json:"average_liquidity"`
-	ActiveTraders24h int       `json:"active_traders_24h"`
-	ComputedAt       time.Time `json:"computed_at"`
-	ExpiresAt        time.Time `json:"expires_at"`
+	CategoryName     string                `json:"category_name"`
+	TotalMarkets     int                   `json:"total_markets"`
+	TotalVolume24h   int64                 `json:"total_volume_24h"`
+	AverageLiquidity float64               `json:"average_liquidity"`
+	Trades24h        int                   `json:"trades_24h"`
+	PriceChanges24h  []MarketMoverResponse `json:"price_changes_24h"`
+	Movers           *MoversResponse       `json:"movers,omitempty"`
+	Activity         *ActivityResponse     `json:"activity,omitempty"`
+	ComputedAt       time.Time             `json:"computed_at"`
+	ExpiresAt        time.Time             `json:"expires_at"`
+}
+
+// ActivityResponse represents trading activity across every open market in a category.
+// Spreads and notional amounts are in cents.
+type ActivityResponse struct {
+	Window               string    `json:"window"`
+	TotalMarkets         int       `json:"total_markets"`
+	TotalVolume24h       int64     `json:"total_volume_24h"`
+	AverageLiquidity     float64   `json:"average_liquidity"`
+	TotalOpenInterest    int64     `json:"total_open_interest"`
+	MedianSpread         float64   `json:"median_spread"`
+	TradeCount           int       `json:"trade_count"`
+	ContractsTraded      int64     `json:"contracts_traded"`
+	NotionalTraded       float64   `json:"notional_traded"`
+	MarketsWithTrades    int       `json:"markets_with_trades"`
+	MarketsWithVolume    int       `json:"markets_with_volume"`
+	MarketsTradesTracked int       `json:"markets_trades_tracked"`
+	TradeCoverage        float64   `json:"trade_coverage"`
+	ComputedAt           time.Time `json:"computed_at"`
 }
 
 // FromCategoryOverviewDTO converts a category overview DTO to API response format.
@@ -23,8 +45,35 @@ func FromCategoryOverviewDTO(overviewDTO *dto.CategoryOverviewDTO) *CategoryOver
 		TotalMarkets:     overviewDTO.TotalMarkets,
 		TotalVolume24h:   overviewDTO.TotalVolume24h,
 		AverageLiquidity: overviewDTO.AverageLiquidity,
-		ActiveTraders24h: overviewDTO.ActiveTraders24h,
+		Trades24h:        overviewDTO.Trades24h,
+		PriceChanges24h:  fromMarketMoverDTOs(overviewDTO.PriceChanges24h),
+		Movers:           FromMoversDTO(overviewDTO.Movers),
+		Activity:         fromActivityDTO(overviewDTO.Activity),
 		ComputedAt:       overviewDTO.ComputedAt,
 		ExpiresAt:        overviewDTO.ExpiresAt,
 	}
 }
+
+// fromActivityDTO converts a category activity DTO to API response format
+func fromActivityDTO(activityDTO *dto.ActivityDTO) *ActivityResponse {
+	if activityDTO == nil {
+		return nil
+	}
+
+	return &ActivityResponse{
+		Window:               activityDTO.Window,
+		TotalMarkets:         activityDTO.TotalMarkets,
+		TotalVolume24h:       activityDTO.TotalVolume24h,
+		AverageLiquidity:     activityDTO.AverageLiquidity,
+		TotalOpenInterest:    activityDTO.TotalOpenInterest,
+		MedianSpread:         displayPrice(activityDTO.MedianSpread),
+		TradeCount:           activityDTO.TradeCount,
+		ContractsTraded:      activityDTO.ContractsTraded,
+		NotionalTraded:       displayPrice(activityDTO.NotionalTraded),
+		MarketsWithTrades:    activityDTO.MarketsWithTrades,
+		MarketsWithVolume:    activityDTO.MarketsWithVolume,
+		MarketsTradesTracked: activityDTO.MarketsTradesTracked,
+		TradeCoverage:        activityDTO.TradeCoverage,
+		ComputedAt:           activityDTO.ComputedAt,
+	}
+}
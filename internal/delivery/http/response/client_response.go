@@ -0,0 +1,118 @@
+package response
+
+import (
+	"time"
+	"upwork-test/internal/application/dto"
+)
+
+// ClientResponse represents a registered API client in the API response.
+type ClientResponse struct {
+	ID        string              `json:"id"`
+	Name      string              `json:"name"`
+	Tier      string              `json:"tier"`
+	Scopes    []string            `json:"scopes"`
+	Status    string              `json:"status"`
+	Keys      []ClientKeyResponse `json:"keys"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// ClientKeyResponse represents one API key of a client, identified by its masked prefix.
+type ClientKeyResponse struct {
+	ID        string     `json:"id"`
+	Prefix    string     `json:"prefix"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// ClientListResponse represents the list of registered clients.
+type ClientListResponse struct {
+	Clients []*ClientResponse `json:"clients"`
+}
+
+// IssuedKeyResponse represents a newly issued API key, shown only in this response.
+type IssuedKeyResponse struct {
+	Client *ClientResponse `json:"client"`
+	KeyID  string          `json:"key_id"`
+	APIKey string          `json:"api_key"`
+}
+
+// AuditLogResponse represents the most recent registry changes.
+type AuditLogResponse struct {
+	Entries []AuditEntryResponse `json:"entries"`
+}
+
+// AuditEntryResponse represents one registry change.
+type AuditEntryResponse struct {
+	ID       string    `json:"id"`
+	Time     time.Time `json:"time"`
+	Actor    string    `json:"actor"`
+	Action   string    `json:"action"`
+	ClientID string    `json:"client_id"`
+	KeyID    string    `json:"key_id,omitempty"`
+	Detail   string    `json:"detail,omitempty"`
+	TraceID  string    `json:"trace_id,omitempty"`
+}
+
+// FromClientDTO converts a client DTO to API response format.
+func FromClientDTO(clientDTO *dto.ClientDTO) *ClientResponse {
+	keys := make([]ClientKeyResponse, len(clientDTO.Keys))
+	for i, key := range clientDTO.Keys {
+		keys[i] = ClientKeyResponse{
+			ID:        key.ID,
+			Prefix:    key.Prefix,
+			CreatedAt: key.CreatedAt,
+		}
+		if !key.ExpiresAt.IsZero() {
+			expiresAt := key.ExpiresAt
+			keys[i].ExpiresAt = &expiresAt
+		}
+	}
+
+	return &ClientResponse{
+		ID:        clientDTO.ID,
+		Name:      clientDTO.Name,
+		Tier:      clientDTO.Tier,
+		Scopes:    clientDTO.Scopes,
+		Status:    clientDTO.Status,
+		Keys:      keys,
+		CreatedAt: clientDTO.CreatedAt,
+		UpdatedAt: clientDTO.UpdatedAt,
+	}
+}
+
+// FromClientDTOs converts client DTOs to the client list response.
+func FromClientDTOs(clientDTOs []*dto.ClientDTO) *ClientListResponse {
+	clients := make([]*ClientResponse, len(clientDTOs))
+	for i, clientDTO := range clientDTOs {
+		clients[i] = FromClientDTO(clientDTO)
+	}
+	return &ClientListResponse{Clients: clients}
+}
+
+// FromIssuedKeyDTO converts an issued key DTO to API response format.
+func FromIssuedKeyDTO(issuedDTO *dto.IssuedKeyDTO) *IssuedKeyResponse {
+	return &IssuedKeyResponse{
+		Client: FromClientDTO(issuedDTO.Client),
+		KeyID:  issuedDTO.KeyID,
+		APIKey: issuedDTO.APIKey,
+	}
+}
+
+// FromAuditEntryDTOs converts audit entry DTOs to the audit log response.
+func FromAuditEntryDTOs(entryDTOs []*dto.AuditEntryDTO) *AuditLogResponse {
+	entries := make([]AuditEntryResponse, len(entryDTOs))
+	for i, entry := range entryDTOs {
+		entries[i] = AuditEntryResponse{
+			ID:       entry.ID,
+			Time:     entry.Time,
+			Actor:    entry.Actor,
+			Action:   entry.Action,
+			ClientID: entry.ClientID,
+			KeyID:    entry.KeyID,
+			Detail:   entry.Detail,
+			TraceID:  entry.TraceID,
+		}
+	}
+	return &AuditLogResponse{Entries: entries}
+}
@@ -0,0 +1,72 @@
+package response
+
+import (
+	"time"
+	"upwork-test/internal/application/dto"
+)
+
+// SchemaDiagnosticsResponse represents upstream schema drift in the API response.
+type SchemaDiagnosticsResponse struct {
+	DriftDetected bool                    `json:"drift_detected"`
+	Reports       []*SchemaReportResponse `json:"reports"`
+}
+
+// SchemaReportResponse represents the diagnostics collected by one process.
+type SchemaReportResponse struct {
+	Source         string                  `json:"source"`
+	StrictDecoding bool                    `json:"strict_decoding"`
+	DriftDetected  bool                    `json:"drift_detected"`
+	Endpoints      []EndpointDriftResponse `json:"endpoints"`
+	Fallbacks      map[string]int64        `json:"fallbacks"`
+	OutOfRange     map[string]int64        `json:"out_of_range"`
+	StartedAt      time.Time               `json:"started_at"`
+	LastDriftAt    *time.Time              `json:"last_drift_at,omitempty"`
+	GeneratedAt    time.Time               `json:"generated_at"`
+}
+
+// EndpointDriftResponse represents drift counters for one upstream endpoint.
+type EndpointDriftResponse struct {
+	Endpoint      string           `json:"endpoint"`
+	Responses     int64            `json:"responses"`
+	DecodeErrors  int64            `json:"decode_errors"`
+	UnknownFields map[string]int64 `json:"unknown_fields"`
+	MissingFields map[string]int64 `json:"missing_fields"`
+}
+
+// FromSchemaDiagnosticsDTO converts a schema diagnostics DTO to API response format.
+func FromSchemaDiagnosticsDTO(diagnosticsDTO *dto.SchemaDiagnosticsDTO) *SchemaDiagnosticsResponse {
+	reports := make([]*SchemaReportResponse, len(diagnosticsDTO.Reports))
+	for i, reportDTO := range diagnosticsDTO.Reports {
+		endpoints := make([]EndpointDriftResponse, len(reportDTO.Endpoints))
+		for j, endpoint := range reportDTO.Endpoints {
+			endpoints[j] = EndpointDriftResponse{
+				Endpoint:      endpoint.Endpoint,
+				Responses:     endpoint.Responses,
+				DecodeErrors:  endpoint.DecodeErrors,
+				UnknownFields: endpoint.UnknownFields,
+				MissingFields: endpoint.MissingFields,
+			}
+		}
+
+		reports[i] = &SchemaReportResponse{
+			Source:         reportDTO.Source,
+			StrictDecoding: reportDTO.StrictDecoding,
+			DriftDetected:  reportDTO.DriftDetected,
+			Endpoints:      endpoints,
+			Fallbacks:      reportDTO.Fallbacks,
+			OutOfRange:     reportDTO.OutOfRange,
+			StartedAt:      reportDTO.StartedAt,
+			GeneratedAt:    reportDTO.GeneratedAt,
+		}
+
+		if !reportDTO.LastDriftAt.IsZero() {
+			lastDriftAt := reportDTO.LastDriftAt
+			reports[i].LastDriftAt = &lastDriftAt
+		}
+	}
+
+	return &SchemaDiagnosticsResponse{
+		DriftDetected: diagnosticsDTO.DriftDetected,
+		Reports:       reports,
+	}
+}
@@ -0,0 +1,97 @@
+package response
+
+import (
+	"upwork-test/internal/application/dto"
+)
+
+// EventDistributionResponse represents the implied probability distribution across an event's outcomes
+type EventDistributionResponse struct {
+	EventTicker       string            `json:"event_ticker"`
+	Title             string            `json:"title"`
+	Category          string            `json:"category"`
+	MutuallyExclusive bool              `json:"mutually_exclusive"`
+	Outcomes          []OutcomeResponse `json:"outcomes"`
+	TotalProbability  float64           `json:"total_probability"`
+	Overround         float64           `json:"overround"`
+	AllYes            BasketResponse    `json:"all_yes"`
+	AllNo             BasketResponse    `json:"all_no"`
+	Arbitrage         bool              `json:"arbitrage"`
+}
+
+// OutcomeResponse represents one outcome's share of an event distribution
+type OutcomeResponse struct {
+	Ticker                string          `json:"ticker"`
+	Title                 string          `json:"title"`
+	Status                string          `json:"status"`
+	Strike                *StrikeResponse `json:"strike,omitempty"`
+	YesBid                float64         `json:"yes_bid"`
+	YesAsk                float64         `json:"yes_ask"`
+	NoAsk                 float64         `json:"no_ask"`
+	LastPrice             float64         `json:"last_price"`
+	ImpliedProbability    float64         `json:"implied_probability"`
+	NormalizedProbability float64         `json:"normalized_probability"`
+	PriceSource           string          `json:"price_source"`
+}
+
+// BasketResponse represents the price of buying one side of every outcome, in cents
+type BasketResponse struct {
+	Side      string  `json:"side"`
+	Contracts int64   `json:"contracts"`
+	Available bool    `json:"available"`
+	Cost      float64 `json:"cost"`
+	Fees      int64   `json:"fees"`
+	NetCost   float64 `json:"net_cost"`
+	Payout    float64 `json:"payout"`
+	Edge      float64 `json:"edge"`
+	BelowPar  bool    `json:"below_par"`
+	AbovePar  bool    `json:"above_par"`
+}
+
+// FromEventDistributionDTO converts an event distribution DTO to API response format
+func FromEventDistributionDTO(distributionDTO *dto.EventDistributionDTO) *EventDistributionResponse {
+	outcomes := make([]OutcomeResponse, len(distributionDTO.Outcomes))
+	for i, outcome := range distributionDTO.Outcomes {
+		outcomes[i] = OutcomeResponse{
+			Ticker:                outcome.Ticker,
+			Title:                 outcome.Title,
+			Status:                outcome.Status,
+			Strike:                FromStrikeDTO(outcome.Strike),
+			YesBid:                displayPrice(outcome.YesBid),
+			YesAsk:                displayPrice(outcome.YesAsk),
+			NoAsk:                 displayPrice(outcome.NoAsk),
+			LastPrice:             displayPrice(outcome.LastPrice),
+			ImpliedProbability:    outcome.ImpliedProbability,
+			NormalizedProbability: outcome.NormalizedProbability,
+			PriceSource:           outcome.PriceSource,
+		}
+	}
+
+	return &EventDistributionResponse{
+		EventTicker:       distributionDTO.EventTicker,
+		Title:             distributionDTO.Title,
+		Category:          distributionDTO.Category,
+		MutuallyExclusive: distributionDTO.MutuallyExclusive,
+		Outcomes:          outcomes,
+		TotalProbability:  distributionDTO.TotalProbability,
+		Overround:         distributionDTO.Overround,
+		AllYes:            fromBasketDTO(distributionDTO.AllYes),
+		AllNo:             fromBasketDTO(distributionDTO.AllNo),
+		Arbitrage:         distributionDTO.Arbitrage,
+	}
+}
+
+// fromBasketDTO converts a basket DTO to response format
+func fromBasketDTO(basket dto.BasketDTO) BasketResponse {
+	return BasketResponse{
+		Side:      basket.Side,
+		Contracts: basket.Contracts,
+		Available: basket.Available,
+		Cost:      displayPrice(basket.Cost),
+		Fees:      basket.Fees,
+		NetCost:   displayPrice(basket.NetCost),
+		Payout:    displayPrice(basket.Payout),
+		Edge:      displayPrice(basket.Edge),
+		BelowPar:  basket.BelowPar,
+		AbovePar:  basket.AbovePar,
+	}
+}
@@ -0,0 +1,31 @@
+package response
+
+import (
+	"encoding/json"
+)
+
+// selectFields returns the JSON object of value restricted to the given top-level fields,
+// plus any always-included ones. Without fields the value is returned unchanged.
+func selectFields(value interface{}, fields []string, always ...string) interface{} {
+	if len(fields) == 0 {
+		return value
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return value
+	}
+
+	selected := make(map[string]json.RawMessage, len(fields)+len(always))
+	for _, field := range append(always, fields...) {
+		if raw, ok := object[field]; ok {
+			selected[field] = raw
+		}
+	}
+	return selected
+}
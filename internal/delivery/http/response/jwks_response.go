@@ -0,0 +1,42 @@
+package response
+
+import "upwork-test/internal/application/dto"
+
+// JWKResponse represents a public signing key as a JSON Web Key
+type JWKResponse struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+// JWKSetResponse represents a JSON Web Key Set. It is served bare, as verifiers expect.
+type JWKSetResponse struct {
+	Keys []JWKResponse `json:"keys"`
+}
+
+// FromJWKSetDTO converts a key set DTO to API response format
+func FromJWKSetDTO(setDTO *dto.JWKSetDTO) *JWKSetResponse {
+	response := &JWKSetResponse{
+		Keys: make([]JWKResponse, len(setDTO.Keys)),
+	}
+	for i, key := range setDTO.Keys {
+		response.Keys[i] = JWKResponse{
+			KeyType:   key.KeyType,
+			Use:       "sig",
+			KeyID:     key.KeyID,
+			Algorithm: key.Algorithm,
+			N:         key.N,
+			E:         key.E,
+			Curve:     key.Curve,
+			X:         key.X,
+			Y:         key.Y,
+		}
+	}
+	return response
+}
@@ -1,6 +1,7 @@
 package response
 
 import (
+	"slices"
 	"time"
 	"upwork-test/internal/application/dto"
 )
@@ -12,8 +13,8 @@ type MarketResponse struct {
 	Category        string    `json:"category"`
 	CloseDate       time.Time `json:"close_date"`
 	SettlementRules string    `json:"settlement_rules,omitempty"`
-	YesPrice        int       `json:"yes_price"`
-	NoPrice         int       `json:"no_price"`
+	YesPrice        float64   `json:"yes_price"`
+	NoPrice         float64   `json:"no_price"`
 	Status          string    `json:"status"`
 	Volume24h       int64     `json:"volume_24h"`
 	LastUpdated     time.Time `json:"last_updated"`
@@ -27,6 +28,8 @@ type PaginationResponse struct {
 	TotalPages int    `json:"total_pages"`
 	NextURL    string `json:"next_url,omitempty"`
 	PrevURL    string `json:"prev_url,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 // MarketListResponse represents the response for market listing.
@@ -43,8 +46,8 @@ func FromMarketDTO(marketDTO *dto.MarketDTO) *MarketResponse {
 		Category:        marketDTO.Category,
 		CloseDate:       marketDTO.CloseDate,
 		SettlementRules: marketDTO.SettlementRules,
-		YesPrice:        marketDTO.YesPrice,
-		NoPrice:         marketDTO.NoPrice,
+		YesPrice:        displayPrice(marketDTO.YesPrice),
+		NoPrice:         displayPrice(marketDTO.NoPrice),
 		Status:          marketDTO.Status,
 		Volume24h:       marketDTO.Volume24h,
 		LastUpdated:     marketDTO.LastUpdated,
@@ -65,6 +68,8 @@ func FromMarketListDTO(listDTO *dto.MarketListDTO) *MarketListResponse {
 		TotalPages: listDTO.Pagination.TotalPages,
 		NextURL:    listDTO.Pagination.NextURL,
 		PrevURL:    listDTO.Pagination.PrevURL,
+		NextCursor: listDTO.Pagination.NextCursor,
+		PrevCursor: listDTO.Pagination.PrevCursor,
 	}
 
 	return &MarketListResponse{
@@ -75,24 +80,30 @@ func FromMarketListDTO(listDTO *dto.MarketListDTO) *MarketListResponse {
 
 // MarketDetailResponse represents comprehensive market information with aggregated data
 type MarketDetailResponse struct {
-	Ticker       string             `json:"ticker"`
-	Title        string             `json:"title"`
-	Category     string             `json:"category"`
-	OpenTime     time.Time          `json:"open_time"`
-	CloseTime    time.Time          `json:"close_time"`
-	Status       string             `json:"status"`
-	YesAsk       int64              `json:"yes_ask"`
-	YesBid       int64              `json:"yes_bid"`
-	NoAsk        int64              `json:"no_ask"`
-	NoBid        int64              `json:"no_bid"`
-	LastPrice    int64              `json:"last_price"`
-	Volume       int64              `json:"volume"`
-	Volume24h    int64              `json:"volume_24h"`
-	Liquidity    int64              `json:"liquidity"`
-	OrderBook    *OrderBookResponse `json:"order_book,omitempty"`
-	RecentTrades []TradeResponse    `json:"recent_trades,omitempty"`
-	IsPartial    bool               `json:"is_partial"`
-	Errors       []string           `json:"errors,omitempty"`
+	Ticker            string              `json:"ticker"`
+	EventTicker       string              `json:"event_ticker,omitempty"`
+	RangedGroupTicker string              `json:"ranged_group_ticker,omitempty"`
+	Title             string              `json:"title"`
+	Category          string              `json:"category"`
+	OpenTime          time.Time           `json:"open_time"`
+	CloseTime         time.Time           `json:"close_time"`
+	Status            string              `json:"status"`
+	YesAsk            float64             `json:"yes_ask"`
+	YesBid            float64             `json:"yes_bid"`
+	NoAsk             float64             `json:"no_ask"`
+	NoBid             float64             `json:"no_bid"`
+	LastPrice         float64             `json:"last_price"`
+	Volume            int64               `json:"volume"`
+	Volume24h         int64               `json:"volume_24h"`
+	Liquidity         int64               `json:"liquidity"`
+	Strike            *StrikeResponse     `json:"strike,omitempty"`
+	OrderBook         *OrderBookResponse  `json:"order_book,omitempty"`
+	RecentTrades      []TradeResponse     `json:"recent_trades,omitempty"`
+	Candles           []CandleResponse    `json:"candles,omitempty"`
+	Event             *EventRefResponse   `json:"event,omitempty"`
+	Settlement        *SettlementResponse `json:"settlement,omitempty"`
+	IsPartial         bool                `json:"is_partial"`
+	Errors            []string            `json:"errors,omitempty"`
 }
 
 // OrderBookResponse represents an order book snapshot
@@ -105,38 +116,163 @@ type OrderBookResponse struct {
 
 // OrderLevelResponse represents a price level in the order book
 type OrderLevelResponse struct {
-	Price    int64 `json:"price"`
-	Quantity int   `json:"quantity"`
+	Price    float64 `json:"price"`
+	Quantity int     `json:"quantity"`
 }
 
 // TradeResponse represents a historical trade
 type TradeResponse struct {
 	TradeID   string    `json:"trade_id"`
-	Price     int64     `json:"price"`
+	Price     float64   `json:"price"`
 	Quantity  int       `json:"quantity"`
 	Side      string    `json:"side"`
 	Timestamp time.Time `json:"timestamp"`
 }
 
-// FromMarketDetailDTO converts a market detail DTO to API response format
-func FromMarketDetailDTO(detailDTO *dto.MarketDetailDTO) *MarketDetailResponse {
+// CandleResponse represents one candlestick period, in cents. Traded prices are null when nothing traded.
+type CandleResponse struct {
+	PeriodEnd    time.Time `json:"period_end"`
+	Open         *float64  `json:"open"`
+	High         *float64  `json:"high"`
+	Low          *float64  `json:"low"`
+	Close        *float64  `json:"close"`
+	YesBidClose  float64   `json:"yes_bid_close"`
+	YesAskClose  float64   `json:"yes_ask_close"`
+	Volume       int64     `json:"volume"`
+	OpenInterest int64     `json:"open_interest"`
+}
+
+// EventRefResponse summarizes the event a market belongs to
+type EventRefResponse struct {
+	EventTicker       string `json:"event_ticker"`
+	SeriesTicker      string `json:"series_ticker"`
+	Title             string `json:"title"`
+	SubTitle          string `json:"sub_title,omitempty"`
+	Category          string `json:"category"`
+	MutuallyExclusive bool   `json:"mutually_exclusive"`
+	MarketCount       int    `json:"market_count"`
+}
+
+// MarketDetailFields are the market's own fields that can be selected with fields=
+var MarketDetailFields = []string{
+	"ticker", "event_ticker", "ranged_group_ticker", "title", "category", "open_time", "close_time", "status",
+	"yes_ask", "yes_bid", "no_ask", "no_bid", "last_price", "volume", "volume_24h", "liquidity", "strike", "settlement",
+}
+
+// marketDetailAlwaysFields are kept whatever fields are selected: the ticker identifies the
+// market, embedded resources are chosen with include=, and partial results must stay visible
+var marketDetailAlwaysFields = []string{"ticker", "order_book", "recent_trades", "candles", "event", "is_partial", "errors"}
+
+// IsMarketDetailField checks if a field can be selected on market details
+func IsMarketDetailField(field string) bool {
+	return slices.Contains(MarketDetailFields, field)
+}
+
+// StrikeResponse represents the threshold or range a scalar/bracket market resolves against
+type StrikeResponse struct {
+	Type       string   `json:"type"`
+	Floor      *float64 `json:"floor,omitempty"`
+	Cap        *float64 `json:"cap,omitempty"`
+	Functional string   `json:"functional,omitempty"`
+	Label      string   `json:"label"`
+}
+
+// FromStrikeDTO converts a strike DTO to API response format
+func FromStrikeDTO(strikeDTO *dto.StrikeDTO) *StrikeResponse {
+	if strikeDTO == nil {
+		return nil
+	}
+
+	return &StrikeResponse{
+		Type:       strikeDTO.Type,
+		Floor:      strikeDTO.Floor,
+		Cap:        strikeDTO.Cap,
+		Functional: strikeDTO.Functional,
+		Label:      strikeDTO.Label,
+	}
+}
+
+// SettlementResponse represents the resolution of a market
+type SettlementResponse struct {
+	Ticker          string    `json:"ticker"`
+	Result          string    `json:"result"`
+	SettlementValue float64   `json:"settlement_value"`
+	ExpirationValue string    `json:"expiration_value,omitempty"`
+	SettledAt       time.Time `json:"settled_at"`
+}
+
+// FromSettlementDTO converts a settlement DTO to API response format
+func FromSettlementDTO(settlementDTO *dto.SettlementDTO) *SettlementResponse {
+	return &SettlementResponse{
+		Ticker:          settlementDTO.Ticker,
+		Result:          settlementDTO.Result,
+		SettlementValue: displayPrice(settlementDTO.SettlementValue),
+		ExpirationValue: settlementDTO.ExpirationValue,
+		SettledAt:       settlementDTO.SettledAt,
+	}
+}
+
+// StatusHistoryResponse represents the observed status transitions of a market, newest first
+type StatusHistoryResponse struct {
+	Ticker      string                     `json:"ticker"`
+	Transitions []StatusTransitionResponse `json:"transitions"`
+}
+
+// StatusTransitionResponse represents one observed change in a market's status
+type StatusTransitionResponse struct {
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	Expected   bool      `json:"expected"`
+	ObservedAt time.Time `json:"observed_at"`
+}
+
+// FromStatusHistoryDTO converts a status history DTO to API response format
+func FromStatusHistoryDTO(historyDTO *dto.StatusHistoryDTO) *StatusHistoryResponse {
+	transitions := make([]StatusTransitionResponse, 0, len(historyDTO.Transitions))
+	for _, transition := range historyDTO.Transitions {
+		transitions = append(transitions, StatusTransitionResponse{
+			From:       transition.From,
+			To:         transition.To,
+			Expected:   transition.Expected,
+			ObservedAt: transition.ObservedAt,
+		})
+	}
+
+	return &StatusHistoryResponse{
+		Ticker:      historyDTO.Ticker,
+		Transitions: transitions,
+	}
+}
+
+// FromMarketDetailDTO converts a market detail DTO to API response format. When fields are
+// given, only those market fields are returned, along with the ticker, embedded resources
+// and partial-result flags.
+func FromMarketDetailDTO(detailDTO *dto.MarketDetailDTO, fields []string) interface{} {
+	return selectFields(fromMarketDetailDTO(detailDTO), fields, marketDetailAlwaysFields...)
+}
+
+// fromMarketDetailDTO converts a market detail DTO to the full API response
+func fromMarketDetailDTO(detailDTO *dto.MarketDetailDTO) *MarketDetailResponse {
 	response := &MarketDetailResponse{
-		Ticker:    detailDTO.Ticker,
-		Title:     detailDTO.Title,
-		Category:  detailDTO.Category,
-		OpenTime:  detailDTO.OpenTime,
-		CloseTime: detailDTO.CloseTime,
-		Status:    detailDTO.Status,
-		YesAsk:    detailDTO.YesAsk,
-		YesBid:    detailDTO.YesBid,
-		NoAsk:     detailDTO.NoAsk,
-		NoBid:     detailDTO.NoBid,
-		LastPrice: detailDTO.LastPrice,
-		Volume:    detailDTO.Volume,
-		Volume24h: detailDTO.Volume24h,
-		Liquidity: detailDTO.Liquidity,
-		IsPartial: detailDTO.IsPartial,
-		Errors:    detailDTO.Errors,
+		Ticker:            detailDTO.Ticker,
+		EventTicker:       detailDTO.EventTicker,
+		RangedGroupTicker: detailDTO.RangedGroupTicker,
+		Title:             detailDTO.Title,
+		Category:          detailDTO.Category,
+		OpenTime:          detailDTO.OpenTime,
+		CloseTime:         detailDTO.CloseTime,
+		Status:            detailDTO.Status,
+		YesAsk:            displayPrice(detailDTO.YesAsk),
+		YesBid:            displayPrice(detailDTO.YesBid),
+		NoAsk:             displayPrice(detailDTO.NoAsk),
+		NoBid:             displayPrice(detailDTO.NoBid),
+		LastPrice:         displayPrice(detailDTO.LastPrice),
+		Volume:            detailDTO.Volume,
+		Volume24h:         detailDTO.Volume24h,
+		Liquidity:         detailDTO.Liquidity,
+		Strike:            FromStrikeDTO(detailDTO.Strike),
+		IsPartial:         detailDTO.IsPartial,
+		Errors:            detailDTO.Errors,
 	}
 
 	// Convert order book if present
@@ -154,6 +290,26 @@ func FromMarketDetailDTO(detailDTO *dto.MarketDetailDTO) *MarketDetailResponse {
 		response.RecentTrades = convertTrades(detailDTO.RecentTrades)
 	}
 
+	if len(detailDTO.Candles) > 0 {
+		response.Candles = convertCandles(detailDTO.Candles)
+	}
+
+	if detailDTO.Event != nil {
+		response.Event = &EventRefResponse{
+			EventTicker:       detailDTO.Event.EventTicker,
+			SeriesTicker:      detailDTO.Event.SeriesTicker,
+			Title:             detailDTO.Event.Title,
+			SubTitle:          detailDTO.Event.SubTitle,
+			Category:          detailDTO.Event.Category,
+			MutuallyExclusive: detailDTO.Event.MutuallyExclusive,
+			MarketCount:       detailDTO.Event.MarketCount,
+		}
+	}
+
+	if detailDTO.Settlement != nil {
+		response.Settlement = FromSettlementDTO(detailDTO.Settlement)
+	}
+
 	return response
 }
 
@@ -162,20 +318,39 @@ func convertOrderLevels(levels []dto.OrderLevelDTO) []OrderLevelResponse {
 	result := make([]OrderLevelResponse, len(levels))
 	for i, level := range levels {
 		result[i] = OrderLevelResponse{
-			Price:    level.Price,
+			Price:    displayPrice(level.Price),
 			Quantity: level.Quantity,
 		}
 	}
 	return result
 }
 
+// convertCandles converts DTO candlesticks to response format
+func convertCandles(candles []dto.CandleDTO) []CandleResponse {
+	result := make([]CandleResponse, len(candles))
+	for i, candle := range candles {
+		result[i] = CandleResponse{
+			PeriodEnd:    candle.PeriodEnd,
+			Open:         displayPriceRef(candle.Open),
+			High:         displayPriceRef(candle.High),
+			Low:          displayPriceRef(candle.Low),
+			Close:        displayPriceRef(candle.Close),
+			YesBidClose:  displayPrice(candle.YesBidClose),
+			YesAskClose:  displayPrice(candle.YesAskClose),
+			Volume:       candle.Volume,
+			OpenInterest: candle.OpenInterest,
+		}
+	}
+	return result
+}
+
 // convertTrades converts DTO trades to response format
 func convertTrades(trades []dto.TradeDTO) []TradeResponse {
 	result := make([]TradeResponse, len(trades))
 	for i, trade := range trades {
 		result[i] = TradeResponse{
 			TradeID:   trade.TradeID,
-			Price:     trade.Price,
+			Price:     displayPrice(trade.Price),
 			Quantity:  trade.Quantity,
 			Side:      trade.Side,
 			Timestamp: trade.Timestamp,
@@ -183,3 +358,53 @@ func convertTrades(trades []dto.TradeDTO) []TradeResponse {
 	}
 	return result
 }
+
+// RangedGroupResponse represents the bracket markets of a range group ordered by strike
+type RangedGroupResponse struct {
+	GroupTicker      string            `json:"group_ticker"`
+	EventTicker      string            `json:"event_ticker"`
+	Brackets         []BracketResponse `json:"brackets"`
+	TotalProbability float64           `json:"total_probability"`
+}
+
+// BracketResponse represents one bracket market with its share of the implied distribution
+type BracketResponse struct {
+	Ticker                string          `json:"ticker"`
+	Title                 string          `json:"title"`
+	Status                string          `json:"status"`
+	Strike                *StrikeResponse `json:"strike,omitempty"`
+	YesBid                float64         `json:"yes_bid"`
+	YesAsk                float64         `json:"yes_ask"`
+	LastPrice             float64         `json:"last_price"`
+	Volume24h             int64           `json:"volume_24h"`
+	ImpliedProbability    float64         `json:"implied_probability"`
+	NormalizedProbability float64         `json:"normalized_probability"`
+	PriceSource           string          `json:"price_source"`
+}
+
+// FromRangedGroupDTO converts a ranged group DTO to API response format
+func FromRangedGroupDTO(groupDTO *dto.RangedGroupDTO) *RangedGroupResponse {
+	brackets := make([]BracketResponse, len(groupDTO.Brackets))
+	for i, bracket := range groupDTO.Brackets {
+		brackets[i] = BracketResponse{
+			Ticker:                bracket.Ticker,
+			Title:                 bracket.Title,
+			Status:                bracket.Status,
+			Strike:                FromStrikeDTO(bracket.Strike),
+			YesBid:                displayPrice(bracket.YesBid),
+			YesAsk:                displayPrice(bracket.YesAsk),
+			LastPrice:             displayPrice(bracket.LastPrice),
+			Volume24h:             bracket.Volume24h,
+			ImpliedProbability:    bracket.ImpliedProbability,
+			NormalizedProbability: bracket.NormalizedProbability,
+			PriceSource:           bracket.PriceSource,
+		}
+	}
+
+	return &RangedGroupResponse{
+		GroupTicker:      groupDTO.GroupTicker,
+		EventTicker:      groupDTO.EventTicker,
+		Brackets:         brackets,
+		TotalProbability: groupDTO.TotalProbability,
+	}
+}
@@ -0,0 +1,89 @@
+package response
+
+import (
+	"math"
+	"time"
+	"upwork-test/internal/application/dto"
+)
+
+// MarketMoverResponse represents a market's price movement and activity over a window, in cents
+type MarketMoverResponse struct {
+	Ticker             string   `json:"ticker"`
+	Title              string   `json:"title"`
+	Category           string   `json:"category"`
+	LastPrice          float64  `json:"last_price"`
+	PreviousPrice      *float64 `json:"previous_price,omitempty"`
+	PriceChange        *float64 `json:"price_change,omitempty"`
+	PriceChangePercent *float64 `json:"price_change_percent,omitempty"`
+	Volume24h          int64    `json:"volume_24h"`
+	Spread             *float64 `json:"spread,omitempty"`
+}
+
+// MoversResponse represents the ranked market movers for a window in the API response
+type MoversResponse struct {
+	Window         string                `json:"window"`
+	Category       string                `json:"category,omitempty"`
+	MarketsScanned int                   `json:"markets_scanned"`
+	BaselineAt     time.Time             `json:"baseline_at"`
+	Gainers        []MarketMoverResponse `json:"gainers"`
+	Losers         []MarketMoverResponse `json:"losers"`
+	HighestVolume  []MarketMoverResponse `json:"highest_volume"`
+	WidestSpreads  []MarketMoverResponse `json:"widest_spreads"`
+	ComputedAt     time.Time             `json:"computed_at"`
+}
+
+// FromMoversDTO converts a movers DTO to API response format
+func FromMoversDTO(moversDTO *dto.MoversDTO) *MoversResponse {
+	if moversDTO == nil {
+		return nil
+	}
+
+	return &MoversResponse{
+		Window:         moversDTO.Window,
+		Category:       moversDTO.Category,
+		MarketsScanned: moversDTO.MarketsScanned,
+		BaselineAt:     moversDTO.BaselineAt,
+		Gainers:        fromMarketMoverDTOs(moversDTO.Gainers),
+		Losers:         fromMarketMoverDTOs(moversDTO.Losers),
+		HighestVolume:  fromMarketMoverDTOs(moversDTO.HighestVolume),
+		WidestSpreads:  fromMarketMoverDTOs(moversDTO.WidestSpreads),
+		ComputedAt:     moversDTO.ComputedAt,
+	}
+}
+
+// fromMarketMoverDTOs converts market mover DTOs to API response format
+func fromMarketMoverDTOs(movers []dto.MarketMoverDTO) []MarketMoverResponse {
+	result := make([]MarketMoverResponse, len(movers))
+	for i, mover := range movers {
+		result[i] = MarketMoverResponse{
+			Ticker:             mover.Ticker,
+			Title:              mover.Title,
+			Category:           mover.Category,
+			LastPrice:          displayPrice(mover.LastPrice),
+			PreviousPrice:      displayPriceRef(mover.PreviousPrice),
+			PriceChange:        displayPriceRef(mover.PriceChange),
+			PriceChangePercent: roundPercent(mover.PriceChangePercent),
+			Volume24h:          mover.Volume24h,
+			Spread:             displayPriceRef(mover.Spread),
+		}
+	}
+	return result
+}
+
+// displayPriceRef rounds an optional amount in cents to the configured display precision
+func displayPriceRef(cents *float64) *float64 {
+	if cents == nil {
+		return nil
+	}
+	rounded := displayPrice(*cents)
+	return &rounded
+}
+
+// roundPercent rounds an optional percentage to two decimal places
+func roundPercent(percent *float64) *float64 {
+	if percent == nil {
+		return nil
+	}
+	rounded := math.Round(*percent*100) / 100
+	return &rounded
+}
@@ -0,0 +1,80 @@
+package response
+
+import (
+	"strings"
+	"time"
+	"upwork-test/internal/application/dto"
+)
+
+// OAuth error codes (RFC 6749 section 5.2, RFC 6750 section 3.1)
+const (
+	OAuthErrorInvalidRequest       = "invalid_request"
+	OAuthErrorInvalidClient        = "invalid_client"
+	OAuthErrorUnsupportedGrantType = "unsupported_grant_type"
+	OAuthErrorInvalidScope         = "invalid_scope"
+	OAuthErrorInsufficientScope    = "insufficient_scope"
+	OAuthErrorServerError          = "server_error"
+)
+
+// OAuthTokenResponse represents a successful OAuth token response (RFC 6749 section 5.1)
+type OAuthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+// OAuthErrorResponse represents an OAuth error response. OAuth clients expect this body
+// rather than the API's error envelope.
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// IntrospectionResponse represents a token introspection response (RFC 7662 section 2.2)
+type IntrospectionResponse struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Sub       string `json:"sub,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	Exp       int64  `json:"exp,omitempty"`
+	Iat       int64  `json:"iat,omitempty"`
+	Jti       string `json:"jti,omitempty"`
+}
+
+// NewOAuthErrorResponse creates a new OAuth error response
+func NewOAuthErrorResponse(code string, description string) *OAuthErrorResponse {
+	return &OAuthErrorResponse{
+		Error:            code,
+		ErrorDescription: description,
+	}
+}
+
+// FromAccessTokenDTO converts an access token DTO to OAuth response format
+func FromAccessTokenDTO(tokenDTO *dto.AccessTokenDTO) *OAuthTokenResponse {
+	return &OAuthTokenResponse{
+		AccessToken: tokenDTO.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(time.Until(tokenDTO.ExpiresAt).Round(time.Second) / time.Second),
+		Scope:       strings.Join(tokenDTO.Scopes, " "),
+	}
+}
+
+// FromIntrospectionDTO converts an introspection DTO to RFC 7662 response format
+func FromIntrospectionDTO(introspection *dto.IntrospectionDTO) *IntrospectionResponse {
+	if !introspection.Active {
+		return &IntrospectionResponse{Active: false}
+	}
+
+	return &IntrospectionResponse{
+		Active:    true,
+		Scope:     strings.Join(introspection.Scopes, " "),
+		ClientID:  introspection.ClientID,
+		Sub:       introspection.ClientID,
+		TokenType: "Bearer",
+		Exp:       introspection.ExpiresAt.Unix(),
+		Iat:       introspection.IssuedAt.Unix(),
+		Jti:       introspection.TokenID,
+	}
+}
@@ -0,0 +1,32 @@
+package response
+
+import (
+	"math"
+)
+
+const (
+	// maxPriceDecimals is the sub-cent precision prices are stored with (hundredths of a cent)
+	maxPriceDecimals = 2
+)
+
+// priceDecimals is the number of decimal places of a cent shown in responses.
+// It is configured once at startup, before the server handles requests.
+var priceDecimals = maxPriceDecimals
+
+// SetPriceDecimals sets how many decimal places of a cent prices are rounded to in responses.
+// 0 shows whole cents; values are clamped to the stored precision.
+func SetPriceDecimals(decimals int) {
+	if decimals < 0 {
+		decimals = 0
+	}
+	if decimals > maxPriceDecimals {
+		decimals = maxPriceDecimals
+	}
+	priceDecimals = decimals
+}
+
+// displayPrice rounds an amount in cents to the configured display precision
+func displayPrice(cents float64) float64 {
+	scale := math.Pow10(priceDecimals)
+	return math.Round(cents*scale) / scale
+}
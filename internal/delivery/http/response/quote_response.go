@@ -0,0 +1,65 @@
+package response
+
+import (
+	"time"
+
+	"upwork-test/internal/application/dto"
+)
+
+// QuoteResponse represents the cost and payoff of an order, in cents
+type QuoteResponse struct {
+	Ticker               string         `json:"ticker"`
+	Side                 string         `json:"side"`
+	Liquidity            string         `json:"liquidity"`
+	Requested            int64          `json:"requested"`
+	Filled               int64          `json:"filled"`
+	FullyFilled          bool           `json:"fully_filled"`
+	Fills                []FillResponse `json:"fills"`
+	AveragePrice         float64        `json:"average_price"`
+	GrossCost            float64        `json:"gross_cost"`
+	Fees                 int64          `json:"fees"`
+	TotalCost            float64        `json:"total_cost"`
+	MaxPayout            float64        `json:"max_payout"`
+	MaxProfit            float64        `json:"max_profit"`
+	BreakEvenProbability float64        `json:"break_even_probability"`
+	ExpectedValue        *float64       `json:"expected_value,omitempty"`
+	OrderBookTimestamp   *time.Time     `json:"order_book_timestamp,omitempty"`
+}
+
+// FillResponse represents the part of an order executed at one price level
+type FillResponse struct {
+	Price    float64 `json:"price"`
+	Quantity int64   `json:"quantity"`
+	Fee      int64   `json:"fee"`
+}
+
+// FromQuoteDTO converts a quote DTO to API response format
+func FromQuoteDTO(quoteDTO *dto.QuoteDTO) *QuoteResponse {
+	fills := make([]FillResponse, len(quoteDTO.Fills))
+	for i, fill := range quoteDTO.Fills {
+		fills[i] = FillResponse{
+			Price:    displayPrice(fill.Price),
+			Quantity: fill.Quantity,
+			Fee:      fill.Fee,
+		}
+	}
+
+	return &QuoteResponse{
+		Ticker:               quoteDTO.Ticker,
+		Side:                 quoteDTO.Side,
+		Liquidity:            quoteDTO.Liquidity,
+		Requested:            quoteDTO.Requested,
+		Filled:               quoteDTO.Filled,
+		FullyFilled:          quoteDTO.FullyFilled,
+		Fills:                fills,
+		AveragePrice:         displayPrice(quoteDTO.AveragePrice),
+		GrossCost:            displayPrice(quoteDTO.GrossCost),
+		Fees:                 quoteDTO.Fees,
+		TotalCost:            displayPrice(quoteDTO.TotalCost),
+		MaxPayout:            displayPrice(quoteDTO.MaxPayout),
+		MaxProfit:            displayPrice(quoteDTO.MaxProfit),
+		BreakEvenProbability: quoteDTO.BreakEvenProbability,
+		ExpectedValue:        quoteDTO.ExpectedValue,
+		OrderBookTimestamp:   quoteDTO.OrderBookTimestamp,
+	}
+}
@@ -0,0 +1,63 @@
+package response
+
+import (
+	"math"
+	"time"
+	"upwork-test/internal/application/dto"
+)
+
+// SearchResponse represents ranked market search results in the API response
+type SearchResponse struct {
+	Query        string              `json:"query"`
+	Results      []SearchHitResponse `json:"results"`
+	Total        int                 `json:"total"`
+	IndexVersion int64               `json:"index_version"`
+	IndexSize    int                 `json:"index_size"`
+}
+
+// SearchHitResponse represents a market matching a search
+type SearchHitResponse struct {
+	Ticker      string    `json:"ticker"`
+	Title       string    `json:"title"`
+	Subtitle    string    `json:"subtitle,omitempty"`
+	EventTicker string    `json:"event_ticker,omitempty"`
+	EventTitle  string    `json:"event_title,omitempty"`
+	SeriesTitle string    `json:"series_title,omitempty"`
+	Category    string    `json:"category"`
+	Status      string    `json:"status"`
+	CloseTime   time.Time `json:"close_time"`
+	Volume24h   int64     `json:"volume_24h"`
+	Liquidity   int64     `json:"liquidity"`
+	LastPrice   float64   `json:"last_price"`
+	Score       float64   `json:"score"`
+}
+
+// FromSearchResultsDTO converts search results DTO to API response format
+func FromSearchResultsDTO(resultsDTO *dto.SearchResultsDTO) *SearchResponse {
+	hits := make([]SearchHitResponse, len(resultsDTO.Results))
+	for i, hit := range resultsDTO.Results {
+		hits[i] = SearchHitResponse{
+			Ticker:      hit.Ticker,
+			Title:       hit.Title,
+			Subtitle:    hit.Subtitle,
+			EventTicker: hit.EventTicker,
+			EventTitle:  hit.EventTitle,
+			SeriesTitle: hit.SeriesTitle,
+			Category:    hit.Category,
+			Status:      hit.Status,
+			CloseTime:   hit.CloseTime,
+			Volume24h:   hit.Volume24h,
+			Liquidity:   hit.Liquidity,
+			LastPrice:   displayPrice(hit.LastPrice),
+			Score:       math.Round(hit.Score*1000) / 1000,
+		}
+	}
+
+	return &SearchResponse{
+		Query:        resultsDTO.Query,
+		Results:      hits,
+		Total:        resultsDTO.Total,
+		IndexVersion: resultsDTO.IndexVersion,
+		IndexSize:    resultsDTO.IndexSize,
+	}
+}
@@ -1,12 +1,17 @@
 package response
 
-import "time"
+import (
+	"time"
+	"upwork-test/internal/application/dto"
+)
 
 // TokenResponse represents the JWT token response
 type TokenResponse struct {
-	Token     string    `json:"token"`
-	ExpiresAt time.Time `json:"expires_at"`
-	TokenType string    `json:"token_type"`
+	Token            string     `json:"token"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	TokenType        string     `json:"token_type"`
+	RefreshToken     string     `json:"refresh_token,omitempty"`
+	RefreshExpiresAt *time.Time `json:"refresh_expires_at,omitempty"`
 }
 
 // NewTokenResponse creates a new token response
@@ -17,3 +22,14 @@ func NewTokenResponse(token string, expiresAt time.Time) *TokenResponse {
 		TokenType: "Bearer",
 	}
 }
+
+// FromTokenResponseDTO converts a token DTO, with its refresh token if any, to API response format
+func FromTokenResponseDTO(tokenDTO *dto.TokenResponse) *TokenResponse {
+	response := NewTokenResponse(tokenDTO.Token, tokenDTO.ExpiresAt)
+	if tokenDTO.RefreshToken != "" {
+		refreshExpiresAt := tokenDTO.RefreshExpiresAt
+		response.RefreshToken = tokenDTO.RefreshToken
+		response.RefreshExpiresAt = &refreshExpiresAt
+	}
+	return response
+}
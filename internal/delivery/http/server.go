@@ -7,7 +7,10 @@ import (
 	"upwork-test/internal/application/usecase"
 	"upwork-test/internal/delivery/http/handler"
 	"upwork-test/internal/delivery/http/middleware"
+	"upwork-test/internal/delivery/http/response"
+	authrepository "upwork-test/internal/domain/auth/repository"
 	"upwork-test/internal/domain/auth/service"
+	authvalueobject "upwork-test/internal/domain/auth/valueobject"
 	ratelimitservice "upwork-test/internal/domain/ratelimit/service"
 	"upwork-test/internal/infrastructure/config"
 
@@ -17,15 +20,34 @@ import (
 
 // Server represents the HTTP server
 type Server struct {
-	config                     *config.Config
-	router                     *gin.Engine
-	httpServer                 *http.Server
-	redisClient                *redis.Client
-	tokenService               *service.TokenService
-	rateLimiter                *ratelimitservice.RateLimiter
-	listMarketsUseCase         *usecase.ListMarkets
-	getMarketDetailsUseCase    *usecase.GetMarketDetails
-	getCategoryOverviewUseCase *usecase.GetCategoryOverview
+	config                             *config.Config
+	router                             *gin.Engine
+	httpServer                         *http.Server
+	redisClient                        *redis.Client
+	tokenService                       *service.TokenService
+	revocationChecker                  *service.RevocationChecker
+	clientRepo                         authrepository.ClientRepository
+	rateLimiter                        *ratelimitservice.RateLimiter
+	rateLimitCosts                     middleware.RouteCosts
+	listMarketsUseCase                 *usecase.ListMarkets
+	getMarketDetailsUseCase            *usecase.GetMarketDetails
+	getCategoryOverviewUseCase         *usecase.GetCategoryOverview
+	getSchemaDiagnosticsUseCase        *usecase.GetSchemaDiagnostics
+	getMarketSettlementUseCase         *usecase.GetMarketSettlement
+	getStatusHistoryUseCase            *usecase.GetStatusHistory
+	getRangedGroupUseCase              *usecase.GetRangedGroup
+	getEventDistributionUseCase        *usecase.GetEventDistribution
+	getMarketQuoteUseCase              *usecase.GetMarketQuote
+	getMoversUseCase                   *usecase.GetMovers
+	searchMarketsUseCase               *usecase.SearchMarkets
+	getMarketsBatchUseCase             *usecase.GetMarketsBatch
+	authenticateUseCase                *usecase.Authenticate
+	refreshAccessTokenUseCase          *usecase.RefreshAccessToken
+	revokeTokenUseCase                 *usecase.RevokeToken
+	manageClientsUseCase               *usecase.ManageClients
+	getJWKSUseCase                     *usecase.GetJWKS
+	issueClientCredentialsTokenUseCase *usecase.IssueClientCredentialsToken
+	introspectTokenUseCase             *usecase.IntrospectToken
 }
 
 // NewServer creates a new HTTP server
@@ -33,23 +55,62 @@ func NewServer(
 	cfg *config.Config,
 	redisClient *redis.Client,
 	tokenService *service.TokenService,
+	revocationChecker *service.RevocationChecker,
+	clientRepo authrepository.ClientRepository,
 	rateLimiter *ratelimitservice.RateLimiter,
+	rateLimitCosts middleware.RouteCosts,
 	listMarketsUseCase *usecase.ListMarkets,
 	getMarketDetailsUseCase *usecase.GetMarketDetails,
 	getCategoryOverviewUseCase *usecase.GetCategoryOverview,
+	getSchemaDiagnosticsUseCase *usecase.GetSchemaDiagnostics,
+	getMarketSettlementUseCase *usecase.GetMarketSettlement,
+	getStatusHistoryUseCase *usecase.GetStatusHistory,
+	getRangedGroupUseCase *usecase.GetRangedGroup,
+	getEventDistributionUseCase *usecase.GetEventDistribution,
+	getMarketQuoteUseCase *usecase.GetMarketQuote,
+	getMoversUseCase *usecase.GetMovers,
+	searchMarketsUseCase *usecase.SearchMarkets,
+	getMarketsBatchUseCase *usecase.GetMarketsBatch,
+	authenticateUseCase *usecase.Authenticate,
+	refreshAccessTokenUseCase *usecase.RefreshAccessToken,
+	revokeTokenUseCase *usecase.RevokeToken,
+	manageClientsUseCase *usecase.ManageClients,
+	getJWKSUseCase *usecase.GetJWKS,
+	issueClientCredentialsTokenUseCase *usecase.IssueClientCredentialsToken,
+	introspectTokenUseCase *usecase.IntrospectToken,
 ) *Server {
 	gin.SetMode(cfg.Server.GinMode)
+	response.SetPriceDecimals(cfg.Server.PriceDecimals)
 	router := gin.New()
 
 	srv := &Server{
-		config:                     cfg,
-		router:                     router,
-		redisClient:                redisClient,
-		tokenService:               tokenService,
-		rateLimiter:                rateLimiter,
-		listMarketsUseCase:         listMarketsUseCase,
-		getMarketDetailsUseCase:    getMarketDetailsUseCase,
-		getCategoryOverviewUseCase: getCategoryOverviewUseCase,
+		config:                             cfg,
+		router:                             router,
+		redisClient:                        redisClient,
+		tokenService:                       tokenService,
+		revocationChecker:                  revocationChecker,
+		clientRepo:                         clientRepo,
+		rateLimiter:                        rateLimiter,
+		rateLimitCosts:                     rateLimitCosts,
+		listMarketsUseCase:                 listMarketsUseCase,
+		getMarketDetailsUseCase:            getMarketDetailsUseCase,
+		getCategoryOverviewUseCase:         getCategoryOverviewUseCase,
+		getSchemaDiagnosticsUseCase:        getSchemaDiagnosticsUseCase,
+		getMarketSettlementUseCase:         getMarketSettlementUseCase,
+		getStatusHistoryUseCase:            getStatusHistoryUseCase,
+		getRangedGroupUseCase:              getRangedGroupUseCase,
+		getEventDistributionUseCase:        getEventDistributionUseCase,
+		getMarketQuoteUseCase:              getMarketQuoteUseCase,
+		getMoversUseCase:                   getMoversUseCase,
+		searchMarketsUseCase:               searchMarketsUseCase,
+		getMarketsBatchUseCase:             getMarketsBatchUseCase,
+		authenticateUseCase:                authenticateUseCase,
+		refreshAccessTokenUseCase:          refreshAccessTokenUseCase,
+		revokeTokenUseCase:                 revokeTokenUseCase,
+		manageClientsUseCase:               manageClientsUseCase,
+		getJWKSUseCase:                     getJWKSUseCase,
+		issueClientCredentialsTokenUseCase: issueClientCredentialsTokenUseCase,
+		introspectTokenUseCase:             introspectTokenUseCase,
 	}
 
 	// Setup middleware and routes
@@ -72,26 +133,39 @@ func NewServer(
 func (s *Server) setupMiddleware() {
 	s.router.Use(gin.Recovery())
 	s.router.Use(middleware.Logging())
-	s.router.Use(middleware.RateLimitMiddleware(s.rateLimiter, s.tokenService))
+	s.router.Use(middleware.RateLimitMiddleware(s.rateLimiter, s.tokenService, s.revocationChecker, s.clientRepo, s.rateLimitCosts))
 	s.router.Use(middleware.ErrorHandler())
 
 }
 
 // setupRoutes configures all routes
 func (s *Server) setupRoutes() {
+	// Public keys for verifying access tokens (public)
+	jwksHandler := handler.NewJWKSHandler(s.getJWKSUseCase)
+	s.router.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
 	v1 := s.router.Group("/api/v1")
 	{
 		// Auth endpoints (public)
 		auth := v1.Group("/auth")
 		{
-			authenticateUseCase := usecase.NewAuthenticate(s.tokenService, s.config.Kalshi.APIKey)
-			authHandler := handler.NewAuthHandler(authenticateUseCase)
+			authHandler := handler.NewAuthHandler(s.authenticateUseCase, s.refreshAccessTokenUseCase, s.revokeTokenUseCase)
 			auth.POST("/token", authHandler.IssueToken)
+			auth.POST("/refresh", authHandler.RefreshToken)
+			auth.POST("/revoke", authHandler.RevokeToken)
+		}
+
+		// OAuth 2.0 endpoints (public, clients authenticate with their credentials)
+		oauth := v1.Group("/oauth")
+		{
+			oauthHandler := handler.NewOAuthHandler(s.issueClientCredentialsTokenUseCase, s.introspectTokenUseCase)
+			oauth.POST("/token", oauthHandler.IssueToken)
+			oauth.POST("/introspect", oauthHandler.IntrospectToken)
 		}
 
 		// Protected market endpoints (require authentication)
 		categories := v1.Group("/categories")
-		categories.Use(middleware.Auth(s.tokenService))
+		categories.Use(middleware.Auth(s.tokenService, s.revocationChecker), middleware.RequireScopes(authvalueobject.ScopeMarketsRead))
 		{
 			marketHandler := handler.NewMarketHandler(s.listMarketsUseCase, s.getMarketDetailsUseCase)
 			categories.GET("/:category/markets", marketHandler.ListMarkets)
@@ -102,14 +176,88 @@ func (s *Server) setupRoutes() {
 
 		// Protected market detail endpoint
 		markets := v1.Group("/markets")
-		markets.Use(middleware.Auth(s.tokenService))
+		markets.Use(middleware.Auth(s.tokenService, s.revocationChecker), middleware.RequireScopes(authvalueobject.ScopeMarketsRead))
 		{
+			searchHandler := handler.NewSearchHandler(s.searchMarketsUseCase)
+			markets.GET("/search", searchHandler.SearchMarkets)
+
+			batchHandler := handler.NewBatchHandler(s.getMarketsBatchUseCase)
+			markets.POST("/batch", batchHandler.GetMarkets)
+
 			marketHandler := handler.NewMarketHandler(s.listMarketsUseCase, s.getMarketDetailsUseCase)
 			markets.GET("/:ticker", marketHandler.GetMarketDetails)
+
+			settlementHandler := handler.NewSettlementHandler(s.getMarketSettlementUseCase)
+			markets.GET("/:ticker/settlement", settlementHandler.GetSettlement)
+
+			statusHistoryHandler := handler.NewStatusHistoryHandler(s.getStatusHistoryUseCase)
+			markets.GET("/:ticker/status-history", statusHistoryHandler.GetStatusHistory)
+
+			quoteHandler := handler.NewQuoteHandler(s.getMarketQuoteUseCase)
+			markets.POST("/:ticker/quote", quoteHandler.CreateQuote)
+		}
+
+		// Protected range group endpoint
+		rangedGroups := v1.Group("/ranged-groups")
+		rangedGroups.Use(middleware.Auth(s.tokenService, s.revocationChecker), middleware.RequireScopes(authvalueobject.ScopeMarketsRead))
+		{
+			rangedGroupHandler := handler.NewRangedGroupHandler(s.getRangedGroupUseCase)
+			rangedGroups.GET("/:ticker", rangedGroupHandler.GetRangedGroup)
+		}
+
+		// Protected movers endpoint
+		movers := v1.Group("/movers")
+		movers.Use(middleware.Auth(s.tokenService, s.revocationChecker), middleware.RequireScopes(authvalueobject.ScopeMarketsRead))
+		{
+			moversHandler := handler.NewMoversHandler(s.getMoversUseCase)
+			movers.GET("", moversHandler.GetMovers)
+		}
+
+		// Protected event endpoints
+		events := v1.Group("/events")
+		events.Use(middleware.Auth(s.tokenService, s.revocationChecker), middleware.RequireScopes(authvalueobject.ScopeMarketsRead))
+		{
+			eventHandler := handler.NewEventHandler(s.getEventDistributionUseCase)
+			events.GET("/:event_ticker/distribution", eventHandler.GetDistribution)
+		}
+
+		// Protected admin endpoints
+		admin := v1.Group("/admin")
+		admin.Use(middleware.Auth(s.tokenService, s.revocationChecker))
+		{
+			// Upstream and cache diagnostics, restricted to clients with the admin:cache scope
+			operations := admin.Group("")
+			operations.Use(middleware.RequireScopes(authvalueobject.ScopeAdminCache))
+			{
+				diagnosticsHandler := handler.NewDiagnosticsHandler(s.getSchemaDiagnosticsUseCase)
+				operations.GET("/diagnostics/schema", diagnosticsHandler.GetSchemaDiagnostics)
+			}
+
+			// API client and key management, restricted to clients with the admin:keys scope
+			keys := admin.Group("")
+			keys.Use(middleware.RequireScopes(authvalueobject.ScopeAdminKeys))
+			{
+				clientHandler := handler.NewClientHandler(s.manageClientsUseCase)
+				keys.POST("/clients", clientHandler.CreateClient)
+				keys.GET("/clients", clientHandler.ListClients)
+				keys.POST("/clients/:client_id/keys", clientHandler.RotateKey)
+				keys.DELETE("/clients/:client_id/keys/:key_id", clientHandler.RevokeKey)
+				keys.PUT("/clients/:client_id/tier", clientHandler.SetTier)
+				keys.POST("/clients/:client_id/disable", clientHandler.DisableClient)
+				keys.POST("/clients/:client_id/enable", clientHandler.EnableClient)
+				keys.POST("/clients/:client_id/revoke-tokens", clientHandler.RevokeClientTokens)
+				keys.DELETE("/clients/:client_id", clientHandler.DeleteClient)
+				keys.GET("/audit", clientHandler.GetAuditLog)
+			}
 		}
 	}
 }
 
+// CheckRateLimitRoutes fails if the rate limit route costs name a route the server does not serve
+func (s *Server) CheckRateLimitRoutes() error {
+	return s.rateLimitCosts.CheckRoutes(s.router.Routes())
+}
+
 // Start starts the HTTP server
 func (s *Server) Start() error {
 	fmt.Printf("Starting HTTP server on port %s\n", s.config.Server.Port)
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// Audit actions recorded for changes to the client registry
+const (
+	AuditClientCreated  = "client.created"
+	AuditClientEnabled  = "client.enabled"
+	AuditClientDisabled = "client.disabled"
+	AuditClientDeleted  = "client.deleted"
+	AuditTokensRevoked  = "client.tokens_revoked"
+	AuditTierChanged    = "client.tier_changed"
+	AuditKeyRotated     = "key.rotated"
+	AuditKeyRevoked     = "key.revoked"
+)
+
+// AuditEntry records who changed an API client, what changed and when
+type AuditEntry struct {
+	ID       string    `json:"id"`
+	Time     time.Time `json:"time"`
+	Actor    string    `json:"actor"` // Client ID of the administrator
+	Action   string    `json:"action"`
+	ClientID string    `json:"client_id"`
+	KeyID    string    `json:"key_id,omitempty"`
+	Detail   string    `json:"detail,omitempty"`
+	TraceID  string    `json:"trace_id,omitempty"`
+}
@@ -0,0 +1,124 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidClientID is returned when a client ID is empty or has unsupported characters
+	ErrInvalidClientID = errors.New("invalid client ID")
+
+	clientIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+)
+
+// ValidateClientID checks that a client ID is 1-64 lowercase letters, digits, dots,
+// underscores or dashes, starting with a letter or digit
+func ValidateClientID(clientID string) error {
+	if !clientIDPattern.MatchString(clientID) {
+		return fmt.Errorf("%w: %q (expected 1-64 lowercase letters, digits, '.', '_' or '-')", ErrInvalidClientID, clientID)
+	}
+	return nil
+}
+
+// ClientStatus is whether an API client may authenticate
+type ClientStatus string
+
+const (
+	// ClientStatusActive clients may authenticate
+	ClientStatusActive ClientStatus = "active"
+	// ClientStatusDisabled clients are refused new tokens
+	ClientStatusDisabled ClientStatus = "disabled"
+)
+
+// NewClientStatus parses a client status, defaulting to active
+func NewClientStatus(status string) (ClientStatus, error) {
+	switch ClientStatus(strings.ToLower(strings.TrimSpace(status))) {
+	case "", ClientStatusActive:
+		return ClientStatusActive, nil
+	case ClientStatusDisabled:
+		return ClientStatusDisabled, nil
+	default:
+		return "", fmt.Errorf("invalid client status: %s (expected active or disabled)", status)
+	}
+}
+
+// Client is a consumer of the API registered with its own keys, rate limit tier and scopes
+type Client struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Tier      string       `json:"tier"`
+	Scopes    []string     `json:"scopes"`
+	Status    ClientStatus `json:"status"`
+	Keys      []ClientKey  `json:"keys"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// ClientKey is one API key of a client. Only its hash is stored; the prefix identifies it.
+type ClientKey struct {
+	ID        string    `json:"id"`
+	Prefix    string    `json:"prefix"`
+	Hash      string    `json:"hash"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"` // Zero for keys that do not expire
+}
+
+// IsActive checks if the client may authenticate
+func (c *Client) IsActive() bool {
+	return c.Status == ClientStatusActive
+}
+
+// FindKey returns the client's key with an ID, or nil
+func (c *Client) FindKey(keyID string) *ClientKey {
+	for i := range c.Keys {
+		if c.Keys[i].ID == keyID {
+			return &c.Keys[i]
+		}
+	}
+	return nil
+}
+
+// AddKey adds a key to the client
+func (c *Client) AddKey(key ClientKey) {
+	c.Keys = append(c.Keys, key)
+}
+
+// RemoveKey removes a key from the client, reporting whether it held the key
+func (c *Client) RemoveKey(keyID string) bool {
+	for i := range c.Keys {
+		if c.Keys[i].ID == keyID {
+			c.Keys = append(c.Keys[:i], c.Keys[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// ExpireKeys makes every key stop being accepted at a time, unless it expires earlier
+func (c *Client) ExpireKeys(at time.Time) {
+	for i := range c.Keys {
+		if c.Keys[i].ExpiresAt.IsZero() || c.Keys[i].ExpiresAt.After(at) {
+			c.Keys[i].ExpiresAt = at
+		}
+	}
+}
+
+// PruneExpiredKeys removes the keys that are no longer accepted
+func (c *Client) PruneExpiredKeys(now time.Time) {
+	active := c.Keys[:0]
+	for _, key := range c.Keys {
+		if !key.IsExpired(now) {
+			active = append(active, key)
+		}
+	}
+	c.Keys = active
+}
+
+// IsExpired checks if the key has stopped being accepted
+func (k *ClientKey) IsExpired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt)
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// RefreshToken is an issued refresh token, stored by hash. Each refresh consumes the token
+// and issues a replacement in the same family; presenting a consumed token again means it
+// leaked, and revokes the whole family.
+type RefreshToken struct {
+	ID        string    `json:"id"` // Hash of the token
+	FamilyID  string    `json:"family_id"`
+	ClientID  string    `json:"client_id"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	UsedAt    time.Time `json:"used_at,omitempty"`
+}
+
+// IsExpired checks if the token can no longer be exchanged
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// IsUsed checks if the token has already been exchanged
+func (t *RefreshToken) IsUsed() bool {
+	return !t.UsedAt.IsZero()
+}
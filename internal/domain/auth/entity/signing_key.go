@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"time"
+	"upwork-test/internal/domain/auth/valueobject"
+)
+
+const rsaKeyBits = 2048
+
+// SigningKey is a private key access tokens are signed with, identified in tokens by its ID
+// (the kid header). A key is published ahead of its activation so verifiers learn it before
+// the first token signed with it, signs tokens until it retires, and is still published for
+// verification until the last of those tokens has expired.
+type SigningKey struct {
+	ID          string                       `json:"id"`
+	Algorithm   valueobject.SigningAlgorithm `json:"algorithm"`
+	PrivateKey  []byte                       `json:"private_key"` // PKCS #8, DER
+	CreatedAt   time.Time                    `json:"created_at"`
+	ActivatesAt time.Time                    `json:"activates_at"`
+	RetiresAt   time.Time                    `json:"retires_at"`
+	ExpiresAt   time.Time                    `json:"expires_at"`
+}
+
+// PublicKey is the public half of a signing key, as published for verification
+type PublicKey struct {
+	ID        string
+	Algorithm valueobject.SigningAlgorithm
+	Key       crypto.PublicKey
+}
+
+// GenerateSigningKey creates a key with a random ID that signs between activatesAt and retiresAt
+func GenerateSigningKey(algorithm valueobject.SigningAlgorithm, now time.Time, activatesAt time.Time, retiresAt time.Time, expiresAt time.Time) (*SigningKey, error) {
+	var privateKey crypto.Signer
+	var err error
+	switch algorithm {
+	case valueobject.SigningAlgorithmRS256:
+		privateKey, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case valueobject.SigningAlgorithmES256:
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, fmt.Errorf("%w: %q", valueobject.ErrUnsupportedAlgorithm, algorithm)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode signing key: %w", err)
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to generate signing key ID: %w", err)
+	}
+
+	return &SigningKey{
+		ID:          hex.EncodeToString(id),
+		Algorithm:   algorithm,
+		PrivateKey:  der,
+		CreatedAt:   now,
+		ActivatesAt: activatesAt,
+		RetiresAt:   retiresAt,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// Signer decodes the private key, checking it matches the key's algorithm
+func (k *SigningKey) Signer() (crypto.Signer, error) {
+	parsed, err := x509.ParsePKCS8PrivateKey(k.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("signing key %s: %w", k.ID, err)
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		if k.Algorithm == valueobject.SigningAlgorithmRS256 {
+			return key, nil
+		}
+	case *ecdsa.PrivateKey:
+		if k.Algorithm == valueobject.SigningAlgorithmES256 && key.Curve == elliptic.P256() {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("signing key %s: key type does not match %s", k.ID, k.Algorithm)
+}
+
+// CanSign checks if the key is active and not yet retired
+func (k *SigningKey) CanSign(now time.Time) bool {
+	return !now.Before(k.ActivatesAt) && now.Before(k.RetiresAt)
+}
+
+// IsExpired checks if every token the key signed has expired, so it is no longer needed
+func (k *SigningKey) IsExpired(now time.Time) bool {
+	return !now.Before(k.ExpiresAt)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"upwork-test/internal/domain/auth/entity"
+)
+
+// AuditRepository defines the interface for the audit log of client registry changes.
+type AuditRepository interface {
+	// Append records an entry
+	Append(ctx context.Context, entry *entity.AuditEntry) error
+
+	// ListRecent retrieves up to limit entries, newest first
+	ListRecent(ctx context.Context, limit int) ([]*entity.AuditEntry, error)
+}
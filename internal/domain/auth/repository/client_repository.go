@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"upwork-test/internal/domain/auth/entity"
+)
+
+var (
+	// ErrClientNotFound is returned when no client is registered with an ID or key
+	ErrClientNotFound = errors.New("client not found")
+	// ErrClientExists is returned when creating a client with an ID already registered
+	ErrClientExists = errors.New("client already exists")
+	// ErrKeyIDConflict is returned when saving a client with a key ID another client holds
+	ErrKeyIDConflict = errors.New("API key ID held by another client")
+)
+
+// ClientUpdate changes a client in place, reporting whether it changed. It runs again on the
+// newly stored client if the client changes before the update is written.
+type ClientUpdate func(client *entity.Client) (bool, error)
+
+// ClientRepository defines the interface for the registry of API clients.
+type ClientRepository interface {
+	// GetByID retrieves a client by its ID
+	GetByID(ctx context.Context, clientID string) (*entity.Client, error)
+
+	// GetByKeyID retrieves the client owning an API key
+	GetByKeyID(ctx context.Context, keyID string) (*entity.Client, error)
+
+	// List retrieves every registered client
+	List(ctx context.Context) ([]*entity.Client, error)
+
+	// Create registers a new client and indexes its keys, failing if the ID or a key ID is taken
+	Create(ctx context.Context, client *entity.Client) error
+
+	// Save creates or replaces a client and indexes its keys, failing if a key ID is taken
+	Save(ctx context.Context, client *entity.Client) error
+
+	// Update applies a change to the stored client atomically and returns the client as stored
+	Update(ctx context.Context, clientID string, update ClientUpdate) (*entity.Client, error)
+
+	// Delete removes a client and its keys
+	Delete(ctx context.Context, clientID string) error
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"upwork-test/internal/domain/auth/entity"
+)
+
+var (
+	// ErrRefreshTokenNotFound is returned when a refresh token was never issued or has expired
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	// ErrRefreshTokenReused is returned when consuming a refresh token that was already consumed
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+	// ErrTokenFamilyRevoked is returned when consuming a refresh token of a revoked family
+	ErrTokenFamilyRevoked = errors.New("refresh token family revoked")
+)
+
+// RefreshTokenRepository defines the interface for issued refresh tokens.
+type RefreshTokenRepository interface {
+	// Get retrieves a refresh token by its hash
+	Get(ctx context.Context, tokenID string) (*entity.RefreshToken, error)
+
+	// Create stores a new refresh token until it expires
+	Create(ctx context.Context, token *entity.RefreshToken) error
+
+	// Consume atomically marks a token used and stores its replacement. It fails if the
+	// token was already used or its family revoked, so a token is exchanged at most once.
+	Consume(ctx context.Context, tokenID string, replacement *entity.RefreshToken) error
+
+	// RevokeFamily stops every token of a family from being exchanged. The revocation is
+	// kept for ttl, which must cover the lifetime of the family's tokens.
+	RevokeFamily(ctx context.Context, familyID string, ttl time.Duration) error
+}
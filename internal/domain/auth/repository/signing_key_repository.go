@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"upwork-test/internal/domain/auth/entity"
+)
+
+// SigningKeyRepository defines the interface for the token signing keys shared by all API processes.
+type SigningKeyRepository interface {
+	// List retrieves every stored signing key
+	List(ctx context.Context) ([]*entity.SigningKey, error)
+
+	// Create stores a new signing key
+	Create(ctx context.Context, key *entity.SigningKey) error
+
+	// Delete removes a signing key
+	Delete(ctx context.Context, keyID string) error
+
+	// AcquireRotationLock claims the right to add keys for ttl, so that processes
+	// rotating at the same time create one key between them. It returns the token
+	// identifying this claim, or false if another process holds the lock.
+	AcquireRotationLock(ctx context.Context, ttl time.Duration) (string, bool, error)
+
+	// ReleaseRotationLock gives the right to add keys back, unless the claim identified
+	// by token has expired and another process holds the lock since
+	ReleaseRotationLock(ctx context.Context, token string) error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// TokenRevocationRepository defines the interface for revoked access tokens.
+type TokenRevocationRepository interface {
+	// RevokeToken denylists a token ID for ttl, the remaining lifetime of the token
+	RevokeToken(ctx context.Context, tokenID string, ttl time.Duration) error
+
+	// IsTokenRevoked checks if a token ID is denylisted
+	IsTokenRevoked(ctx context.Context, tokenID string) (bool, error)
+
+	// SetClientWatermark invalidates every token of a client issued before a time
+	SetClientWatermark(ctx context.Context, clientID string, issuedBefore time.Time) error
+
+	// GetClientWatermark retrieves the time before which a client's tokens are invalid,
+	// or the zero time if none is set
+	GetClientWatermark(ctx context.Context, clientID string) (time.Time, error)
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+	"upwork-test/internal/domain/auth/repository"
+	"upwork-test/internal/domain/auth/valueobject"
+)
+
+// revocationCacheSize bounds each local cache; when full, expired entries are dropped
+// and, if that is not enough, the cache starts over
+const revocationCacheSize = 10000
+
+type cachedRevocation struct {
+	revoked   bool
+	expiresAt time.Time
+}
+
+type cachedWatermark struct {
+	issuedBefore time.Time
+	expiresAt    time.Time
+}
+
+// RevocationChecker decides whether an access token has been revoked, either by ID
+// (denylisted) or because its client's tokens issued before a watermark were revoked.
+// Lookups are cached in process for cacheTTL to keep the request path fast, so a
+// revocation made by another process takes up to cacheTTL to apply here. Revocations
+// made through this checker apply here immediately.
+type RevocationChecker struct {
+	repo     repository.TokenRevocationRepository
+	cacheTTL time.Duration
+
+	mu         sync.Mutex
+	tokens     map[string]cachedRevocation
+	watermarks map[string]cachedWatermark
+}
+
+// NewRevocationChecker creates a new RevocationChecker
+func NewRevocationChecker(repo repository.TokenRevocationRepository, cacheTTL time.Duration) *RevocationChecker {
+	return &RevocationChecker{
+		repo:       repo,
+		cacheTTL:   cacheTTL,
+		tokens:     make(map[string]cachedRevocation),
+		watermarks: make(map[string]cachedWatermark),
+	}
+}
+
+// IsRevoked checks if a validated token has been revoked
+func (c *RevocationChecker) IsRevoked(ctx context.Context, token valueobject.Token) (bool, error) {
+	issuedBefore, err := c.ClientWatermark(ctx, token.UserID())
+	if err != nil {
+		return false, err
+	}
+	if token.IssuedAt().Before(issuedBefore) {
+		return true, nil
+	}
+
+	// Tokens issued before token IDs were introduced can only be revoked by watermark
+	if token.ID() == "" {
+		return false, nil
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	cached, ok := c.tokens[token.ID()]
+	c.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.revoked, nil
+	}
+
+	revoked, err := c.repo.IsTokenRevoked(ctx, token.ID())
+	if err != nil {
+		return false, err
+	}
+
+	// A revocation is final, so it is cached for as long as the token lives
+	expiresAt := now.Add(c.cacheTTL)
+	if revoked {
+		expiresAt = token.ExpiresAt()
+	}
+	c.cacheToken(token.ID(), cachedRevocation{revoked: revoked, expiresAt: expiresAt})
+
+	return revoked, nil
+}
+
+// ClientWatermark returns the time before which a client's tokens are invalid, or the zero time
+func (c *RevocationChecker) ClientWatermark(ctx context.Context, clientID string) (time.Time, error) {
+	now := time.Now()
+	c.mu.Lock()
+	cached, ok := c.watermarks[clientID]
+	c.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.issuedBefore, nil
+	}
+
+	issuedBefore, err := c.repo.GetClientWatermark(ctx, clientID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	c.cacheWatermark(clientID, cachedWatermark{issuedBefore: issuedBefore, expiresAt: now.Add(c.cacheTTL)})
+	return issuedBefore, nil
+}
+
+// RevokeToken denylists a token for the rest of its lifetime
+func (c *RevocationChecker) RevokeToken(ctx context.Context, token valueobject.Token) error {
+	if token.ID() == "" {
+		return nil
+	}
+
+	if err := c.repo.RevokeToken(ctx, token.ID(), time.Until(token.ExpiresAt())); err != nil {
+		return err
+	}
+
+	c.cacheToken(token.ID(), cachedRevocation{revoked: true, expiresAt: token.ExpiresAt()})
+	return nil
+}
+
+// RevokeClientTokens invalidates every token of a client issued before a time. Token issue
+// times are kept in whole seconds, so the watermark is truncated to the second and only
+// tokens issued strictly before it are revoked: a token issued within the same second
+// cannot be told apart from one issued just after, and is kept.
+func (c *RevocationChecker) RevokeClientTokens(ctx context.Context, clientID string, issuedBefore time.Time) error {
+	watermark := issuedBefore.Truncate(time.Second)
+
+	if err := c.repo.SetClientWatermark(ctx, clientID, watermark); err != nil {
+		return err
+	}
+
+	c.cacheWatermark(clientID, cachedWatermark{issuedBefore: watermark, expiresAt: time.Now().Add(c.cacheTTL)})
+	return nil
+}
+
+func (c *RevocationChecker) cacheToken(tokenID string, entry cachedRevocation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.tokens) >= revocationCacheSize {
+		now := time.Now()
+		for id, cached := range c.tokens {
+			if !now.Before(cached.expiresAt) {
+				delete(c.tokens, id)
+			}
+		}
+		if len(c.tokens) >= revocationCacheSize {
+			c.tokens = make(map[string]cachedRevocation)
+		}
+	}
+	c.tokens[tokenID] = entry
+}
+
+func (c *RevocationChecker) cacheWatermark(clientID string, entry cachedWatermark) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.watermarks) >= revocationCacheSize {
+		c.watermarks = make(map[string]cachedWatermark)
+	}
+	c.watermarks[clientID] = entry
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"upwork-test/internal/domain/auth/valueobject"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTokenRevocationRepository keeps the denylist and client watermarks in memory
+type fakeTokenRevocationRepository struct {
+	mu         sync.Mutex
+	denylist   map[string]time.Duration
+	watermarks map[string]time.Time
+	err        error // Returned by every call when set
+	lookups    int
+}
+
+func newFakeTokenRevocationRepository() *fakeTokenRevocationRepository {
+	return &fakeTokenRevocationRepository{
+		denylist:   make(map[string]time.Duration),
+		watermarks: make(map[string]time.Time),
+	}
+}
+
+func (r *fakeTokenRevocationRepository) RevokeToken(_ context.Context, tokenID string, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+	r.denylist[tokenID] = ttl
+	return nil
+}
+
+func (r *fakeTokenRevocationRepository) IsTokenRevoked(_ context.Context, tokenID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lookups++
+	if r.err != nil {
+		return false, r.err
+	}
+	_, ok := r.denylist[tokenID]
+	return ok, nil
+}
+
+func (r *fakeTokenRevocationRepository) SetClientWatermark(_ context.Context, clientID string, issuedBefore time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+	r.watermarks[clientID] = issuedBefore
+	return nil
+}
+
+func (r *fakeTokenRevocationRepository) GetClientWatermark(_ context.Context, clientID string) (time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lookups++
+	if r.err != nil {
+		return time.Time{}, r.err
+	}
+	return r.watermarks[clientID], nil
+}
+
+// newTestToken returns a validated token of a client, issued at a time and living 15 minutes
+func newTestToken(tokenID string, clientID string, issuedAt time.Time) valueobject.Token {
+	return valueobject.NewTokenFromClaims("header.payload.signature", &valueobject.Claims{
+		UserID: clientID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(issuedAt.Add(15 * time.Minute)),
+		},
+	})
+}
+
+func TestRevocationCheckerDenylist(t *testing.T) {
+	repo := newFakeTokenRevocationRepository()
+	checker := NewRevocationChecker(repo, 0)
+	ctx := context.Background()
+	now := time.Now()
+
+	revoked := newTestToken("token-1", "team-a", now)
+	other := newTestToken("token-2", "team-a", now)
+	require.NoError(t, checker.RevokeToken(ctx, revoked))
+
+	// The token is denylisted until it would have expired
+	assert.InDelta(t, (15 * time.Minute).Seconds(), repo.denylist["token-1"].Seconds(), 5)
+
+	tests := []struct {
+		name  string
+		token valueobject.Token
+		want  bool
+	}{
+		{name: "revoked token", token: revoked, want: true},
+		{name: "other token of the client", token: other, want: false},
+		{name: "token of another client", token: newTestToken("token-3", "team-b", now), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checker.IsRevoked(ctx, tt.token)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRevocationCheckerTokenWithoutID(t *testing.T) {
+	repo := newFakeTokenRevocationRepository()
+	checker := NewRevocationChecker(repo, 0)
+	ctx := context.Background()
+
+	// Tokens issued before token IDs were introduced cannot be denylisted
+	token := newTestToken("", "team-a", time.Now())
+	require.NoError(t, checker.RevokeToken(ctx, token))
+	assert.Empty(t, repo.denylist)
+
+	revoked, err := checker.IsRevoked(ctx, token)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestRevocationCheckerWatermark(t *testing.T) {
+	repo := newFakeTokenRevocationRepository()
+	checker := NewRevocationChecker(repo, 0)
+	ctx := context.Background()
+
+	second := time.Now().Truncate(time.Second)
+	revokedAt := second.Add(700 * time.Millisecond)
+	require.NoError(t, checker.RevokeClientTokens(ctx, "team-a", revokedAt))
+
+	// The watermark is truncated to the second, never rounded up
+	assert.Equal(t, second, repo.watermarks["team-a"])
+
+	tests := []struct {
+		name  string
+		token valueobject.Token
+		want  bool
+	}{
+		{name: "issued a second earlier", token: newTestToken("token-1", "team-a", second.Add(-time.Second)), want: true},
+		{name: "issued long before", token: newTestToken("token-2", "team-a", second.Add(-time.Hour)), want: true},
+		{name: "issued in the same second", token: newTestToken("token-3", "team-a", second), want: false},
+		{name: "issued a second later", token: newTestToken("token-4", "team-a", second.Add(time.Second)), want: false},
+		{name: "token without ID issued earlier", token: newTestToken("", "team-a", second.Add(-time.Second)), want: true},
+		{name: "token of another client", token: newTestToken("token-5", "team-b", second.Add(-time.Second)), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checker.IsRevoked(ctx, tt.token)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRevocationCheckerCache(t *testing.T) {
+	repo := newFakeTokenRevocationRepository()
+	checker := NewRevocationChecker(repo, time.Hour)
+	ctx := context.Background()
+	now := time.Now()
+	token := newTestToken("token-1", "team-a", now.Add(-time.Minute))
+
+	revoked, err := checker.IsRevoked(ctx, token)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+	lookups := repo.lookups
+
+	// A revocation made by another process is not seen until the cached lookups expire
+	repo.denylist["token-1"] = time.Minute
+	repo.watermarks["team-a"] = now.Add(time.Second)
+	revoked, err = checker.IsRevoked(ctx, token)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+	assert.Equal(t, lookups, repo.lookups)
+
+	// A revocation made through the checker applies immediately
+	require.NoError(t, checker.RevokeClientTokens(ctx, "team-a", now))
+	revoked, err = checker.IsRevoked(ctx, token)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	other := newTestToken("token-2", "team-b", now)
+	require.NoError(t, checker.RevokeToken(ctx, other))
+	revoked, err = checker.IsRevoked(ctx, other)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestRevocationCheckerLookupFailure(t *testing.T) {
+	repo := newFakeTokenRevocationRepository()
+	checker := NewRevocationChecker(repo, 0)
+	ctx := context.Background()
+
+	repo.err = errors.New("connection refused")
+	_, err := checker.IsRevoked(ctx, newTestToken("token-1", "team-a", time.Now()))
+	assert.ErrorIs(t, err, repo.err)
+	assert.ErrorIs(t, checker.RevokeClientTokens(ctx, "team-a", time.Now()), repo.err)
+
+	// A failed lookup is not cached
+	repo.err = nil
+	revoked, err := checker.IsRevoked(ctx, newTestToken("token-1", "team-a", time.Now()))
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
@@ -1,43 +1,133 @@
 package service
 
 import (
+	"crypto"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"time"
+	"upwork-test/internal/domain/auth/entity"
 	"upwork-test/internal/domain/auth/valueobject"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
-// TokenService handles JWT token generation and validation
+// ErrNoSigningKey is returned when no loaded key can sign tokens
+var ErrNoSigningKey = errors.New("no active signing key")
+
+// TokenService handles JWT token generation and validation. Tokens are signed with the
+// active key of a key set and carry its ID in the kid header; they are verified against
+// whichever key of the set signed them, so tokens signed before a rotation stay valid.
 type TokenService struct {
-	secretKey  string
 	expiration time.Duration
+
+	mu   sync.RWMutex
+	keys []*loadedKey // Ordered by activation
+}
+
+type loadedKey struct {
+	key    *entity.SigningKey
+	signer crypto.Signer
+	method jwt.SigningMethod
 }
 
-// NewTokenService creates a new TokenService
-func NewTokenService(secretKey string, expiration time.Duration) *TokenService {
+// NewTokenService creates a new TokenService. It cannot sign or verify tokens until keys are set.
+func NewTokenService(expiration time.Duration) *TokenService {
 	return &TokenService{
-		secretKey:  secretKey,
 		expiration: expiration,
 	}
 }
 
-// GenerateToken generates a new JWT token for a user
-func (s *TokenService) GenerateToken(userID string) (valueobject.Token, error) {
+// Expiration returns the lifetime of issued tokens
+func (s *TokenService) Expiration() time.Duration {
+	return s.expiration
+}
+
+// SetKeys replaces the key set. Expired keys are dropped.
+func (s *TokenService) SetKeys(keys []*entity.SigningKey) error {
+	now := time.Now()
+	loaded := make([]*loadedKey, 0, len(keys))
+	for _, key := range keys {
+		if key.IsExpired(now) {
+			continue
+		}
+
+		signer, err := key.Signer()
+		if err != nil {
+			return err
+		}
+
+		method := jwt.GetSigningMethod(key.Algorithm.String())
+		if method == nil {
+			return fmt.Errorf("%w: %q", valueobject.ErrUnsupportedAlgorithm, key.Algorithm)
+		}
+
+		loaded = append(loaded, &loadedKey{key: key, signer: signer, method: method})
+	}
+
+	sort.Slice(loaded, func(i, j int) bool {
+		return loaded[i].key.ActivatesAt.Before(loaded[j].key.ActivatesAt)
+	})
+
+	s.mu.Lock()
+	s.keys = loaded
+	s.mu.Unlock()
+	return nil
+}
+
+// CanSign checks if a loaded key is active
+func (s *TokenService) CanSign() bool {
+	return s.signingKey(time.Now()) != nil
+}
+
+// PublicKeys returns the public keys tokens are verified with, including keys not yet active
+func (s *TokenService) PublicKeys() []entity.PublicKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	publicKeys := make([]entity.PublicKey, 0, len(s.keys))
+	for _, loaded := range s.keys {
+		if loaded.key.IsExpired(now) {
+			continue
+		}
+		publicKeys = append(publicKeys, entity.PublicKey{
+			ID:        loaded.key.ID,
+			Algorithm: loaded.key.Algorithm,
+			Key:       loaded.signer.Public(),
+		})
+	}
+	return publicKeys
+}
+
+// GenerateToken generates a new JWT token for a user, granting it scopes and naming its rate limit tier
+func (s *TokenService) GenerateToken(userID string, scopes []string, tier string) (valueobject.Token, error) {
 	now := time.Now()
 	expiresAt := now.Add(s.expiration)
 
+	signingKey := s.signingKey(now)
+	if signingKey == nil {
+		return valueobject.Token{}, ErrNoSigningKey
+	}
+
 	claims := &valueobject.Claims{
 		UserID: userID,
+		Scope:  strings.Join(scopes, " "),
+		Tier:   tier,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(s.secretKey))
+	token := jwt.NewWithClaims(signingKey.method, claims)
+	token.Header["kid"] = signingKey.key.ID
+	tokenString, err := token.SignedString(signingKey.signer)
 	if err != nil {
 		return valueobject.Token{}, fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -47,12 +137,7 @@ func (s *TokenService) GenerateToken(userID string) (valueobject.Token, error) {
 
 // ValidateToken validates a JWT token and returns the claims
 func (s *TokenService) ValidateToken(tokenString string) (valueobject.Token, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &valueobject.Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.secretKey), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &valueobject.Claims{}, s.verificationKey)
 
 	if err != nil {
 		return valueobject.Token{}, fmt.Errorf("%w: %v", valueobject.ErrInvalidToken, err)
@@ -69,9 +154,7 @@ func (s *TokenService) ValidateToken(tokenString string) (valueobject.Token, err
 // ExtractUserID extracts the user ID from a token without full validation
 // Useful for logging or metrics where we need the user ID even if token is expired
 func (s *TokenService) ExtractUserID(tokenString string) (string, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &valueobject.Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(s.secretKey), nil
-	}, jwt.WithoutClaimsValidation())
+	token, err := jwt.ParseWithClaims(tokenString, &valueobject.Claims{}, s.verificationKey, jwt.WithoutClaimsValidation())
 
 	if err != nil {
 		return "", fmt.Errorf("failed to parse token: %w", err)
@@ -83,4 +166,40 @@ func (s *TokenService) ExtractUserID(tokenString string) (string, error) {
 	}
 
 	return claims.UserID, nil
-}
\ No newline at end of file
+}
+
+// signingKey returns the most recently activated key that can sign, or nil
+func (s *TokenService) signingKey(now time.Time) *loadedKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for i := len(s.keys) - 1; i >= 0; i-- {
+		if s.keys[i].key.CanSign(now) {
+			return s.keys[i]
+		}
+	}
+	return nil
+}
+
+// verificationKey finds the public key named by a token's kid header. The token's algorithm
+// must be the key's, so a token cannot pick how its signature is checked.
+func (s *TokenService) verificationKey(token *jwt.Token) (interface{}, error) {
+	keyID, _ := token.Header["kid"].(string)
+	if keyID == "" {
+		return nil, errors.New("missing key ID")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, loaded := range s.keys {
+		if loaded.key.ID != keyID {
+			continue
+		}
+		if token.Method.Alg() != loaded.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return loaded.signer.Public(), nil
+	}
+	return nil, fmt.Errorf("unknown key ID: %s", keyID)
+}
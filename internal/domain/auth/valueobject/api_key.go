@@ -0,0 +1,84 @@
+package valueobject
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// APIKeyPrefix marks the API keys issued by this service
+	APIKeyPrefix = "kag"
+
+	apiKeyIDBytes     = 8
+	apiKeySecretBytes = 32
+)
+
+// APIKey is a client API key of the form kag_<key id>_<secret>. The key ID is public and
+// locates the key in the client registry; only a hash of the whole key is stored.
+type APIKey struct {
+	id    string
+	value string
+}
+
+// GenerateAPIKey creates a new random API key
+func GenerateAPIKey() (APIKey, error) {
+	id := make([]byte, apiKeyIDBytes)
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(id); err != nil {
+		return APIKey{}, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return APIKey{}, fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	keyID := hex.EncodeToString(id)
+	return APIKey{
+		id:    keyID,
+		value: APIKeyPrefix + "_" + keyID + "_" + base64.RawURLEncoding.EncodeToString(secret),
+	}, nil
+}
+
+// ParseAPIKey reads the API key presented in credentials. Anything not shaped like a key
+// issued by this service, such as an upstream Kalshi key, is rejected.
+func ParseAPIKey(credentials Credentials) (APIKey, error) {
+	value := credentials.APIKey()
+
+	rest, found := strings.CutPrefix(value, APIKeyPrefix+"_")
+	if !found {
+		return APIKey{}, fmt.Errorf("%w: not a client API key", ErrInvalidCredentials)
+	}
+
+	keyID, secret, found := strings.Cut(rest, "_")
+	if !found || len(keyID) != hex.EncodedLen(apiKeyIDBytes) || secret == "" {
+		return APIKey{}, fmt.Errorf("%w: malformed client API key", ErrInvalidCredentials)
+	}
+	if _, err := hex.DecodeString(keyID); err != nil {
+		return APIKey{}, fmt.Errorf("%w: malformed client API key", ErrInvalidCredentials)
+	}
+
+	return APIKey{id: keyID, value: value}, nil
+}
+
+// ID returns the public key ID
+func (k APIKey) ID() string {
+	return k.id
+}
+
+// Value returns the full secret key
+func (k APIKey) Value() string {
+	return k.value
+}
+
+// Masked returns the key ID and the last characters of the secret, in the style of
+// Credentials.MaskedAPIKey, safe to store and display for identification
+func (k APIKey) Masked() string {
+	return MaskedAPIKeyPrefix(k.id) + k.value[len(k.value)-4:]
+}
+
+// MaskedAPIKeyPrefix returns the masked form of a key known only by its ID
+func MaskedAPIKeyPrefix(keyID string) string {
+	return APIKeyPrefix + "_" + keyID + "_****"
+}
@@ -0,0 +1,54 @@
+package valueobject
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	refreshTokenPrefix = "rt_"
+	refreshTokenBytes  = 32
+)
+
+// RefreshToken is an opaque, single-use token exchanged for a new access token.
+// Only its hash is stored, so a leaked store cannot be used to refresh.
+type RefreshToken struct {
+	value string
+}
+
+// GenerateRefreshToken creates a new random refresh token
+func GenerateRefreshToken() (RefreshToken, error) {
+	secret := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return RefreshToken{}, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return RefreshToken{value: refreshTokenPrefix + base64.RawURLEncoding.EncodeToString(secret)}, nil
+}
+
+// NewRefreshToken creates a RefreshToken value object from a presented token
+func NewRefreshToken(value string) (RefreshToken, error) {
+	normalized := strings.TrimSpace(value)
+
+	secret, found := strings.CutPrefix(normalized, refreshTokenPrefix)
+	if !found || base64.RawURLEncoding.DecodedLen(len(secret)) != refreshTokenBytes {
+		return RefreshToken{}, fmt.Errorf("%w: malformed refresh token", ErrInvalidToken)
+	}
+
+	return RefreshToken{value: normalized}, nil
+}
+
+// String returns the token value
+func (t RefreshToken) String() string {
+	return t.value
+}
+
+// Hash returns the SHA-256 hash of the token, under which it is stored
+func (t RefreshToken) Hash() string {
+	sum := sha256.Sum256([]byte(t.value))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,44 @@
+package valueobject
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownScope is returned for a scope no route requires
+var ErrUnknownScope = errors.New("unknown scope")
+
+// Scopes granted to API clients and carried in their access tokens
+const (
+	// ScopeMarketsRead allows reading markets, categories, events and quotes
+	ScopeMarketsRead = "markets:read"
+	// ScopeStreamSubscribe allows subscribing to live market streams
+	ScopeStreamSubscribe = "stream:subscribe"
+	// ScopeAdminCache allows the operational endpoints over the market cache and its upstream
+	ScopeAdminCache = "admin:cache"
+	// ScopeAdminKeys allows managing API clients and their keys
+	ScopeAdminKeys = "admin:keys"
+	// ScopeTokensIntrospect allows introspecting access tokens, for gateways
+	ScopeTokensIntrospect = "tokens:introspect"
+)
+
+var knownScopes = map[string]bool{
+	ScopeMarketsRead:      true,
+	ScopeStreamSubscribe:  true,
+	ScopeAdminCache:       true,
+	ScopeAdminKeys:        true,
+	ScopeTokensIntrospect: true,
+}
+
+// DefaultScopes returns the scopes of a client registered without any
+func DefaultScopes() []string {
+	return []string{ScopeMarketsRead}
+}
+
+// ValidateScope checks that a scope is one of the known scopes
+func ValidateScope(scope string) error {
+	if !knownScopes[scope] {
+		return fmt.Errorf("%w: %q", ErrUnknownScope, scope)
+	}
+	return nil
+}
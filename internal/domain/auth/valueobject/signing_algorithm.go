@@ -0,0 +1,36 @@
+package valueobject
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedAlgorithm is returned for a signing algorithm other than RS256 or ES256
+var ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+
+// SigningAlgorithm is the JWS algorithm access tokens are signed with
+type SigningAlgorithm string
+
+const (
+	// SigningAlgorithmRS256 signs with RSASSA-PKCS1-v1_5 using SHA-256 and a 2048-bit key
+	SigningAlgorithmRS256 SigningAlgorithm = "RS256"
+	// SigningAlgorithmES256 signs with ECDSA using P-256 and SHA-256
+	SigningAlgorithmES256 SigningAlgorithm = "ES256"
+)
+
+// NewSigningAlgorithm creates a SigningAlgorithm from its JWS name
+func NewSigningAlgorithm(name string) (SigningAlgorithm, error) {
+	algorithm := SigningAlgorithm(strings.ToUpper(strings.TrimSpace(name)))
+	switch algorithm {
+	case SigningAlgorithmRS256, SigningAlgorithmES256:
+		return algorithm, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, name)
+	}
+}
+
+// String returns the JWS name of the algorithm
+func (a SigningAlgorithm) String() string {
+	return string(a)
+}
@@ -3,6 +3,7 @@ package valueobject
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -17,14 +18,20 @@ var (
 // Token represents a JWT token
 type Token struct {
 	value     string
+	id        string
 	userID    string
+	scopes    []string
+	tier      string
 	issuedAt  time.Time
 	expiresAt time.Time
 }
 
-// Claims represents the JWT claims
+// Claims represents the JWT claims. Scopes are space-separated, as in RFC 9068; the tier
+// names the client's rate limit tier.
 type Claims struct {
 	UserID string `json:"user_id"`
+	Scope  string `json:"scope,omitempty"`
+	Tier   string `json:"tier,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -48,7 +55,10 @@ func NewToken(tokenString string) (Token, error) {
 func NewTokenFromClaims(tokenString string, claims *Claims) Token {
 	return Token{
 		value:     tokenString,
+		id:        claims.ID,
 		userID:    claims.UserID,
+		scopes:    strings.Fields(claims.Scope),
+		tier:      claims.Tier,
 		issuedAt:  claims.IssuedAt.Time,
 		expiresAt: claims.ExpiresAt.Time,
 	}
@@ -59,11 +69,31 @@ func (t Token) String() string {
 	return t.value
 }
 
+// ID returns the unique token ID (the jti claim)
+func (t Token) ID() string {
+	return t.id
+}
+
 // UserID returns the user ID from the token
 func (t Token) UserID() string {
 	return t.userID
 }
 
+// Scopes returns the scopes granted to the token
+func (t Token) Scopes() []string {
+	return t.scopes
+}
+
+// HasScope checks if the token was granted a scope
+func (t Token) HasScope(scope string) bool {
+	return slices.Contains(t.scopes, scope)
+}
+
+// Tier returns the rate limit tier of the token's client, empty for tokens issued without one
+func (t Token) Tier() string {
+	return t.tier
+}
+
 // IssuedAt returns when the token was issued
 func (t Token) IssuedAt() time.Time {
 	return t.issuedAt
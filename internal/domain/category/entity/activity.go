@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"upwork-test/internal/domain/category/valueobject"
+)
+
+// CategoryActivity holds trading activity metrics for every open market in a category,
+// computed by the worker from the full market set and a rolling window of trades
+type CategoryActivity struct {
+	CategoryName         valueobject.CategoryName `json:"category_name"`
+	Window               time.Duration            `json:"window"`
+	TotalMarkets         int                      `json:"total_markets"`
+	TotalVolume24h       int64                    `json:"total_volume_24h"`
+	AverageLiquidity     float64                  `json:"average_liquidity"`
+	TotalOpenInterest    int64                    `json:"total_open_interest"`
+	MedianSpread         float64                  `json:"median_spread"`          // Cents, over two-sided books
+	TradeCount           int                      `json:"trade_count"`            // Distinct trade IDs in the window
+	ContractsTraded      int64                    `json:"contracts_traded"`       // Sum of trade quantities
+	NotionalTraded       float64                  `json:"notional_traded"`        // Cents, valued at the YES price
+	MarketsWithTrades    int                      `json:"markets_with_trades"`    // Markets with at least one trade
+	MarketsWithVolume    int                      `json:"markets_with_volume"`    // Markets Kalshi reports 24h volume for
+	MarketsTradesTracked int                      `json:"markets_trades_tracked"` // Markets with volume whose trades have been fetched
+	ComputedAt           time.Time                `json:"computed_at"`
+}
+
+// TradeCoverage returns the share of markets with 24h volume whose trades are included (0-1).
+// Trade metrics are lower bounds until coverage reaches 1.
+func (a *CategoryActivity) TradeCoverage() float64 {
+	if a.MarketsWithVolume == 0 {
+		return 1
+	}
+	return float64(a.MarketsTradesTracked) / float64(a.MarketsWithVolume)
+}
+
+// IsStale checks if the metrics are older than maxAge
+func (a *CategoryActivity) IsStale(maxAge time.Duration) bool {
+	return time.Since(a.ComputedAt) > maxAge
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"time"
+
+	"upwork-test/internal/domain/category/valueobject"
+)
+
+// MarketMover summarizes a market's price movement and activity over a window.
+// Prices, changes and spreads are in cents.
+type MarketMover struct {
+	Ticker             string   `json:"ticker"`
+	Title              string   `json:"title"`
+	Category           string   `json:"category"`
+	LastPrice          float64  `json:"last_price"`
+	PreviousPrice      *float64 `json:"previous_price,omitempty"`       // nil when no baseline price is known
+	PriceChange        *float64 `json:"price_change,omitempty"`         // nil when no baseline price is known
+	PriceChangePercent *float64 `json:"price_change_percent,omitempty"` // Relative to the baseline price
+	Volume24h          int64    `json:"volume_24h"`
+	Spread             *float64 `json:"spread,omitempty"` // nil for one-sided books
+}
+
+// Movers holds the ranked market movers for a window
+type Movers struct {
+	Window         valueobject.MoverWindow `json:"window"`
+	BaselineAt     time.Time               `json:"baseline_at"`
+	Gainers        []MarketMover           `json:"gainers"`
+	Losers         []MarketMover           `json:"losers"`
+	HighestVolume  []MarketMover           `json:"highest_volume"`
+	WidestSpreads  []MarketMover           `json:"widest_spreads"`
+	MarketsScanned int                     `json:"markets_scanned"`
+	ComputedAt     time.Time               `json:"computed_at"`
+}
+
+// Top returns the movers with each list cut to at most limit markets
+func (m *Movers) Top(limit int) *Movers {
+	top := *m
+	top.Gainers = firstMovers(m.Gainers, limit)
+	top.Losers = firstMovers(m.Losers, limit)
+	top.HighestVolume = firstMovers(m.HighestVolume, limit)
+	top.WidestSpreads = firstMovers(m.WidestSpreads, limit)
+	return &top
+}
+
+// firstMovers returns at most limit movers from the front of a list
+func firstMovers(movers []MarketMover, limit int) []MarketMover {
+	if len(movers) > limit {
+		return movers[:limit]
+	}
+	return movers
+}
@@ -14,8 +14,8 @@ var (
 	ErrInvalidTotalVolume = errors.New("total volume cannot be negative")
 	// ErrInvalidAverageLiquidity is returned when average liquidity is negative
 	ErrInvalidAverageLiquidity = errors.New("average liquidity cannot be negative")
-	// ErrInvalidActiveTraders is returned when active traders is negative
-	ErrInvalidActiveTraders = errors.New("active traders cannot be negative")
+	// ErrInvalidTrades24h is returned when the trade count is negative
+	ErrInvalidTrades24h = errors.New("trade count cannot be negative")
 	// ErrInvalidTimeRange is returned when computed time is after expiry time
 	ErrInvalidTimeRange = errors.New("computed time must be before expiry time")
 )
@@ -26,7 +26,10 @@ type CategoryOverview struct {
 	TotalMarkets     int                      `json:"total_markets"`
 	TotalVolume24h   int64                    `json:"total_volume_24h"`
 	AverageLiquidity float64                  `json:"average_liquidity"`
-	ActiveTraders24h int                      `json:"active_traders_24h"`
+	Trades24h        int                      `json:"trades_24h"`
+	PriceChanges24h  []MarketMover            `json:"price_changes_24h"`
+	Movers           *Movers                  `json:"movers,omitempty"`
+	Activity         *CategoryActivity        `json:"activity,omitempty"` // Full-set metrics from the worker's analytics pipeline
 	ComputedAt       time.Time                `json:"computed_at"`
 	ExpiresAt        time.Time                `json:"expires_at"`
 }
@@ -37,7 +40,7 @@ func NewCategoryOverview(
 	totalMarkets int,
 	totalVolume24h int64,
 	averageLiquidity float64,
-	activeTraders24h int,
+	trades24h int,
 	ttl time.Duration,
 ) (*CategoryOverview, error) {
 	now := time.Now()
@@ -53,8 +56,8 @@ func NewCategoryOverview(
 	if averageLiquidity < 0 {
 		return nil, ErrInvalidAverageLiquidity
 	}
-	if activeTraders24h < 0 {
-		return nil, ErrInvalidActiveTraders
+	if trades24h < 0 {
+		return nil, ErrInvalidTrades24h
 	}
 
 	return &CategoryOverview{
@@ -62,7 +65,8 @@ func NewCategoryOverview(
 		TotalMarkets:     totalMarkets,
 		TotalVolume24h:   totalVolume24h,
 		AverageLiquidity: averageLiquidity,
-		ActiveTraders24h: activeTraders24h,
+		Trades24h:        trades24h,
+		PriceChanges24h:  []MarketMover{},
 		ComputedAt:       now,
 		ExpiresAt:        expiresAt,
 	}, nil
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"upwork-test/internal/domain/category/entity"
+)
+
+var (
+	// ErrActivityNotFound is returned when no activity metrics have been computed for a category
+	ErrActivityNotFound = errors.New("category activity not found")
+)
+
+// ActivityRepository defines the interface for category activity metrics computed by the worker.
+type ActivityRepository interface {
+	// Get retrieves the latest activity metrics for a category
+	Get(ctx context.Context, categoryName string) (*entity.CategoryActivity, error)
+
+	// Save stores activity metrics for a category
+	Save(ctx context.Context, activity *entity.CategoryActivity) error
+}
@@ -25,6 +25,9 @@ type CategoryRepository interface {
 	// GetOverview retrieves the overview metrics for a category (cached for 10 minutes)
 	GetOverview(ctx context.Context, categoryName string) (*entity.CategoryOverview, error)
 
+	// RefreshOverview recomputes and caches the overview metrics for a category, bypassing the cache
+	RefreshOverview(ctx context.Context, categoryName string) (*entity.CategoryOverview, error)
+
 	// SaveOverview saves or updates category overview metrics
 	SaveOverview(ctx context.Context, overview *entity.CategoryOverview) error
 }
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"upwork-test/internal/domain/category/entity"
+	"upwork-test/internal/domain/category/valueobject"
+)
+
+var (
+	// ErrMoversNotFound is returned when no movers have been ranked for a window and category
+	ErrMoversNotFound = errors.New("movers not found")
+)
+
+// MoversRepository defines the interface for market movers ranked by the worker.
+// An empty category name stands for the ranking across every category.
+type MoversRepository interface {
+	// Get retrieves the latest movers for a window
+	Get(ctx context.Context, categoryName string, window valueobject.MoverWindow) (*entity.Movers, error)
+
+	// Save stores the movers for their window
+	Save(ctx context.Context, categoryName string, movers *entity.Movers) error
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"upwork-test/internal/domain/category/entity"
+	"upwork-test/internal/domain/category/valueobject"
+	marketentity "upwork-test/internal/domain/market/entity"
+	marketvalueobject "upwork-test/internal/domain/market/valueobject"
+)
+
+// ActivityAnalyzer computes category activity metrics from markets and trades
+type ActivityAnalyzer struct{}
+
+// NewActivityAnalyzer creates a new ActivityAnalyzer service
+func NewActivityAnalyzer() *ActivityAnalyzer {
+	return &ActivityAnalyzer{}
+}
+
+// Analyze computes activity over the window ending now.
+// markets is the full open market set; trades are the trades observed in the window;
+// tracked holds the tickers whose trades have been fetched.
+func (aa *ActivityAnalyzer) Analyze(
+	category valueobject.CategoryName,
+	window time.Duration,
+	markets []*marketentity.Market,
+	trades []*marketentity.Trade,
+	tracked map[string]bool,
+) *entity.CategoryActivity {
+	activity := &entity.CategoryActivity{
+		CategoryName: category,
+		Window:       window,
+		ComputedAt:   time.Now(),
+	}
+
+	var totalLiquidity int64
+	var spreads []float64
+
+	for _, market := range markets {
+		if market == nil || !market.IsOpen() {
+			continue
+		}
+
+		activity.TotalMarkets++
+		activity.TotalVolume24h += market.Volume24h
+		activity.TotalOpenInterest += market.OpenInterest
+		totalLiquidity += market.Liquidity
+
+		if spread, ok := market.Spread(); ok {
+			spreads = append(spreads, spread)
+		}
+
+		if market.Volume24h > 0 {
+			activity.MarketsWithVolume++
+			if tracked[market.Ticker.String()] {
+				activity.MarketsTradesTracked++
+			}
+		}
+	}
+
+	if activity.TotalMarkets > 0 {
+		activity.AverageLiquidity = float64(totalLiquidity) / float64(activity.TotalMarkets)
+	}
+	activity.MedianSpread = median(spreads)
+
+	since := activity.ComputedAt.Add(-window)
+	seen := make(map[string]bool, len(trades))
+	tradedMarkets := make(map[string]bool)
+	var notionalUnits int64
+
+	for _, trade := range trades {
+		if trade == nil || trade.Timestamp.Before(since) || seen[trade.TradeID] {
+			continue
+		}
+		seen[trade.TradeID] = true
+
+		activity.TradeCount++
+		activity.ContractsTraded += int64(trade.Quantity)
+		notionalUnits += trade.Notional()
+		tradedMarkets[trade.Ticker.String()] = true
+	}
+
+	activity.NotionalTraded = float64(notionalUnits) / marketvalueobject.PriceScale
+	activity.MarketsWithTrades = len(tradedMarkets)
+
+	return activity
+}
+
+// median returns the middle value of a list, or 0 when it is empty
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[middle]
+	}
+	return (sorted[middle-1] + sorted[middle]) / 2
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"upwork-test/internal/domain/category/entity"
+	"upwork-test/internal/domain/category/valueobject"
+	marketentity "upwork-test/internal/domain/market/entity"
+	marketvalueobject "upwork-test/internal/domain/market/valueobject"
+)
+
+// Baseline returns the price a market is compared against and whether one is known
+type Baseline func(market *marketentity.Market) (marketvalueobject.Price, bool)
+
+// PreviousDayBaseline compares markets against the last price Kalshi reports from 24 hours ago
+func PreviousDayBaseline(market *marketentity.Market) (marketvalueobject.Price, bool) {
+	return market.PreviousPrice, !market.PreviousPrice.IsZero()
+}
+
+// SnapshotBaseline compares markets against a recorded snapshot of last prices by ticker
+func SnapshotBaseline(prices map[string]marketvalueobject.Price) Baseline {
+	return func(market *marketentity.Market) (marketvalueobject.Price, bool) {
+		price, ok := prices[market.Ticker.String()]
+		return price, ok && !price.IsZero()
+	}
+}
+
+// MoversRanker ranks open markets by price change, volume and spread
+type MoversRanker struct{}
+
+// NewMoversRanker creates a new MoversRanker service
+func NewMoversRanker() *MoversRanker {
+	return &MoversRanker{}
+}
+
+// Changes returns the price change of every open market with a known baseline, ordered by ticker
+func (mr *MoversRanker) Changes(markets []*marketentity.Market, baseline Baseline) []entity.MarketMover {
+	changes := make([]entity.MarketMover, 0, len(markets))
+	for _, market := range markets {
+		if market == nil || !market.IsOpen() {
+			continue
+		}
+
+		mover := mr.toMover(market, baseline)
+		if mover.PriceChange != nil {
+			changes = append(changes, mover)
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Ticker < changes[j].Ticker
+	})
+
+	return changes
+}
+
+// Rank builds the top gainers, losers, highest-volume and widest-spread lists for open markets.
+// Each list holds at most limit markets.
+func (mr *MoversRanker) Rank(
+	window valueobject.MoverWindow,
+	baselineAt time.Time,
+	markets []*marketentity.Market,
+	baseline Baseline,
+	limit int,
+) *entity.Movers {
+	var gainers, losers, volume, spreads []entity.MarketMover
+
+	for _, market := range markets {
+		if market == nil || !market.IsOpen() {
+			continue
+		}
+
+		mover := mr.toMover(market, baseline)
+		if mover.PriceChange != nil {
+			switch {
+			case *mover.PriceChange > 0:
+				gainers = append(gainers, mover)
+			case *mover.PriceChange < 0:
+				losers = append(losers, mover)
+			}
+		}
+		if mover.Volume24h > 0 {
+			volume = append(volume, mover)
+		}
+		if mover.Spread != nil && *mover.Spread > 0 {
+			spreads = append(spreads, mover)
+		}
+	}
+
+	// Ties are broken by volume, then ticker, so rankings are stable between refreshes
+	sortMovers(gainers, func(m entity.MarketMover) float64 { return *m.PriceChange })
+	sortMovers(losers, func(m entity.MarketMover) float64 { return -*m.PriceChange })
+	sortMovers(volume, func(m entity.MarketMover) float64 { return float64(m.Volume24h) })
+	sortMovers(spreads, func(m entity.MarketMover) float64 { return *m.Spread })
+
+	return &entity.Movers{
+		Window:         window,
+		BaselineAt:     baselineAt,
+		Gainers:        truncateMovers(gainers, limit),
+		Losers:         truncateMovers(losers, limit),
+		HighestVolume:  truncateMovers(volume, limit),
+		WidestSpreads:  truncateMovers(spreads, limit),
+		MarketsScanned: len(markets),
+		ComputedAt:     time.Now(),
+	}
+}
+
+// toMover summarizes a market against its baseline price
+func (mr *MoversRanker) toMover(market *marketentity.Market, baseline Baseline) entity.MarketMover {
+	mover := entity.MarketMover{
+		Ticker:    market.Ticker.String(),
+		Title:     market.Title,
+		Category:  market.Category,
+		LastPrice: market.LastPrice.ExactCents(),
+		Volume24h: market.Volume24h,
+	}
+
+	if previous, ok := baseline(market); ok {
+		if change, ok := market.PriceChange(previous); ok {
+			previousCents := previous.ExactCents()
+			percent := change / previousCents * 100
+			mover.PreviousPrice = &previousCents
+			mover.PriceChange = &change
+			mover.PriceChangePercent = &percent
+		}
+	}
+
+	if spread, ok := market.Spread(); ok {
+		mover.Spread = &spread
+	}
+
+	return mover
+}
+
+// sortMovers orders movers by descending score
+func sortMovers(movers []entity.MarketMover, score func(entity.MarketMover) float64) {
+	sort.Slice(movers, func(i, j int) bool {
+		si, sj := score(movers[i]), score(movers[j])
+		if si != sj {
+			return si > sj
+		}
+		if movers[i].Volume24h != movers[j].Volume24h {
+			return movers[i].Volume24h > movers[j].Volume24h
+		}
+		return movers[i].Ticker < movers[j].Ticker
+	})
+}
+
+// truncateMovers returns at most limit movers, never nil so lists encode as []
+func truncateMovers(movers []entity.MarketMover, limit int) []entity.MarketMover {
+	if limit > 0 && len(movers) > limit {
+		movers = movers[:limit]
+	}
+	if movers == nil {
+		return []entity.MarketMover{}
+	}
+	return movers
+}
@@ -0,0 +1,61 @@
+package valueobject
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidMoverWindow is returned when a price change window is not supported
+	ErrInvalidMoverWindow = errors.New("invalid mover window")
+)
+
+// MoverWindow is the lookback period over which market price changes are measured
+type MoverWindow string
+
+const (
+	// MoverWindow1h measures changes against the price snapshot taken an hour ago
+	MoverWindow1h MoverWindow = "1h"
+	// MoverWindow6h measures changes against the price snapshot taken six hours ago
+	MoverWindow6h MoverWindow = "6h"
+	// MoverWindow24h measures changes against Kalshi's previous-day prices
+	MoverWindow24h MoverWindow = "24h"
+)
+
+// NewMoverWindow creates a new MoverWindow value object; an empty value defaults to 24h
+func NewMoverWindow(value string) (MoverWindow, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+
+	switch MoverWindow(normalized) {
+	case "":
+		return MoverWindow24h, nil
+	case MoverWindow1h, MoverWindow6h, MoverWindow24h:
+		return MoverWindow(normalized), nil
+	default:
+		return "", fmt.Errorf("%w: %s (expected 1h, 6h or 24h)", ErrInvalidMoverWindow, value)
+	}
+}
+
+// Duration returns the length of the window
+func (w MoverWindow) Duration() time.Duration {
+	switch w {
+	case MoverWindow1h:
+		return time.Hour
+	case MoverWindow6h:
+		return 6 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// UsesPreviousDayPrices checks if the window is served by the previous-day prices Kalshi publishes
+func (w MoverWindow) UsesPreviousDayPrices() bool {
+	return w == MoverWindow24h
+}
+
+// String returns the string representation of the window
+func (w MoverWindow) String() string {
+	return string(w)
+}
@@ -0,0 +1,42 @@
+package entity
+
+import "time"
+
+// EndpointDrift holds schema drift counters for a single upstream endpoint
+type EndpointDrift struct {
+	Endpoint      string           `json:"endpoint"`
+	Responses     int64            `json:"responses"`
+	DecodeErrors  int64            `json:"decode_errors"`
+	UnknownFields map[string]int64 `json:"unknown_fields"`
+	MissingFields map[string]int64 `json:"missing_fields"`
+}
+
+// HasDrift returns true if the endpoint returned fields we do not model or omitted ones we expect
+func (e *EndpointDrift) HasDrift() bool {
+	return e.DecodeErrors > 0 || len(e.UnknownFields) > 0 || len(e.MissingFields) > 0
+}
+
+// SchemaReport is a snapshot of upstream schema diagnostics collected by one process
+type SchemaReport struct {
+	Source         string           `json:"source"`
+	StrictDecoding bool             `json:"strict_decoding"`
+	Endpoints      []EndpointDrift  `json:"endpoints"`
+	Fallbacks      map[string]int64 `json:"fallbacks"`
+	OutOfRange     map[string]int64 `json:"out_of_range"`
+	StartedAt      time.Time        `json:"started_at"`
+	LastDriftAt    time.Time        `json:"last_drift_at,omitempty"`
+	GeneratedAt    time.Time        `json:"generated_at"`
+}
+
+// HasDrift returns true if any endpoint drifted or the mapper had to fall back
+func (r *SchemaReport) HasDrift() bool {
+	if len(r.Fallbacks) > 0 || len(r.OutOfRange) > 0 {
+		return true
+	}
+	for i := range r.Endpoints {
+		if r.Endpoints[i].HasDrift() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"upwork-test/internal/domain/diagnostics/entity"
+)
+
+// SchemaReportRepository defines the interface for sharing schema reports between processes.
+type SchemaReportRepository interface {
+	// Save stores the latest report for its source, replacing any previous one
+	Save(ctx context.Context, report *entity.SchemaReport) error
+
+	// List retrieves the latest report from every source
+	List(ctx context.Context) ([]*entity.SchemaReport, error)
+}
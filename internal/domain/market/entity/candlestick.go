@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"upwork-test/internal/domain/market/valueobject"
+)
+
+// Candlestick summarizes a market over one period: traded prices, the closing YES
+// quotes, and the volume and open interest at the end of the period
+type Candlestick struct {
+	Ticker       valueobject.Ticker
+	PeriodEnd    time.Time
+	Open         valueobject.Price
+	High         valueobject.Price
+	Low          valueobject.Price
+	Close        valueobject.Price
+	HasTrades    bool // Open, High, Low and Close are zero when nothing traded in the period
+	YesBidClose  valueobject.Price
+	YesAskClose  valueobject.Price
+	Volume       int64
+	OpenInterest int64
+}
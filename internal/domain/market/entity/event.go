@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"time"
+)
+
+// Event represents a Kalshi event: a question answered by one or more markets
+type Event struct {
+	EventTicker       string    `json:"event_ticker"`
+	SeriesTicker      string    `json:"series_ticker"`
+	Title             string    `json:"title"`
+	SubTitle          string    `json:"sub_title,omitempty"`
+	Category          string    `json:"category"`
+	MutuallyExclusive bool      `json:"mutually_exclusive"`
+	Markets           []*Market `json:"markets"`
+	LastUpdated       time.Time `json:"last_updated"`
+}
+
+// NewEvent creates a new Event entity
+func NewEvent(eventTicker, seriesTicker, title, category string, mutuallyExclusive bool) *Event {
+	return &Event{
+		EventTicker:       eventTicker,
+		SeriesTicker:      seriesTicker,
+		Title:             title,
+		Category:          category,
+		MutuallyExclusive: mutuallyExclusive,
+		Markets:           []*Market{},
+		LastUpdated:       time.Now(),
+	}
+}
+
+// OpenMarkets returns the markets that can still be traded
+func (e *Event) OpenMarkets() []*Market {
+	open := make([]*Market, 0, len(e.Markets))
+	for _, market := range e.Markets {
+		if market.IsOpen() {
+			open = append(open, market)
+		}
+	}
+	return open
+}
+
+// RangedGroup returns the markets of the range group groupTicker: the markets Kalshi tagged
+// with that group, or every market when groupTicker names this event and its markets are
+// mutually exclusive. Other events' markets are independent questions, not a range group.
+func (e *Event) RangedGroup(groupTicker string) []*Market {
+	wholeEvent := e.MutuallyExclusive && groupTicker == e.EventTicker
+
+	group := make([]*Market, 0, len(e.Markets))
+	for _, market := range e.Markets {
+		if wholeEvent || market.RangedGroupTicker == groupTicker {
+			group = append(group, market)
+		}
+	}
+	return group
+}
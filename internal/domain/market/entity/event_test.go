@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"upwork-test/internal/domain/market/valueobject"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eventMarket returns an open market of eventTicker, tagged with a range group if rangedGroup is set
+func eventMarket(t *testing.T, ticker, eventTicker, rangedGroup string) *Market {
+	t.Helper()
+
+	tickerVO, err := valueobject.NewTicker(ticker)
+	require.NoError(t, err)
+
+	market := NewMarket(tickerVO, ticker, "Climate", time.Now(), time.Now().Add(time.Hour), MarketStatusOpen)
+	market.EventTicker = eventTicker
+	market.RangedGroupTicker = rangedGroup
+	return market
+}
+
+func TestEventRangedGroup(t *testing.T) {
+	tests := []struct {
+		name              string
+		mutuallyExclusive bool
+		markets           [][2]string // ticker, ranged group ticker
+		groupTicker       string
+		want              []string
+	}{
+		{
+			name:              "mutually exclusive event by event ticker",
+			mutuallyExclusive: true,
+			markets:           [][2]string{{"EV-T44", ""}, {"EV-B45", ""}, {"EV-T47", ""}},
+			groupTicker:       "EV",
+			want:              []string{"EV-T44", "EV-B45", "EV-T47"},
+		},
+		{
+			name:        "independent event by event ticker",
+			markets:     [][2]string{{"EV-A", ""}, {"EV-B", ""}},
+			groupTicker: "EV",
+			want:        []string{},
+		},
+		{
+			name:        "ranged markets of an independent event",
+			markets:     [][2]string{{"EV-B45", "EV"}, {"EV-B47", "EV"}, {"EV-OTHER", ""}},
+			groupTicker: "EV",
+			want:        []string{"EV-B45", "EV-B47"},
+		},
+		{
+			name:              "one of several tagged groups",
+			mutuallyExclusive: true,
+			markets:           [][2]string{{"EV-HIGH-B45", "EV-HIGH"}, {"EV-LOW-B30", "EV-LOW"}, {"EV-HIGH-B47", "EV-HIGH"}},
+			groupTicker:       "EV-HIGH",
+			want:              []string{"EV-HIGH-B45", "EV-HIGH-B47"},
+		},
+		{
+			name:              "unknown group",
+			mutuallyExclusive: true,
+			markets:           [][2]string{{"EV-B45", ""}},
+			groupTicker:       "OTHER",
+			want:              []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := NewEvent("EV", "SERIES", "Event", "Climate", tt.mutuallyExclusive)
+			for _, market := range tt.markets {
+				event.Markets = append(event.Markets, eventMarket(t, market[0], "EV", market[1]))
+			}
+
+			got := make([]string, 0)
+			for _, market := range event.RangedGroup(tt.groupTicker) {
+				got = append(got, market.Ticker.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
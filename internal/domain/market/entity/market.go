@@ -5,32 +5,32 @@ import (
 	"upwork-test/internal/domain/market/valueobject"
 )
 
-// MarketStatus represents the status of a market
-type MarketStatus string
-
-const (
-	MarketStatusOpen    MarketStatus = "open"
-	MarketStatusClosed  MarketStatus = "closed"
-	MarketStatusSettled MarketStatus = "settled"
-)
-
 // Market represents a prediction market
 type Market struct {
-	Ticker      valueobject.Ticker `json:"ticker"`
-	Title       string             `json:"title"`
-	Category    string             `json:"category"`
-	OpenTime    time.Time          `json:"open_time"`
-	CloseTime   time.Time          `json:"close_time"`
-	Status      MarketStatus       `json:"status"`
-	YesAsk      valueobject.Price  `json:"yes_ask"`
-	YesBid      valueobject.Price  `json:"yes_bid"`
-	NoAsk       valueobject.Price  `json:"no_ask"`
-	NoBid       valueobject.Price  `json:"no_bid"`
-	LastPrice   valueobject.Price  `json:"last_price"`
-	Volume      int64              `json:"volume"`
-	Volume24h   int64              `json:"volume_24h"`
-	Liquidity   int64              `json:"liquidity"`
-	LastUpdated time.Time          `json:"last_updated"`
+	Ticker            valueobject.Ticker  `json:"ticker"`
+	EventTicker       string              `json:"event_ticker,omitempty"`
+	RangedGroupTicker string              `json:"ranged_group_ticker,omitempty"`
+	Title             string              `json:"title"`
+	Subtitle          string              `json:"subtitle,omitempty"`
+	Category          string              `json:"category"`
+	OpenTime          time.Time           `json:"open_time"`
+	CloseTime         time.Time           `json:"close_time"`
+	Status            MarketStatus        `json:"status"`
+	YesAsk            valueobject.Price   `json:"yes_ask"`
+	YesBid            valueobject.Price   `json:"yes_bid"`
+	NoAsk             valueobject.Price   `json:"no_ask"`
+	NoBid             valueobject.Price   `json:"no_bid"`
+	LastPrice         valueobject.Price   `json:"last_price"`
+	PreviousYesAsk    valueobject.Price   `json:"previous_yes_ask"` // 24 hours ago
+	PreviousYesBid    valueobject.Price   `json:"previous_yes_bid"` // 24 hours ago
+	PreviousPrice     valueobject.Price   `json:"previous_price"`   // Last price 24 hours ago
+	Volume            int64               `json:"volume"`
+	Volume24h         int64               `json:"volume_24h"`
+	Liquidity         int64               `json:"liquidity"`
+	OpenInterest      int64               `json:"open_interest"` // Contracts outstanding
+	Strike            *valueobject.Strike `json:"strike,omitempty"`
+	Settlement        *Settlement         `json:"settlement,omitempty"`
+	LastUpdated       time.Time           `json:"last_updated"`
 }
 
 // NewMarket creates a new Market entity
@@ -53,12 +53,49 @@ func NewMarket(
 	}
 }
 
-// IsOpen checks if the market is currently open
+// IsOpen checks if the market is currently open for trading
 func (m *Market) IsOpen() bool {
-	return m.Status == MarketStatusOpen
+	return m.Status.IsTradable()
 }
 
-// IsClosed checks if the market is closed
+// IsClosed checks if the market has stopped trading for good
 func (m *Market) IsClosed() bool {
-	return m.Status == MarketStatusClosed || m.Status == MarketStatusSettled
+	return m.Status.IsClosed()
+}
+
+// IsResolved checks if the market outcome has been determined
+func (m *Market) IsResolved() bool {
+	return m.Status.IsResolved()
+}
+
+// HasStrike checks if the market resolves against a strike threshold or range
+func (m *Market) HasStrike() bool {
+	return m.Strike != nil && !m.Strike.IsZero()
+}
+
+// IsSettled checks if the market has a settlement result
+func (m *Market) IsSettled() bool {
+	return m.Settlement != nil
+}
+
+// PriceChange returns the move in cents of the last price since a baseline price.
+// ok is false when either price is unknown (zero), as for markets that have not traded.
+func (m *Market) PriceChange(baseline valueobject.Price) (change float64, ok bool) {
+	if m.LastPrice.IsZero() || baseline.IsZero() {
+		return 0, false
+	}
+	return m.LastPrice.ExactCents() - baseline.ExactCents(), true
+}
+
+// PriceChange24h returns the move in cents of the last price over the past 24 hours
+func (m *Market) PriceChange24h() (float64, bool) {
+	return m.PriceChange(m.PreviousPrice)
+}
+
+// Spread returns the gap in cents between the best YES bid and ask; ok is false for one-sided books
+func (m *Market) Spread() (spread float64, ok bool) {
+	if m.YesBid.IsZero() || m.YesAsk.IsZero() || m.YesAsk.LessThan(m.YesBid) {
+		return 0, false
+	}
+	return m.YesAsk.ExactCents() - m.YesBid.ExactCents(), true
 }
@@ -0,0 +1,141 @@
+package entity
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"upwork-test/internal/domain/market/valueobject"
+)
+
+// MarketFilter narrows a market listing. Zero-valued fields do not filter.
+type MarketFilter struct {
+	Status       MarketStatus
+	MinPrice     *valueobject.Price // Inclusive, on the last traded YES price
+	MaxPrice     *valueobject.Price // Inclusive, on the last traded YES price
+	ClosesAfter  time.Time          // Inclusive
+	ClosesBefore time.Time          // Exclusive
+	MinLiquidity int64
+	EventTicker  string
+}
+
+// IsZero checks if the filter matches every market
+func (f MarketFilter) IsZero() bool {
+	return f == MarketFilter{}
+}
+
+// Matches checks if a market passes every filter
+func (f MarketFilter) Matches(market *Market) bool {
+	if f.Status != "" && !market.Status.Matches(f.Status) {
+		return false
+	}
+	if f.MinPrice != nil && market.LastPrice.Units() < f.MinPrice.Units() {
+		return false
+	}
+	if f.MaxPrice != nil && market.LastPrice.Units() > f.MaxPrice.Units() {
+		return false
+	}
+	if !f.ClosesAfter.IsZero() && market.CloseTime.Before(f.ClosesAfter) {
+		return false
+	}
+	if !f.ClosesBefore.IsZero() && !market.CloseTime.Before(f.ClosesBefore) {
+		return false
+	}
+	if market.Liquidity < f.MinLiquidity {
+		return false
+	}
+	if f.EventTicker != "" && !strings.EqualFold(market.EventTicker, f.EventTicker) {
+		return false
+	}
+	return true
+}
+
+// FilterMarkets returns the markets matching the filter, preserving their order
+func FilterMarkets(markets []*Market, filter MarketFilter) []*Market {
+	if filter.IsZero() {
+		return markets
+	}
+
+	filtered := make([]*Market, 0, len(markets))
+	for _, market := range markets {
+		if filter.Matches(market) {
+			filtered = append(filtered, market)
+		}
+	}
+	return filtered
+}
+
+// MarketSortKey is the value a market is ordered by in a sorted listing.
+// Missing keys (a market without a spread) sort after every present key.
+type MarketSortKey struct {
+	Value   int64 `json:"v"`
+	Missing bool  `json:"m,omitempty"`
+}
+
+// SortKey returns the value a market is ordered by for a sort field
+func (m *Market) SortKey(field valueobject.MarketSortField) MarketSortKey {
+	switch field {
+	case valueobject.MarketSortVolume24h:
+		return MarketSortKey{Value: m.Volume24h}
+	case valueobject.MarketSortCloseTime:
+		return MarketSortKey{Value: m.CloseTime.UnixNano()}
+	case valueobject.MarketSortLiquidity:
+		return MarketSortKey{Value: m.Liquidity}
+	case valueobject.MarketSortPrice:
+		return MarketSortKey{Value: m.LastPrice.Units()}
+	case valueobject.MarketSortSpread:
+		if _, ok := m.Spread(); !ok {
+			return MarketSortKey{Missing: true}
+		}
+		return MarketSortKey{Value: m.YesAsk.Units() - m.YesBid.Units()}
+	default:
+		return MarketSortKey{}
+	}
+}
+
+// CompareMarketPositions compares two positions in a sorted listing, each a sort key
+// and a ticker. Ties on the key are broken by ticker so every position is unique.
+func CompareMarketPositions(keyA MarketSortKey, tickerA string, keyB MarketSortKey, tickerB string, order valueobject.MarketSort) int {
+	if keyA.Missing != keyB.Missing {
+		if keyA.Missing {
+			return 1
+		}
+		return -1
+	}
+
+	cmp := compareInt64(keyA.Value, keyB.Value)
+	if order.IsDescending() {
+		cmp = -cmp
+	}
+	if cmp != 0 {
+		return cmp
+	}
+	return strings.Compare(tickerA, tickerB)
+}
+
+// SortMarkets orders markets in place. Ties are broken by ticker so pages are stable,
+// and markets without a spread (a missing bid or ask) sort last in either direction.
+func SortMarkets(markets []*Market, order valueobject.MarketSort) {
+	if order.IsZero() {
+		return
+	}
+
+	sort.SliceStable(markets, func(i, j int) bool {
+		a, b := markets[i], markets[j]
+		return CompareMarketPositions(
+			a.SortKey(order.Field), a.Ticker.String(),
+			b.SortKey(order.Field), b.Ticker.String(),
+			order,
+		) < 0
+	})
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,149 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidMarketStatus is returned when a status is not part of the market lifecycle
+	ErrInvalidMarketStatus = errors.New("invalid market status")
+)
+
+// MarketStatus represents the status of a market in Kalshi's lifecycle
+type MarketStatus string
+
+const (
+	MarketStatusInitialized MarketStatus = "initialized"
+	MarketStatusUnopened    MarketStatus = "unopened"
+	MarketStatusOpen        MarketStatus = "open"
+	MarketStatusActive      MarketStatus = "active"
+	MarketStatusInactive    MarketStatus = "inactive"
+	MarketStatusClosed      MarketStatus = "closed"
+	MarketStatusDetermined  MarketStatus = "determined"
+	MarketStatusDisputed    MarketStatus = "disputed"
+	MarketStatusAmended     MarketStatus = "amended"
+	MarketStatusSettled     MarketStatus = "settled"
+	MarketStatusFinalized   MarketStatus = "finalized"
+
+	// MarketStatusUnknown stands for a status Kalshi sent that is not part of the lifecycle.
+	// It is not a valid status, so it cannot be filtered on, and no transition is recorded for it.
+	MarketStatusUnknown MarketStatus = "unknown"
+)
+
+// marketStatusLifecycle lists every status in lifecycle order
+var marketStatusLifecycle = []MarketStatus{
+	MarketStatusInitialized,
+	MarketStatusUnopened,
+	MarketStatusOpen,
+	MarketStatusActive,
+	MarketStatusInactive,
+	MarketStatusClosed,
+	MarketStatusDetermined,
+	MarketStatusDisputed,
+	MarketStatusAmended,
+	MarketStatusSettled,
+	MarketStatusFinalized,
+}
+
+// marketStatusTransitions defines the statuses a market may move to from each status.
+// Kalshi uses "open" and "active" interchangeably for tradable markets, so both are treated alike.
+var marketStatusTransitions = map[MarketStatus][]MarketStatus{
+	MarketStatusInitialized: {MarketStatusUnopened, MarketStatusOpen, MarketStatusActive, MarketStatusInactive, MarketStatusClosed},
+	MarketStatusUnopened:    {MarketStatusOpen, MarketStatusActive, MarketStatusInactive, MarketStatusClosed},
+	MarketStatusOpen:        {MarketStatusActive, MarketStatusInactive, MarketStatusClosed, MarketStatusDetermined},
+	MarketStatusActive:      {MarketStatusOpen, MarketStatusInactive, MarketStatusClosed, MarketStatusDetermined},
+	MarketStatusInactive:    {MarketStatusOpen, MarketStatusActive, MarketStatusClosed, MarketStatusDetermined},
+	MarketStatusClosed:      {MarketStatusOpen, MarketStatusActive, MarketStatusDetermined, MarketStatusSettled},
+	MarketStatusDetermined:  {MarketStatusDisputed, MarketStatusAmended, MarketStatusSettled, MarketStatusFinalized},
+	MarketStatusDisputed:    {MarketStatusDetermined, MarketStatusAmended, MarketStatusSettled, MarketStatusFinalized},
+	MarketStatusAmended:     {MarketStatusDisputed, MarketStatusSettled, MarketStatusFinalized},
+	MarketStatusSettled:     {MarketStatusFinalized},
+	MarketStatusFinalized:   {},
+}
+
+// ParseMarketStatus converts a status string into a MarketStatus
+func ParseMarketStatus(value string) (MarketStatus, error) {
+	status := MarketStatus(strings.ToLower(strings.TrimSpace(value)))
+	if !status.IsValid() {
+		return "", fmt.Errorf("%w: %s", ErrInvalidMarketStatus, value)
+	}
+	return status, nil
+}
+
+// AllMarketStatuses returns every market status in lifecycle order
+func AllMarketStatuses() []MarketStatus {
+	statuses := make([]MarketStatus, len(marketStatusLifecycle))
+	copy(statuses, marketStatusLifecycle)
+	return statuses
+}
+
+// IsValid checks if the status is part of the market lifecycle
+func (s MarketStatus) IsValid() bool {
+	_, ok := marketStatusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo checks if the lifecycle allows moving from this status to next
+func (s MarketStatus) CanTransitionTo(next MarketStatus) bool {
+	for _, allowed := range marketStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTradable checks if orders can currently be placed on the market
+func (s MarketStatus) IsTradable() bool {
+	return s == MarketStatusOpen || s == MarketStatusActive
+}
+
+// IsPreOpen checks if the market has been created but has not opened yet
+func (s MarketStatus) IsPreOpen() bool {
+	return s == MarketStatusInitialized || s == MarketStatusUnopened
+}
+
+// IsSettled checks if the market has settled and its result is final
+func (s MarketStatus) IsSettled() bool {
+	return s == MarketStatusSettled || s == MarketStatusFinalized
+}
+
+// IsResolved checks if the market outcome has been determined
+func (s MarketStatus) IsResolved() bool {
+	switch s {
+	case MarketStatusDetermined, MarketStatusAmended, MarketStatusSettled, MarketStatusFinalized:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsClosed checks if trading has ended, whether or not the outcome is known yet
+func (s MarketStatus) IsClosed() bool {
+	return s == MarketStatusClosed || s == MarketStatusDisputed || s.IsResolved()
+}
+
+// IsTerminal checks if no further transitions are possible
+func (s MarketStatus) IsTerminal() bool {
+	return s == MarketStatusFinalized
+}
+
+// Matches checks if the status satisfies a status filter.
+// "open" also matches "active" and "settled" also matches "finalized", mirroring Kalshi's own filters.
+func (s MarketStatus) Matches(filter MarketStatus) bool {
+	switch filter {
+	case MarketStatusOpen:
+		return s.IsTradable()
+	case MarketStatusSettled:
+		return s == MarketStatusSettled || s == MarketStatusFinalized
+	default:
+		return s == filter
+	}
+}
+
+// String returns the status as a string
+func (s MarketStatus) String() string {
+	return string(s)
+}
@@ -0,0 +1,152 @@
+package entity
+
+import (
+	"testing"
+
+	"upwork-test/internal/domain/market/valueobject"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMarketStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    MarketStatus
+		wantErr bool
+	}{
+		{name: "lowercase", value: "open", want: MarketStatusOpen},
+		{name: "mixed case and spaces", value: " Finalized ", want: MarketStatusFinalized},
+		{name: "unknown status", value: "halted", wantErr: true},
+		{name: "unknown placeholder", value: "unknown", wantErr: true},
+		{name: "empty", value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := ParseMarketStatus(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidMarketStatus)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, status)
+		})
+	}
+}
+
+func TestMarketStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name string
+		from MarketStatus
+		to   MarketStatus
+		want bool
+	}{
+		// The regular lifecycle
+		{name: "initialized to open", from: MarketStatusInitialized, to: MarketStatusOpen, want: true},
+		{name: "open to closed", from: MarketStatusOpen, to: MarketStatusClosed, want: true},
+		{name: "closed to determined", from: MarketStatusClosed, to: MarketStatusDetermined, want: true},
+		{name: "determined to settled", from: MarketStatusDetermined, to: MarketStatusSettled, want: true},
+		{name: "settled to finalized", from: MarketStatusSettled, to: MarketStatusFinalized, want: true},
+		// Kalshi switches between open and active freely, and may reopen a closed market
+		{name: "open to active", from: MarketStatusOpen, to: MarketStatusActive, want: true},
+		{name: "active to open", from: MarketStatusActive, to: MarketStatusOpen, want: true},
+		{name: "closed to open", from: MarketStatusClosed, to: MarketStatusOpen, want: true},
+		// Disputes go back to determination or amendment
+		{name: "disputed to determined", from: MarketStatusDisputed, to: MarketStatusDetermined, want: true},
+		{name: "amended to disputed", from: MarketStatusAmended, to: MarketStatusDisputed, want: true},
+
+		// Illegal transitions
+		{name: "same status", from: MarketStatusOpen, to: MarketStatusOpen},
+		{name: "initialized straight to settled", from: MarketStatusInitialized, to: MarketStatusSettled},
+		{name: "open straight to settled", from: MarketStatusOpen, to: MarketStatusSettled},
+		{name: "open straight to finalized", from: MarketStatusOpen, to: MarketStatusFinalized},
+		{name: "open back to unopened", from: MarketStatusOpen, to: MarketStatusUnopened},
+		{name: "determined back to open", from: MarketStatusDetermined, to: MarketStatusOpen},
+		{name: "settled back to open", from: MarketStatusSettled, to: MarketStatusOpen},
+		{name: "settled back to disputed", from: MarketStatusSettled, to: MarketStatusDisputed},
+		{name: "finalized is terminal", from: MarketStatusFinalized, to: MarketStatusSettled},
+		{name: "from unknown", from: MarketStatusUnknown, to: MarketStatusOpen},
+		{name: "to unknown", from: MarketStatusOpen, to: MarketStatusUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestMarketStatusLifecycle(t *testing.T) {
+	statuses := AllMarketStatuses()
+	require.NotEmpty(t, statuses)
+
+	for _, status := range statuses {
+		assert.True(t, status.IsValid(), "%s is in the lifecycle", status)
+		assert.False(t, status.CanTransitionTo(status), "%s cannot move to itself", status)
+		assert.Equal(t, status == MarketStatusFinalized, status.IsTerminal(), "%s terminal", status)
+
+		// Nothing leaves a terminal status, and every other status has somewhere to go
+		if status.IsTerminal() {
+			for _, next := range statuses {
+				assert.False(t, status.CanTransitionTo(next), "%s -> %s", status, next)
+			}
+		} else {
+			assert.NotEmpty(t, marketStatusTransitions[status], "%s has no transitions", status)
+		}
+	}
+
+	assert.False(t, MarketStatusUnknown.IsValid())
+}
+
+func TestNewStatusTransition(t *testing.T) {
+	ticker, err := valueobject.NewTicker("KXTEST-24DEC31")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		from         MarketStatus
+		to           MarketStatus
+		wantExpected bool
+	}{
+		{name: "lifecycle step", from: MarketStatusClosed, to: MarketStatusSettled, wantExpected: true},
+		{name: "skipped closing", from: MarketStatusActive, to: MarketStatusSettled},
+		{name: "reopened after settling", from: MarketStatusSettled, to: MarketStatusActive},
+		{name: "left finalized", from: MarketStatusFinalized, to: MarketStatusAmended},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transition := NewStatusTransition(ticker, tt.from, tt.to)
+
+			// Illegal transitions are still recorded, flagged as unexpected
+			assert.Equal(t, tt.from, transition.From)
+			assert.Equal(t, tt.to, transition.To)
+			assert.Equal(t, tt.wantExpected, transition.Expected)
+			assert.False(t, transition.ObservedAt.IsZero())
+		})
+	}
+}
+
+func TestMarketStatusMatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		status MarketStatus
+		filter MarketStatus
+		want   bool
+	}{
+		{name: "open matches open", status: MarketStatusOpen, filter: MarketStatusOpen, want: true},
+		{name: "active matches open", status: MarketStatusActive, filter: MarketStatusOpen, want: true},
+		{name: "finalized matches settled", status: MarketStatusFinalized, filter: MarketStatusSettled, want: true},
+		{name: "closed does not match open", status: MarketStatusClosed, filter: MarketStatusOpen},
+		{name: "open does not match active", status: MarketStatusOpen, filter: MarketStatusActive},
+		{name: "settled does not match finalized", status: MarketStatusSettled, filter: MarketStatusFinalized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.status.Matches(tt.filter))
+		})
+	}
+}
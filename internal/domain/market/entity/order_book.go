@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"sort"
 	"time"
 
 	"upwork-test/internal/domain/market/valueobject"
@@ -43,14 +44,14 @@ func NewOrderBook(
 	}
 }
 
-// Spread returns the difference between best bid and best ask in cents
+// Spread returns the difference between best bid and best ask, each rounded to the nearest cent
 func (ob *OrderBook) Spread() int64 {
 	if len(ob.Bids) == 0 || len(ob.Asks) == 0 {
 		return 0
 	}
 
-	bestBid := ob.Bids[0].Price.Value()
-	bestAsk := ob.Asks[0].Price.Value()
+	bestBid := ob.Bids[0].Price.Round(0).Value()
+	bestAsk := ob.Asks[0].Price.Round(0).Value()
 
 	if bestAsk > bestBid {
 		return bestAsk - bestBid
@@ -104,3 +105,36 @@ func (ob *OrderBook) BestAsk() *valueobject.Price {
 func (ob *OrderBook) IsEmpty() bool {
 	return len(ob.Bids) == 0 && len(ob.Asks) == 0
 }
+
+// YesOffers returns the levels a YES buyer can take, cheapest first.
+// Kalshi books only hold bids: a NO bid at P is an offer to sell YES at 100 − P.
+func (ob *OrderBook) YesOffers() []OrderLevel {
+	return complementLevels(ob.Asks)
+}
+
+// NoOffers returns the levels a NO buyer can take, cheapest first.
+// A YES bid at P is an offer to sell NO at 100 − P.
+func (ob *OrderBook) NoOffers() []OrderLevel {
+	return complementLevels(ob.Bids)
+}
+
+// complementLevels converts bids on one side into offers on the other side
+func complementLevels(bids []OrderLevel) []OrderLevel {
+	offers := make([]OrderLevel, 0, len(bids))
+	for _, level := range bids {
+		if level.Quantity <= 0 {
+			continue
+		}
+		price := level.Price.Complement()
+		if price.IsZero() {
+			continue
+		}
+		offers = append(offers, OrderLevel{Price: price, Quantity: level.Quantity})
+	}
+
+	sort.SliceStable(offers, func(i, j int) bool {
+		return offers[i].Price.LessThan(offers[j].Price)
+	})
+
+	return offers
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"upwork-test/internal/domain/market/valueobject"
+)
+
+// PriceSnapshot records the last traded price of markets at a point in time.
+// Snapshots provide the baseline for price changes over windows Kalshi does not report.
+type PriceSnapshot struct {
+	TakenAt time.Time
+	Prices  map[string]valueobject.Price // Keyed by market ticker
+}
+
+// NewPriceSnapshot creates an empty PriceSnapshot taken now
+func NewPriceSnapshot() *PriceSnapshot {
+	return &PriceSnapshot{
+		TakenAt: time.Now(),
+		Prices:  make(map[string]valueobject.Price),
+	}
+}
+
+// Record adds the last price of markets that have traded
+func (s *PriceSnapshot) Record(markets []*Market) {
+	for _, market := range markets {
+		if market == nil || market.LastPrice.IsZero() {
+			continue
+		}
+		s.Prices[market.Ticker.String()] = market.LastPrice
+	}
+}
+
+// IsEmpty checks if the snapshot holds no prices
+func (s *PriceSnapshot) IsEmpty() bool {
+	return len(s.Prices) == 0
+}
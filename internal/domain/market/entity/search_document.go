@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	"upwork-test/internal/domain/market/valueobject"
+)
+
+// SearchDocument is the searchable view of a market, enriched with its event and series titles
+type SearchDocument struct {
+	Ticker       string            `json:"ticker"`
+	Title        string            `json:"title"`
+	Subtitle     string            `json:"subtitle,omitempty"`
+	EventTicker  string            `json:"event_ticker,omitempty"`
+	EventTitle   string            `json:"event_title,omitempty"`
+	SeriesTicker string            `json:"series_ticker,omitempty"`
+	SeriesTitle  string            `json:"series_title,omitempty"`
+	Category     string            `json:"category"`
+	Status       MarketStatus      `json:"status"`
+	CloseTime    time.Time         `json:"close_time"`
+	Volume24h    int64             `json:"volume_24h"`
+	Liquidity    int64             `json:"liquidity"`
+	LastPrice    valueobject.Price `json:"last_price"`
+}
+
+// NewSearchDocument creates a SearchDocument for a market; event and series may be nil
+func NewSearchDocument(market *Market, event *Event, series *Series) *SearchDocument {
+	doc := &SearchDocument{
+		Ticker:      market.Ticker.String(),
+		Title:       market.Title,
+		Subtitle:    market.Subtitle,
+		EventTicker: market.EventTicker,
+		Category:    market.Category,
+		Status:      market.Status,
+		CloseTime:   market.CloseTime,
+		Volume24h:   market.Volume24h,
+		Liquidity:   market.Liquidity,
+		LastPrice:   market.LastPrice,
+	}
+
+	if event != nil {
+		doc.EventTitle = event.Title
+		doc.SeriesTicker = event.SeriesTicker
+	}
+	if series != nil {
+		doc.SeriesTicker = series.Ticker
+		doc.SeriesTitle = series.Title
+	}
+
+	return doc
+}
+
+// Fingerprint returns a hash of the document's contents, used to skip unchanged documents when reindexing
+func (d *SearchDocument) Fingerprint() string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\x00%s\x00%s\x00%s\x00%s\x00%s\x00%d\x00%d\x00%d\x00%d",
+		d.Ticker, d.Title, d.Subtitle, d.EventTicker, d.EventTitle, d.SeriesTicker, d.SeriesTitle,
+		d.Category, d.Status, d.CloseTime.Unix(), d.Volume24h, d.Liquidity, d.LastPrice.Units())
+	return strconv.FormatUint(h.Sum64(), 16)
+}
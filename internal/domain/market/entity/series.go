@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"strings"
+)
+
+// Series represents a Kalshi series: a recurring template of events, e.g. a monthly CPI print
+type Series struct {
+	Ticker    string `json:"ticker"`
+	Title     string `json:"title"`
+	Category  string `json:"category"`
+	Frequency string `json:"frequency,omitempty"`
+}
+
+// NewSeries creates a new Series entity
+func NewSeries(ticker, title, category, frequency string) *Series {
+	return &Series{
+		Ticker:    ticker,
+		Title:     title,
+		Category:  strings.ToUpper(category),
+		Frequency: frequency,
+	}
+}
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"upwork-test/internal/domain/market/valueobject"
+)
+
+var (
+	// ErrInvalidSettlementResult is returned when a settlement result is not yes, no or void
+	ErrInvalidSettlementResult = errors.New("invalid settlement result")
+)
+
+// SettlementResult represents the resolved outcome of a market
+type SettlementResult string
+
+const (
+	SettlementResultYes  SettlementResult = "yes"
+	SettlementResultNo   SettlementResult = "no"
+	SettlementResultVoid SettlementResult = "void"
+)
+
+// ParseSettlementResult converts a result string into a SettlementResult
+func ParseSettlementResult(value string) (SettlementResult, error) {
+	switch result := SettlementResult(strings.ToLower(strings.TrimSpace(value))); result {
+	case SettlementResultYes, SettlementResultNo, SettlementResultVoid:
+		return result, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrInvalidSettlementResult, value)
+	}
+}
+
+// Settlement represents the resolution of a market
+type Settlement struct {
+	Ticker          valueobject.Ticker `json:"ticker"`
+	Result          SettlementResult   `json:"result"`
+	SettlementValue valueobject.Price  `json:"settlement_value"` // Payout per YES contract in cents
+	ExpirationValue string             `json:"expiration_value,omitempty"`
+	SettledAt       time.Time          `json:"settled_at"`
+}
+
+// NewSettlement creates a new Settlement entity
+func NewSettlement(
+	ticker valueobject.Ticker,
+	result SettlementResult,
+	settlementValue valueobject.Price,
+	expirationValue string,
+	settledAt time.Time,
+) *Settlement {
+	return &Settlement{
+		Ticker:          ticker,
+		Result:          result,
+		SettlementValue: settlementValue,
+		ExpirationValue: expirationValue,
+		SettledAt:       settledAt,
+	}
+}
+
+// IsVoid returns true if the market was voided. A voided market pays out nothing;
+// instead every position is refunded at the price it was bought for.
+func (s *Settlement) IsVoid() bool {
+	return s.Result == SettlementResultVoid
+}
+
+// YesPayout returns the payout per YES contract in cents.
+// ok is false for a voided market, whose contracts are refunded rather than paid out.
+func (s *Settlement) YesPayout() (payout int64, ok bool) {
+	if s.IsVoid() {
+		return 0, false
+	}
+	return s.SettlementValue.Value(), true
+}
+
+// NoPayout returns the payout per NO contract in cents.
+// ok is false for a voided market, whose contracts are refunded rather than paid out.
+func (s *Settlement) NoPayout() (payout int64, ok bool) {
+	if s.IsVoid() {
+		return 0, false
+	}
+	return 100 - s.SettlementValue.Value(), true
+}
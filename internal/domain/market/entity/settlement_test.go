@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"upwork-test/internal/domain/market/valueobject"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSettlementResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    SettlementResult
+		wantErr bool
+	}{
+		{name: "yes", value: "yes", want: SettlementResultYes},
+		{name: "no with spaces", value: " no ", want: SettlementResultNo},
+		{name: "void uppercase", value: "VOID", want: SettlementResultVoid},
+		{name: "empty", value: "", wantErr: true},
+		{name: "unknown", value: "all_no", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseSettlementResult(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidSettlementResult)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func TestSettlementPayout(t *testing.T) {
+	ticker, err := valueobject.NewTicker("KXTEST-24DEC31")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		result SettlementResult
+		value  int64
+		yes    int64
+		no     int64
+		void   bool
+	}{
+		{name: "binary yes", result: SettlementResultYes, value: 100, yes: 100, no: 0},
+		{name: "binary no", result: SettlementResultNo, value: 0, yes: 0, no: 100},
+		{name: "scalar", result: SettlementResultNo, value: 40, yes: 40, no: 60},
+		{name: "void", result: SettlementResultVoid, value: 0, void: true},
+		// A value sent alongside a void result is ignored
+		{name: "void with value", result: SettlementResultVoid, value: 55, void: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := valueobject.NewPrice(tt.value)
+			require.NoError(t, err)
+			settlement := NewSettlement(ticker, tt.result, value, "", time.Now())
+
+			yes, yesOK := settlement.YesPayout()
+			no, noOK := settlement.NoPayout()
+
+			assert.Equal(t, tt.void, settlement.IsVoid())
+			assert.Equal(t, !tt.void, yesOK)
+			assert.Equal(t, !tt.void, noOK)
+			assert.Equal(t, tt.yes, yes)
+			assert.Equal(t, tt.no, no)
+		})
+	}
+}
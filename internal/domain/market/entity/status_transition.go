@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"upwork-test/internal/domain/market/valueobject"
+)
+
+// StatusTransition records an observed change in a market's status
+type StatusTransition struct {
+	Ticker     valueobject.Ticker `json:"ticker"`
+	From       MarketStatus       `json:"from"`
+	To         MarketStatus       `json:"to"`
+	Expected   bool               `json:"expected"` // False if the lifecycle does not allow From -> To
+	ObservedAt time.Time          `json:"observed_at"`
+}
+
+// NewStatusTransition creates a new StatusTransition entity
+func NewStatusTransition(ticker valueobject.Ticker, from MarketStatus, to MarketStatus) *StatusTransition {
+	return &StatusTransition{
+		Ticker:     ticker,
+		From:       from,
+		To:         to,
+		Expected:   from.CanTransitionTo(to),
+		ObservedAt: time.Now(),
+	}
+}
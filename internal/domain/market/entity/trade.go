@@ -53,7 +53,12 @@ func (t *Trade) IsSell() bool {
 	return t.Side == TradeSideSell
 }
 
-// Value returns the total value of the trade in cents (price * quantity)
+// Notional returns the value of the trade at the YES price in fixed-point price units
+func (t *Trade) Notional() int64 {
+	return t.Price.Times(int64(t.Quantity))
+}
+
+// Value returns the total value of the trade in cents (price rounded to the nearest cent * quantity)
 func (t *Trade) Value() int64 {
-	return t.Price.Value() * int64(t.Quantity)
+	return t.Price.Round(0).Value() * int64(t.Quantity)
 }
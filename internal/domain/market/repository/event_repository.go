@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"upwork-test/internal/domain/market/entity"
+)
+
+var (
+	// ErrEventNotFound is returned when an event does not exist
+	ErrEventNotFound = errors.New("event not found")
+)
+
+// EventRepository defines the interface for event data access.
+type EventRepository interface {
+	// GetByTicker retrieves an event and its markets
+	GetByTicker(ctx context.Context, eventTicker string) (*entity.Event, error)
+
+	// ListByStatus retrieves every event with a status across all categories, without markets (cached for 15min)
+	ListByStatus(ctx context.Context, status string) ([]*entity.Event, error)
+}
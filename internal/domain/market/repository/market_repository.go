@@ -3,29 +3,87 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/valueobject"
 )
 
 var (
 	// ErrNotFound is returned when a market is not found
 	ErrNotFound = errors.New("market not found")
+	// ErrCursorExpired is returned when a cursor's position can no longer be located
+	ErrCursorExpired = errors.New("cursor expired")
 )
 
+// MarketQuery filters and orders a category market listing before it is paginated.
+// The zero value lists every market in upstream order.
+type MarketQuery struct {
+	Filter entity.MarketFilter
+	Sort   valueobject.MarketSort
+}
+
+// MarketCursor is a position in a filtered and sorted category listing: the sort key and
+// ticker of the market a page ends at (or, going back, starts at)
+type MarketCursor struct {
+	Version int64 // Snapshot of the category list the cursor was issued against
+	SortKey entity.MarketSortKey
+	Ticker  string
+	Before  bool // The page holds the markets before the position rather than after it
+}
+
+// MarketPageRequest selects one page of a category listing, either at an offset or next to a cursor
+type MarketPageRequest struct {
+	Query  MarketQuery
+	Limit  int
+	Offset int
+	Cursor *MarketCursor
+}
+
+// MarketPage is one page of a category listing
+type MarketPage struct {
+	Markets []*entity.Market
+	Offset  int   // Position of the first market among all matching markets
+	Total   int   // Number of matching markets
+	Version int64 // Snapshot of the category list the page was read from
+}
+
 // MarketRepository defines the interface for market data access.
 type MarketRepository interface {
-	// ListByCategory retrieves markets for a category with pagination
-	ListByCategory(ctx context.Context, category string, page int, limit int, status string) ([]*entity.Market, int, error)
+	// ListByCategory retrieves the markets of a category matching a query with pagination.
+	// The total counts every matching market.
+	ListByCategory(ctx context.Context, category string, page int, limit int, query MarketQuery) ([]*entity.Market, int, error)
+
+	// ListPageByCategory retrieves one page of a category listing. Cursor pages are read from the
+	// snapshot the cursor was issued against while it is retained, so the list cannot shift between
+	// requests; afterwards the position is located in the current list.
+	ListPageByCategory(ctx context.Context, category string, request MarketPageRequest) (*MarketPage, error)
+
+	// ListAllByCategory retrieves every open market in a category across all series (cached for 15min).
+	// It is expensive upstream and meant for background analytics rather than request paths.
+	ListAllByCategory(ctx context.Context, category string) ([]*entity.Market, error)
 
 	// GetByTicker retrieves a single market by ticker
 	GetByTicker(ctx context.Context, ticker string) (*entity.Market, error)
 
-	// GetMultiple retrieves multiple markets by tickers
-	GetMultiple(ctx context.Context, tickers []string) ([]*entity.Market, error)
+	// ListByRangedGroup retrieves every bracket market in a range group
+	ListByRangedGroup(ctx context.Context, groupTicker string) ([]*entity.Market, error)
+
+	// GetMultiple retrieves the cached markets among tickers in a single round trip, keyed by ticker.
+	// Tickers that are not cached are returned as missing, in request order, for the caller to fetch.
+	GetMultiple(ctx context.Context, tickers []string) (found map[string]*entity.Market, missing []string, err error)
 
 	// GetOrderBook retrieves the order book for a market (cached for 30s)
 	GetOrderBook(ctx context.Context, ticker string) (*entity.OrderBook, error)
 
-	// GetRecentTrades retrieves recent trades for a market (cached for 1min)
+	// GetRecentTrades retrieves up to limit recent trades for a market, newest first (cached for 1min)
 	GetRecentTrades(ctx context.Context, ticker string, limit int) ([]*entity.Trade, error)
+
+	// GetCandlesticks retrieves a market's candlesticks over the period's lookback, oldest first (cached for 1min).
+	// Kalshi serves candlesticks per series, so the market's series ticker is required.
+	GetCandlesticks(ctx context.Context, seriesTicker, ticker string, period valueobject.CandlePeriod) ([]*entity.Candlestick, error)
+
+	// GetTradesSince retrieves every trade in a market created at or after since (not cached).
+	// truncated is true when only part of them could be fetched.
+	GetTradesSince(ctx context.Context, ticker string, since time.Time) (trades []*entity.Trade, truncated bool, err error)
 }
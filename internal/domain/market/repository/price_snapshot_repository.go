@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"upwork-test/internal/domain/market/entity"
+)
+
+var (
+	// ErrSnapshotNotFound is returned when no price snapshot covers the requested time
+	ErrSnapshotNotFound = errors.New("price snapshot not found")
+)
+
+// PriceSnapshotRepository defines the interface for historical market price snapshots.
+type PriceSnapshotRepository interface {
+	// Save stores a snapshot; snapshots older than the retention period are discarded
+	Save(ctx context.Context, snapshot *entity.PriceSnapshot) error
+
+	// GetAt retrieves the latest snapshot taken at or before at, and no earlier than at minus tolerance
+	GetAt(ctx context.Context, at time.Time, tolerance time.Duration) (*entity.PriceSnapshot, error)
+}
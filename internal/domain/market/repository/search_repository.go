@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"upwork-test/internal/domain/market/entity"
+)
+
+var (
+	// ErrSearchChangesPruned is returned when the changes after a version are no longer recorded
+	ErrSearchChangesPruned = errors.New("search changes pruned")
+)
+
+// SearchRepository defines the interface for the shared, versioned set of market search documents.
+// Every change bumps the version so search indexes can catch up incrementally.
+type SearchRepository interface {
+	// Apply stores changed documents and removes tickers, returning the new version
+	Apply(ctx context.Context, upserts []*entity.SearchDocument, removals []string) (int64, error)
+
+	// Version returns the current version, 0 if nothing has been indexed
+	Version(ctx context.Context) (int64, error)
+
+	// ChangedSince returns the tickers upserted or removed after a version,
+	// or ErrSearchChangesPruned if that version is older than the recorded changes
+	ChangedSince(ctx context.Context, version int64) ([]string, error)
+
+	// GetAll retrieves every document and the version they reflect
+	GetAll(ctx context.Context) ([]*entity.SearchDocument, int64, error)
+
+	// GetMany retrieves documents by ticker; removed tickers are omitted
+	GetMany(ctx context.Context, tickers []string) ([]*entity.SearchDocument, error)
+
+	// Fingerprints returns the fingerprint of every stored document by ticker
+	Fingerprints(ctx context.Context) (map[string]string, error)
+}
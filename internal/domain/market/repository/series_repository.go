@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"upwork-test/internal/domain/market/entity"
+)
+
+// SeriesRepository defines the interface for series data access.
+type SeriesRepository interface {
+	// ListByCategory retrieves the series of a category (cached for 24h)
+	ListByCategory(ctx context.Context, category string) ([]*entity.Series, error)
+}
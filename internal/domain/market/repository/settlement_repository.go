@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"upwork-test/internal/domain/market/entity"
+)
+
+var (
+	// ErrSettlementNotFound is returned when no settlement is stored for a market
+	ErrSettlementNotFound = errors.New("settlement not found")
+)
+
+// SettlementRepository defines the interface for permanently stored settled markets.
+type SettlementRepository interface {
+	// Get retrieves the settled market for a ticker
+	Get(ctx context.Context, ticker string) (*entity.Market, error)
+
+	// Save stores a settled market permanently, replacing a stored one whose result or settlement
+	// value differs; returns false if the same settlement was already stored
+	Save(ctx context.Context, market *entity.Market) (bool, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"upwork-test/internal/domain/market/entity"
+)
+
+// StatusHistoryRepository defines the interface for tracking market status changes.
+type StatusHistoryRepository interface {
+	// SwapStatus stores the latest observed status and returns the previous one, if any
+	SwapStatus(ctx context.Context, ticker string, status entity.MarketStatus) (previous entity.MarketStatus, found bool, err error)
+
+	// AppendTransition adds a transition to the market's status history
+	AppendTransition(ctx context.Context, transition *entity.StatusTransition) error
+
+	// GetHistory retrieves the most recent transitions for a market, newest first
+	GetHistory(ctx context.Context, ticker string, limit int) ([]*entity.StatusTransition, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"upwork-test/internal/domain/market/entity"
+)
+
+// TradeWindowRepository defines the interface for the rolling window of trades used by analytics.
+// Trades are grouped by category and deduplicated by trade ID.
+type TradeWindowRepository interface {
+	// Add stores trades, ignoring trade IDs already stored; returns the number added
+	Add(ctx context.Context, category string, trades []*entity.Trade) (int, error)
+
+	// ListSince retrieves the stored trades created at or after since
+	ListSince(ctx context.Context, category string, since time.Time) ([]*entity.Trade, error)
+
+	// Prune discards trades created before the given time
+	Prune(ctx context.Context, category string, before time.Time) error
+
+	// GetWatermarks returns, per market ticker, the time up to which trades have been fetched
+	GetWatermarks(ctx context.Context, category string) (map[string]time.Time, error)
+
+	// SetWatermarks records the time up to which trades have been fetched for markets
+	SetWatermarks(ctx context.Context, category string, watermarks map[string]time.Time) error
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/valueobject"
+)
+
+// BasketSide identifies which side of every outcome a basket buys
+type BasketSide string
+
+const (
+	BasketSideYes BasketSide = "yes"
+	BasketSideNo  BasketSide = "no"
+)
+
+// OutcomeProbability is one outcome's share of an event's implied distribution
+type OutcomeProbability struct {
+	Market     *entity.Market
+	Implied    ImpliedProbability
+	Normalized float64
+}
+
+// BasketQuote prices buying the same side of every outcome in a mutually exclusive event.
+// Exactly one outcome resolves YES, so an all-YES basket pays 100¢ per contract set
+// and an all-NO basket pays (n−1)×100¢. All amounts are fixed-point price units
+// (valueobject.PriceScale per cent) for the whole basket.
+type BasketQuote struct {
+	Side      BasketSide
+	Contracts int64
+	Available bool // False when some outcome is not open or has no ask to buy from
+	Cost      int64
+	Fees      int64
+	NetCost   int64
+	Payout    int64
+	Edge      int64 // Payout − NetCost; positive means a riskless profit after fees
+	BelowPar  bool  // Buying the basket costs less than it pays, after fees
+	AbovePar  bool  // Buying the basket costs more than it pays, after fees
+}
+
+// EventDistribution is the implied probability distribution across an event's outcomes
+type EventDistribution struct {
+	Event            *entity.Event
+	Outcomes         []OutcomeProbability
+	TotalProbability float64
+	Overround        float64
+	AllYes           BasketQuote
+	AllNo            BasketQuote
+}
+
+// HasArbitrage returns true if either basket can be bought below par after fees
+func (d *EventDistribution) HasArbitrage() bool {
+	return d.AllYes.BelowPar || d.AllNo.BelowPar
+}
+
+// DistributionAnalyzer computes implied distributions and basket pricing for events
+type DistributionAnalyzer struct {
+	estimator *ProbabilityEstimator
+	fees      valueobject.FeeSchedule
+}
+
+// NewDistributionAnalyzer creates a new DistributionAnalyzer service
+func NewDistributionAnalyzer(estimator *ProbabilityEstimator, fees valueobject.FeeSchedule) *DistributionAnalyzer {
+	return &DistributionAnalyzer{
+		estimator: estimator,
+		fees:      fees,
+	}
+}
+
+// Analyze computes the distribution over the event's tradable outcomes, falling back to
+// every outcome once the event has stopped trading. The baskets always span every outcome,
+// since any of them may still resolve YES; a basket with an outcome that is not open cannot
+// be bought. contracts sizes the basket quotes, since fees are rounded up per order and
+// dominate single-contract baskets.
+func (da *DistributionAnalyzer) Analyze(event *entity.Event, contracts int64) *EventDistribution {
+	if event == nil {
+		return nil
+	}
+	if contracts < 1 {
+		contracts = 1
+	}
+
+	markets := event.OpenMarkets()
+	if len(markets) == 0 {
+		markets = event.Markets
+	}
+
+	outcomes := make([]OutcomeProbability, len(markets))
+	probabilities := make([]float64, len(markets))
+	for i, market := range markets {
+		outcomes[i] = OutcomeProbability{
+			Market:  market,
+			Implied: da.estimator.Estimate(market),
+		}
+		probabilities[i] = outcomes[i].Implied.Probability
+	}
+
+	normalized, total := da.estimator.Normalize(probabilities)
+	for i := range outcomes {
+		outcomes[i].Normalized = normalized[i]
+	}
+
+	distribution := &EventDistribution{
+		Event:            event,
+		Outcomes:         outcomes,
+		TotalProbability: total,
+		Overround:        total - 1,
+	}
+
+	n := int64(len(event.Markets))
+	par := valueobject.MaxPrice()
+	distribution.AllYes = da.quoteBasket(BasketSideYes, event.Markets, contracts, par.Times(contracts))
+	distribution.AllNo = da.quoteBasket(BasketSideNo, event.Markets, contracts, par.Times((n-1)*contracts))
+
+	// Basket payouts only hold when exactly one outcome can resolve YES
+	if !event.MutuallyExclusive {
+		distribution.AllYes.BelowPar, distribution.AllYes.AbovePar = false, false
+		distribution.AllNo.BelowPar, distribution.AllNo.AbovePar = false, false
+	}
+
+	return distribution
+}
+
+// quoteBasket prices taking the ask on one side of every market, paying the taker fee on each leg
+func (da *DistributionAnalyzer) quoteBasket(side BasketSide, markets []*entity.Market, contracts, payout int64) BasketQuote {
+	quote := BasketQuote{
+		Side:      side,
+		Contracts: contracts,
+		Available: len(markets) > 0,
+		Payout:    payout,
+	}
+
+	for _, market := range markets {
+		ask := market.YesAsk
+		if side == BasketSideNo {
+			ask = market.NoAsk
+		}
+
+		if !market.IsOpen() || ask.IsZero() {
+			quote.Available = false
+			continue
+		}
+
+		quote.Cost += ask.Times(contracts)
+		quote.Fees += da.fees.TakerFee(ask, contracts) * valueobject.PriceScale
+	}
+
+	quote.NetCost = quote.Cost + quote.Fees
+	quote.Edge = quote.Payout - quote.NetCost
+
+	if quote.Available {
+		quote.BelowPar = quote.NetCost < quote.Payout
+		quote.AbovePar = quote.NetCost > quote.Payout
+	}
+
+	return quote
+}
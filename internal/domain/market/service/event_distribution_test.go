@@ -0,0 +1,267 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/valueobject"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// quotedMarket returns a market with a YES bid and ask in cents; the NO ask mirrors the YES bid
+func quotedMarket(t *testing.T, ticker string, bid, ask int64, status entity.MarketStatus) *entity.Market {
+	t.Helper()
+
+	tickerVO, err := valueobject.NewTicker(ticker)
+	require.NoError(t, err)
+
+	market := entity.NewMarket(tickerVO, ticker, "Climate", time.Now(), time.Now().Add(time.Hour), status)
+	market.YesBid = mustPrice(t, bid)
+	market.YesAsk = mustPrice(t, ask)
+	if bid > 0 {
+		market.NoAsk = mustPrice(t, 100-bid)
+	}
+	return market
+}
+
+// mustPrice returns a whole-cent price
+func mustPrice(t *testing.T, cents int64) valueobject.Price {
+	t.Helper()
+
+	price, err := valueobject.NewPrice(cents)
+	require.NoError(t, err)
+	return price
+}
+
+// eventOf returns an event holding markets
+func eventOf(mutuallyExclusive bool, markets ...*entity.Market) *entity.Event {
+	event := entity.NewEvent("KXTEST", "KX", "Test event", "Climate", mutuallyExclusive)
+	event.Markets = markets
+	return event
+}
+
+func TestDistributionAnalyzerNormalization(t *testing.T) {
+	open := entity.MarketStatusOpen
+	closed := entity.MarketStatusClosed
+
+	tests := []struct {
+		name           string
+		markets        func(t *testing.T) []*entity.Market
+		wantTickers    []string
+		wantNormalized []float64
+		wantTotal      float64
+	}{
+		{
+			name: "yes prices above 100",
+			markets: func(t *testing.T) []*entity.Market {
+				return []*entity.Market{
+					quotedMarket(t, "A", 29, 31, open),
+					quotedMarket(t, "B", 39, 41, open),
+					quotedMarket(t, "C", 49, 51, open),
+				}
+			},
+			wantTickers:    []string{"A", "B", "C"},
+			wantNormalized: []float64{0.25, 1.0 / 3, 5.0 / 12},
+			wantTotal:      1.2,
+		},
+		{
+			name: "yes prices below 100",
+			markets: func(t *testing.T) []*entity.Market {
+				return []*entity.Market{
+					quotedMarket(t, "A", 19, 21, open),
+					quotedMarket(t, "B", 29, 31, open),
+					quotedMarket(t, "C", 29, 31, open),
+				}
+			},
+			wantTickers:    []string{"A", "B", "C"},
+			wantNormalized: []float64{0.25, 0.375, 0.375},
+			wantTotal:      0.8,
+		},
+		{
+			name: "yes prices sum to 100",
+			markets: func(t *testing.T) []*entity.Market {
+				return []*entity.Market{
+					quotedMarket(t, "A", 59, 61, open),
+					quotedMarket(t, "B", 39, 41, open),
+				}
+			},
+			wantTickers:    []string{"A", "B"},
+			wantNormalized: []float64{0.6, 0.4},
+			wantTotal:      1,
+		},
+		{
+			name: "no quotes",
+			markets: func(t *testing.T) []*entity.Market {
+				return []*entity.Market{
+					quotedMarket(t, "A", 0, 0, open),
+					quotedMarket(t, "B", 0, 0, open),
+				}
+			},
+			wantTickers:    []string{"A", "B"},
+			wantNormalized: []float64{0, 0},
+			wantTotal:      0,
+		},
+		{
+			name: "closed outcomes are left out while others trade",
+			markets: func(t *testing.T) []*entity.Market {
+				return []*entity.Market{
+					quotedMarket(t, "A", 29, 31, open),
+					quotedMarket(t, "B", 89, 91, closed),
+					quotedMarket(t, "C", 59, 61, open),
+				}
+			},
+			wantTickers:    []string{"A", "C"},
+			wantNormalized: []float64{1.0 / 3, 2.0 / 3},
+			wantTotal:      0.9,
+		},
+		{
+			name: "every outcome once trading stopped",
+			markets: func(t *testing.T) []*entity.Market {
+				return []*entity.Market{
+					quotedMarket(t, "A", 49, 51, closed),
+					quotedMarket(t, "B", 69, 71, closed),
+				}
+			},
+			wantTickers:    []string{"A", "B"},
+			wantNormalized: []float64{5.0 / 12, 7.0 / 12},
+			wantTotal:      1.2,
+		},
+	}
+
+	analyzer := NewDistributionAnalyzer(NewProbabilityEstimator(), valueobject.DefaultFeeSchedule())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distribution := analyzer.Analyze(eventOf(true, tt.markets(t)...), 1)
+			require.NotNil(t, distribution)
+			require.Len(t, distribution.Outcomes, len(tt.wantTickers))
+
+			sum := 0.0
+			for i, outcome := range distribution.Outcomes {
+				assert.Equal(t, tt.wantTickers[i], outcome.Market.Ticker.String())
+				assert.InDelta(t, tt.wantNormalized[i], outcome.Normalized, 1e-9, outcome.Market.Ticker.String())
+				sum += outcome.Normalized
+			}
+
+			assert.InDelta(t, tt.wantTotal, distribution.TotalProbability, 1e-9)
+			assert.InDelta(t, tt.wantTotal-1, distribution.Overround, 1e-9)
+			if tt.wantTotal > 0 {
+				assert.InDelta(t, 1, sum, 1e-9, "normalized probabilities sum to 1")
+			}
+		})
+	}
+}
+
+func TestDistributionAnalyzerBaskets(t *testing.T) {
+	open := entity.MarketStatusOpen
+
+	tests := []struct {
+		name              string
+		mutuallyExclusive bool
+		markets           func(t *testing.T) []*entity.Market
+		contracts         int64
+		wantYes           BasketQuote
+		wantArbitrage     bool
+	}{
+		{
+			// 3 × 30¢ plus a 2¢ fee per leg costs 96¢ for a basket paying 100¢
+			name:              "all-yes basket below par",
+			mutuallyExclusive: true,
+			markets: func(t *testing.T) []*entity.Market {
+				return []*entity.Market{
+					quotedMarket(t, "A", 28, 30, open),
+					quotedMarket(t, "B", 28, 30, open),
+					quotedMarket(t, "C", 28, 30, open),
+				}
+			},
+			contracts: 1,
+			wantYes: BasketQuote{
+				Side: BasketSideYes, Contracts: 1, Available: true,
+				Cost: 9000, Fees: 600, NetCost: 9600, Payout: 10000, Edge: 400, BelowPar: true,
+			},
+			wantArbitrage: true,
+		},
+		{
+			// Fees are rounded up per leg: 100 contracts at 29¢ owe 144.13¢, charged as 145¢
+			name:              "fees are rounded up per leg",
+			mutuallyExclusive: true,
+			markets: func(t *testing.T) []*entity.Market {
+				return []*entity.Market{
+					quotedMarket(t, "A", 27, 29, open),
+					quotedMarket(t, "B", 27, 29, open),
+					quotedMarket(t, "C", 27, 29, open),
+				}
+			},
+			contracts: 100,
+			wantYes: BasketQuote{
+				Side: BasketSideYes, Contracts: 100, Available: true,
+				Cost: 870000, Fees: 43500, NetCost: 913500, Payout: 1000000, Edge: 86500, BelowPar: true,
+			},
+			wantArbitrage: true,
+		},
+		{
+			name:              "all-yes basket above par",
+			mutuallyExclusive: true,
+			markets: func(t *testing.T) []*entity.Market {
+				return []*entity.Market{
+					quotedMarket(t, "A", 48, 50, open),
+					quotedMarket(t, "B", 48, 50, open),
+				}
+			},
+			contracts: 1,
+			wantYes: BasketQuote{
+				Side: BasketSideYes, Contracts: 1, Available: true,
+				Cost: 10000, Fees: 400, NetCost: 10400, Payout: 10000, Edge: -400, AbovePar: true,
+			},
+		},
+		{
+			name: "independent outcomes have no par",
+			markets: func(t *testing.T) []*entity.Market {
+				return []*entity.Market{
+					quotedMarket(t, "A", 28, 30, open),
+					quotedMarket(t, "B", 28, 30, open),
+				}
+			},
+			contracts: 1,
+			wantYes: BasketQuote{
+				Side: BasketSideYes, Contracts: 1, Available: true,
+				Cost: 6000, Fees: 400, NetCost: 6400, Payout: 10000, Edge: 3600,
+			},
+		},
+		{
+			name:              "an outcome without an ask",
+			mutuallyExclusive: true,
+			markets: func(t *testing.T) []*entity.Market {
+				return []*entity.Market{
+					quotedMarket(t, "A", 28, 30, open),
+					quotedMarket(t, "B", 0, 0, open),
+				}
+			},
+			contracts: 1,
+			wantYes: BasketQuote{
+				Side: BasketSideYes, Contracts: 1,
+				Cost: 3000, Fees: 200, NetCost: 3200, Payout: 10000, Edge: 6800,
+			},
+		},
+	}
+
+	analyzer := NewDistributionAnalyzer(NewProbabilityEstimator(), valueobject.DefaultFeeSchedule())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distribution := analyzer.Analyze(eventOf(tt.mutuallyExclusive, tt.markets(t)...), tt.contracts)
+			require.NotNil(t, distribution)
+
+			assert.Equal(t, tt.wantYes, distribution.AllYes)
+			assert.Equal(t, tt.wantArbitrage, distribution.HasArbitrage())
+		})
+	}
+}
+
+func TestDistributionAnalyzerNilEvent(t *testing.T) {
+	analyzer := NewDistributionAnalyzer(NewProbabilityEstimator(), valueobject.DefaultFeeSchedule())
+	assert.Nil(t, analyzer.Analyze(nil, 1))
+}
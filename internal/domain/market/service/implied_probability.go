@@ -0,0 +1,74 @@
+package service
+
+import (
+	"upwork-test/internal/domain/market/entity"
+)
+
+// PriceSource identifies which quote an implied probability was derived from
+type PriceSource string
+
+const (
+	PriceSourceMid  PriceSource = "mid"
+	PriceSourceLast PriceSource = "last"
+	PriceSourceBid  PriceSource = "bid"
+	PriceSourceAsk  PriceSource = "ask"
+	PriceSourceNone PriceSource = "none"
+)
+
+// ImpliedProbability is the market-implied chance of a YES outcome
+type ImpliedProbability struct {
+	Probability float64 // 0-1
+	Source      PriceSource
+}
+
+// ProbabilityEstimator derives implied probabilities from market quotes
+type ProbabilityEstimator struct{}
+
+// NewProbabilityEstimator creates a new ProbabilityEstimator service
+func NewProbabilityEstimator() *ProbabilityEstimator {
+	return &ProbabilityEstimator{}
+}
+
+// Estimate returns the implied YES probability of a market.
+// The bid/ask midpoint is preferred; the last trade is used when the book is one-sided or empty.
+func (pe *ProbabilityEstimator) Estimate(market *entity.Market) ImpliedProbability {
+	if market == nil {
+		return ImpliedProbability{Source: PriceSourceNone}
+	}
+
+	bid := market.YesBid
+	ask := market.YesAsk
+
+	switch {
+	case !bid.IsZero() && !ask.IsZero() && !ask.LessThan(bid):
+		return ImpliedProbability{Probability: (bid.Dollars() + ask.Dollars()) / 2, Source: PriceSourceMid}
+	case !market.LastPrice.IsZero():
+		return ImpliedProbability{Probability: market.LastPrice.Dollars(), Source: PriceSourceLast}
+	case !bid.IsZero():
+		return ImpliedProbability{Probability: market.YesBid.Dollars(), Source: PriceSourceBid}
+	case !ask.IsZero():
+		return ImpliedProbability{Probability: market.YesAsk.Dollars(), Source: PriceSourceAsk}
+	default:
+		return ImpliedProbability{Source: PriceSourceNone}
+	}
+}
+
+// Normalize scales probabilities of mutually exclusive outcomes so they sum to 1.
+// It also returns the raw total; a total above 1 is the book's overround.
+func (pe *ProbabilityEstimator) Normalize(probabilities []float64) ([]float64, float64) {
+	total := 0.0
+	for _, p := range probabilities {
+		total += p
+	}
+
+	normalized := make([]float64, len(probabilities))
+	if total <= 0 {
+		return normalized, total
+	}
+
+	for i, p := range probabilities {
+		normalized[i] = p / total
+	}
+
+	return normalized, total
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/valueobject"
+)
+
+var (
+	// ErrInvalidQuote is returned when quote parameters are inconsistent
+	ErrInvalidQuote = errors.New("invalid quote")
+)
+
+// QuoteSide is the contract side being bought
+type QuoteSide string
+
+const (
+	QuoteSideYes QuoteSide = "yes"
+	QuoteSideNo  QuoteSide = "no"
+)
+
+// Liquidity describes whether an order takes resting liquidity or rests on the book
+type Liquidity string
+
+const (
+	LiquidityTaker Liquidity = "taker"
+	LiquidityMaker Liquidity = "maker"
+)
+
+// QuoteParams describes a hypothetical order
+type QuoteParams struct {
+	Side       QuoteSide
+	Quantity   int64
+	Liquidity  Liquidity
+	LimitPrice *valueobject.Price // Required for maker orders; caps taker fills when set
+}
+
+// Fill is the part of an order executed at one price level
+type Fill struct {
+	Price    valueobject.Price
+	Quantity int64
+	Fee      int64 // In fixed-point price units
+}
+
+// Quote is the cost and payoff of buying contracts.
+// Amounts are fixed-point price units (valueobject.PriceScale per cent).
+type Quote struct {
+	Side                 QuoteSide
+	Liquidity            Liquidity
+	Requested            int64
+	Filled               int64
+	Fills                []Fill
+	AveragePrice         float64 // In cents
+	GrossCost            int64
+	Fees                 int64
+	TotalCost            int64
+	MaxPayout            int64
+	MaxProfit            int64
+	BreakEvenProbability float64 // Probability of winning at which the order has zero expected value
+}
+
+// IsFullyFilled returns true if the whole requested quantity could be filled
+func (q *Quote) IsFullyFilled() bool {
+	return q.Filled == q.Requested
+}
+
+// ExpectedValue returns the expected profit in price units for a given probability of winning
+func (q *Quote) ExpectedValue(probability float64) float64 {
+	return probability*float64(q.MaxPayout) - float64(q.TotalCost)
+}
+
+// QuoteCalculator prices orders against an order book including Kalshi fees
+type QuoteCalculator struct {
+	fees valueobject.FeeSchedule
+}
+
+// NewQuoteCalculator creates a new QuoteCalculator service
+func NewQuoteCalculator(fees valueobject.FeeSchedule) *QuoteCalculator {
+	return &QuoteCalculator{
+		fees: fees,
+	}
+}
+
+// Calculate prices an order. Taker orders walk the book from the best offer and may fill
+// partially; maker orders assume the full quantity fills at the limit price.
+func (qc *QuoteCalculator) Calculate(orderBook *entity.OrderBook, params QuoteParams) (*Quote, error) {
+	if params.Side != QuoteSideYes && params.Side != QuoteSideNo {
+		return nil, fmt.Errorf("%w: unknown side %q", ErrInvalidQuote, params.Side)
+	}
+	if params.Quantity <= 0 {
+		return nil, fmt.Errorf("%w: quantity must be positive", ErrInvalidQuote)
+	}
+	if params.Liquidity == "" {
+		params.Liquidity = LiquidityTaker
+	}
+
+	quote := &Quote{
+		Side:      params.Side,
+		Liquidity: params.Liquidity,
+		Requested: params.Quantity,
+		Fills:     []Fill{},
+	}
+
+	switch params.Liquidity {
+	case LiquidityMaker:
+		if params.LimitPrice == nil || params.LimitPrice.IsZero() {
+			return nil, fmt.Errorf("%w: maker quotes require a limit price", ErrInvalidQuote)
+		}
+		quote.addFill(*params.LimitPrice, params.Quantity, qc.fees.MakerFee(*params.LimitPrice, params.Quantity)*valueobject.PriceScale)
+
+	case LiquidityTaker:
+		if orderBook != nil {
+			qc.walkBook(quote, orderBook, params)
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: unknown liquidity %q", ErrInvalidQuote, params.Liquidity)
+	}
+
+	quote.TotalCost = quote.GrossCost + quote.Fees
+	quote.MaxPayout = valueobject.MaxPrice().Times(quote.Filled)
+	quote.MaxProfit = quote.MaxPayout - quote.TotalCost
+	if quote.Filled > 0 {
+		quote.AveragePrice = float64(quote.GrossCost) / float64(quote.Filled) / valueobject.PriceScale
+		quote.BreakEvenProbability = float64(quote.TotalCost) / float64(quote.MaxPayout)
+	}
+
+	return quote, nil
+}
+
+// walkBook fills a taker order level by level, respecting the limit price if set
+func (qc *QuoteCalculator) walkBook(quote *Quote, orderBook *entity.OrderBook, params QuoteParams) {
+	offers := orderBook.YesOffers()
+	if params.Side == QuoteSideNo {
+		offers = orderBook.NoOffers()
+	}
+
+	remaining := params.Quantity
+	for _, level := range offers {
+		if remaining == 0 {
+			break
+		}
+		if params.LimitPrice != nil && level.Price.GreaterThan(*params.LimitPrice) {
+			break
+		}
+
+		quantity := int64(level.Quantity)
+		if quantity > remaining {
+			quantity = remaining
+		}
+
+		// Kalshi charges fees per fill, each rounded up to the cent
+		quote.addFill(level.Price, quantity, qc.fees.TakerFee(level.Price, quantity)*valueobject.PriceScale)
+		remaining -= quantity
+	}
+}
+
+// addFill records an execution on the quote
+func (q *Quote) addFill(price valueobject.Price, quantity, fee int64) {
+	q.Fills = append(q.Fills, Fill{Price: price, Quantity: quantity, Fee: fee})
+	q.Filled += quantity
+	q.GrossCost += price.Times(quantity)
+	q.Fees += fee
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/valueobject"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testOrderBook holds YES bids at 35¢ (5) and NO bids at 60¢ (10) and 55¢ (20),
+// so YES sells at 40¢ and 45¢ and NO sells at 65¢
+func testOrderBook(t *testing.T) *entity.OrderBook {
+	t.Helper()
+
+	ticker, err := valueobject.NewTicker("KXTEST-24DEC31")
+	require.NoError(t, err)
+
+	return entity.NewOrderBook(ticker, time.Now(),
+		[]entity.OrderLevel{{Price: mustPrice(t, 35), Quantity: 5}},
+		[]entity.OrderLevel{{Price: mustPrice(t, 55), Quantity: 20}, {Price: mustPrice(t, 60), Quantity: 10}},
+	)
+}
+
+func TestQuoteCalculatorCalculate(t *testing.T) {
+	limit42 := mustPrice(t, 42)
+	limit40 := mustPrice(t, 40)
+
+	tests := []struct {
+		name   string
+		params QuoteParams
+		want   Quote
+	}{
+		{
+			// 10 @ 40¢ owe 16.8¢ → 17¢, 15 @ 45¢ owe 25.9875¢ → 26¢
+			name:   "taker walks the book with a fee per fill",
+			params: QuoteParams{Side: QuoteSideYes, Quantity: 25},
+			want: Quote{
+				Side: QuoteSideYes, Liquidity: LiquidityTaker, Requested: 25, Filled: 25,
+				Fills: []Fill{
+					{Price: mustPrice(t, 40), Quantity: 10, Fee: 1700},
+					{Price: mustPrice(t, 45), Quantity: 15, Fee: 2600},
+				},
+				AveragePrice: 43, GrossCost: 107500, Fees: 4300, TotalCost: 111800,
+				MaxPayout: 250000, MaxProfit: 138200, BreakEvenProbability: 0.4472,
+			},
+		},
+		{
+			name:   "taker fills partially when the book runs out",
+			params: QuoteParams{Side: QuoteSideNo, Quantity: 8},
+			want: Quote{
+				Side: QuoteSideNo, Liquidity: LiquidityTaker, Requested: 8, Filled: 5,
+				// 0.07 × 5 × 0.65 × 0.35 = 7.9625¢ → 8¢
+				Fills:        []Fill{{Price: mustPrice(t, 65), Quantity: 5, Fee: 800}},
+				AveragePrice: 65, GrossCost: 32500, Fees: 800, TotalCost: 33300,
+				MaxPayout: 50000, MaxProfit: 16700, BreakEvenProbability: 0.666,
+			},
+		},
+		{
+			name:   "taker stops at the limit price",
+			params: QuoteParams{Side: QuoteSideYes, Quantity: 25, LimitPrice: &limit42},
+			want: Quote{
+				Side: QuoteSideYes, Liquidity: LiquidityTaker, Requested: 25, Filled: 10,
+				Fills:        []Fill{{Price: mustPrice(t, 40), Quantity: 10, Fee: 1700}},
+				AveragePrice: 40, GrossCost: 40000, Fees: 1700, TotalCost: 41700,
+				MaxPayout: 100000, MaxProfit: 58300, BreakEvenProbability: 0.417,
+			},
+		},
+		{
+			// 0.0175 × 10 × 0.4 × 0.6 = 4.2¢ → 5¢
+			name:   "maker fills at the limit price",
+			params: QuoteParams{Side: QuoteSideYes, Quantity: 10, Liquidity: LiquidityMaker, LimitPrice: &limit40},
+			want: Quote{
+				Side: QuoteSideYes, Liquidity: LiquidityMaker, Requested: 10, Filled: 10,
+				Fills:        []Fill{{Price: mustPrice(t, 40), Quantity: 10, Fee: 500}},
+				AveragePrice: 40, GrossCost: 40000, Fees: 500, TotalCost: 40500,
+				MaxPayout: 100000, MaxProfit: 59500, BreakEvenProbability: 0.405,
+			},
+		},
+	}
+
+	calculator := NewQuoteCalculator(valueobject.DefaultFeeSchedule())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote, err := calculator.Calculate(testOrderBook(t), tt.params)
+			require.NoError(t, err)
+
+			assert.InDelta(t, tt.want.BreakEvenProbability, quote.BreakEvenProbability, 1e-9)
+			assert.InDelta(t, tt.want.AveragePrice, quote.AveragePrice, 1e-9)
+			tt.want.BreakEvenProbability, quote.BreakEvenProbability = 0, 0
+			tt.want.AveragePrice, quote.AveragePrice = 0, 0
+			assert.Equal(t, tt.want, *quote)
+			assert.Equal(t, tt.want.Filled == tt.want.Requested, quote.IsFullyFilled())
+		})
+	}
+}
+
+func TestQuoteCalculatorEmptyBook(t *testing.T) {
+	calculator := NewQuoteCalculator(valueobject.DefaultFeeSchedule())
+
+	quote, err := calculator.Calculate(nil, QuoteParams{Side: QuoteSideYes, Quantity: 5})
+	require.NoError(t, err)
+
+	assert.Empty(t, quote.Fills)
+	assert.Equal(t, int64(0), quote.Filled)
+	assert.Equal(t, int64(0), quote.TotalCost)
+	assert.Zero(t, quote.BreakEvenProbability)
+	assert.False(t, quote.IsFullyFilled())
+}
+
+func TestQuoteCalculatorInvalidParams(t *testing.T) {
+	zero := mustPrice(t, 0)
+
+	tests := []struct {
+		name   string
+		params QuoteParams
+	}{
+		{name: "unknown side", params: QuoteParams{Side: "maybe", Quantity: 1}},
+		{name: "zero quantity", params: QuoteParams{Side: QuoteSideYes}},
+		{name: "negative quantity", params: QuoteParams{Side: QuoteSideYes, Quantity: -1}},
+		{name: "maker without limit", params: QuoteParams{Side: QuoteSideYes, Quantity: 1, Liquidity: LiquidityMaker}},
+		{name: "maker at zero", params: QuoteParams{Side: QuoteSideYes, Quantity: 1, Liquidity: LiquidityMaker, LimitPrice: &zero}},
+		{name: "unknown liquidity", params: QuoteParams{Side: QuoteSideYes, Quantity: 1, Liquidity: "iceberg"}},
+	}
+
+	calculator := NewQuoteCalculator(valueobject.DefaultFeeSchedule())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := calculator.Calculate(testOrderBook(t), tt.params)
+			assert.ErrorIs(t, err, ErrInvalidQuote)
+		})
+	}
+}
+
+func TestQuoteExpectedValue(t *testing.T) {
+	quote := Quote{TotalCost: 41700, MaxPayout: 100000}
+
+	assert.InDelta(t, 8300, quote.ExpectedValue(0.5), 1e-9)
+	assert.InDelta(t, -41700, quote.ExpectedValue(0), 1e-9)
+	assert.InDelta(t, 0, quote.ExpectedValue(0.417), 1e-6)
+}
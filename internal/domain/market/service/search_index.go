@@ -0,0 +1,442 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"upwork-test/internal/domain/market/entity"
+)
+
+// SearchSort orders search results
+type SearchSort string
+
+const (
+	SearchSortRelevance SearchSort = "relevance"
+	SearchSortVolume    SearchSort = "volume"
+	SearchSortCloseTime SearchSort = "close_time"
+)
+
+// searchField identifies the document field a term was found in
+type searchField int
+
+const (
+	fieldTicker searchField = iota
+	fieldTitle
+	fieldSubtitle
+	fieldEventTitle
+	fieldSeriesTitle
+)
+
+// fieldWeights rank matches in titles above matches in broader event and series context
+var fieldWeights = map[searchField]float64{
+	fieldTicker:      4,
+	fieldTitle:       3,
+	fieldSubtitle:    2,
+	fieldEventTitle:  2,
+	fieldSeriesTitle: 1,
+}
+
+const (
+	// Score multipliers for inexact term matches
+	prefixMatchFactor = 0.75
+	oneEditFactor     = 0.6
+	twoEditFactor     = 0.35
+
+	// minPrefixLength is the shortest query term expanded by prefix
+	minPrefixLength = 2
+	// Query terms need this many runes before one (or two) typos are tolerated
+	oneEditMinLength = 4
+	twoEditMinLength = 8
+)
+
+// stopWords are ignored in documents and queries
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "at": true, "be": true, "by": true, "for": true,
+	"in": true, "is": true, "of": true, "on": true, "or": true, "the": true, "to": true, "will": true,
+}
+
+// SearchQuery describes a market search; zero-valued filters are ignored
+type SearchQuery struct {
+	Text         string
+	Category     string
+	Status       entity.MarketStatus
+	ClosesBefore time.Time
+	MinVolume    int64
+	Sort         SearchSort
+	Limit        int
+}
+
+// SearchResult is a matching document and its relevance score
+type SearchResult struct {
+	Document *entity.SearchDocument
+	Score    float64
+}
+
+// termMatch is an index term that matches a query term, with its match quality
+type termMatch struct {
+	term   string
+	factor float64
+}
+
+// SearchIndex is an in-memory inverted index over market search documents.
+// Terms map to the documents containing them with the weight of the best field they appear in.
+// It is safe for concurrent use.
+type SearchIndex struct {
+	mu         sync.RWMutex
+	docs       map[string]*entity.SearchDocument
+	postings   map[string]map[string]float64 // term -> ticker -> field weight
+	docTerms   map[string][]string           // ticker -> terms, for removal
+	terms      []string                      // sorted vocabulary for prefix lookups
+	termsDirty bool
+}
+
+// NewSearchIndex creates an empty SearchIndex
+func NewSearchIndex() *SearchIndex {
+	return &SearchIndex{
+		docs:     make(map[string]*entity.SearchDocument),
+		postings: make(map[string]map[string]float64),
+		docTerms: make(map[string][]string),
+	}
+}
+
+// Len returns the number of indexed documents
+func (si *SearchIndex) Len() int {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return len(si.docs)
+}
+
+// Replace discards the index contents and indexes docs
+func (si *SearchIndex) Replace(docs []*entity.SearchDocument) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.docs = make(map[string]*entity.SearchDocument, len(docs))
+	si.postings = make(map[string]map[string]float64)
+	si.docTerms = make(map[string][]string, len(docs))
+	for _, doc := range docs {
+		si.put(doc)
+	}
+	si.termsDirty = true
+}
+
+// Put adds or replaces documents
+func (si *SearchIndex) Put(docs ...*entity.SearchDocument) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	for _, doc := range docs {
+		si.remove(doc.Ticker)
+		si.put(doc)
+	}
+	si.termsDirty = true
+}
+
+// Remove deletes documents by ticker
+func (si *SearchIndex) Remove(tickers ...string) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	for _, ticker := range tickers {
+		si.remove(ticker)
+	}
+	si.termsDirty = true
+}
+
+// Search returns the documents matching every query term and the filters, best first.
+// Query terms match index terms exactly, as a prefix, or within a small edit distance.
+// An empty query text matches every document that passes the filters.
+func (si *SearchIndex) Search(query SearchQuery) ([]SearchResult, int) {
+	si.refreshTerms()
+
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	queryTerms := tokenize(query.Text)
+
+	var scores map[string]float64
+	if len(queryTerms) == 0 {
+		scores = make(map[string]float64, len(si.docs))
+		for ticker := range si.docs {
+			scores[ticker] = 0
+		}
+	} else {
+		scores = si.score(queryTerms)
+	}
+
+	results := make([]SearchResult, 0, len(scores))
+	for ticker, score := range scores {
+		doc := si.docs[ticker]
+		if doc == nil || !matchesFilters(doc, query) {
+			continue
+		}
+		results = append(results, SearchResult{Document: doc, Score: score})
+	}
+
+	sortResults(results, query.Sort, len(queryTerms) > 0)
+
+	total := len(results)
+	if query.Limit > 0 && len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+
+	return results, total
+}
+
+// score returns the relevance of every document matching all query terms. Caller must hold mu.
+func (si *SearchIndex) score(queryTerms []string) map[string]float64 {
+	var scores map[string]float64
+	docCount := float64(len(si.docs))
+
+	for i, queryTerm := range queryTerms {
+		termScores := make(map[string]float64)
+
+		for _, match := range si.expand(queryTerm) {
+			postings := si.postings[match.term]
+			idf := math.Log(1 + docCount/float64(len(postings)))
+
+			for ticker, weight := range postings {
+				score := match.factor * weight * idf
+				if score > termScores[ticker] {
+					termScores[ticker] = score
+				}
+			}
+		}
+
+		// Every query term must match
+		if i == 0 {
+			scores = termScores
+			continue
+		}
+		for ticker, score := range scores {
+			termScore, ok := termScores[ticker]
+			if !ok {
+				delete(scores, ticker)
+				continue
+			}
+			scores[ticker] = score + termScore
+		}
+	}
+
+	return scores
+}
+
+// expand returns the index terms a query term matches. Caller must hold mu.
+func (si *SearchIndex) expand(queryTerm string) []termMatch {
+	matches := make(map[string]float64)
+
+	if _, ok := si.postings[queryTerm]; ok {
+		matches[queryTerm] = 1
+	}
+
+	length := len([]rune(queryTerm))
+
+	if length >= minPrefixLength {
+		start := sort.SearchStrings(si.terms, queryTerm)
+		for i := start; i < len(si.terms) && strings.HasPrefix(si.terms[i], queryTerm); i++ {
+			if si.terms[i] != queryTerm {
+				matches[si.terms[i]] = prefixMatchFactor
+			}
+		}
+	}
+
+	maxEdits := 0
+	if length >= twoEditMinLength {
+		maxEdits = 2
+	} else if length >= oneEditMinLength {
+		maxEdits = 1
+	}
+
+	if maxEdits > 0 {
+		for _, term := range si.terms {
+			if _, ok := matches[term]; ok {
+				continue
+			}
+			switch distance := editDistance(queryTerm, term, maxEdits); {
+			case distance > maxEdits:
+			case distance == 1:
+				matches[term] = oneEditFactor
+			case distance == 2:
+				matches[term] = twoEditFactor
+			}
+		}
+	}
+
+	result := make([]termMatch, 0, len(matches))
+	for term, factor := range matches {
+		result = append(result, termMatch{term: term, factor: factor})
+	}
+	return result
+}
+
+// put indexes a document. Caller must hold mu.
+func (si *SearchIndex) put(doc *entity.SearchDocument) {
+	weights := make(map[string]float64)
+	add := func(field searchField, text string) {
+		for _, term := range tokenize(text) {
+			if fieldWeights[field] > weights[term] {
+				weights[term] = fieldWeights[field]
+			}
+		}
+	}
+
+	add(fieldTicker, doc.Ticker)
+	add(fieldTitle, doc.Title)
+	add(fieldSubtitle, doc.Subtitle)
+	add(fieldEventTitle, doc.EventTitle)
+	add(fieldSeriesTitle, doc.SeriesTitle)
+
+	terms := make([]string, 0, len(weights))
+	for term, weight := range weights {
+		postings, ok := si.postings[term]
+		if !ok {
+			postings = make(map[string]float64)
+			si.postings[term] = postings
+		}
+		postings[doc.Ticker] = weight
+		terms = append(terms, term)
+	}
+
+	si.docs[doc.Ticker] = doc
+	si.docTerms[doc.Ticker] = terms
+}
+
+// remove deletes a document from the index. Caller must hold mu.
+func (si *SearchIndex) remove(ticker string) {
+	for _, term := range si.docTerms[ticker] {
+		postings := si.postings[term]
+		delete(postings, ticker)
+		if len(postings) == 0 {
+			delete(si.postings, term)
+		}
+	}
+	delete(si.docTerms, ticker)
+	delete(si.docs, ticker)
+}
+
+// refreshTerms rebuilds the sorted vocabulary after the index changed
+func (si *SearchIndex) refreshTerms() {
+	si.mu.RLock()
+	dirty := si.termsDirty
+	si.mu.RUnlock()
+	if !dirty {
+		return
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	if !si.termsDirty {
+		return
+	}
+
+	terms := make([]string, 0, len(si.postings))
+	for term := range si.postings {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+
+	si.terms = terms
+	si.termsDirty = false
+}
+
+// matchesFilters checks a document against the query filters
+func matchesFilters(doc *entity.SearchDocument, query SearchQuery) bool {
+	if query.Category != "" && !strings.EqualFold(doc.Category, query.Category) {
+		return false
+	}
+	if query.Status != "" && !doc.Status.Matches(query.Status) {
+		return false
+	}
+	if !query.ClosesBefore.IsZero() && !doc.CloseTime.Before(query.ClosesBefore) {
+		return false
+	}
+	if doc.Volume24h < query.MinVolume {
+		return false
+	}
+	return true
+}
+
+// sortResults orders results; relevance falls back to volume when there is no query text
+func sortResults(results []SearchResult, order SearchSort, hasText bool) {
+	if order == "" {
+		order = SearchSortRelevance
+	}
+	if order == SearchSortRelevance && !hasText {
+		order = SearchSortVolume
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		switch order {
+		case SearchSortCloseTime:
+			if !a.Document.CloseTime.Equal(b.Document.CloseTime) {
+				return a.Document.CloseTime.Before(b.Document.CloseTime)
+			}
+		case SearchSortRelevance:
+			if a.Score != b.Score {
+				return a.Score > b.Score
+			}
+		}
+		if a.Document.Volume24h != b.Document.Volume24h {
+			return a.Document.Volume24h > b.Document.Volume24h
+		}
+		return a.Document.Ticker < b.Document.Ticker
+	})
+}
+
+// tokenize lowercases text and splits it into terms on anything but letters and digits
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if stopWords[field] || seen[field] {
+			continue
+		}
+		seen[field] = true
+		terms = append(terms, field)
+	}
+	return terms
+}
+
+// editDistance returns the Levenshtein distance between a and b, or max+1 once it exceeds max
+func editDistance(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+	if diff := len(ra) - len(rb); diff > max || -diff > max {
+		return max + 1
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		rowMin := current[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if current[j] < rowMin {
+				rowMin = current[j]
+			}
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"upwork-test/internal/domain/market/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var searchNow = time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+
+// searchDocs returns a small corpus spread across fields, categories, statuses and close times
+func searchDocs() []*entity.SearchDocument {
+	return []*entity.SearchDocument{
+		{
+			Ticker: "KXHIGHNY-24DEC31-B45", Title: "Will the high temperature in NYC be 45-46°?",
+			EventTitle: "Highest temperature in NYC on Dec 31", SeriesTitle: "NYC daily high",
+			Category: "Climate", Status: entity.MarketStatusOpen, CloseTime: searchNow.Add(30 * 24 * time.Hour), Volume24h: 500,
+		},
+		{
+			Ticker: "KXHIGHCHI-24DEC31-B30", Title: "Will the high temperature in Chicago be 30-31°?",
+			EventTitle: "Highest temperature in Chicago on Dec 31", SeriesTitle: "Chicago daily high",
+			Category: "Climate", Status: entity.MarketStatusOpen, CloseTime: searchNow.Add(31 * 24 * time.Hour), Volume24h: 900,
+		},
+		{
+			Ticker: "KXFEDDECISION-24DEC-C25", Title: "Fed cuts rates by 25bps",
+			Subtitle: "December FOMC meeting", EventTitle: "Fed decision in December",
+			Category: "Economics", Status: entity.MarketStatusOpen, CloseTime: searchNow.Add(17 * 24 * time.Hour), Volume24h: 5000,
+		},
+		{
+			Ticker: "KXCPIYOY-24NOV-T2.7", Title: "CPI year over year above 2.7%", SeriesTitle: "Inflation",
+			Category: "Economics", Status: entity.MarketStatusClosed, CloseTime: searchNow.Add(-24 * time.Hour), Volume24h: 1200,
+		},
+		{
+			Ticker: "KXNBAGAME-24DEC25LALGSW-LAL", Title: "Lakers beat the Warriors",
+			EventTitle: "Lakers at Warriors on Christmas", SeriesTitle: "NBA games",
+			Category: "Sports", Status: entity.MarketStatusOpen, CloseTime: searchNow.Add(25 * 24 * time.Hour), Volume24h: 3000,
+		},
+	}
+}
+
+func newTestSearchIndex() *SearchIndex {
+	index := NewSearchIndex()
+	index.Replace(searchDocs())
+	return index
+}
+
+// tickersOf returns the tickers of results in order
+func tickersOf(results []SearchResult) []string {
+	tickers := make([]string, 0, len(results))
+	for _, result := range results {
+		tickers = append(tickers, result.Document.Ticker)
+	}
+	return tickers
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "lowercases", text: "Fed CUTS Rates", want: []string{"fed", "cuts", "rates"}},
+		{name: "splits on punctuation", text: "KXHIGHNY-24DEC31-B45", want: []string{"kxhighny", "24dec31", "b45"}},
+		{name: "decimal points split", text: "above 2.7%", want: []string{"above", "2", "7"}},
+		{name: "drops stop words", text: "Will the Fed cut rates in December?", want: []string{"fed", "cut", "rates", "december"}},
+		{name: "drops repeated terms", text: "high high HIGH", want: []string{"high"}},
+		{name: "keeps non-ASCII letters", text: "Zürich température", want: []string{"zürich", "température"}},
+		{name: "only stop words", text: "the of and", want: []string{}},
+		{name: "empty", text: "", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tokenize(tt.text))
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		max  int
+		want int
+	}{
+		{name: "equal", a: "lakers", b: "lakers", max: 2, want: 0},
+		{name: "substitution", a: "lakers", b: "lakars", max: 2, want: 1},
+		{name: "insertion", a: "laker", b: "lakers", max: 2, want: 1},
+		{name: "transposition counts twice", a: "lakers", b: "lakres", max: 2, want: 2},
+		{name: "bounded by max", a: "lakers", b: "warriors", max: 1, want: 2},
+		{name: "length difference above max", a: "fed", b: "federal", max: 2, want: 3},
+		{name: "runes not bytes", a: "zürich", b: "zurich", max: 1, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, editDistance(tt.a, tt.b, tt.max))
+		})
+	}
+}
+
+func TestSearchIndexMatching(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "exact title term", query: "lakers", want: []string{"KXNBAGAME-24DEC25LALGSW-LAL"}},
+		{name: "case-insensitive", query: "LAKERS", want: []string{"KXNBAGAME-24DEC25LALGSW-LAL"}},
+		{name: "ticker segment", query: "kxcpiyoy", want: []string{"KXCPIYOY-24NOV-T2.7"}},
+		{name: "subtitle", query: "fomc", want: []string{"KXFEDDECISION-24DEC-C25"}},
+		{name: "series title", query: "inflation", want: []string{"KXCPIYOY-24NOV-T2.7"}},
+		{name: "prefix", query: "warr", want: []string{"KXNBAGAME-24DEC25LALGSW-LAL"}},
+		{name: "a transposition is two edits", query: "lakres", want: []string{}},
+		{name: "one substitution", query: "chicogo", want: []string{"KXHIGHCHI-24DEC31-B30"}},
+		{name: "two typos in a long term", query: "tempratur", want: []string{"KXHIGHCHI-24DEC31-B30", "KXHIGHNY-24DEC31-B45"}},
+		{name: "short terms need an exact match", query: "nbx", want: []string{}},
+		{name: "every term must match", query: "temperature nyc", want: []string{"KXHIGHNY-24DEC31-B45"}},
+		{name: "one term without a match", query: "temperature lakers", want: []string{}},
+		{name: "stop words are ignored", query: "the lakers", want: []string{"KXNBAGAME-24DEC25LALGSW-LAL"}},
+		{name: "no match", query: "bitcoin", want: []string{}},
+	}
+
+	index := newTestSearchIndex()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, total := index.Search(SearchQuery{Text: tt.query})
+			assert.ElementsMatch(t, tt.want, tickersOf(results))
+			assert.Equal(t, len(tt.want), total)
+		})
+	}
+}
+
+func TestSearchIndexRanking(t *testing.T) {
+	tests := []struct {
+		name  string
+		docs  []*entity.SearchDocument
+		query string
+		want  []string
+	}{
+		{
+			name: "title match above series match",
+			docs: []*entity.SearchDocument{
+				{Ticker: "SERIES", Title: "Daily close", SeriesTitle: "Gold price"},
+				{Ticker: "TITLE", Title: "Gold above $2,000"},
+			},
+			query: "gold",
+			want:  []string{"TITLE", "SERIES"},
+		},
+		{
+			name: "ticker match above title match",
+			docs: []*entity.SearchDocument{
+				{Ticker: "OTHER-GOLD", Title: "Commodity close"},
+				{Ticker: "OTHER", Title: "Gold close"},
+			},
+			query: "gold",
+			want:  []string{"OTHER-GOLD", "OTHER"},
+		},
+		{
+			name: "exact match above prefix match",
+			docs: []*entity.SearchDocument{
+				{Ticker: "PREFIX", Title: "Golden State wins"},
+				{Ticker: "EXACT", Title: "Gold wins"},
+			},
+			query: "gold",
+			want:  []string{"EXACT", "PREFIX"},
+		},
+		{
+			name: "prefix match above typo",
+			docs: []*entity.SearchDocument{
+				{Ticker: "TYPO", Title: "Rains in Seattle"},
+				{Ticker: "PREFIX", Title: "Rainfall in Seattle"},
+			},
+			query: "rainf",
+			want:  []string{"PREFIX", "TYPO"},
+		},
+		{
+			name: "rare terms outweigh common ones",
+			docs: []*entity.SearchDocument{
+				{Ticker: "A", Title: "Election turnout"},
+				{Ticker: "B", Title: "Election winner", SeriesTitle: "Senate"},
+				{Ticker: "C", Title: "Senate winner"},
+			},
+			query: "senate winner",
+			want:  []string{"C", "B"},
+		},
+		{
+			name: "ties break by volume",
+			docs: []*entity.SearchDocument{
+				{Ticker: "QUIET", Title: "Gold close", Volume24h: 10},
+				{Ticker: "BUSY", Title: "Gold close", Volume24h: 100},
+			},
+			query: "gold",
+			want:  []string{"BUSY", "QUIET"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index := NewSearchIndex()
+			index.Replace(tt.docs)
+
+			results, _ := index.Search(SearchQuery{Text: tt.query})
+			assert.Equal(t, tt.want, tickersOf(results))
+			for i := 1; i < len(results); i++ {
+				assert.GreaterOrEqual(t, results[i-1].Score, results[i].Score)
+			}
+		})
+	}
+}
+
+func TestSearchIndexFiltersAndSort(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     SearchQuery
+		want      []string
+		wantTotal int
+	}{
+		{
+			name:      "empty query sorts by volume",
+			query:     SearchQuery{},
+			want:      []string{"KXFEDDECISION-24DEC-C25", "KXNBAGAME-24DEC25LALGSW-LAL", "KXCPIYOY-24NOV-T2.7", "KXHIGHCHI-24DEC31-B30", "KXHIGHNY-24DEC31-B45"},
+			wantTotal: 5,
+		},
+		{
+			name:      "category is case-insensitive",
+			query:     SearchQuery{Category: "economics"},
+			want:      []string{"KXFEDDECISION-24DEC-C25", "KXCPIYOY-24NOV-T2.7"},
+			wantTotal: 2,
+		},
+		{
+			name:      "status",
+			query:     SearchQuery{Category: "Economics", Status: entity.MarketStatusOpen},
+			want:      []string{"KXFEDDECISION-24DEC-C25"},
+			wantTotal: 1,
+		},
+		{
+			name:      "closes before",
+			query:     SearchQuery{ClosesBefore: searchNow.Add(26 * 24 * time.Hour), Sort: SearchSortCloseTime},
+			want:      []string{"KXCPIYOY-24NOV-T2.7", "KXFEDDECISION-24DEC-C25", "KXNBAGAME-24DEC25LALGSW-LAL"},
+			wantTotal: 3,
+		},
+		{
+			name:      "minimum volume",
+			query:     SearchQuery{MinVolume: 1200},
+			want:      []string{"KXFEDDECISION-24DEC-C25", "KXNBAGAME-24DEC25LALGSW-LAL", "KXCPIYOY-24NOV-T2.7"},
+			wantTotal: 3,
+		},
+		{
+			name:      "text and filters",
+			query:     SearchQuery{Text: "temperature", Category: "Climate", Sort: SearchSortVolume},
+			want:      []string{"KXHIGHCHI-24DEC31-B30", "KXHIGHNY-24DEC31-B45"},
+			wantTotal: 2,
+		},
+		{
+			name:      "limit keeps the total",
+			query:     SearchQuery{Limit: 2},
+			want:      []string{"KXFEDDECISION-24DEC-C25", "KXNBAGAME-24DEC25LALGSW-LAL"},
+			wantTotal: 5,
+		},
+	}
+
+	index := newTestSearchIndex()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, total := index.Search(tt.query)
+			assert.Equal(t, tt.want, tickersOf(results))
+			assert.Equal(t, tt.wantTotal, total)
+		})
+	}
+}
+
+func TestSearchIndexUpdates(t *testing.T) {
+	index := newTestSearchIndex()
+	require.Equal(t, 5, index.Len())
+
+	// Replacing a document drops the terms it no longer holds
+	index.Put(&entity.SearchDocument{Ticker: "KXNBAGAME-24DEC25LALGSW-LAL", Title: "Celtics beat the Knicks", Volume24h: 3000})
+	results, _ := index.Search(SearchQuery{Text: "lakers"})
+	assert.Empty(t, results)
+	results, _ = index.Search(SearchQuery{Text: "celtics"})
+	assert.Equal(t, []string{"KXNBAGAME-24DEC25LALGSW-LAL"}, tickersOf(results))
+	assert.Equal(t, 5, index.Len())
+
+	// New terms are found by prefix once added
+	index.Put(&entity.SearchDocument{Ticker: "KXBTC-24DEC31", Title: "Bitcoin above $100k"})
+	results, _ = index.Search(SearchQuery{Text: "bitc"})
+	assert.Equal(t, []string{"KXBTC-24DEC31"}, tickersOf(results))
+
+	index.Remove("KXBTC-24DEC31", "KXNBAGAME-24DEC25LALGSW-LAL", "UNKNOWN")
+	results, _ = index.Search(SearchQuery{Text: "bitc"})
+	assert.Empty(t, results)
+	results, _ = index.Search(SearchQuery{Text: "celtics"})
+	assert.Empty(t, results)
+	assert.Equal(t, 4, index.Len())
+
+	index.Replace(nil)
+	assert.Equal(t, 0, index.Len())
+	results, total := index.Search(SearchQuery{Text: "temperature"})
+	assert.Empty(t, results)
+	assert.Zero(t, total)
+}
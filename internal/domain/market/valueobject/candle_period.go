@@ -0,0 +1,74 @@
+package valueobject
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidCandlePeriod is returned when a candlestick period is not supported
+	ErrInvalidCandlePeriod = errors.New("invalid candle period")
+)
+
+// CandlePeriod is the length of one candlestick; Kalshi aggregates by minute, hour or day
+type CandlePeriod string
+
+const (
+	// CandlePeriodMinute aggregates one minute per candle
+	CandlePeriodMinute CandlePeriod = "1m"
+	// CandlePeriodHour aggregates one hour per candle
+	CandlePeriodHour CandlePeriod = "1h"
+	// CandlePeriodDay aggregates one day per candle
+	CandlePeriodDay CandlePeriod = "1d"
+)
+
+// NewCandlePeriod creates a new CandlePeriod value object; an empty value defaults to 1h
+func NewCandlePeriod(value string) (CandlePeriod, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+
+	switch CandlePeriod(normalized) {
+	case "":
+		return CandlePeriodHour, nil
+	case CandlePeriodMinute, CandlePeriodHour, CandlePeriodDay:
+		return CandlePeriod(normalized), nil
+	default:
+		return "", fmt.Errorf("%w: %s (expected 1m, 1h or 1d)", ErrInvalidCandlePeriod, value)
+	}
+}
+
+// Duration returns the length of one candle
+func (p CandlePeriod) Duration() time.Duration {
+	switch p {
+	case CandlePeriodMinute:
+		return time.Minute
+	case CandlePeriodDay:
+		return 24 * time.Hour
+	default:
+		return time.Hour
+	}
+}
+
+// Lookback returns how far back the candles of a market detail view reach:
+// an hour of minutes, a day of hours or a month of days
+func (p CandlePeriod) Lookback() time.Duration {
+	switch p {
+	case CandlePeriodMinute:
+		return time.Hour
+	case CandlePeriodDay:
+		return 30 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// Minutes returns the length of one candle in minutes, as Kalshi's period_interval expects
+func (p CandlePeriod) Minutes() int {
+	return int(p.Duration() / time.Minute)
+}
+
+// String returns the string representation of the period
+func (p CandlePeriod) String() string {
+	return string(p)
+}
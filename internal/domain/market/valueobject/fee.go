@@ -0,0 +1,77 @@
+package valueobject
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrInvalidFeeRate is returned when a fee rate is negative or implausibly large
+	ErrInvalidFeeRate = errors.New("invalid fee rate")
+)
+
+const (
+	// DefaultTakerFeeRate is Kalshi's general taker fee coefficient
+	DefaultTakerFeeRate = 0.07
+	// DefaultMakerFeeRate is Kalshi's maker fee coefficient on markets that charge makers
+	DefaultMakerFeeRate = 0.0175
+)
+
+// FeeSchedule models Kalshi's trading fee formula:
+// fee = ceil(rate × contracts × P × (1 − P)) rounded up to the next cent, with P in dollars.
+type FeeSchedule struct {
+	takerRate float64
+	makerRate float64
+}
+
+// NewFeeSchedule creates a new FeeSchedule value object
+func NewFeeSchedule(takerRate, makerRate float64) (FeeSchedule, error) {
+	if takerRate < 0 || takerRate > 1 {
+		return FeeSchedule{}, fmt.Errorf("%w: taker rate %v", ErrInvalidFeeRate, takerRate)
+	}
+	if makerRate < 0 || makerRate > 1 {
+		return FeeSchedule{}, fmt.Errorf("%w: maker rate %v", ErrInvalidFeeRate, makerRate)
+	}
+
+	return FeeSchedule{takerRate: takerRate, makerRate: makerRate}, nil
+}
+
+// DefaultFeeSchedule returns Kalshi's published general fee schedule
+func DefaultFeeSchedule() FeeSchedule {
+	return FeeSchedule{takerRate: DefaultTakerFeeRate, makerRate: DefaultMakerFeeRate}
+}
+
+// TakerRate returns the taker fee coefficient
+func (f FeeSchedule) TakerRate() float64 {
+	return f.takerRate
+}
+
+// MakerRate returns the maker fee coefficient
+func (f FeeSchedule) MakerRate() float64 {
+	return f.makerRate
+}
+
+// TakerFee returns the fee in cents for taking contracts at a price
+func (f FeeSchedule) TakerFee(price Price, contracts int64) int64 {
+	return feeCents(f.takerRate, price, contracts)
+}
+
+// MakerFee returns the fee in cents for resting orders filled at a price
+func (f FeeSchedule) MakerFee(price Price, contracts int64) int64 {
+	return feeCents(f.makerRate, price, contracts)
+}
+
+// feeCents applies the fee formula and rounds up to a whole cent, so the result stays whole
+// cents even for sub-cent prices
+func feeCents(rate float64, price Price, contracts int64) int64 {
+	if contracts <= 0 || rate == 0 {
+		return 0
+	}
+
+	p := price.Dollars()
+	dollars := rate * float64(contracts) * p * (1 - p)
+
+	// Guard against float noise such as 0.07*100*0.5*0.5 = 1.7500000000000002
+	return int64(math.Ceil(math.Round(dollars*100*1e6) / 1e6))
+}
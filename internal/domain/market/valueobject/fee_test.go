@@ -0,0 +1,81 @@
+package valueobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFeeSchedule(t *testing.T) {
+	tests := []struct {
+		name      string
+		takerRate float64
+		makerRate float64
+		wantErr   bool
+	}{
+		{name: "default rates", takerRate: DefaultTakerFeeRate, makerRate: DefaultMakerFeeRate},
+		{name: "no fees", takerRate: 0, makerRate: 0},
+		{name: "negative taker rate", takerRate: -0.01, makerRate: 0, wantErr: true},
+		{name: "maker rate above one", takerRate: 0.07, makerRate: 1.5, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fees, err := NewFeeSchedule(tt.takerRate, tt.makerRate)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidFeeRate)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.takerRate, fees.TakerRate())
+			assert.Equal(t, tt.makerRate, fees.MakerRate())
+		})
+	}
+}
+
+func TestFeeScheduleRounding(t *testing.T) {
+	tests := []struct {
+		name      string
+		price     string // Cents
+		contracts int64
+		taker     int64 // Cents
+		maker     int64 // Cents
+	}{
+		// 0.07 × 1 × 0.5 × 0.5 = 1.75¢, charged as 2¢
+		{name: "single contract rounds up", price: "50", contracts: 1, taker: 2, maker: 1},
+		// 0.07 × 100 × 0.5 × 0.5 = $1.75 exactly; float noise must not add a cent
+		{name: "exact cent is not rounded up", price: "50", contracts: 100, taker: 175, maker: 44},
+		// 0.07 × 100 × 0.29 × 0.71 = $1.4413
+		{name: "fraction of a cent rounds up", price: "29", contracts: 100, taker: 145, maker: 37},
+		{name: "cheap contract", price: "1", contracts: 1, taker: 1, maker: 1},
+		{name: "sub-cent price", price: "56.25", contracts: 10, taker: 18, maker: 5},
+		{name: "free at zero", price: "0", contracts: 10, taker: 0, maker: 0},
+		{name: "free at payout", price: "100", contracts: 10, taker: 0, maker: 0},
+		{name: "no contracts", price: "50", contracts: 0, taker: 0, maker: 0},
+		{name: "negative contracts", price: "50", contracts: -5, taker: 0, maker: 0},
+	}
+
+	fees := DefaultFeeSchedule()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, err := ParseCents(tt.price)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.taker, fees.TakerFee(price, tt.contracts), "taker fee")
+			assert.Equal(t, tt.maker, fees.MakerFee(price, tt.contracts), "maker fee")
+		})
+	}
+}
+
+func TestFeeScheduleZeroRate(t *testing.T) {
+	fees, err := NewFeeSchedule(0.07, 0)
+	require.NoError(t, err)
+
+	price, err := NewPrice(50)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(0), fees.MakerFee(price, 100))
+	assert.Equal(t, int64(175), fees.TakerFee(price, 100))
+}
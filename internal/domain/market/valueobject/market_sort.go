@@ -0,0 +1,88 @@
+package valueobject
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidMarketSort is returned when a market listing sort field or direction is not supported
+	ErrInvalidMarketSort = errors.New("invalid market sort")
+)
+
+// MarketSortField is the market attribute a listing is ordered by
+type MarketSortField string
+
+const (
+	// MarketSortNone keeps the upstream order
+	MarketSortNone MarketSortField = ""
+	// MarketSortVolume24h orders by contracts traded in the last 24 hours
+	MarketSortVolume24h MarketSortField = "volume_24h"
+	// MarketSortCloseTime orders by scheduled close time
+	MarketSortCloseTime MarketSortField = "close_time"
+	// MarketSortLiquidity orders by resting liquidity
+	MarketSortLiquidity MarketSortField = "liquidity"
+	// MarketSortPrice orders by last traded YES price
+	MarketSortPrice MarketSortField = "price"
+	// MarketSortSpread orders by YES bid/ask spread
+	MarketSortSpread MarketSortField = "spread"
+)
+
+// SortDirection is the direction of a listing order
+type SortDirection string
+
+const (
+	// SortAscending orders from the smallest value
+	SortAscending SortDirection = "asc"
+	// SortDescending orders from the largest value
+	SortDescending SortDirection = "desc"
+)
+
+// MarketSort orders a market listing by one field
+type MarketSort struct {
+	Field     MarketSortField
+	Direction SortDirection
+}
+
+// NewMarketSort creates a new MarketSort value object. An empty direction defaults to
+// descending for volume, liquidity and price and ascending for close time and spread,
+// so the most useful markets come first.
+func NewMarketSort(field string, direction string) (MarketSort, error) {
+	normalizedField := MarketSortField(strings.ToLower(strings.TrimSpace(field)))
+	normalizedDirection := SortDirection(strings.ToLower(strings.TrimSpace(direction)))
+
+	switch normalizedField {
+	case MarketSortNone:
+		if normalizedDirection != "" {
+			return MarketSort{}, fmt.Errorf("%w: direction requires a sort field", ErrInvalidMarketSort)
+		}
+		return MarketSort{}, nil
+	case MarketSortVolume24h, MarketSortLiquidity, MarketSortPrice:
+		if normalizedDirection == "" {
+			normalizedDirection = SortDescending
+		}
+	case MarketSortCloseTime, MarketSortSpread:
+		if normalizedDirection == "" {
+			normalizedDirection = SortAscending
+		}
+	default:
+		return MarketSort{}, fmt.Errorf("%w: unknown field %s (expected volume_24h, close_time, liquidity, price or spread)", ErrInvalidMarketSort, field)
+	}
+
+	if normalizedDirection != SortAscending && normalizedDirection != SortDescending {
+		return MarketSort{}, fmt.Errorf("%w: unknown direction %s (expected asc or desc)", ErrInvalidMarketSort, direction)
+	}
+
+	return MarketSort{Field: normalizedField, Direction: normalizedDirection}, nil
+}
+
+// IsZero checks if the sort keeps the upstream order
+func (s MarketSort) IsZero() bool {
+	return s.Field == MarketSortNone
+}
+
+// IsDescending checks if the sort starts from the largest value
+func (s MarketSort) IsDescending() bool {
+	return s.Direction == SortDescending
+}
@@ -3,6 +3,8 @@ package valueobject
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 var (
@@ -10,12 +12,23 @@ var (
 	ErrInvalidPrice = errors.New("invalid price")
 )
 
-// Price represents a market price in cents (0-100)
+const (
+	// PriceScale is the number of fixed-point units per cent. One unit is $0.0001,
+	// the precision of Kalshi's dollar-denominated price fields.
+	PriceScale = 100
+	// PriceDecimals is the number of decimal places of a cent a Price can hold
+	PriceDecimals = 2
+
+	// maxPriceUnits is $1.00, the payout of a winning contract
+	maxPriceUnits = 100 * PriceScale
+)
+
+// Price represents a market price as a fixed-point number of hundredths of a cent (0-100 cents)
 type Price struct {
-	value int64
+	units int64
 }
 
-// NewPrice creates a new Price value object
+// NewPrice creates a new Price value object from whole cents
 func NewPrice(value int64) (Price, error) {
 	// Validate range (Kalshi prices are 0-100 cents)
 	if value < 0 {
@@ -26,55 +39,238 @@ func NewPrice(value int64) (Price, error) {
 		return Price{}, fmt.Errorf("%w: price cannot exceed 100 cents", ErrInvalidPrice)
 	}
 
-	return Price{value: value}, nil
+	return Price{units: value * PriceScale}, nil
+}
+
+// NewPriceFromUnits creates a new Price from fixed-point units (hundredths of a cent)
+func NewPriceFromUnits(units int64) (Price, error) {
+	if units < 0 {
+		return Price{}, fmt.Errorf("%w: price cannot be negative", ErrInvalidPrice)
+	}
+
+	if units > maxPriceUnits {
+		return Price{}, fmt.Errorf("%w: price cannot exceed 100 cents", ErrInvalidPrice)
+	}
+
+	return Price{units: units}, nil
+}
+
+// ParseCents creates a new Price from a decimal number of cents such as "56" or "56.5"
+func ParseCents(value string) (Price, error) {
+	units, err := parseFixed(value, PriceDecimals)
+	if err != nil {
+		return Price{}, err
+	}
+	return NewPriceFromUnits(units)
+}
+
+// ParseDollars creates a new Price from a decimal number of dollars such as "0.5650"
+func ParseDollars(value string) (Price, error) {
+	units, err := parseFixed(value, PriceDecimals+2)
+	if err != nil {
+		return Price{}, err
+	}
+	return NewPriceFromUnits(units)
+}
+
+// MaxPrice returns 100 cents, the payout of a winning contract
+func MaxPrice() Price {
+	return Price{units: maxPriceUnits}
 }
 
-// Value returns the price value in cents
+// Value returns the price in whole cents, dropping any fraction of a cent.
+// Use Round(0).Value() for the nearest whole cent.
 func (p Price) Value() int64 {
-	return p.value
+	return p.units / PriceScale
 }
 
-// Cents returns the price in cents (same as Value)
+// Cents returns the price in whole cents (same as Value)
 func (p Price) Cents() int64 {
-	return p.value
+	return p.Value()
+}
+
+// Units returns the exact price in fixed-point units (hundredths of a cent)
+func (p Price) Units() int64 {
+	return p.units
+}
+
+// ExactCents returns the exact price in cents, including fractions of a cent
+func (p Price) ExactCents() float64 {
+	return float64(p.units) / PriceScale
 }
 
 // Dollars returns the price in dollars as a float
 func (p Price) Dollars() float64 {
-	return float64(p.value) / 100.0
+	return float64(p.units) / maxPriceUnits
+}
+
+// IsWholeCent checks if the price has no sub-cent component
+func (p Price) IsWholeCent() bool {
+	return p.units%PriceScale == 0
 }
 
 // Equals checks if two prices are equal
 func (p Price) Equals(other Price) bool {
-	return p.value == other.value
+	return p.units == other.units
+}
+
+// Add returns the sum of two prices; the result must still be a valid price
+func (p Price) Add(other Price) (Price, error) {
+	return NewPriceFromUnits(p.units + other.units)
+}
+
+// Sub returns the difference of two prices; the result must still be a valid price
+func (p Price) Sub(other Price) (Price, error) {
+	return NewPriceFromUnits(p.units - other.units)
+}
+
+// Midpoint returns the price halfway between two prices, rounded down to the nearest unit
+func (p Price) Midpoint(other Price) Price {
+	return Price{units: (p.units + other.units) / 2}
+}
+
+// Complement returns the price of the opposite side of the contract (100 cents minus this price)
+func (p Price) Complement() Price {
+	return Price{units: maxPriceUnits - p.units}
 }
 
-// MarshalJSON implements json.Marshaler
+// Times returns the cost of a quantity of contracts at this price, in fixed-point units
+func (p Price) Times(quantity int64) int64 {
+	return p.units * quantity
+}
+
+// Round returns the price rounded half up to the given number of decimal places of a cent.
+// Negative values round to tens of cents (-1) or whole dollars (-2).
+func (p Price) Round(decimals int) Price {
+	if decimals >= PriceDecimals {
+		return p
+	}
+	if decimals < -2 {
+		decimals = -2
+	}
+
+	step := int64(1)
+	for i := decimals; i < PriceDecimals; i++ {
+		step *= 10
+	}
+
+	return Price{units: (p.units + step/2) / step * step}
+}
+
+// String returns the price in cents with its full precision, e.g. "56" or "56.25"
+func (p Price) String() string {
+	return formatFixed(p.units, PriceDecimals)
+}
+
+// DollarString returns the price in dollars with a fixed number of decimals, e.g. "0.5625"
+func (p Price) DollarString(decimals int) string {
+	rounded := p.Round(decimals - 2)
+	text := formatFixed(rounded.units, PriceDecimals+2)
+
+	whole, fraction, _ := strings.Cut(text, ".")
+	if decimals <= 0 {
+		return whole
+	}
+	return whole + "." + (fraction + strings.Repeat("0", decimals))[:decimals]
+}
+
+// MarshalJSON implements json.Marshaler.
+// Prices are written as a JSON number of cents; whole-cent prices keep the legacy integer encoding.
 func (p Price) MarshalJSON() ([]byte, error) {
-	return []byte(fmt.Sprintf("%d", p.value)), nil
+	return []byte(p.String()), nil
 }
 
-// UnmarshalJSON implements json.Unmarshaler
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts a number of cents (integer or decimal) and validates the range.
 func (p *Price) UnmarshalJSON(data []byte) error {
-	var value int64
-	if _, err := fmt.Sscanf(string(data), "%d", &value); err != nil {
+	text := strings.Trim(strings.TrimSpace(string(data)), `"`)
+	if text == "null" {
+		return nil
+	}
+
+	price, err := ParseCents(text)
+	if err != nil {
 		return err
 	}
-	p.value = value
+
+	*p = price
 	return nil
 }
 
 // GreaterThan checks if this price is greater than another
 func (p Price) GreaterThan(other Price) bool {
-	return p.value > other.value
+	return p.units > other.units
 }
 
 // LessThan checks if this price is less than another
 func (p Price) LessThan(other Price) bool {
-	return p.value < other.value
+	return p.units < other.units
 }
 
 // IsZero checks if the price is zero
 func (p Price) IsZero() bool {
-	return p.value == 0
+	return p.units == 0
+}
+
+// parseFixed parses a non-negative decimal string into an integer scaled by 10^decimals,
+// rejecting values with more precision than the scale can hold
+func parseFixed(value string, decimals int) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("%w: empty value", ErrInvalidPrice)
+	}
+	if strings.HasPrefix(value, "-") {
+		return 0, fmt.Errorf("%w: price cannot be negative", ErrInvalidPrice)
+	}
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" {
+		whole = "0"
+	}
+
+	trimmed := strings.TrimRight(fraction, "0")
+	if len(trimmed) > decimals {
+		return 0, fmt.Errorf("%w: %s has more than %d decimal places", ErrInvalidPrice, value, decimals)
+	}
+
+	// ParseUint rejects signs; the bit size leaves room for the fraction digits
+	wholePart, err := strconv.ParseUint(whole, 10, 40)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidPrice, value)
+	}
+
+	scaled := int64(wholePart)
+	for i := 0; i < decimals; i++ {
+		scaled *= 10
+	}
+
+	if trimmed != "" {
+		for _, r := range trimmed {
+			if r < '0' || r > '9' {
+				return 0, fmt.Errorf("%w: %s", ErrInvalidPrice, value)
+			}
+		}
+
+		fractionPart, _ := strconv.ParseInt(trimmed+strings.Repeat("0", decimals-len(trimmed)), 10, 64)
+		scaled += fractionPart
+	}
+
+	return scaled, nil
+}
+
+// formatFixed formats a non-negative integer scaled by 10^decimals without trailing zeros
+func formatFixed(value int64, decimals int) string {
+	scale := int64(1)
+	for i := 0; i < decimals; i++ {
+		scale *= 10
+	}
+
+	whole := value / scale
+	fraction := value % scale
+	if fraction == 0 {
+		return strconv.FormatInt(whole, 10)
+	}
+
+	text := fmt.Sprintf("%0*d", decimals, fraction)
+	return strconv.FormatInt(whole, 10) + "." + strings.TrimRight(text, "0")
 }
@@ -0,0 +1,181 @@
+package valueobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCents(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		units   int64
+		wantErr bool
+	}{
+		{name: "whole cents", value: "56", units: 5600},
+		{name: "zero", value: "0", units: 0},
+		{name: "payout", value: "100", units: 10000},
+		{name: "one decimal", value: "56.5", units: 5650},
+		{name: "two decimals", value: "56.25", units: 5625},
+		{name: "trailing zeros", value: "56.2500", units: 5625},
+		{name: "leading dot", value: ".5", units: 50},
+		{name: "trailing dot", value: "56.", units: 5600},
+		{name: "surrounding spaces", value: " 56 ", units: 5600},
+		{name: "empty", value: "", wantErr: true},
+		{name: "negative", value: "-1", wantErr: true},
+		{name: "explicit sign", value: "+1", wantErr: true},
+		{name: "above payout", value: "100.01", wantErr: true},
+		{name: "too precise", value: "56.125", wantErr: true},
+		{name: "letters", value: "5a", wantErr: true},
+		{name: "letters in fraction", value: "5.a", wantErr: true},
+		{name: "overflow", value: "92233720368547758", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, err := ParseCents(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPrice)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.units, price.Units())
+		})
+	}
+}
+
+func TestParseDollars(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		units   int64
+		wantErr bool
+	}{
+		{name: "four decimals", value: "0.5650", units: 5650},
+		{name: "sub-cent", value: "0.5625", units: 5625},
+		{name: "smallest unit", value: "0.0001", units: 1},
+		{name: "one dollar", value: "1", units: 10000},
+		{name: "one dollar with decimals", value: "1.0000", units: 10000},
+		{name: "zero", value: "0.0000", units: 0},
+		{name: "empty", value: "", wantErr: true},
+		{name: "negative", value: "-0.50", wantErr: true},
+		{name: "above one dollar", value: "1.0001", wantErr: true},
+		{name: "too precise", value: "0.56251", wantErr: true},
+		{name: "not a number", value: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, err := ParseDollars(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPrice)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.units, price.Units())
+		})
+	}
+}
+
+func TestPriceRound(t *testing.T) {
+	tests := []struct {
+		name     string
+		units    int64
+		decimals int
+		want     int64
+	}{
+		{name: "full precision unchanged", units: 5625, decimals: 2, want: 5625},
+		{name: "above precision unchanged", units: 5625, decimals: 4, want: 5625},
+		{name: "one decimal rounds half up", units: 5625, decimals: 1, want: 5630},
+		{name: "one decimal rounds down", units: 5624, decimals: 1, want: 5620},
+		{name: "whole cents rounds half up", units: 5650, decimals: 0, want: 5700},
+		{name: "whole cents rounds down", units: 5649, decimals: 0, want: 5600},
+		{name: "tens of cents", units: 5500, decimals: -1, want: 6000},
+		{name: "whole dollars", units: 4999, decimals: -2, want: 0},
+		{name: "whole dollars rounds half up", units: 5000, decimals: -2, want: 10000},
+		{name: "below whole dollars clamps", units: 5000, decimals: -5, want: 10000},
+		{name: "payout stays in range", units: 10000, decimals: 0, want: 10000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, err := NewPriceFromUnits(tt.units)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, price.Round(tt.decimals).Units())
+		})
+	}
+}
+
+func TestPriceValueTruncates(t *testing.T) {
+	tests := []struct {
+		name  string
+		units int64
+		want  int64
+	}{
+		{name: "whole cents", units: 5600, want: 56},
+		{name: "below half a cent", units: 5625, want: 56},
+		{name: "half a cent", units: 5650, want: 56},
+		{name: "above half a cent", units: 5699, want: 56},
+		{name: "under a cent", units: 99, want: 0},
+		{name: "payout", units: 10000, want: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, err := NewPriceFromUnits(tt.units)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, price.Value())
+			assert.Equal(t, tt.want, price.Cents())
+		})
+	}
+}
+
+func TestPriceArithmetic(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     int64 // Units
+		sum      int64
+		sumErr   bool
+		diff     int64
+		diffErr  bool
+		midpoint int64
+	}{
+		{name: "whole cents", a: 5600, b: 4000, sum: 9600, diff: 1600, midpoint: 4800},
+		{name: "sub-cent", a: 5625, b: 1050, sum: 6675, diff: 4575, midpoint: 3337},
+		{name: "sum at payout", a: 6000, b: 4000, sum: 10000, diff: 2000, midpoint: 5000},
+		{name: "sum above payout", a: 6000, b: 4001, sumErr: true, diff: 1999, midpoint: 5000},
+		{name: "negative difference", a: 4000, b: 5600, sum: 9600, diffErr: true, midpoint: 4800},
+		{name: "equal prices", a: 5625, b: 5625, sumErr: true, diff: 0, midpoint: 5625},
+		{name: "zero", a: 0, b: 0, sum: 0, diff: 0, midpoint: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewPriceFromUnits(tt.a)
+			require.NoError(t, err)
+			b, err := NewPriceFromUnits(tt.b)
+			require.NoError(t, err)
+
+			sum, err := a.Add(b)
+			if tt.sumErr {
+				assert.ErrorIs(t, err, ErrInvalidPrice)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.sum, sum.Units())
+			}
+
+			diff, err := a.Sub(b)
+			if tt.diffErr {
+				assert.ErrorIs(t, err, ErrInvalidPrice)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.diff, diff.Units())
+			}
+
+			assert.Equal(t, tt.midpoint, a.Midpoint(b).Units())
+			assert.Equal(t, tt.midpoint, b.Midpoint(a).Units())
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidStrike is returned when strike bounds do not match the strike type
+	ErrInvalidStrike = errors.New("invalid strike")
+)
+
+// StrikeType describes how a market's strike bounds are interpreted
+type StrikeType string
+
+const (
+	StrikeTypeGreater        StrikeType = "greater"
+	StrikeTypeGreaterOrEqual StrikeType = "greater_or_equal"
+	StrikeTypeLess           StrikeType = "less"
+	StrikeTypeLessOrEqual    StrikeType = "less_or_equal"
+	StrikeTypeBetween        StrikeType = "between"
+	StrikeTypeFunctional     StrikeType = "functional"
+	StrikeTypeCustom         StrikeType = "custom"
+	StrikeTypeStructured     StrikeType = "structured"
+)
+
+// Strike represents the threshold or range a scalar/bracket market resolves against
+type Strike struct {
+	strikeType StrikeType
+	floor      *float64
+	cap        *float64
+	functional string
+}
+
+// strikeJSON is the serialized form of a Strike
+type strikeJSON struct {
+	Type       StrikeType `json:"type"`
+	Floor      *float64   `json:"floor,omitempty"`
+	Cap        *float64   `json:"cap,omitempty"`
+	Functional string     `json:"functional,omitempty"`
+}
+
+// NewStrike creates a new Strike value object
+func NewStrike(strikeType string, floor, cap *float64, functional string) (Strike, error) {
+	normalized := StrikeType(strings.ToLower(strings.TrimSpace(strikeType)))
+
+	switch normalized {
+	case StrikeTypeGreater, StrikeTypeGreaterOrEqual:
+		if floor == nil {
+			return Strike{}, fmt.Errorf("%w: %s strike requires a floor", ErrInvalidStrike, normalized)
+		}
+	case StrikeTypeLess, StrikeTypeLessOrEqual:
+		if cap == nil {
+			return Strike{}, fmt.Errorf("%w: %s strike requires a cap", ErrInvalidStrike, normalized)
+		}
+	case StrikeTypeBetween:
+		if floor == nil || cap == nil {
+			return Strike{}, fmt.Errorf("%w: between strike requires a floor and a cap", ErrInvalidStrike)
+		}
+		if *floor > *cap {
+			return Strike{}, fmt.Errorf("%w: floor %v exceeds cap %v", ErrInvalidStrike, *floor, *cap)
+		}
+	case StrikeTypeFunctional, StrikeTypeCustom, StrikeTypeStructured:
+	case "":
+		return Strike{}, fmt.Errorf("%w: strike type cannot be empty", ErrInvalidStrike)
+	default:
+		return Strike{}, fmt.Errorf("%w: unknown strike type %s", ErrInvalidStrike, strikeType)
+	}
+
+	return Strike{
+		strikeType: normalized,
+		floor:      copyFloat(floor),
+		cap:        copyFloat(cap),
+		functional: functional,
+	}, nil
+}
+
+// Type returns the strike type
+func (s Strike) Type() StrikeType {
+	return s.strikeType
+}
+
+// Floor returns the lower bound, if any
+func (s Strike) Floor() (float64, bool) {
+	if s.floor == nil {
+		return 0, false
+	}
+	return *s.floor, true
+}
+
+// Cap returns the upper bound, if any
+func (s Strike) Cap() (float64, bool) {
+	if s.cap == nil {
+		return 0, false
+	}
+	return *s.cap, true
+}
+
+// Functional returns the functional strike description, if any
+func (s Strike) Functional() string {
+	return s.functional
+}
+
+// IsZero checks if the strike is unset
+func (s Strike) IsZero() bool {
+	return s.strikeType == ""
+}
+
+// SortKey returns the value brackets are ordered by: the lower bound, or -Inf for open-ended low brackets
+func (s Strike) SortKey() float64 {
+	if s.floor != nil {
+		return *s.floor
+	}
+	if s.cap != nil {
+		return math.Inf(-1)
+	}
+	return math.Inf(1)
+}
+
+// Less orders strikes by lower bound, then upper bound
+func (s Strike) Less(other Strike) bool {
+	if s.SortKey() != other.SortKey() {
+		return s.SortKey() < other.SortKey()
+	}
+
+	sCap, otherCap := math.Inf(1), math.Inf(1)
+	if s.cap != nil {
+		sCap = *s.cap
+	}
+	if other.cap != nil {
+		otherCap = *other.cap
+	}
+	return sCap < otherCap
+}
+
+// Contains checks if a value resolves this strike to YES
+func (s Strike) Contains(value float64) bool {
+	switch s.strikeType {
+	case StrikeTypeGreater:
+		return value > *s.floor
+	case StrikeTypeGreaterOrEqual:
+		return value >= *s.floor
+	case StrikeTypeLess:
+		return value < *s.cap
+	case StrikeTypeLessOrEqual:
+		return value <= *s.cap
+	case StrikeTypeBetween:
+		return value >= *s.floor && value <= *s.cap
+	default:
+		return false
+	}
+}
+
+// Label returns a short human-readable description of the strike
+func (s Strike) Label() string {
+	switch s.strikeType {
+	case StrikeTypeGreater:
+		return ">" + formatBound(*s.floor)
+	case StrikeTypeGreaterOrEqual:
+		return ">=" + formatBound(*s.floor)
+	case StrikeTypeLess:
+		return "<" + formatBound(*s.cap)
+	case StrikeTypeLessOrEqual:
+		return "<=" + formatBound(*s.cap)
+	case StrikeTypeBetween:
+		return formatBound(*s.floor) + "-" + formatBound(*s.cap)
+	default:
+		return s.functional
+	}
+}
+
+// Equals checks if two strikes are equal
+func (s Strike) Equals(other Strike) bool {
+	return s.strikeType == other.strikeType &&
+		floatPtrEqual(s.floor, other.floor) &&
+		floatPtrEqual(s.cap, other.cap) &&
+		s.functional == other.functional
+}
+
+// MarshalJSON implements json.Marshaler
+func (s Strike) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strikeJSON{
+		Type:       s.strikeType,
+		Floor:      s.floor,
+		Cap:        s.cap,
+		Functional: s.functional,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (s *Strike) UnmarshalJSON(data []byte) error {
+	var raw strikeJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	strike, err := NewStrike(string(raw.Type), raw.Floor, raw.Cap, raw.Functional)
+	if err != nil {
+		return err
+	}
+
+	*s = strike
+	return nil
+}
+
+// formatBound formats a strike bound without trailing zeros
+func formatBound(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// copyFloat copies a float pointer so a Strike never aliases caller memory
+func copyFloat(value *float64) *float64 {
+	if value == nil {
+		return nil
+	}
+	v := *value
+	return &v
+}
+
+// floatPtrEqual compares two optional floats
+func floatPtrEqual(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
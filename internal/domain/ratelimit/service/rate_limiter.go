@@ -16,8 +16,9 @@ type RateLimitRepository interface {
 	// Save persists the rate limit state
 	Save(ctx context.Context, rateLimit *entity.RateLimit) error
 
-	// IncrementAndCheck atomically increments and checks if request is allowed
-	IncrementAndCheck(ctx context.Context, userID string, tier valueobject.RateLimitTier) (allowed bool, remaining int, resetTime time.Time, err error)
+	// IncrementAndCheck atomically checks if the request is allowed in the bucket of its route class
+	// and, if so, counts it with its cost. For a denied request, the reset time is when it can be retried.
+	IncrementAndCheck(ctx context.Context, userID string, tier valueobject.RateLimitTier, class valueobject.RouteClass, cost int) (allowed bool, remaining int, resetTime time.Time, err error)
 }
 
 // RateLimiter is a domain service that handles rate limiting logic.
@@ -32,10 +33,15 @@ func NewRateLimiter(repo RateLimitRepository) *RateLimiter {
 	}
 }
 
-// CheckLimit verifies if a request is allowed for the user and increments the counter.
+// CheckLimit verifies if a request is allowed for the user and increments the counter by its cost.
+// Most requests cost 1; heavier ones, such as batch lookups, count as one request of a larger weight.
+// Each route class has its own counter, with the tier's limit.
 // Returns whether the request is allowed, remaining requests, and reset time.
-func (rl *RateLimiter) CheckLimit(ctx context.Context, userID string, tier valueobject.RateLimitTier) (allowed bool, remaining int, resetTime time.Time, err error) {
-	return rl.repo.IncrementAndCheck(ctx, userID, tier)
+func (rl *RateLimiter) CheckLimit(ctx context.Context, userID string, tier valueobject.RateLimitTier, class valueobject.RouteClass, cost int) (allowed bool, remaining int, resetTime time.Time, err error) {
+	if cost < 1 {
+		cost = 1
+	}
+	return rl.repo.IncrementAndCheck(ctx, userID, tier, class, cost)
 }
 
 // GetCurrentLimit retrieves the current rate limit status for a user without incrementing.
@@ -0,0 +1,49 @@
+package valueobject
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedAlgorithm is returned for an unknown rate limiting algorithm
+var ErrUnsupportedAlgorithm = errors.New("unsupported rate limit algorithm")
+
+// RateLimitAlgorithm is how requests are counted against a tier's limit
+type RateLimitAlgorithm string
+
+const (
+	// AlgorithmFixedWindow counts requests in consecutive windows. Clients can make up to twice
+	// the limit across a window boundary.
+	AlgorithmFixedWindow RateLimitAlgorithm = "fixed_window"
+	// AlgorithmSlidingLog records every request and counts those within the last window.
+	// It is exact, at the cost of memory proportional to the limit.
+	AlgorithmSlidingLog RateLimitAlgorithm = "sliding_log"
+	// AlgorithmSlidingWindow weighs the previous window's count by its overlap with the last
+	// window. It approximates the sliding log with two counters.
+	AlgorithmSlidingWindow RateLimitAlgorithm = "sliding_window"
+	// AlgorithmGCRA spaces requests evenly over the window, allowing bursts of up to the tier's
+	// burst (the generic cell rate algorithm)
+	AlgorithmGCRA RateLimitAlgorithm = "gcra"
+)
+
+// RateLimitAlgorithms lists the supported algorithms
+func RateLimitAlgorithms() []RateLimitAlgorithm {
+	return []RateLimitAlgorithm{AlgorithmFixedWindow, AlgorithmSlidingLog, AlgorithmSlidingWindow, AlgorithmGCRA}
+}
+
+// NewRateLimitAlgorithm creates a RateLimitAlgorithm from its name
+func NewRateLimitAlgorithm(name string) (RateLimitAlgorithm, error) {
+	algorithm := RateLimitAlgorithm(strings.ToLower(strings.TrimSpace(name)))
+	for _, supported := range RateLimitAlgorithms() {
+		if algorithm == supported {
+			return algorithm, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, name)
+}
+
+// String returns the algorithm name
+func (a RateLimitAlgorithm) String() string {
+	return string(a)
+}
@@ -1,53 +1,111 @@
 package valueobject
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
+	"sync"
 	"time"
 )
 
+// Names of the built-in tiers. Their limits come from configuration; the authenticated and
+// unauthenticated tiers must always exist, as every request falls in one of them by default.
+const (
+	TierAuthenticated   = "authenticated"
+	TierUnauthenticated = "unauthenticated"
+	TierWorker          = "worker"
+)
+
+// ErrInvalidTier is returned for an unknown tier name or an invalid tier definition
+var ErrInvalidTier = errors.New("invalid rate limit tier")
+
+var tierNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
 // RateLimitTier represents different rate limit tiers in the system.
 type RateLimitTier struct {
 	name        string
 	maxRequests int
 	window      time.Duration
+	burst       int
 }
 
-// Predefined rate limit tiers.
+// Built-in tiers at their default limits, until tiers are configured
 var (
-	// Authenticated tier: 100 requests per minute for logged-in users
-	Authenticated = RateLimitTier{
-		name:        "authenticated",
-		maxRequests: 100,
-		window:      time.Minute,
+	tiersMu sync.RWMutex
+	tiers   = map[string]RateLimitTier{
+		TierAuthenticated:   {name: TierAuthenticated, maxRequests: 100, window: time.Minute, burst: 100},
+		TierUnauthenticated: {name: TierUnauthenticated, maxRequests: 10, window: time.Minute, burst: 10},
+		TierWorker:          {name: TierWorker, maxRequests: 80, window: time.Minute, burst: 80},
 	}
+)
 
-	// Unauthenticated tier: 10 requests per minute for anonymous users
-	Unauthenticated = RateLimitTier{
-		name:        "unauthenticated",
-		maxRequests: 10,
-		window:      time.Minute,
+// DefineRateLimitTier validates a tier definition. A burst of 0 defaults to maxRequests.
+func DefineRateLimitTier(name string, maxRequests int, window time.Duration, burst int) (RateLimitTier, error) {
+	if !tierNamePattern.MatchString(name) {
+		return RateLimitTier{}, fmt.Errorf("%w: name %q must be lowercase letters, digits, '_' or '-'", ErrInvalidTier, name)
+	}
+	if maxRequests < 1 {
+		return RateLimitTier{}, fmt.Errorf("%w: %s: limit must be positive", ErrInvalidTier, name)
+	}
+	if window < time.Second {
+		return RateLimitTier{}, fmt.Errorf("%w: %s: window must be at least 1s", ErrInvalidTier, name)
 	}
+	if burst == 0 {
+		burst = maxRequests
+	}
+	if burst < 1 {
+		return RateLimitTier{}, fmt.Errorf("%w: %s: burst must be positive", ErrInvalidTier, name)
+	}
+
+	return RateLimitTier{
+		name:        name,
+		maxRequests: maxRequests,
+		window:      window,
+		burst:       burst,
+	}, nil
+}
 
-	// Worker tier: 80 requests per minute for background workers
-	Worker = RateLimitTier{
-		name:        "worker",
-		maxRequests: 80,
-		window:      time.Minute,
+// ConfigureTiers replaces the known tiers. It runs once at startup, before requests are served.
+func ConfigureTiers(definitions []RateLimitTier) error {
+	configured := make(map[string]RateLimitTier, len(definitions))
+	for _, tier := range definitions {
+		if _, exists := configured[tier.name]; exists {
+			return fmt.Errorf("%w: %s defined twice", ErrInvalidTier, tier.name)
+		}
+		configured[tier.name] = tier
 	}
-)
 
-// NewRateLimitTier creates a new RateLimitTier from a tier name.
+	for _, required := range []string{TierAuthenticated, TierUnauthenticated} {
+		if _, ok := configured[required]; !ok {
+			return fmt.Errorf("%w: the %s tier must be defined", ErrInvalidTier, required)
+		}
+	}
+
+	tiersMu.Lock()
+	tiers = configured
+	tiersMu.Unlock()
+	return nil
+}
+
+// NewRateLimitTier creates a new RateLimitTier from a tier name, among the configured tiers.
 func NewRateLimitTier(tierName string) (RateLimitTier, error) {
-	switch tierName {
-	case "authenticated":
-		return Authenticated, nil
-	case "unauthenticated":
-		return Unauthenticated, nil
-	case "worker":
-		return Worker, nil
-	default:
-		return RateLimitTier{}, fmt.Errorf("invalid rate limit tier: %s", tierName)
+	tiersMu.RLock()
+	tier, ok := tiers[tierName]
+	tiersMu.RUnlock()
+
+	if !ok {
+		return RateLimitTier{}, fmt.Errorf("%w: %s", ErrInvalidTier, tierName)
+	}
+	return tier, nil
+}
+
+// MustRateLimitTier returns a built-in tier that ConfigureTiers guarantees to exist.
+func MustRateLimitTier(tierName string) RateLimitTier {
+	tier, err := NewRateLimitTier(tierName)
+	if err != nil {
+		panic(err)
 	}
+	return tier
 }
 
 // Name returns the tier name.
@@ -65,7 +123,22 @@ func (t RateLimitTier) Window() time.Duration {
 	return t.window
 }
 
+// Burst returns how many requests may arrive back to back. Only the GCRA algorithm, which
+// spaces requests over the window, uses it; the others admit the whole limit at once.
+func (t RateLimitTier) Burst() int {
+	return t.burst
+}
+
+// MaxCost returns the largest cost a single request can have and still be admitted under
+// algorithm: the whole limit, or under GCRA, the burst.
+func (t RateLimitTier) MaxCost(algorithm RateLimitAlgorithm) int {
+	if algorithm == AlgorithmGCRA {
+		return min(t.maxRequests, t.burst)
+	}
+	return t.maxRequests
+}
+
 // String returns a string representation of the tier.
 func (t RateLimitTier) String() string {
-	return fmt.Sprintf("%s (%d req/%s)", t.name, t.maxRequests, t.window)
+	return fmt.Sprintf("%s (%d req/%s, burst %d)", t.name, t.maxRequests, t.window, t.burst)
 }
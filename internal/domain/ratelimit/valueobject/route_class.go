@@ -0,0 +1,39 @@
+package valueobject
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownRouteClass is returned for a route class other than the defined ones
+var ErrUnknownRouteClass = errors.New("unknown route class")
+
+// RouteClass groups routes that share a rate limit bucket. Each class is limited separately
+// under the client's tier, so traffic of one class cannot use up the limit of another.
+type RouteClass string
+
+const (
+	// RouteClassRead is the class of market data reads, and of any route not classified otherwise
+	RouteClassRead RouteClass = "read"
+	// RouteClassHandshake is the class of authentication and key discovery
+	RouteClassHandshake RouteClass = "handshake"
+	// RouteClassStream is the class of streaming subscriptions
+	RouteClassStream RouteClass = "stream"
+)
+
+// NewRouteClass creates a RouteClass from its name
+func NewRouteClass(name string) (RouteClass, error) {
+	class := RouteClass(strings.ToLower(strings.TrimSpace(name)))
+	switch class {
+	case RouteClassRead, RouteClassHandshake, RouteClassStream:
+		return class, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownRouteClass, name)
+	}
+}
+
+// String returns the class name
+func (c RouteClass) String() string {
+	return string(c)
+}
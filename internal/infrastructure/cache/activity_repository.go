@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+	"upwork-test/internal/domain/category/entity"
+	"upwork-test/internal/domain/category/repository"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// categoryActivityCacheTTL keeps metrics through a few missed analytics runs
+	categoryActivityCacheTTL = 1 * time.Hour
+)
+
+// ActivityRepository stores category activity metrics in Redis.
+type ActivityRepository struct {
+	redisClient *redis.Client
+	keyBuilder  *KeyBuilder
+}
+
+// NewActivityRepository creates a new activity repository.
+func NewActivityRepository(redisClient *redis.Client) *ActivityRepository {
+	return &ActivityRepository{
+		redisClient: redisClient,
+		keyBuilder:  NewKeyBuilder("kalshi"),
+	}
+}
+
+// Get retrieves the latest activity metrics for a category.
+func (r *ActivityRepository) Get(ctx context.Context, categoryName string) (*entity.CategoryActivity, error) {
+	data, err := r.redisClient.Get(ctx, r.keyBuilder.CategoryActivity(categoryName)).Result()
+	if err == redis.Nil {
+		return nil, repository.ErrActivityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category activity: %w", err)
+	}
+
+	var activity entity.CategoryActivity
+	if err := json.Unmarshal([]byte(data), &activity); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal category activity: %w", err)
+	}
+
+	return &activity, nil
+}
+
+// Save stores activity metrics for a category.
+func (r *ActivityRepository) Save(ctx context.Context, activity *entity.CategoryActivity) error {
+	data, err := json.Marshal(activity)
+	if err != nil {
+		return fmt.Errorf("failed to marshal category activity: %w", err)
+	}
+
+	if err := r.redisClient.Set(ctx, r.keyBuilder.CategoryActivity(activity.CategoryName.String()), data, categoryActivityCacheTTL).Err(); err != nil {
+		return fmt.Errorf("failed to save category activity: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+	"upwork-test/internal/domain/auth/entity"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// auditLogMaxLen caps the audit stream; trimming is approximate
+	auditLogMaxLen = 10000
+)
+
+// AuditRepository keeps the audit log of client registry changes in a Redis stream.
+type AuditRepository struct {
+	redisClient *redis.Client
+	keyBuilder  *KeyBuilder
+}
+
+// NewAuditRepository creates a new audit repository.
+func NewAuditRepository(redisClient *redis.Client) *AuditRepository {
+	return &AuditRepository{
+		redisClient: redisClient,
+		keyBuilder:  NewKeyBuilder("kalshi"),
+	}
+}
+
+// Append adds an entry to the stream. The stream assigns the entry ID.
+func (r *AuditRepository) Append(ctx context.Context, entry *entity.AuditEntry) error {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	err = r.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: r.keyBuilder.AuthAudit(),
+		MaxLen: auditLogMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"action":    entry.Action,
+			"actor":     entry.Actor,
+			"client_id": entry.ClientID,
+			"time":      entry.Time.Format(time.RFC3339),
+			"payload":   payload,
+		},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// ListRecent retrieves up to limit entries, newest first.
+func (r *AuditRepository) ListRecent(ctx context.Context, limit int) ([]*entity.AuditEntry, error) {
+	messages, err := r.redisClient.XRevRangeN(ctx, r.keyBuilder.AuthAudit(), "+", "-", int64(limit)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+
+	entries := make([]*entity.AuditEntry, 0, len(messages))
+	for _, message := range messages {
+		payload, ok := message.Values["payload"].(string)
+		if !ok {
+			continue
+		}
+
+		var entry entity.AuditEntry
+		if err := json.Unmarshal([]byte(payload), &entry); err != nil {
+			fmt.Printf("Warning: skipping unreadable audit entry %s: %v\n", message.ID, err)
+			continue
+		}
+		entry.ID = message.ID
+		entries = append(entries, &entry)
+	}
+
+	return entries, nil
+}
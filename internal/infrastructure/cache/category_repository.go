@@ -3,10 +3,12 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 	"upwork-test/internal/domain/category/entity"
 	"upwork-test/internal/domain/category/repository"
+	categoryservice "upwork-test/internal/domain/category/service"
 	"upwork-test/internal/domain/category/valueobject"
 	marketrepo "upwork-test/internal/domain/market/repository"
 	"upwork-test/internal/infrastructure/kalshi"
@@ -17,6 +19,12 @@ import (
 const (
 	categoryListCacheTTL     = 24 * time.Hour
 	categoryOverviewCacheTTL = 10 * time.Minute
+
+	// maxActivityAge is how old worker activity metrics may be before overviews ignore them
+	maxActivityAge = 1 * time.Hour
+
+	// overviewMoversLimit is the number of markets in each movers list of an overview
+	overviewMoversLimit = 10
 )
 
 // CategoryRepository implements the category repository with Redis caching.
@@ -24,15 +32,24 @@ type CategoryRepository struct {
 	redisClient  *redis.Client
 	kalshiClient *kalshi.Client
 	marketRepo   marketrepo.MarketRepository
+	activityRepo repository.ActivityRepository
+	moversRanker *categoryservice.MoversRanker
 	keyBuilder   *KeyBuilder
 }
 
 // NewCategoryRepository creates a new category repository.
-func NewCategoryRepository(redisClient *redis.Client, kalshiClient *kalshi.Client, marketRepo marketrepo.MarketRepository) *CategoryRepository {
+func NewCategoryRepository(
+	redisClient *redis.Client,
+	kalshiClient *kalshi.Client,
+	marketRepo marketrepo.MarketRepository,
+	activityRepo repository.ActivityRepository,
+) *CategoryRepository {
 	return &CategoryRepository{
 		redisClient:  redisClient,
 		kalshiClient: kalshiClient,
 		marketRepo:   marketRepo,
+		activityRepo: activityRepo,
+		moversRanker: categoryservice.NewMoversRanker(),
 		keyBuilder:   NewKeyBuilder("kalshi"),
 	}
 }
@@ -91,6 +108,11 @@ func (r *CategoryRepository) GetOverview(ctx context.Context, categoryName strin
 		}
 	}
 
+	return r.RefreshOverview(ctx, categoryName)
+}
+
+// RefreshOverview recomputes and caches the overview metrics for a category.
+func (r *CategoryRepository) RefreshOverview(ctx context.Context, categoryName string) (*entity.CategoryOverview, error) {
 	overview, err := r.computeOverview(ctx, categoryName)
 	if err != nil {
 		return nil, err
@@ -124,7 +146,7 @@ func (r *CategoryRepository) computeOverview(ctx context.Context, categoryName s
 		return nil, repository.ErrCategoryNotFound
 	}
 
-	markets, total, err := r.marketRepo.ListByCategory(ctx, categoryName, 1, 1000, "")
+	markets, total, err := r.marketRepo.ListByCategory(ctx, categoryName, 1, 1000, marketrepo.MarketQuery{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch markets: %w", err)
 	}
@@ -146,19 +168,44 @@ func (r *CategoryRepository) computeOverview(ctx context.Context, categoryName s
 		avgLiquidity = float64(totalLiquidity) / float64(len(markets))
 	}
 
-	// ActiveTraders24h not available from Kalshi API, would come from analytics service in production
+	// Prefer the worker's metrics over the full market set; the listing above only samples a few series
+	var trades24h int
+	activity, err := r.activityRepo.Get(ctx, catName.String())
+	switch {
+	case err == nil && !activity.IsStale(maxActivityAge):
+		total = activity.TotalMarkets
+		totalVolume24h = activity.TotalVolume24h
+		avgLiquidity = activity.AverageLiquidity
+		trades24h = activity.TradeCount
+	case err != nil && !errors.Is(err, repository.ErrActivityNotFound):
+		fmt.Printf("Warning: failed to get activity for category %s: %v\n", catName.String(), err)
+		activity = nil
+	default:
+		activity = nil
+	}
+
 	overview, err := entity.NewCategoryOverview(
 		catName,
 		total,
 		totalVolume24h,
 		avgLiquidity,
-		0, // ActiveTraders24h - not available from current API
+		trades24h,
 		categoryOverviewCacheTTL,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create overview: %w", err)
 	}
 
+	overview.Activity = activity
+	overview.PriceChanges24h = r.moversRanker.Changes(markets, categoryservice.PreviousDayBaseline)
+	overview.Movers = r.moversRanker.Rank(
+		valueobject.MoverWindow24h,
+		overview.ComputedAt.Add(-valueobject.MoverWindow24h.Duration()),
+		markets,
+		categoryservice.PreviousDayBaseline,
+		overviewMoversLimit,
+	)
+
 	return overview, nil
 }
 
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/valueobject"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// MigrateClientScopes grants the default scopes to every client holding none. Clients
+// registered before routes required scopes were stored with an empty list, which now grants
+// nothing. It runs once: a marker records the migration, so clients later registered
+// without scopes on purpose keep none. Returns the number of clients granted scopes.
+func MigrateClientScopes(ctx context.Context, redisClient *redis.Client) (int, error) {
+	keyBuilder := NewKeyBuilder("kalshi")
+
+	done, err := redisClient.Exists(ctx, keyBuilder.AuthScopesMigrated()).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read client scopes migration: %w", err)
+	}
+	if done > 0 {
+		return 0, nil
+	}
+
+	clientRepo := NewClientRepository(redisClient)
+	clients, err := clientRepo.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	for _, client := range clients {
+		if len(client.Scopes) > 0 {
+			continue
+		}
+
+		_, err := clientRepo.Update(ctx, client.ID, func(client *entity.Client) (bool, error) {
+			if len(client.Scopes) > 0 {
+				return false, nil
+			}
+			client.Scopes = valueobject.DefaultScopes()
+			return true, nil
+		})
+		if err != nil {
+			return migrated, fmt.Errorf("failed to grant default scopes to client %s: %w", client.ID, err)
+		}
+		migrated++
+	}
+
+	if err := redisClient.Set(ctx, keyBuilder.AuthScopesMigrated(), 1, 0).Err(); err != nil {
+		return migrated, fmt.Errorf("failed to record client scopes migration: %w", err)
+	}
+
+	return migrated, nil
+}
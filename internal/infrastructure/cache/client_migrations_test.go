@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/valueobject"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRedisClient connects to the Redis at REDIS_HOST and REDIS_PORT (localhost:6379 by
+// default), on the database named by REDIS_TEST_DB. That database is flushed before and
+// after the test, so the test is skipped unless it is set, and when Redis is unreachable.
+func testRedisClient(t *testing.T) *redis.Client {
+	t.Helper()
+
+	db, err := strconv.Atoi(os.Getenv("REDIS_TEST_DB"))
+	if err != nil {
+		t.Skip("REDIS_TEST_DB is not set to a scratch Redis database")
+	}
+
+	host, port := os.Getenv("REDIS_HOST"), os.Getenv("REDIS_PORT")
+	if host == "" {
+		host = "localhost"
+	}
+	if port == "" {
+		port = "6379"
+	}
+	addr := net.JoinHostPort(host, port)
+	client := redis.NewClient(&redis.Options{
+		Addr:        addr,
+		Password:    os.Getenv("REDIS_PASSWORD"),
+		DB:          db,
+		DialTimeout: time.Second,
+		// Fail fast when Redis is not running, so the test is skipped quickly
+		DialerRetries: 1,
+	})
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		t.Skipf("Redis unreachable at %s: %v", addr, err)
+	}
+	require.NoError(t, client.FlushDB(ctx).Err())
+	t.Cleanup(func() {
+		client.FlushDB(context.Background())
+		client.Close()
+	})
+	return client
+}
+
+// createTestClient registers a client holding scopes, which may be empty
+func createTestClient(t *testing.T, repo *ClientRepository, clientID string, scopes []string) {
+	t.Helper()
+
+	now := time.Now()
+	require.NoError(t, repo.Create(context.Background(), &entity.Client{
+		ID:        clientID,
+		Name:      clientID,
+		Tier:      "authenticated",
+		Scopes:    scopes,
+		Status:    entity.ClientStatusActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}))
+}
+
+func TestMigrateClientScopes(t *testing.T) {
+	redisClient := testRedisClient(t)
+	ctx := context.Background()
+	repo := NewClientRepository(redisClient)
+
+	createTestClient(t, repo, "legacy", nil)
+	createTestClient(t, repo, "reader", []string{valueobject.ScopeMarketsRead})
+
+	migrated, err := MigrateClientScopes(ctx, redisClient)
+	require.NoError(t, err)
+	assert.Equal(t, 1, migrated)
+
+	legacy, err := repo.GetByID(ctx, "legacy")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.DefaultScopes(), legacy.Scopes)
+	reader, err := repo.GetByID(ctx, "reader")
+	require.NoError(t, err)
+	assert.Equal(t, []string{valueobject.ScopeMarketsRead}, reader.Scopes)
+
+	marked, err := redisClient.Exists(ctx, NewKeyBuilder("kalshi").AuthScopesMigrated()).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), marked)
+
+	// Once marked, clients registered without scopes on purpose keep none
+	createTestClient(t, repo, "restricted", []string{})
+	migrated, err = MigrateClientScopes(ctx, redisClient)
+	require.NoError(t, err)
+	assert.Zero(t, migrated)
+
+	restricted, err := repo.GetByID(ctx, "restricted")
+	require.NoError(t, err)
+	assert.Empty(t, restricted.Scopes)
+}
@@ -0,0 +1,276 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/repository"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// maxClientSaveAttempts bounds the retries of a write that lost a race on the client or key index
+const maxClientSaveAttempts = 5
+
+// ClientRepository stores the API client registry in Redis. Each client is one JSON value;
+// a set lists the client IDs and a hash maps every key ID to the client owning it.
+type ClientRepository struct {
+	redisClient *redis.Client
+	keyBuilder  *KeyBuilder
+}
+
+// NewClientRepository creates a new client repository.
+func NewClientRepository(redisClient *redis.Client) *ClientRepository {
+	return &ClientRepository{
+		redisClient: redisClient,
+		keyBuilder:  NewKeyBuilder("kalshi"),
+	}
+}
+
+// GetByID retrieves a client by its ID.
+func (r *ClientRepository) GetByID(ctx context.Context, clientID string) (*entity.Client, error) {
+	return r.get(ctx, r.redisClient, clientID)
+}
+
+// GetByKeyID retrieves the client owning an API key.
+func (r *ClientRepository) GetByKeyID(ctx context.Context, keyID string) (*entity.Client, error) {
+	clientID, err := r.redisClient.HGet(ctx, r.keyBuilder.AuthKeyIndex(), keyID).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, repository.ErrClientNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up API key: %w", err)
+	}
+
+	return r.get(ctx, r.redisClient, clientID)
+}
+
+// List retrieves every registered client, ordered by ID.
+func (r *ClientRepository) List(ctx context.Context) ([]*entity.Client, error) {
+	clientIDs, err := r.redisClient.SMembers(ctx, r.keyBuilder.AuthClientIndex()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clients: %w", err)
+	}
+	if len(clientIDs) == 0 {
+		return []*entity.Client{}, nil
+	}
+
+	keys := make([]string, len(clientIDs))
+	for i, clientID := range clientIDs {
+		keys[i] = r.keyBuilder.AuthClient(clientID)
+	}
+
+	values, err := r.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clients: %w", err)
+	}
+
+	clients := make([]*entity.Client, 0, len(values))
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var client entity.Client
+		if err := json.Unmarshal([]byte(data), &client); err != nil {
+			fmt.Printf("Warning: skipping unreadable client %s: %v\n", clientIDs[i], err)
+			continue
+		}
+		clients = append(clients, &client)
+	}
+
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].ID < clients[j].ID
+	})
+
+	return clients, nil
+}
+
+// Create registers a new client, failing with ErrClientExists if the ID is taken
+// and ErrKeyIDConflict if another client holds one of its key IDs.
+func (r *ClientRepository) Create(ctx context.Context, client *entity.Client) error {
+	return r.save(ctx, client, true)
+}
+
+// Save creates or replaces a client. Keys the client no longer holds are unindexed in the
+// same transaction, so a removed key stops resolving as soon as the client is saved.
+// Fails with ErrKeyIDConflict if another client holds one of its key IDs.
+func (r *ClientRepository) Save(ctx context.Context, client *entity.Client) error {
+	return r.save(ctx, client, false)
+}
+
+// Delete removes a client and unindexes its keys.
+func (r *ClientRepository) Delete(ctx context.Context, clientID string) error {
+	clientKey := r.keyBuilder.AuthClient(clientID)
+	err := r.redisClient.Watch(ctx, func(tx *redis.Tx) error {
+		client, err := r.get(ctx, tx, clientID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Del(ctx, clientKey)
+			pipe.SRem(ctx, r.keyBuilder.AuthClientIndex(), clientID)
+			for _, key := range client.Keys {
+				pipe.HDel(ctx, r.keyBuilder.AuthKeyIndex(), key.ID)
+			}
+			return nil
+		})
+		return err
+	}, clientKey)
+	if errors.Is(err, repository.ErrClientNotFound) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete client %s: %w", clientID, err)
+	}
+
+	return nil
+}
+
+// Update applies a change to a client read inside the transaction that writes it back, so
+// concurrent changes are never overwritten: if the client changes before the write, the
+// change is applied again to the new state.
+func (r *ClientRepository) Update(ctx context.Context, clientID string, update repository.ClientUpdate) (*entity.Client, error) {
+	var updated *entity.Client
+	var updateErr error
+	err := r.write(ctx, clientID, func(stored *entity.Client) (*entity.Client, error) {
+		if stored == nil {
+			return nil, repository.ErrClientNotFound
+		}
+		updated = stored
+
+		changed, err := update(stored)
+		if err != nil {
+			updateErr = err
+			return nil, err
+		}
+		if !changed {
+			return nil, nil
+		}
+		return stored, nil
+	})
+	if updateErr != nil {
+		return nil, updateErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// save creates or replaces a client with the given state
+func (r *ClientRepository) save(ctx context.Context, client *entity.Client, create bool) error {
+	return r.write(ctx, client.ID, func(stored *entity.Client) (*entity.Client, error) {
+		if stored != nil && create {
+			return nil, repository.ErrClientExists
+		}
+		return client, nil
+	})
+}
+
+// write writes a client and its key index in one transaction. next receives the stored
+// client (nil if none) and returns the client to write, or nil to leave it unchanged.
+// The client and the key index are watched, so the stale keys unindexed and the key IDs
+// claimed are checked against the state written over; a write that loses a race is retried
+// against the new state.
+func (r *ClientRepository) write(ctx context.Context, clientID string, next func(stored *entity.Client) (*entity.Client, error)) error {
+	clientKey := r.keyBuilder.AuthClient(clientID)
+	keyIndex := r.keyBuilder.AuthKeyIndex()
+	txf := func(tx *redis.Tx) error {
+		stored, err := r.get(ctx, tx, clientID)
+		if err != nil && !errors.Is(err, repository.ErrClientNotFound) {
+			return err
+		}
+
+		client, err := next(stored)
+		if err != nil || client == nil {
+			return err
+		}
+
+		data, err := json.Marshal(client)
+		if err != nil {
+			return fmt.Errorf("failed to marshal client: %w", err)
+		}
+
+		keyIDs := make([]string, len(client.Keys))
+		for i, key := range client.Keys {
+			keyIDs[i] = key.ID
+		}
+
+		if len(keyIDs) > 0 {
+			owners, err := tx.HMGet(ctx, keyIndex, keyIDs...).Result()
+			if err != nil {
+				return fmt.Errorf("failed to look up API key owners: %w", err)
+			}
+			for i, owner := range owners {
+				if owner, ok := owner.(string); ok && owner != clientID {
+					return fmt.Errorf("%w: key %s", repository.ErrKeyIDConflict, keyIDs[i])
+				}
+			}
+		}
+
+		var staleKeys []string
+		if stored != nil {
+			for _, key := range stored.Keys {
+				if client.FindKey(key.ID) == nil {
+					staleKeys = append(staleKeys, key.ID)
+				}
+			}
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, clientKey, data, 0)
+			pipe.SAdd(ctx, r.keyBuilder.AuthClientIndex(), clientID)
+			for _, keyID := range keyIDs {
+				pipe.HSet(ctx, keyIndex, keyID, clientID)
+			}
+			if len(staleKeys) > 0 {
+				pipe.HDel(ctx, keyIndex, staleKeys...)
+			}
+			return nil
+		})
+		return err
+	}
+
+	var err error
+	for attempt := 0; attempt < maxClientSaveAttempts; attempt++ {
+		err = r.redisClient.Watch(ctx, txf, clientKey, keyIndex)
+		if !errors.Is(err, redis.TxFailedErr) {
+			break
+		}
+	}
+	if errors.Is(err, repository.ErrClientExists) ||
+		errors.Is(err, repository.ErrClientNotFound) ||
+		errors.Is(err, repository.ErrKeyIDConflict) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save client %s: %w", clientID, err)
+	}
+
+	return nil
+}
+
+// get reads a client through a connection or a watching transaction
+func (r *ClientRepository) get(ctx context.Context, cmd redis.Cmdable, clientID string) (*entity.Client, error) {
+	data, err := cmd.Get(ctx, r.keyBuilder.AuthClient(clientID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, repository.ErrClientNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client %s: %w", clientID, err)
+	}
+
+	var client entity.Client
+	if err := json.Unmarshal(data, &client); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal client %s: %w", clientID, err)
+	}
+
+	return &client, nil
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+	"upwork-test/internal/domain/market/entity"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// settlementEventsMaxLen caps the settlement stream; trimming is approximate
+	settlementEventsMaxLen = 10000
+)
+
+// EventPublisher publishes domain events to Redis streams for downstream consumers.
+type EventPublisher struct {
+	redisClient *redis.Client
+	keyBuilder  *KeyBuilder
+}
+
+// NewEventPublisher creates a new event publisher.
+func NewEventPublisher(redisClient *redis.Client) *EventPublisher {
+	return &EventPublisher{
+		redisClient: redisClient,
+		keyBuilder:  NewKeyBuilder("kalshi"),
+	}
+}
+
+// PublishSettlement appends a market.settled event to the settlement stream.
+func (p *EventPublisher) PublishSettlement(ctx context.Context, settlement *entity.Settlement) error {
+	payload, err := json.Marshal(settlement)
+	if err != nil {
+		return fmt.Errorf("failed to marshal settlement event: %w", err)
+	}
+
+	err = p.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.keyBuilder.SettlementEvents(),
+		MaxLen: settlementEventsMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"type":             "market.settled",
+			"ticker":           settlement.Ticker.String(),
+			"result":           string(settlement.Result),
+			"settlement_value": settlement.SettlementValue.String(),
+			"settled_at":       settlement.SettledAt.Format(time.RFC3339),
+			"payload":          payload,
+		},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to publish settlement event: %w", err)
+	}
+
+	return nil
+}
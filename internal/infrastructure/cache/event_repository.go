@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/repository"
+	"upwork-test/internal/infrastructure/kalshi"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// Distributions and arbitrage checks go stale quickly, so events are cached briefly
+	eventCacheTTL = 30 * time.Second
+	// Event titles rarely change; the list is only used to enrich background jobs
+	eventListCacheTTL = 15 * time.Minute
+)
+
+// EventRepository implements the event repository with Redis caching.
+type EventRepository struct {
+	redisClient  *redis.Client
+	kalshiClient *kalshi.Client
+	keyBuilder   *KeyBuilder
+	mapper       *kalshi.Mapper
+}
+
+// NewEventRepository creates a new event repository.
+func NewEventRepository(redisClient *redis.Client, kalshiClient *kalshi.Client) *EventRepository {
+	return &EventRepository{
+		redisClient:  redisClient,
+		kalshiClient: kalshiClient,
+		keyBuilder:   NewKeyBuilder("kalshi"),
+		mapper:       kalshi.NewMapper(kalshiClient.SchemaMonitor()),
+	}
+}
+
+// GetByTicker retrieves an event and its markets.
+func (r *EventRepository) GetByTicker(ctx context.Context, eventTicker string) (*entity.Event, error) {
+	cacheKey := r.keyBuilder.Event(eventTicker)
+
+	cachedData, err := r.redisClient.Get(ctx, cacheKey).Result()
+	if err == nil {
+		var event entity.Event
+		if err := json.Unmarshal([]byte(cachedData), &event); err == nil {
+			return &event, nil
+		}
+	}
+
+	kalshiEvent, err := r.kalshiClient.GetEvent(ctx, eventTicker)
+	if err != nil {
+		if errors.Is(err, kalshi.ErrNotFound) {
+			return nil, repository.ErrEventNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch event from Kalshi: %w", err)
+	}
+
+	// Kalshi lists the event's markets alongside it; fall back to the markets endpoint if they are omitted
+	if len(kalshiEvent.Markets) == 0 {
+		marketList, err := r.kalshiClient.GetMarketsByEvent(ctx, eventTicker)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch event markets from Kalshi: %w", err)
+		}
+		kalshiEvent.Markets = marketList.Markets
+	}
+
+	event, err := r.mapper.ToEventEntity(kalshiEvent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map event: %w", err)
+	}
+
+	if data, err := json.Marshal(event); err == nil {
+		r.redisClient.Set(ctx, cacheKey, data, eventCacheTTL)
+	}
+
+	return event, nil
+}
+
+// ListByStatus retrieves every event with a status across all categories, without markets.
+func (r *EventRepository) ListByStatus(ctx context.Context, status string) ([]*entity.Event, error) {
+	cacheKey := r.keyBuilder.EventList(status)
+
+	cachedData, err := r.redisClient.Get(ctx, cacheKey).Result()
+	if err == nil {
+		var events []*entity.Event
+		if err := json.Unmarshal([]byte(cachedData), &events); err == nil {
+			return events, nil
+		}
+	}
+
+	kalshiResponse, err := r.kalshiClient.GetEvents(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch events from Kalshi: %w", err)
+	}
+
+	events := r.mapper.ToEventEntities(kalshiResponse.Events)
+
+	// A partial listing is served but not cached, so the next call retries the full one
+	if kalshiResponse.Truncated {
+		return events, nil
+	}
+
+	if data, err := json.Marshal(events); err == nil {
+		r.redisClient.Set(ctx, cacheKey, data, eventListCacheTTL)
+	}
+
+	return events, nil
+}
@@ -21,6 +21,36 @@ func (kb *KeyBuilder) MarketList(category string) string {
 	return fmt.Sprintf("%s:markets:list:%s", kb.namespace, category)
 }
 
+// MarketListSnapshot builds a key for a retained version of a category's market list
+func (kb *KeyBuilder) MarketListSnapshot(category string, version int64) string {
+	return fmt.Sprintf("%s:markets:list:%s:v%d", kb.namespace, category, version)
+}
+
+// MarketListAll builds a key for the full open market list of a category
+func (kb *KeyBuilder) MarketListAll(category string) string {
+	return fmt.Sprintf("%s:markets:all:%s", kb.namespace, category)
+}
+
+// MarketRangedGroup builds a key for the markets of a range group
+func (kb *KeyBuilder) MarketRangedGroup(groupTicker string) string {
+	return fmt.Sprintf("%s:markets:ranged:%s", kb.namespace, groupTicker)
+}
+
+// Event builds a key for an event and its markets
+func (kb *KeyBuilder) Event(eventTicker string) string {
+	return fmt.Sprintf("%s:event:%s", kb.namespace, eventTicker)
+}
+
+// EventList builds a key for the list of events with a status
+func (kb *KeyBuilder) EventList(status string) string {
+	return fmt.Sprintf("%s:events:list:%s", kb.namespace, status)
+}
+
+// SeriesList builds a key for the list of series in a category
+func (kb *KeyBuilder) SeriesList(category string) string {
+	return fmt.Sprintf("%s:series:list:%s", kb.namespace, category)
+}
+
 // MarketMetadata builds a key for market metadata cache
 func (kb *KeyBuilder) MarketMetadata(ticker string) string {
 	return fmt.Sprintf("%s:markets:metadata:%s", kb.namespace, ticker)
@@ -36,6 +66,31 @@ func (kb *KeyBuilder) MarketTrades(ticker string) string {
 	return fmt.Sprintf("%s:markets:trades:%s", kb.namespace, ticker)
 }
 
+// MarketCandlesticks builds a key for a market's candlesticks of one period
+func (kb *KeyBuilder) MarketCandlesticks(ticker string, period string) string {
+	return fmt.Sprintf("%s:markets:candles:%s:%s", kb.namespace, ticker, period)
+}
+
+// MarketStatusLatest builds a key for the hash of last observed market statuses
+func (kb *KeyBuilder) MarketStatusLatest() string {
+	return fmt.Sprintf("%s:markets:status:latest", kb.namespace)
+}
+
+// MarketStatusHistory builds a key for a market's status transition history
+func (kb *KeyBuilder) MarketStatusHistory(ticker string) string {
+	return fmt.Sprintf("%s:markets:status:history:%s", kb.namespace, ticker)
+}
+
+// MarketSettled builds a key for a permanently cached settled market
+func (kb *KeyBuilder) MarketSettled(ticker string) string {
+	return fmt.Sprintf("%s:markets:settled:%s", kb.namespace, ticker)
+}
+
+// SettlementEvents builds a key for the settlement event stream
+func (kb *KeyBuilder) SettlementEvents() string {
+	return fmt.Sprintf("%s:events:settlements", kb.namespace)
+}
+
 // CategoryOverview builds a key for category overview cache
 func (kb *KeyBuilder) CategoryOverview(category string) string {
 	return fmt.Sprintf("%s:categories:overview:%s", kb.namespace, category)
@@ -60,3 +115,132 @@ func (kb *KeyBuilder) RequestCoalescingLock(resource string) string {
 func (kb *KeyBuilder) HotMarkets() string {
 	return fmt.Sprintf("%s:markets:hot", kb.namespace)
 }
+
+// SchemaReports builds a key for the hash of upstream schema reports by source
+func (kb *KeyBuilder) SchemaReports() string {
+	return fmt.Sprintf("%s:diagnostics:schema", kb.namespace)
+}
+
+// CacheVersion builds the key holding the encoding version of cached values
+func (kb *KeyBuilder) CacheVersion() string {
+	return fmt.Sprintf("%s:meta:cache_version", kb.namespace)
+}
+
+// PriceSnapshotIndex builds a key for the sorted set of price snapshot times
+func (kb *KeyBuilder) PriceSnapshotIndex() string {
+	return fmt.Sprintf("%s:markets:prices:index", kb.namespace)
+}
+
+// PriceSnapshot builds a key for the hash of market prices captured at a time
+func (kb *KeyBuilder) PriceSnapshot(takenAt int64) string {
+	return fmt.Sprintf("%s:markets:prices:%d", kb.namespace, takenAt)
+}
+
+// AnalyticsTrades builds a key for the hash of trades in a category's analytics window
+func (kb *KeyBuilder) AnalyticsTrades(category string) string {
+	return fmt.Sprintf("%s:analytics:trades:%s", kb.namespace, category)
+}
+
+// AnalyticsTradeIndex builds a key for the sorted set of trade IDs by trade time
+func (kb *KeyBuilder) AnalyticsTradeIndex(category string) string {
+	return fmt.Sprintf("%s:analytics:trades:%s:index", kb.namespace, category)
+}
+
+// AnalyticsWatermarks builds a key for the hash of per-market trade fetch watermarks
+func (kb *KeyBuilder) AnalyticsWatermarks(category string) string {
+	return fmt.Sprintf("%s:analytics:watermarks:%s", kb.namespace, category)
+}
+
+// CategoryActivity builds a key for category activity metrics
+func (kb *KeyBuilder) CategoryActivity(category string) string {
+	return fmt.Sprintf("%s:categories:activity:%s", kb.namespace, category)
+}
+
+// Movers builds a key for the ranked movers of a window in a category, or in all categories if category is empty
+func (kb *KeyBuilder) Movers(window string, category string) string {
+	if category == "" {
+		category = "all"
+	}
+	return fmt.Sprintf("%s:movers:%s:%s", kb.namespace, window, category)
+}
+
+// SearchDocuments builds a key for the hash of market search documents
+func (kb *KeyBuilder) SearchDocuments() string {
+	return fmt.Sprintf("%s:search:docs", kb.namespace)
+}
+
+// SearchFingerprints builds a key for the hash of search document fingerprints
+func (kb *KeyBuilder) SearchFingerprints() string {
+	return fmt.Sprintf("%s:search:fingerprints", kb.namespace)
+}
+
+// SearchChanges builds a key for the sorted set of tickers by the version they last changed in
+func (kb *KeyBuilder) SearchChanges() string {
+	return fmt.Sprintf("%s:search:changes", kb.namespace)
+}
+
+// SearchChangesFloor builds a key for the oldest version the change set still covers
+func (kb *KeyBuilder) SearchChangesFloor() string {
+	return fmt.Sprintf("%s:search:changes:floor", kb.namespace)
+}
+
+// SearchVersion builds a key for the search document version counter
+func (kb *KeyBuilder) SearchVersion() string {
+	return fmt.Sprintf("%s:search:version", kb.namespace)
+}
+
+// AuthClient builds a key for a registered API client
+func (kb *KeyBuilder) AuthClient(clientID string) string {
+	return fmt.Sprintf("%s:auth:clients:%s", kb.namespace, clientID)
+}
+
+// AuthClientIndex builds a key for the set of registered client IDs
+func (kb *KeyBuilder) AuthClientIndex() string {
+	return fmt.Sprintf("%s:auth:clients", kb.namespace)
+}
+
+// AuthKeyIndex builds a key for the hash of API key IDs to the client owning them
+func (kb *KeyBuilder) AuthKeyIndex() string {
+	return fmt.Sprintf("%s:auth:keys", kb.namespace)
+}
+
+// AuthScopesMigrated builds a key marking that clients registered before scopes were
+// enforced have been granted the default scopes
+func (kb *KeyBuilder) AuthScopesMigrated() string {
+	return fmt.Sprintf("%s:auth:migrations:default_scopes", kb.namespace)
+}
+
+// AuthAudit builds a key for the audit log stream of client registry changes
+func (kb *KeyBuilder) AuthAudit() string {
+	return fmt.Sprintf("%s:auth:audit", kb.namespace)
+}
+
+// RefreshToken builds a key for an issued refresh token by hash
+func (kb *KeyBuilder) RefreshToken(tokenID string) string {
+	return fmt.Sprintf("%s:auth:refresh:%s", kb.namespace, tokenID)
+}
+
+// RefreshTokenFamilyRevoked builds a key marking a refresh token family as revoked
+func (kb *KeyBuilder) RefreshTokenFamilyRevoked(familyID string) string {
+	return fmt.Sprintf("%s:auth:refresh_families:%s:revoked", kb.namespace, familyID)
+}
+
+// RevokedToken builds a key denylisting an access token by ID
+func (kb *KeyBuilder) RevokedToken(tokenID string) string {
+	return fmt.Sprintf("%s:auth:revoked:%s", kb.namespace, tokenID)
+}
+
+// TokenWatermarks builds a key for the hash of client IDs to the time before which their tokens are invalid
+func (kb *KeyBuilder) TokenWatermarks() string {
+	return fmt.Sprintf("%s:auth:watermarks", kb.namespace)
+}
+
+// SigningKeys builds a key for the hash of token signing key IDs to the keys
+func (kb *KeyBuilder) SigningKeys() string {
+	return fmt.Sprintf("%s:auth:signing_keys", kb.namespace)
+}
+
+// SigningKeyRotationLock builds a key for the lock held while adding a signing key
+func (kb *KeyBuilder) SigningKeyRotationLock() string {
+	return fmt.Sprintf("%s:auth:signing_keys:lock", kb.namespace)
+}
@@ -3,22 +3,37 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"strings"
+	"slices"
+	"sort"
 	"time"
 
 	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/repository"
 	"upwork-test/internal/domain/market/valueobject"
 	"upwork-test/internal/infrastructure/kalshi"
 
 	"github.com/redis/go-redis/v9"
 )
 
-
 const (
-	marketListCacheTTL = 5 * time.Minute
+	marketListCacheTTL   = 5 * time.Minute
+	candlesticksCacheTTL = time.Minute
+	// recentTradesFetchLimit is the number of recent trades fetched and cached per market
+	recentTradesFetchLimit = 100
+	// marketListSnapshotTTL is how long a listing snapshot is retained for cursor pagination
+	marketListSnapshotTTL = 30 * time.Minute
+	// fullMarketListCacheTTL covers the interval between analytics sweeps
+	fullMarketListCacheTTL = 15 * time.Minute
 )
 
+// marketListSnapshot is one fetch of a category's market list, identified by a version
+type marketListSnapshot struct {
+	Version int64            `json:"version"`
+	Markets []*entity.Market `json:"markets"`
+}
+
 // MarketRepository implements the market repository with Redis caching.
 type MarketRepository struct {
 	redisClient  *redis.Client
@@ -33,42 +48,185 @@ func NewMarketRepository(redisClient *redis.Client, kalshiClient *kalshi.Client)
 		redisClient:  redisClient,
 		kalshiClient: kalshiClient,
 		keyBuilder:   NewKeyBuilder("kalshi"),
-		mapper:       kalshi.NewMapper(),
+		mapper:       kalshi.NewMapper(kalshiClient.SchemaMonitor()),
+	}
+}
+
+// ListByCategory retrieves the markets of a category matching a query with pagination.
+func (r *MarketRepository) ListByCategory(ctx context.Context, category string, page int, limit int, query repository.MarketQuery) ([]*entity.Market, int, error) {
+	snapshot, err := r.loadMarketList(ctx, category)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	paginated, total := r.paginate(r.applyQuery(snapshot.Markets, query), page, limit)
+
+	return paginated, total, nil
+}
+
+// ListPageByCategory retrieves one page of a category listing at an offset or next to a cursor.
+func (r *MarketRepository) ListPageByCategory(ctx context.Context, category string, request repository.MarketPageRequest) (*repository.MarketPage, error) {
+	var snapshot *marketListSnapshot
+	if request.Cursor != nil {
+		snapshot = r.getMarketListSnapshot(ctx, category, request.Cursor.Version)
+	}
+	if snapshot == nil {
+		current, err := r.loadMarketList(ctx, category)
+		if err != nil {
+			return nil, err
+		}
+		snapshot = current
+	}
+
+	markets := r.applyQuery(snapshot.Markets, request.Query)
+
+	start, end, err := r.locatePage(markets, request)
+	if err != nil {
+		return nil, err
 	}
+
+	return &repository.MarketPage{
+		Markets: markets[start:end],
+		Offset:  start,
+		Total:   len(markets),
+		Version: snapshot.Version,
+	}, nil
 }
 
-// ListByCategory retrieves markets for a category with pagination.
-func (r *MarketRepository) ListByCategory(ctx context.Context, category string, page int, limit int, status string) ([]*entity.Market, int, error) {
+// loadMarketList retrieves the current snapshot of a category's market list, fetching a new one
+// when the cached snapshot has expired. Each fetch is also retained under its version so cursor
+// pages keep reading the list they started on. A partial listing is only retained under its
+// version, so it serves the pages issued from it but the next listing fetches it again.
+func (r *MarketRepository) loadMarketList(ctx context.Context, category string) (*marketListSnapshot, error) {
 	cacheKey := r.keyBuilder.MarketList(category)
 
+	cachedData, err := r.redisClient.Get(ctx, cacheKey).Result()
+	if err == nil {
+		var snapshot marketListSnapshot
+		if err := json.Unmarshal([]byte(cachedData), &snapshot); err == nil && snapshot.Version != 0 {
+			return &snapshot, nil
+		}
+	}
+
+	// The cached list is shared by every filter, so always fetch every market of every series
+	kalshiResponse, err := r.kalshiClient.GetAllMarkets(ctx, category, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch markets from Kalshi: %w", err)
+	}
+
+	markets, err := r.mapper.ToMarketEntities(kalshiResponse.Markets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map markets: %w", err)
+	}
+
+	snapshot := &marketListSnapshot{
+		Version: time.Now().UnixNano(),
+		Markets: markets,
+	}
+
+	if data, err := json.Marshal(snapshot); err == nil {
+		pipe := r.redisClient.Pipeline()
+		if !kalshiResponse.Truncated {
+			pipe.Set(ctx, cacheKey, data, marketListCacheTTL)
+		}
+		pipe.Set(ctx, r.keyBuilder.MarketListSnapshot(category, snapshot.Version), data, marketListSnapshotTTL)
+		pipe.Exec(ctx)
+	}
+
+	return snapshot, nil
+}
+
+// getMarketListSnapshot retrieves a retained snapshot of a category's market list, or nil once it has expired.
+func (r *MarketRepository) getMarketListSnapshot(ctx context.Context, category string, version int64) *marketListSnapshot {
+	if version == 0 {
+		return nil
+	}
+
+	cachedData, err := r.redisClient.Get(ctx, r.keyBuilder.MarketListSnapshot(category, version)).Result()
+	if err != nil {
+		return nil
+	}
+
+	var snapshot marketListSnapshot
+	if err := json.Unmarshal([]byte(cachedData), &snapshot); err != nil {
+		return nil
+	}
+	return &snapshot
+}
+
+// locatePage returns the bounds of the requested page within the filtered and sorted list.
+// Sorted cursors are located by key, so they survive markets being added or removed; upstream
+// order has no key, so the cursor's market must still be listed.
+func (r *MarketRepository) locatePage(markets []*entity.Market, request repository.MarketPageRequest) (int, int, error) {
+	total := len(markets)
+	cursor := request.Cursor
+
+	if cursor == nil {
+		start := min(max(request.Offset, 0), total)
+		return start, min(start+request.Limit, total), nil
+	}
+
+	// before is the number of markets preceding the cursor position, and after the index of
+	// the first market following it; they differ only when the cursor's market is listed
+	var before, after int
+	if order := request.Query.Sort; !order.IsZero() {
+		compare := func(i int) int {
+			return entity.CompareMarketPositions(
+				markets[i].SortKey(order.Field), markets[i].Ticker.String(),
+				cursor.SortKey, cursor.Ticker,
+				order,
+			)
+		}
+		before = sort.Search(total, func(i int) bool { return compare(i) >= 0 })
+		after = sort.Search(total, func(i int) bool { return compare(i) > 0 })
+	} else {
+		index := slices.IndexFunc(markets, func(market *entity.Market) bool {
+			return market.Ticker.String() == cursor.Ticker
+		})
+		if index < 0 {
+			return 0, 0, fmt.Errorf("%w: market %s is no longer listed", repository.ErrCursorExpired, cursor.Ticker)
+		}
+		before, after = index, index+1
+	}
+
+	if cursor.Before {
+		return max(before-request.Limit, 0), before, nil
+	}
+	return after, min(after+request.Limit, total), nil
+}
+
+// ListAllByCategory retrieves every open market in a category across all series.
+func (r *MarketRepository) ListAllByCategory(ctx context.Context, category string) ([]*entity.Market, error) {
+	cacheKey := r.keyBuilder.MarketListAll(category)
+
 	cachedData, err := r.redisClient.Get(ctx, cacheKey).Result()
 	if err == nil {
 		var markets []*entity.Market
 		if err := json.Unmarshal([]byte(cachedData), &markets); err == nil {
-			filtered := r.filterByStatus(markets, status)
-			paginated, total := r.paginate(filtered, page, limit)
-			return paginated, total, nil
+			return markets, nil
 		}
 	}
 
-	kalshiResponse, err := r.kalshiClient.GetMarkets(ctx, category, status)
+	kalshiResponse, err := r.kalshiClient.GetAllMarkets(ctx, category, "open")
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to fetch markets from Kalshi: %w", err)
+		return nil, fmt.Errorf("failed to fetch markets from Kalshi: %w", err)
 	}
 
 	markets, err := r.mapper.ToMarketEntities(kalshiResponse.Markets)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to map markets: %w", err)
+		return nil, fmt.Errorf("failed to map markets: %w", err)
 	}
 
-	if data, err := json.Marshal(markets); err == nil {
-		r.redisClient.Set(ctx, cacheKey, data, marketListCacheTTL)
+	// A partial listing is used once but not cached, so the next call fetches it again
+	if kalshiResponse.Truncated {
+		return markets, nil
 	}
 
-	filtered := r.filterByStatus(markets, status)
-	paginated, total := r.paginate(filtered, page, limit)
+	if data, err := json.Marshal(markets); err == nil {
+		r.redisClient.Set(ctx, cacheKey, data, fullMarketListCacheTTL)
+	}
 
-	return paginated, total, nil
+	return markets, nil
 }
 
 // GetByTicker retrieves a single market by ticker.
@@ -83,6 +241,15 @@ func (r *MarketRepository) GetByTicker(ctx context.Context, tickerStr string) (*
 		}
 	}
 
+	// Settled markets are kept without expiry; the worker rewrites them if a settlement is amended
+	settledData, err := r.redisClient.Get(ctx, r.keyBuilder.MarketSettled(tickerStr)).Result()
+	if err == nil {
+		var market entity.Market
+		if err := json.Unmarshal([]byte(settledData), &market); err == nil {
+			return &market, nil
+		}
+	}
+
 	ticker, err := valueobject.NewTicker(tickerStr)
 	if err != nil {
 		return nil, fmt.Errorf("invalid ticker: %w", err)
@@ -90,6 +257,9 @@ func (r *MarketRepository) GetByTicker(ctx context.Context, tickerStr string) (*
 
 	kalshiMarket, err := r.kalshiClient.GetMarket(ctx, ticker.String())
 	if err != nil {
+		if errors.Is(err, kalshi.ErrNotFound) {
+			return nil, repository.ErrNotFound
+		}
 		return nil, fmt.Errorf("failed to fetch market from Kalshi: %w", err)
 	}
 
@@ -105,37 +275,142 @@ func (r *MarketRepository) GetByTicker(ctx context.Context, tickerStr string) (*
 	return market, nil
 }
 
-// GetMultiple retrieves multiple markets by tickers.
-func (r *MarketRepository) GetMultiple(ctx context.Context, tickers []string) ([]*entity.Market, error) {
-	markets := make([]*entity.Market, 0, len(tickers))
+// ListByRangedGroup retrieves every bracket market in a range group.
+// Range groups live inside a single event, so the group ticker is first tried as an
+// event ticker; otherwise it is resolved through a member market's event. Only markets
+// tagged with the group, or all markets of a mutually exclusive event, belong to it.
+func (r *MarketRepository) ListByRangedGroup(ctx context.Context, groupTicker string) ([]*entity.Market, error) {
+	cacheKey := r.keyBuilder.MarketRangedGroup(groupTicker)
 
-	for _, ticker := range tickers {
-		market, err := r.GetByTicker(ctx, ticker)
+	cachedData, err := r.redisClient.Get(ctx, cacheKey).Result()
+	if err == nil {
+		var markets []*entity.Market
+		if err := json.Unmarshal([]byte(cachedData), &markets); err == nil {
+			return markets, nil
+		}
+	}
+
+	event, err := r.loadEvent(ctx, groupTicker)
+	if errors.Is(err, repository.ErrNotFound) {
+		member, memberErr := r.kalshiClient.GetMarket(ctx, groupTicker)
+		if memberErr != nil || member.EventTicker == "" {
+			return nil, repository.ErrNotFound
+		}
+
+		groupTicker = member.EventTicker
+		if member.RangedGroupTicker != "" {
+			groupTicker = member.RangedGroupTicker
+		}
+		event, err = r.loadEvent(ctx, member.EventTicker)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	group := event.RangedGroup(groupTicker)
+	if len(group) == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	if data, err := json.Marshal(group); err == nil {
+		r.redisClient.Set(ctx, cacheKey, data, marketListCacheTTL)
+	}
+
+	return group, nil
+}
+
+// loadEvent fetches and maps an event with its markets, or returns ErrNotFound
+func (r *MarketRepository) loadEvent(ctx context.Context, eventTicker string) (*entity.Event, error) {
+	kalshiEvent, err := r.kalshiClient.GetEvent(ctx, eventTicker)
+	if err != nil {
+		if errors.Is(err, kalshi.ErrNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch event from Kalshi: %w", err)
+	}
+
+	event, err := r.mapper.ToEventEntity(kalshiEvent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map event: %w", err)
+	}
+
+	// Kalshi lists the event's markets alongside it; fall back to the markets endpoint if they are omitted
+	if len(event.Markets) == 0 {
+		event.Markets, err = r.listEventMarkets(ctx, eventTicker)
 		if err != nil {
-			// Skip markets that fail to fetch
-			continue
+			return nil, err
 		}
-		markets = append(markets, market)
+	}
+
+	return event, nil
+}
+
+// listEventMarkets fetches and maps every market in an event
+func (r *MarketRepository) listEventMarkets(ctx context.Context, eventTicker string) ([]*entity.Market, error) {
+	kalshiResponse, err := r.kalshiClient.GetMarketsByEvent(ctx, eventTicker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch event markets from Kalshi: %w", err)
+	}
+
+	markets, err := r.mapper.ToMarketEntities(kalshiResponse.Markets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map markets: %w", err)
 	}
 
 	return markets, nil
 }
 
-// filterByStatus filters markets by status.
-func (r *MarketRepository) filterByStatus(markets []*entity.Market, status string) []*entity.Market {
-	if status == "" {
-		return markets
+// GetMultiple retrieves the cached markets among tickers with a single MGET over the
+// metadata and settled keys; uncached tickers are returned as missing.
+func (r *MarketRepository) GetMultiple(ctx context.Context, tickers []string) (map[string]*entity.Market, []string, error) {
+	found := make(map[string]*entity.Market, len(tickers))
+	if len(tickers) == 0 {
+		return found, nil, nil
+	}
+
+	keys := make([]string, 0, 2*len(tickers))
+	for _, ticker := range tickers {
+		keys = append(keys, r.keyBuilder.MarketMetadata(ticker), r.keyBuilder.MarketSettled(ticker))
 	}
 
-	filtered := make([]*entity.Market, 0, len(markets))
-	normalizedStatus := strings.ToLower(status)
+	values, err := r.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get cached markets: %w", err)
+	}
 
-	for _, market := range markets {
-		if strings.ToLower(string(market.Status)) == normalizedStatus {
-			filtered = append(filtered, market)
+	var missing []string
+	for i, ticker := range tickers {
+		if market := r.decodeCachedMarket(values[2*i]); market != nil {
+			found[ticker] = market
+		} else if market := r.decodeCachedMarket(values[2*i+1]); market != nil {
+			found[ticker] = market
+		} else {
+			missing = append(missing, ticker)
 		}
 	}
 
+	return found, missing, nil
+}
+
+// decodeCachedMarket decodes one MGET value, returning nil for misses and undecodable entries.
+func (r *MarketRepository) decodeCachedMarket(value interface{}) *entity.Market {
+	data, ok := value.(string)
+	if !ok {
+		return nil
+	}
+
+	var market entity.Market
+	if err := json.Unmarshal([]byte(data), &market); err != nil {
+		return nil
+	}
+	return &market
+}
+
+// applyQuery filters and sorts the market list. The list is freshly decoded or fetched
+// on every call, so sorting in place is safe.
+func (r *MarketRepository) applyQuery(markets []*entity.Market, query repository.MarketQuery) []*entity.Market {
+	filtered := entity.FilterMarkets(markets, query.Filter)
+	entity.SortMarkets(filtered, query.Sort)
 	return filtered
 }
 
@@ -185,7 +460,8 @@ func (r *MarketRepository) GetOrderBook(ctx context.Context, ticker string) (*en
 	return orderBook, nil
 }
 
-// GetRecentTrades retrieves recent trades for a market (cached for 1min)
+// GetRecentTrades retrieves up to limit recent trades for a market (cached for 1min).
+// The cache holds the latest recentTradesFetchLimit trades, so any smaller limit is served from it.
 func (r *MarketRepository) GetRecentTrades(ctx context.Context, ticker string, limit int) ([]*entity.Trade, error) {
 	cacheKey := r.keyBuilder.MarketTrades(ticker)
 
@@ -193,11 +469,11 @@ func (r *MarketRepository) GetRecentTrades(ctx context.Context, ticker string, l
 	if err == nil {
 		var trades []*entity.Trade
 		if err := json.Unmarshal([]byte(cachedData), &trades); err == nil {
-			return trades, nil
+			return firstTrades(trades, limit), nil
 		}
 	}
 
-	kalshiResponse, err := r.kalshiClient.GetTrades(ctx, ticker, limit)
+	kalshiResponse, err := r.kalshiClient.GetTrades(ctx, ticker, max(limit, recentTradesFetchLimit))
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch trades from Kalshi: %w", err)
 	}
@@ -211,5 +487,62 @@ func (r *MarketRepository) GetRecentTrades(ctx context.Context, ticker string, l
 		_ = r.redisClient.Set(ctx, cacheKey, data, 1*time.Minute).Err()
 	}
 
-	return trades, nil
+	return firstTrades(trades, limit), nil
+}
+
+// firstTrades returns at most limit trades
+func firstTrades(trades []*entity.Trade, limit int) []*entity.Trade {
+	if limit > 0 && len(trades) > limit {
+		return trades[:limit]
+	}
+	return trades
+}
+
+// GetCandlesticks retrieves a market's candlesticks over the period's lookback (cached for 1min)
+func (r *MarketRepository) GetCandlesticks(ctx context.Context, seriesTicker, ticker string, period valueobject.CandlePeriod) ([]*entity.Candlestick, error) {
+	cacheKey := r.keyBuilder.MarketCandlesticks(ticker, period.String())
+
+	cachedData, err := r.redisClient.Get(ctx, cacheKey).Result()
+	if err == nil {
+		var candles []*entity.Candlestick
+		if err := json.Unmarshal([]byte(cachedData), &candles); err == nil {
+			return candles, nil
+		}
+	}
+
+	end := time.Now()
+	kalshiResponse, err := r.kalshiClient.GetCandlesticks(ctx, seriesTicker, ticker, end.Add(-period.Lookback()), end, period.Minutes())
+	if err != nil {
+		if errors.Is(err, kalshi.ErrNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch candlesticks from Kalshi: %w", err)
+	}
+
+	candles, err := r.mapper.ToCandlestickEntities(kalshiResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert candlesticks: %w", err)
+	}
+
+	if data, err := json.Marshal(candles); err == nil {
+		_ = r.redisClient.Set(ctx, cacheKey, data, candlesticksCacheTTL).Err()
+	}
+
+	return candles, nil
+}
+
+// GetTradesSince retrieves every trade in a market created at or after since (not cached).
+// truncated is true when Kalshi had more pages than the client follows.
+func (r *MarketRepository) GetTradesSince(ctx context.Context, ticker string, since time.Time) ([]*entity.Trade, bool, error) {
+	kalshiResponse, err := r.kalshiClient.GetTradesSince(ctx, ticker, since)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch trades from Kalshi: %w", err)
+	}
+
+	trades, err := r.mapper.ToTradeEntities(kalshiResponse.Trades)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to convert trades: %w", err)
+	}
+
+	return trades, kalshiResponse.Truncated, nil
 }
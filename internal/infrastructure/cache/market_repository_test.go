@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/repository"
+	"upwork-test/internal/domain/market/valueobject"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// volumeMarkets returns open markets with the given 24h volumes, named KX<volume>
+func volumeMarkets(t *testing.T, volumes ...int64) []*entity.Market {
+	t.Helper()
+
+	markets := make([]*entity.Market, 0, len(volumes))
+	for _, volume := range volumes {
+		ticker, err := valueobject.NewTicker("KX" + string(rune('A'+len(markets))))
+		require.NoError(t, err)
+
+		market := entity.NewMarket(ticker, ticker.String(), "Economics", time.Now(), time.Now().Add(time.Hour), entity.MarketStatusOpen)
+		market.Volume24h = volume
+		markets = append(markets, market)
+	}
+	return markets
+}
+
+func TestMarketRepositoryLocatePage(t *testing.T) {
+	byVolume, err := valueobject.NewMarketSort("volume_24h", "")
+	require.NoError(t, err)
+	sorted := repository.MarketQuery{Sort: byVolume}
+
+	tests := []struct {
+		name      string
+		volumes   []int64
+		request   repository.MarketPageRequest
+		wantStart int
+		wantEnd   int
+		wantErr   error
+	}{
+		{
+			name:    "first page by offset",
+			volumes: []int64{10, 20, 30},
+			request: repository.MarketPageRequest{Limit: 2}, wantStart: 0, wantEnd: 2,
+		},
+		{
+			name:    "offset past the end",
+			volumes: []int64{10, 20, 30},
+			request: repository.MarketPageRequest{Limit: 2, Offset: 5}, wantStart: 3, wantEnd: 3,
+		},
+		{
+			// Sorted KXC(30), KXB(20), KXA(10); the page ended at KXB
+			name:    "after a sorted position",
+			volumes: []int64{10, 20, 30},
+			request: repository.MarketPageRequest{
+				Query: sorted, Limit: 2,
+				Cursor: &repository.MarketCursor{SortKey: entity.MarketSortKey{Value: 20}, Ticker: "KXB"},
+			},
+			wantStart: 2, wantEnd: 3,
+		},
+		{
+			// No market is left at volume 20 and others were added; the position is still found by key
+			name:    "sorted position survives list changes",
+			volumes: []int64{10, 40, 30, 25},
+			request: repository.MarketPageRequest{
+				Query: sorted, Limit: 2,
+				Cursor: &repository.MarketCursor{SortKey: entity.MarketSortKey{Value: 20}, Ticker: "KXB"},
+			},
+			wantStart: 3, wantEnd: 4,
+		},
+		{
+			name:    "before a sorted position",
+			volumes: []int64{10, 20, 30},
+			request: repository.MarketPageRequest{
+				Query: sorted, Limit: 2,
+				Cursor: &repository.MarketCursor{SortKey: entity.MarketSortKey{Value: 10}, Ticker: "KXA", Before: true},
+			},
+			wantStart: 0, wantEnd: 2,
+		},
+		{
+			name:    "after an unsorted position",
+			volumes: []int64{10, 20, 30},
+			request: repository.MarketPageRequest{
+				Limit:  2,
+				Cursor: &repository.MarketCursor{Ticker: "KXA"},
+			},
+			wantStart: 1, wantEnd: 3,
+		},
+		{
+			name:    "unsorted position no longer listed",
+			volumes: []int64{10, 20},
+			request: repository.MarketPageRequest{
+				Limit:  2,
+				Cursor: &repository.MarketCursor{Ticker: "KXZ"},
+			},
+			wantErr: repository.ErrCursorExpired,
+		},
+	}
+
+	repo := &MarketRepository{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markets := repo.applyQuery(volumeMarkets(t, tt.volumes...), tt.request.Query)
+
+			start, end, err := repo.locatePage(markets, tt.request)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"upwork-test/internal/domain/market/entity"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// CacheFormatVersion is the encoding version of values stored in Redis.
+	// Version 2 stores prices as validated fixed-point decimals of a cent.
+	CacheFormatVersion = 2
+
+	// maxMigrationAttempts bounds the retries of a hash rewritten concurrently by another process
+	maxMigrationAttempts = 5
+)
+
+// priceKeyFamily is a group of cached keys whose values hold prices.
+// newValue returns an empty value of the type stored in each key, or in each hash field.
+type priceKeyFamily struct {
+	pattern  string
+	hash     bool
+	newValue func() interface{}
+	// companion names a hash whose field of the same name must go when a field is dropped
+	companion string
+}
+
+// priceKeyFamilies lists every cached value that holds prices
+func priceKeyFamilies(keyBuilder *KeyBuilder) []priceKeyFamily {
+	market := func() interface{} { return &entity.Market{} }
+	markets := func() interface{} { return &[]*entity.Market{} }
+
+	return []priceKeyFamily{
+		// Matches both the current list and its retained versions
+		{pattern: keyBuilder.MarketList("*"), newValue: func() interface{} { return &marketListSnapshot{} }},
+		{pattern: keyBuilder.MarketListAll("*"), newValue: markets},
+		{pattern: keyBuilder.MarketRangedGroup("*"), newValue: markets},
+		{pattern: keyBuilder.MarketMetadata("*"), newValue: market},
+		{pattern: keyBuilder.MarketSettled("*"), newValue: market},
+		{pattern: keyBuilder.MarketOrderBook("*"), newValue: func() interface{} { return &entity.OrderBook{} }},
+		{pattern: keyBuilder.MarketTrades("*"), newValue: func() interface{} { return &[]*entity.Trade{} }},
+		{pattern: keyBuilder.MarketCandlesticks("*", "*"), newValue: func() interface{} { return &[]*entity.Candlestick{} }},
+		{pattern: keyBuilder.Event("*"), newValue: func() interface{} { return &entity.Event{} }},
+		{pattern: keyBuilder.AnalyticsTrades("*"), hash: true, newValue: func() interface{} { return &entity.Trade{} }},
+		{
+			pattern:  keyBuilder.SearchDocuments(),
+			hash:     true,
+			newValue: func() interface{} { return &entity.SearchDocument{} },
+			// A document without a fingerprint is indexed again on the next sync
+			companion: keyBuilder.SearchFingerprints(),
+		},
+	}
+}
+
+// MigrateCache upgrades values written by older releases to the current encoding.
+// Legacy integer cents decode unchanged, but prices are now validated, so every cached value
+// holding prices is decoded and rewritten in the current encoding; values that no longer decode
+// are deleted, so they are fetched again instead of failing every read. Expiring values keep
+// their TTL. It is idempotent and safe to run from several processes.
+func MigrateCache(ctx context.Context, redisClient *redis.Client) error {
+	keyBuilder := NewKeyBuilder("kalshi")
+
+	current := 1
+	stored, err := redisClient.Get(ctx, keyBuilder.CacheVersion()).Result()
+	if err == nil {
+		if version, err := strconv.Atoi(stored); err == nil {
+			current = version
+		}
+	} else if err != redis.Nil {
+		return fmt.Errorf("failed to read cache version: %w", err)
+	}
+
+	if current >= CacheFormatVersion {
+		return nil
+	}
+
+	if current < 2 {
+		for _, family := range priceKeyFamilies(keyBuilder) {
+			if err := migratePriceFamily(ctx, redisClient, family); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := redisClient.Set(ctx, keyBuilder.CacheVersion(), CacheFormatVersion, 0).Err(); err != nil {
+		return fmt.Errorf("failed to store cache version: %w", err)
+	}
+
+	return nil
+}
+
+// migratePriceFamily re-encodes every key of a family, reporting how many values were rewritten or dropped
+func migratePriceFamily(ctx context.Context, redisClient *redis.Client, family priceKeyFamily) error {
+	keyType := "string"
+	if family.hash {
+		keyType = "hash"
+	}
+
+	rewritten, dropped := 0, 0
+	iter := redisClient.ScanType(ctx, 0, family.pattern, 100, keyType).Iterator()
+	for iter.Next(ctx) {
+		migrate := migratePriceKey
+		if family.hash {
+			migrate = migratePriceHash
+		}
+
+		keyRewritten, keyDropped, err := migrate(ctx, redisClient, iter.Val(), family)
+		if err != nil {
+			return err
+		}
+		rewritten += keyRewritten
+		dropped += keyDropped
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("failed to scan %s: %w", family.pattern, err)
+	}
+
+	if rewritten > 0 || dropped > 0 {
+		fmt.Printf("Migrated %s to fixed-point prices: %d rewritten, %d invalid dropped\n", family.pattern, rewritten, dropped)
+	}
+	return nil
+}
+
+// migratePriceKey re-encodes one string value, keeping its TTL, or deletes it if it no longer decodes.
+// A key written concurrently is left alone, since the writer already used the current encoding.
+func migratePriceKey(ctx context.Context, redisClient *redis.Client, key string, family priceKeyFamily) (int, int, error) {
+	rewritten, dropped := 0, 0
+
+	err := redisClient.Watch(ctx, func(tx *redis.Tx) error {
+		data, err := tx.Get(ctx, key).Bytes()
+		if err == redis.Nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		encoded, valid := reencodePrices(data, family.newValue())
+		if valid && bytes.Equal(encoded, data) {
+			return nil
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			if !valid {
+				pipe.Del(ctx, key)
+				return nil
+			}
+			pipe.SetArgs(ctx, key, encoded, redis.SetArgs{KeepTTL: true})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if valid {
+			rewritten++
+		} else {
+			fmt.Printf("Warning: dropped cached %s with invalid prices\n", key)
+			dropped++
+		}
+		return nil
+	}, key)
+	if err != nil && !errors.Is(err, redis.TxFailedErr) {
+		return 0, 0, fmt.Errorf("failed to migrate %s: %w", key, err)
+	}
+
+	return rewritten, dropped, nil
+}
+
+// migratePriceHash re-encodes every field of a hash, deleting fields that no longer decode.
+// The hash is rewritten in one transaction, retried if another process changes it meanwhile.
+func migratePriceHash(ctx context.Context, redisClient *redis.Client, key string, family priceKeyFamily) (int, int, error) {
+	for attempt := 0; attempt < maxMigrationAttempts; attempt++ {
+		rewritten, dropped := 0, 0
+
+		err := redisClient.Watch(ctx, func(tx *redis.Tx) error {
+			fields, err := tx.HGetAll(ctx, key).Result()
+			if err != nil {
+				return err
+			}
+
+			updates := make(map[string]interface{})
+			var invalid []string
+			for field, value := range fields {
+				encoded, valid := reencodePrices([]byte(value), family.newValue())
+				switch {
+				case !valid:
+					invalid = append(invalid, field)
+				case !bytes.Equal(encoded, []byte(value)):
+					updates[field] = encoded
+				}
+			}
+			if len(updates) == 0 && len(invalid) == 0 {
+				return nil
+			}
+
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				if len(updates) > 0 {
+					pipe.HSet(ctx, key, updates)
+				}
+				if len(invalid) > 0 {
+					pipe.HDel(ctx, key, invalid...)
+					if family.companion != "" {
+						pipe.HDel(ctx, family.companion, invalid...)
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			rewritten, dropped = len(updates), len(invalid)
+			return nil
+		}, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to migrate %s: %w", key, err)
+		}
+		return rewritten, dropped, nil
+	}
+
+	return 0, 0, fmt.Errorf("failed to migrate %s: changed concurrently %d times", key, maxMigrationAttempts)
+}
+
+// reencodePrices decodes data into value, validating every price, and encodes it again.
+// valid is false if data no longer decodes.
+func reencodePrices(data []byte, value interface{}) (encoded []byte, valid bool) {
+	if err := json.Unmarshal(data, value); err != nil {
+		return nil, false
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, false
+	}
+	return encoded, true
+}
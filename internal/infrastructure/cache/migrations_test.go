@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"testing"
+
+	"upwork-test/internal/domain/market/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReencodePrices(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		wantValid bool
+		wantPrice int64 // Units
+	}{
+		{name: "legacy integer cents", data: `{"Ticker":"KXTEST-24DEC31","Price":56,"Quantity":3}`, wantValid: true, wantPrice: 5600},
+		{name: "fixed-point cents", data: `{"Ticker":"KXTEST-24DEC31","Price":56.25,"Quantity":3}`, wantValid: true, wantPrice: 5625},
+		{name: "price above payout", data: `{"Ticker":"KXTEST-24DEC31","Price":150,"Quantity":3}`},
+		{name: "negative price", data: `{"Ticker":"KXTEST-24DEC31","Price":-1,"Quantity":3}`},
+		{name: "not JSON", data: `56`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, valid := reencodePrices([]byte(tt.data), &entity.Trade{})
+			assert.Equal(t, tt.wantValid, valid)
+			if !tt.wantValid {
+				assert.Nil(t, encoded)
+				return
+			}
+
+			// The rewritten value is stable, so a second run leaves it alone
+			again, valid := reencodePrices(encoded, &entity.Trade{})
+			require.True(t, valid)
+			assert.Equal(t, encoded, again)
+
+			var trade entity.Trade
+			_, valid = reencodePrices(encoded, &trade)
+			require.True(t, valid)
+			assert.Equal(t, tt.wantPrice, trade.Price.Units())
+			assert.Equal(t, 3, trade.Quantity)
+		})
+	}
+}
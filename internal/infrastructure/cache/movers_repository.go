@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+	"upwork-test/internal/domain/category/entity"
+	"upwork-test/internal/domain/category/repository"
+	"upwork-test/internal/domain/category/valueobject"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// moversCacheTTL keeps rankings through a few missed price snapshots
+	moversCacheTTL = 30 * time.Minute
+)
+
+// MoversRepository stores market movers ranked by the worker in Redis.
+type MoversRepository struct {
+	redisClient *redis.Client
+	keyBuilder  *KeyBuilder
+}
+
+// NewMoversRepository creates a new movers repository.
+func NewMoversRepository(redisClient *redis.Client) *MoversRepository {
+	return &MoversRepository{
+		redisClient: redisClient,
+		keyBuilder:  NewKeyBuilder("kalshi"),
+	}
+}
+
+// Get retrieves the latest movers for a window in a category, or across all categories if categoryName is empty.
+func (r *MoversRepository) Get(ctx context.Context, categoryName string, window valueobject.MoverWindow) (*entity.Movers, error) {
+	data, err := r.redisClient.Get(ctx, r.keyBuilder.Movers(window.String(), categoryName)).Result()
+	if err == redis.Nil {
+		return nil, repository.ErrMoversNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get movers: %w", err)
+	}
+
+	var movers entity.Movers
+	if err := json.Unmarshal([]byte(data), &movers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal movers: %w", err)
+	}
+
+	return &movers, nil
+}
+
+// Save stores the movers for their window in a category, or across all categories if categoryName is empty.
+func (r *MoversRepository) Save(ctx context.Context, categoryName string, movers *entity.Movers) error {
+	data, err := json.Marshal(movers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal movers: %w", err)
+	}
+
+	if err := r.redisClient.Set(ctx, r.keyBuilder.Movers(movers.Window.String(), categoryName), data, moversCacheTTL).Err(); err != nil {
+		return fmt.Errorf("failed to save movers: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/repository"
+	"upwork-test/internal/domain/market/valueobject"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// priceSnapshotRetention keeps snapshots long enough to serve the 24h window with slack
+	priceSnapshotRetention = 25 * time.Hour
+)
+
+// PriceSnapshotRepository stores market price snapshots in Redis.
+// Each snapshot is a hash of ticker to price units; a sorted set indexes snapshots by time.
+type PriceSnapshotRepository struct {
+	redisClient *redis.Client
+	keyBuilder  *KeyBuilder
+}
+
+// NewPriceSnapshotRepository creates a new price snapshot repository.
+func NewPriceSnapshotRepository(redisClient *redis.Client) *PriceSnapshotRepository {
+	return &PriceSnapshotRepository{
+		redisClient: redisClient,
+		keyBuilder:  NewKeyBuilder("kalshi"),
+	}
+}
+
+// Save stores a snapshot and discards index entries older than the retention period.
+func (r *PriceSnapshotRepository) Save(ctx context.Context, snapshot *entity.PriceSnapshot) error {
+	if snapshot.IsEmpty() {
+		return nil
+	}
+
+	takenAt := snapshot.TakenAt.Unix()
+	snapshotKey := r.keyBuilder.PriceSnapshot(takenAt)
+	indexKey := r.keyBuilder.PriceSnapshotIndex()
+
+	fields := make(map[string]interface{}, len(snapshot.Prices))
+	for ticker, price := range snapshot.Prices {
+		fields[ticker] = price.Units()
+	}
+
+	pipe := r.redisClient.TxPipeline()
+	pipe.HSet(ctx, snapshotKey, fields)
+	pipe.Expire(ctx, snapshotKey, priceSnapshotRetention)
+	pipe.ZAdd(ctx, indexKey, redis.Z{Score: float64(takenAt), Member: takenAt})
+	pipe.ZRemRangeByScore(ctx, indexKey, "-inf", strconv.FormatInt(takenAt-int64(priceSnapshotRetention.Seconds()), 10))
+	pipe.Expire(ctx, indexKey, priceSnapshotRetention)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save price snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// GetAt retrieves the latest snapshot taken at or before at, and no earlier than at minus tolerance.
+func (r *PriceSnapshotRepository) GetAt(ctx context.Context, at time.Time, tolerance time.Duration) (*entity.PriceSnapshot, error) {
+	members, err := r.redisClient.ZRevRangeByScore(ctx, r.keyBuilder.PriceSnapshotIndex(), &redis.ZRangeBy{
+		Max:   strconv.FormatInt(at.Unix(), 10),
+		Min:   strconv.FormatInt(at.Add(-tolerance).Unix(), 10),
+		Count: 1,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find price snapshot: %w", err)
+	}
+	if len(members) == 0 {
+		return nil, repository.ErrSnapshotNotFound
+	}
+
+	takenAt, err := strconv.ParseInt(members[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price snapshot index entry %q: %w", members[0], err)
+	}
+
+	values, err := r.redisClient.HGetAll(ctx, r.keyBuilder.PriceSnapshot(takenAt)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price snapshot: %w", err)
+	}
+	if len(values) == 0 {
+		// Index entry outlived its snapshot hash
+		return nil, repository.ErrSnapshotNotFound
+	}
+
+	snapshot := &entity.PriceSnapshot{
+		TakenAt: time.Unix(takenAt, 0),
+		Prices:  make(map[string]valueobject.Price, len(values)),
+	}
+	for ticker, value := range values {
+		units, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		price, err := valueobject.NewPriceFromUnits(units)
+		if err != nil {
+			continue
+		}
+		snapshot.Prices[ticker] = price
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/repository"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RefreshTokenRepository stores issued refresh tokens in Redis, each expiring with the token.
+// Consumed tokens are kept until they expire so that presenting one again is detected.
+type RefreshTokenRepository struct {
+	redisClient *redis.Client
+	keyBuilder  *KeyBuilder
+}
+
+// NewRefreshTokenRepository creates a new refresh token repository.
+func NewRefreshTokenRepository(redisClient *redis.Client) *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		redisClient: redisClient,
+		keyBuilder:  NewKeyBuilder("kalshi"),
+	}
+}
+
+// Get retrieves a refresh token by its hash.
+func (r *RefreshTokenRepository) Get(ctx context.Context, tokenID string) (*entity.RefreshToken, error) {
+	return r.get(ctx, r.redisClient, tokenID)
+}
+
+// Create stores a new refresh token until it expires.
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to marshal refresh token: %w", err)
+	}
+
+	if err := r.redisClient.Set(ctx, r.keyBuilder.RefreshToken(token.ID), data, time.Until(token.ExpiresAt)).Err(); err != nil {
+		return fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// Consume marks a token used and stores its replacement in one transaction. The token is
+// watched, so of two concurrent exchanges of the same token only one succeeds; the other
+// is reported as reuse.
+func (r *RefreshTokenRepository) Consume(ctx context.Context, tokenID string, replacement *entity.RefreshToken) error {
+	tokenKey := r.keyBuilder.RefreshToken(tokenID)
+	replacementData, err := json.Marshal(replacement)
+	if err != nil {
+		return fmt.Errorf("failed to marshal refresh token: %w", err)
+	}
+
+	err = r.redisClient.Watch(ctx, func(tx *redis.Tx) error {
+		token, err := r.get(ctx, tx, tokenID)
+		if err != nil {
+			return err
+		}
+		if token.IsUsed() {
+			return repository.ErrRefreshTokenReused
+		}
+
+		revoked, err := tx.Exists(ctx, r.keyBuilder.RefreshTokenFamilyRevoked(token.FamilyID)).Result()
+		if err != nil {
+			return fmt.Errorf("failed to check refresh token family: %w", err)
+		}
+		if revoked > 0 {
+			return repository.ErrTokenFamilyRevoked
+		}
+
+		token.UsedAt = time.Now()
+		data, err := json.Marshal(token)
+		if err != nil {
+			return fmt.Errorf("failed to marshal refresh token: %w", err)
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.SetArgs(ctx, tokenKey, data, redis.SetArgs{KeepTTL: true})
+			pipe.Set(ctx, r.keyBuilder.RefreshToken(replacement.ID), replacementData, time.Until(replacement.ExpiresAt))
+			return nil
+		})
+		return err
+	}, tokenKey)
+
+	switch {
+	case errors.Is(err, redis.TxFailedErr):
+		return repository.ErrRefreshTokenReused
+	case errors.Is(err, repository.ErrRefreshTokenNotFound),
+		errors.Is(err, repository.ErrRefreshTokenReused),
+		errors.Is(err, repository.ErrTokenFamilyRevoked):
+		return err
+	case err != nil:
+		return fmt.Errorf("failed to consume refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeFamily marks a token family revoked for ttl.
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, ttl time.Duration) error {
+	if err := r.redisClient.Set(ctx, r.keyBuilder.RefreshTokenFamilyRevoked(familyID), 1, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+	return nil
+}
+
+// get reads a token through a connection or a watching transaction
+func (r *RefreshTokenRepository) get(ctx context.Context, cmd redis.Cmdable, tokenID string) (*entity.RefreshToken, error) {
+	data, err := cmd.Get(ctx, r.keyBuilder.RefreshToken(tokenID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, repository.ErrRefreshTokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	var token entity.RefreshToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal refresh token: %w", err)
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"upwork-test/internal/domain/diagnostics/entity"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SchemaReportRepository stores the latest schema report from each process in Redis.
+type SchemaReportRepository struct {
+	redisClient *redis.Client
+	keyBuilder  *KeyBuilder
+}
+
+// NewSchemaReportRepository creates a new schema report repository.
+func NewSchemaReportRepository(redisClient *redis.Client) *SchemaReportRepository {
+	return &SchemaReportRepository{
+		redisClient: redisClient,
+		keyBuilder:  NewKeyBuilder("kalshi"),
+	}
+}
+
+// Save stores the latest report for its source.
+func (r *SchemaReportRepository) Save(ctx context.Context, report *entity.SchemaReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal schema report: %w", err)
+	}
+
+	if err := r.redisClient.HSet(ctx, r.keyBuilder.SchemaReports(), report.Source, data).Err(); err != nil {
+		return fmt.Errorf("failed to save schema report: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves the latest report from every source, ordered by source name.
+func (r *SchemaReportRepository) List(ctx context.Context) ([]*entity.SchemaReport, error) {
+	values, err := r.redisClient.HGetAll(ctx, r.keyBuilder.SchemaReports()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schema reports: %w", err)
+	}
+
+	reports := make([]*entity.SchemaReport, 0, len(values))
+	for source, data := range values {
+		var report entity.SchemaReport
+		if err := json.Unmarshal([]byte(data), &report); err != nil {
+			fmt.Printf("Warning: skipping unreadable schema report from %s: %v\n", source, err)
+			continue
+		}
+		reports = append(reports, &report)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Source < reports[j].Source
+	})
+
+	return reports, nil
+}
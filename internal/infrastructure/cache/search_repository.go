@@ -0,0 +1,202 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/repository"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// searchChangesRetention is how many versions of changes are kept; readers further behind reload everything
+const searchChangesRetention = 1000
+
+// applySearchChangesScript bumps the version and records every change under it atomically,
+// then drops changes older than the retention and raises the floor to match.
+// ARGV: retention, upsert count, then ticker/document/fingerprint triples, then removed tickers.
+var applySearchChangesScript = redis.NewScript(`
+	local version = redis.call('INCR', KEYS[4])
+	local retention = tonumber(ARGV[1])
+	local upserts = tonumber(ARGV[2])
+	local i = 3
+	for _ = 1, upserts do
+		redis.call('HSET', KEYS[1], ARGV[i], ARGV[i + 1])
+		redis.call('HSET', KEYS[2], ARGV[i], ARGV[i + 2])
+		redis.call('ZADD', KEYS[3], version, ARGV[i])
+		i = i + 3
+	end
+	while i <= #ARGV do
+		redis.call('HDEL', KEYS[1], ARGV[i])
+		redis.call('HDEL', KEYS[2], ARGV[i])
+		redis.call('ZADD', KEYS[3], version, ARGV[i])
+		i = i + 1
+	end
+	local floor = version - retention
+	if floor > 0 then
+		redis.call('ZREMRANGEBYSCORE', KEYS[3], '-inf', floor)
+		redis.call('SET', KEYS[5], floor)
+	end
+	return version
+`)
+
+// SearchRepository stores market search documents in Redis, shared by the worker that
+// builds them and every API instance that searches them.
+// A sorted set scores each ticker by the version it last changed in, so readers can fetch
+// only what changed since the version they hold.
+type SearchRepository struct {
+	redisClient *redis.Client
+	keyBuilder  *KeyBuilder
+}
+
+// NewSearchRepository creates a new search repository.
+func NewSearchRepository(redisClient *redis.Client) *SearchRepository {
+	return &SearchRepository{
+		redisClient: redisClient,
+		keyBuilder:  NewKeyBuilder("kalshi"),
+	}
+}
+
+// Apply stores changed documents and removes tickers, returning the new version.
+func (r *SearchRepository) Apply(ctx context.Context, upserts []*entity.SearchDocument, removals []string) (int64, error) {
+	if len(upserts) == 0 && len(removals) == 0 {
+		return r.Version(ctx)
+	}
+
+	args := make([]interface{}, 0, 2+len(upserts)*3+len(removals))
+	args = append(args, searchChangesRetention, len(upserts))
+	for _, doc := range upserts {
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal search document: %w", err)
+		}
+		args = append(args, doc.Ticker, data, doc.Fingerprint())
+	}
+	for _, ticker := range removals {
+		args = append(args, ticker)
+	}
+
+	keys := []string{
+		r.keyBuilder.SearchDocuments(),
+		r.keyBuilder.SearchFingerprints(),
+		r.keyBuilder.SearchChanges(),
+		r.keyBuilder.SearchVersion(),
+		r.keyBuilder.SearchChangesFloor(),
+	}
+
+	version, err := applySearchChangesScript.Run(ctx, r.redisClient, keys, args...).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to apply search changes: %w", err)
+	}
+
+	return version, nil
+}
+
+// Version returns the current version, 0 if nothing has been indexed.
+func (r *SearchRepository) Version(ctx context.Context) (int64, error) {
+	version, err := r.redisClient.Get(ctx, r.keyBuilder.SearchVersion()).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get search version: %w", err)
+	}
+	return version, nil
+}
+
+// ChangedSince returns the tickers upserted or removed after a version,
+// or repository.ErrSearchChangesPruned if changes after it have been dropped.
+// The floor and the changes are read in one transaction so a concurrent prune cannot slip between them.
+func (r *SearchRepository) ChangedSince(ctx context.Context, version int64) ([]string, error) {
+	var floorCmd *redis.StringCmd
+	var changesCmd *redis.StringSliceCmd
+	_, err := r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		floorCmd = pipe.Get(ctx, r.keyBuilder.SearchChangesFloor())
+		changesCmd = pipe.ZRangeByScore(ctx, r.keyBuilder.SearchChanges(), &redis.ZRangeBy{
+			Min: "(" + strconv.FormatInt(version, 10),
+			Max: "+inf",
+		})
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to get search changes: %w", err)
+	}
+
+	floor, err := floorCmd.Int64()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to get search changes floor: %w", err)
+	}
+	if version < floor {
+		return nil, repository.ErrSearchChangesPruned
+	}
+
+	tickers, err := changesCmd.Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search changes: %w", err)
+	}
+	return tickers, nil
+}
+
+// GetAll retrieves every document and the version they reflect.
+// The version is read first, so documents may be newer than it; replaying later changes is harmless.
+func (r *SearchRepository) GetAll(ctx context.Context) ([]*entity.SearchDocument, int64, error) {
+	version, err := r.Version(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	values, err := r.redisClient.HGetAll(ctx, r.keyBuilder.SearchDocuments()).Result()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get search documents: %w", err)
+	}
+
+	docs := make([]*entity.SearchDocument, 0, len(values))
+	for _, value := range values {
+		var doc entity.SearchDocument
+		if err := json.Unmarshal([]byte(value), &doc); err != nil {
+			continue
+		}
+		docs = append(docs, &doc)
+	}
+
+	return docs, version, nil
+}
+
+// GetMany retrieves documents by ticker; removed tickers are omitted.
+func (r *SearchRepository) GetMany(ctx context.Context, tickers []string) ([]*entity.SearchDocument, error) {
+	if len(tickers) == 0 {
+		return []*entity.SearchDocument{}, nil
+	}
+
+	values, err := r.redisClient.HMGet(ctx, r.keyBuilder.SearchDocuments(), tickers...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search documents: %w", err)
+	}
+
+	docs := make([]*entity.SearchDocument, 0, len(values))
+	for _, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var doc entity.SearchDocument
+		if err := json.Unmarshal([]byte(data), &doc); err != nil {
+			continue
+		}
+		docs = append(docs, &doc)
+	}
+
+	return docs, nil
+}
+
+// Fingerprints returns the fingerprint of every stored document by ticker.
+func (r *SearchRepository) Fingerprints(ctx context.Context) (map[string]string, error) {
+	fingerprints, err := r.redisClient.HGetAll(ctx, r.keyBuilder.SearchFingerprints()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search fingerprints: %w", err)
+	}
+	return fingerprints, nil
+}
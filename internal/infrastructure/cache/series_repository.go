@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/infrastructure/kalshi"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	seriesListCacheTTL = 24 * time.Hour
+)
+
+// SeriesRepository implements the series repository with Redis caching.
+type SeriesRepository struct {
+	redisClient  *redis.Client
+	kalshiClient *kalshi.Client
+	keyBuilder   *KeyBuilder
+	mapper       *kalshi.Mapper
+}
+
+// NewSeriesRepository creates a new series repository.
+func NewSeriesRepository(redisClient *redis.Client, kalshiClient *kalshi.Client) *SeriesRepository {
+	return &SeriesRepository{
+		redisClient:  redisClient,
+		kalshiClient: kalshiClient,
+		keyBuilder:   NewKeyBuilder("kalshi"),
+		mapper:       kalshi.NewMapper(kalshiClient.SchemaMonitor()),
+	}
+}
+
+// ListByCategory retrieves the series of a category.
+func (r *SeriesRepository) ListByCategory(ctx context.Context, category string) ([]*entity.Series, error) {
+	cacheKey := r.keyBuilder.SeriesList(category)
+
+	cachedData, err := r.redisClient.Get(ctx, cacheKey).Result()
+	if err == nil {
+		var series []*entity.Series
+		if err := json.Unmarshal([]byte(cachedData), &series); err == nil {
+			return series, nil
+		}
+	}
+
+	kalshiResponse, err := r.kalshiClient.GetSeries(ctx, category)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch series from Kalshi: %w", err)
+	}
+
+	series := r.mapper.ToSeriesEntities(kalshiResponse.Series)
+
+	if data, err := json.Marshal(series); err == nil {
+		r.redisClient.Set(ctx, cacheKey, data, seriesListCacheTTL)
+	}
+
+	return series, nil
+}
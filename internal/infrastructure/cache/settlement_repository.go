@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/repository"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SettlementRepository stores settled markets in Redis without expiry.
+type SettlementRepository struct {
+	redisClient *redis.Client
+	keyBuilder  *KeyBuilder
+}
+
+// NewSettlementRepository creates a new settlement repository.
+func NewSettlementRepository(redisClient *redis.Client) *SettlementRepository {
+	return &SettlementRepository{
+		redisClient: redisClient,
+		keyBuilder:  NewKeyBuilder("kalshi"),
+	}
+}
+
+// Get retrieves the settled market for a ticker.
+func (r *SettlementRepository) Get(ctx context.Context, ticker string) (*entity.Market, error) {
+	data, err := r.redisClient.Get(ctx, r.keyBuilder.MarketSettled(ticker)).Result()
+	if err == redis.Nil {
+		return nil, repository.ErrSettlementNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settled market: %w", err)
+	}
+
+	var market entity.Market
+	if err := json.Unmarshal([]byte(data), &market); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal settled market: %w", err)
+	}
+
+	return &market, nil
+}
+
+// Save stores a settled market permanently, replacing a stored one whose result or settlement
+// value differs, as when Kalshi amends a settlement. Returns false if the same settlement was already stored.
+func (r *SettlementRepository) Save(ctx context.Context, market *entity.Market) (bool, error) {
+	if market.Settlement == nil {
+		return false, fmt.Errorf("market %s has no settlement", market.Ticker.String())
+	}
+
+	data, err := json.Marshal(market)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal settled market: %w", err)
+	}
+
+	key := r.keyBuilder.MarketSettled(market.Ticker.String())
+	changed := false
+
+	err = r.redisClient.Watch(ctx, func(tx *redis.Tx) error {
+		stored, err := tx.Get(ctx, key).Result()
+		if err != nil && err != redis.Nil {
+			return err
+		}
+
+		if err == nil {
+			var previous entity.Market
+			if err := json.Unmarshal([]byte(stored), &previous); err == nil && sameSettlement(previous.Settlement, market.Settlement) {
+				return nil
+			}
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, 0)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		changed = true
+		return nil
+	}, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to save settled market: %w", err)
+	}
+
+	return changed, nil
+}
+
+// sameSettlement reports whether a stored settlement has the same outcome as a new one
+func sameSettlement(stored *entity.Settlement, settlement *entity.Settlement) bool {
+	return stored != nil &&
+		stored.Result == settlement.Result &&
+		stored.SettlementValue.Equals(settlement.SettlementValue)
+}
@@ -0,0 +1,222 @@
+package cache
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"upwork-test/internal/domain/auth/entity"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// encryptedSigningKeyPrefix marks a stored signing key sealed with the key-encryption key
+const encryptedSigningKeyPrefix = "aes256gcm:"
+
+// releaseLockScript deletes a lock only if it still holds the token of the caller's claim
+var releaseLockScript = redis.NewScript(`
+	if redis.call('GET', KEYS[1]) == ARGV[1] then
+		return redis.call('DEL', KEYS[1])
+	end
+	return 0
+`)
+
+// SigningKeyRepository keeps the token signing keys in a Redis hash, so every API process
+// signs and verifies with the same keys. With a key-encryption key, each key is sealed with
+// AES-256-GCM before it is stored, so the private keys never reach Redis in the clear, and
+// stored keys that are not sealed are refused.
+type SigningKeyRepository struct {
+	redisClient      *redis.Client
+	keyBuilder       *KeyBuilder
+	kek              cipher.AEAD
+	migratePlaintext bool
+}
+
+// NewSigningKeyRepository creates a new signing key repository. keyEncryptionKey is the
+// 32-byte AES key sealing stored keys; without one, keys are stored unencrypted.
+func NewSigningKeyRepository(redisClient *redis.Client, keyEncryptionKey []byte) (*SigningKeyRepository, error) {
+	repo := &SigningKeyRepository{
+		redisClient: redisClient,
+		keyBuilder:  NewKeyBuilder("kalshi"),
+	}
+
+	if keyEncryptionKey != nil {
+		block, err := aes.NewCipher(keyEncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing key encryption key: %w", err)
+		}
+		if repo.kek, err = cipher.NewGCM(block); err != nil {
+			return nil, fmt.Errorf("invalid signing key encryption key: %w", err)
+		}
+	}
+
+	return repo, nil
+}
+
+// MigratePlaintextKeys makes List accept keys stored unencrypted, before a key-encryption
+// key was configured, and seal them in place. It is meant to run once, while upgrading.
+func (r *SigningKeyRepository) MigratePlaintextKeys() {
+	r.migratePlaintext = true
+}
+
+// List retrieves every stored signing key, ordered by activation.
+func (r *SigningKeyRepository) List(ctx context.Context) ([]*entity.SigningKey, error) {
+	entries, err := r.redisClient.HGetAll(ctx, r.keyBuilder.SigningKeys()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	keys := make([]*entity.SigningKey, 0, len(entries))
+	for id, value := range entries {
+		data, err := r.open(id, value)
+		if err != nil {
+			fmt.Printf("Warning: failed to read signing key %s: %v\n", id, err)
+			continue
+		}
+
+		var key entity.SigningKey
+		if err := json.Unmarshal(data, &key); err != nil {
+			fmt.Printf("Warning: failed to unmarshal signing key %s: %v\n", id, err)
+			continue
+		}
+		keys = append(keys, &key)
+
+		// Keys stored before a key-encryption key was configured are sealed in place
+		if r.kek != nil && r.migratePlaintext && !strings.HasPrefix(value, encryptedSigningKeyPrefix) {
+			if err := r.reseal(ctx, id, value, data); err != nil {
+				fmt.Printf("Warning: failed to encrypt signing key %s: %v\n", id, err)
+			}
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ActivatesAt.Before(keys[j].ActivatesAt)
+	})
+	return keys, nil
+}
+
+// Create stores a new signing key.
+func (r *SigningKeyRepository) Create(ctx context.Context, key *entity.SigningKey) error {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("failed to marshal signing key: %w", err)
+	}
+
+	sealed, err := r.seal(key.ID, data)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt signing key: %w", err)
+	}
+
+	created, err := r.redisClient.HSetNX(ctx, r.keyBuilder.SigningKeys(), key.ID, sealed).Result()
+	if err != nil {
+		return fmt.Errorf("failed to store signing key: %w", err)
+	}
+	if !created {
+		return fmt.Errorf("signing key %s already exists", key.ID)
+	}
+
+	return nil
+}
+
+// Delete removes a signing key.
+func (r *SigningKeyRepository) Delete(ctx context.Context, keyID string) error {
+	if err := r.redisClient.HDel(ctx, r.keyBuilder.SigningKeys(), keyID).Err(); err != nil {
+		return fmt.Errorf("failed to delete signing key: %w", err)
+	}
+	return nil
+}
+
+// AcquireRotationLock claims the right to add keys for ttl, returning a random token
+// identifying the claim.
+func (r *SigningKeyRepository) AcquireRotationLock(ctx context.Context, ttl time.Duration) (string, bool, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", false, fmt.Errorf("failed to generate signing key rotation lock token: %w", err)
+	}
+
+	lockToken := hex.EncodeToString(token)
+	acquired, err := r.redisClient.SetNX(ctx, r.keyBuilder.SigningKeyRotationLock(), lockToken, ttl).Result()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to acquire signing key rotation lock: %w", err)
+	}
+	return lockToken, acquired, nil
+}
+
+// ReleaseRotationLock gives the right to add keys back. A lock that expired and was
+// claimed by another process since is left to that process.
+func (r *SigningKeyRepository) ReleaseRotationLock(ctx context.Context, token string) error {
+	if err := releaseLockScript.Run(ctx, r.redisClient, []string{r.keyBuilder.SigningKeyRotationLock()}, token).Err(); err != nil {
+		return fmt.Errorf("failed to release signing key rotation lock: %w", err)
+	}
+	return nil
+}
+
+// seal encrypts a stored key, bound to its ID so it cannot be swapped for another key's
+// value. Without a key-encryption key it is stored as is.
+func (r *SigningKeyRepository) seal(keyID string, data []byte) (string, error) {
+	if r.kek == nil {
+		return string(data), nil
+	}
+
+	nonce := make([]byte, r.kek.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := r.kek.Seal(nonce, nonce, data, []byte(keyID))
+	return encryptedSigningKeyPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// reseal replaces an unencrypted stored key with its sealed form, unless it was deleted or
+// replaced since it was read
+func (r *SigningKeyRepository) reseal(ctx context.Context, keyID string, value string, data []byte) error {
+	sealed, err := r.seal(keyID, data)
+	if err != nil {
+		return err
+	}
+	return r.redisClient.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := tx.HGet(ctx, r.keyBuilder.SigningKeys(), keyID).Result()
+		if err != nil || current != value {
+			return nil
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, r.keyBuilder.SigningKeys(), keyID, sealed)
+			return nil
+		})
+		return err
+	}, r.keyBuilder.SigningKeys())
+}
+
+// open decrypts a stored key. Unencrypted keys are returned as is without a key-encryption
+// key, or while migrating them; otherwise they are refused, as they may have been planted.
+func (r *SigningKeyRepository) open(keyID string, value string) ([]byte, error) {
+	encoded, encrypted := strings.CutPrefix(value, encryptedSigningKeyPrefix)
+	if !encrypted {
+		if r.kek != nil && !r.migratePlaintext {
+			return nil, errors.New("key is not encrypted but a key-encryption key is configured")
+		}
+		return []byte(value), nil
+	}
+	if r.kek == nil {
+		return nil, errors.New("key is encrypted but no key-encryption key is configured")
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < r.kek.NonceSize() {
+		return nil, errors.New("malformed encrypted key")
+	}
+
+	nonce, ciphertext := sealed[:r.kek.NonceSize()], sealed[r.kek.NonceSize():]
+	data, err := r.kek.Open(nil, nonce, ciphertext, []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt key: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSealingRepository returns a signing key repository sealing with a fixed key-encryption
+// key. Sealing needs no Redis connection.
+func newSealingRepository(t *testing.T) *SigningKeyRepository {
+	t.Helper()
+
+	repo, err := NewSigningKeyRepository(nil, bytes.Repeat([]byte{7}, 32))
+	require.NoError(t, err)
+	return repo
+}
+
+func TestSigningKeyRepositorySeal(t *testing.T) {
+	repo := newSealingRepository(t)
+	data := []byte(`{"id":"key-1","private_key":"secret"}`)
+
+	sealed, err := repo.seal("key-1", data)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sealed, encryptedSigningKeyPrefix))
+	assert.NotContains(t, sealed, "secret")
+
+	opened, err := repo.open("key-1", sealed)
+	require.NoError(t, err)
+	assert.Equal(t, data, opened)
+
+	// Each seal uses a fresh nonce
+	again, err := repo.seal("key-1", data)
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed, again)
+}
+
+func TestSigningKeyRepositoryOpenRejects(t *testing.T) {
+	repo := newSealingRepository(t)
+	data := []byte(`{"id":"key-1"}`)
+	sealed, err := repo.seal("key-1", data)
+	require.NoError(t, err)
+
+	encoded := strings.TrimPrefix(sealed, encryptedSigningKeyPrefix)
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	raw[len(raw)-1] ^= 1
+	tampered := encryptedSigningKeyPrefix + base64.StdEncoding.EncodeToString(raw)
+
+	otherKEK, err := NewSigningKeyRepository(nil, bytes.Repeat([]byte{8}, 32))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		repo  *SigningKeyRepository
+		keyID string
+		value string
+	}{
+		{name: "tampered ciphertext", repo: repo, keyID: "key-1", value: tampered},
+		{name: "sealed for another key ID", repo: repo, keyID: "key-2", value: sealed},
+		{name: "sealed with another key-encryption key", repo: otherKEK, keyID: "key-1", value: sealed},
+		{name: "malformed", repo: repo, keyID: "key-1", value: encryptedSigningKeyPrefix + "not base64!"},
+		{name: "too short", repo: repo, keyID: "key-1", value: encryptedSigningKeyPrefix + "AAAA"},
+		{name: "plaintext", repo: repo, keyID: "key-1", value: string(data)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.repo.open(tt.keyID, tt.value)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSigningKeyRepositoryPlaintext(t *testing.T) {
+	data := []byte(`{"id":"key-1"}`)
+
+	// Without a key-encryption key, keys are stored and read as is
+	plain, err := NewSigningKeyRepository(nil, nil)
+	require.NoError(t, err)
+	stored, err := plain.seal("key-1", data)
+	require.NoError(t, err)
+	assert.Equal(t, string(data), stored)
+	opened, err := plain.open("key-1", stored)
+	require.NoError(t, err)
+	assert.Equal(t, data, opened)
+
+	// Sealed keys cannot be read without the key-encryption key
+	sealed, err := newSealingRepository(t).seal("key-1", data)
+	require.NoError(t, err)
+	_, err = plain.open("key-1", sealed)
+	assert.Error(t, err)
+
+	// While migrating, unencrypted keys are accepted alongside sealed ones
+	migrating := newSealingRepository(t)
+	migrating.MigratePlaintextKeys()
+	opened, err = migrating.open("key-1", string(data))
+	require.NoError(t, err)
+	assert.Equal(t, data, opened)
+	opened, err = migrating.open("key-1", sealed)
+	require.NoError(t, err)
+	assert.Equal(t, data, opened)
+}
@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"upwork-test/internal/domain/market/entity"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// statusHistoryMaxEntries caps the number of transitions kept per market
+	statusHistoryMaxEntries = 100
+)
+
+// swapStatusScript atomically replaces a market's last observed status and returns the old one
+var swapStatusScript = redis.NewScript(`
+	local previous = redis.call('HGET', KEYS[1], ARGV[1])
+	redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+	return previous
+`)
+
+// StatusHistoryRepository implements market status tracking in Redis.
+type StatusHistoryRepository struct {
+	redisClient *redis.Client
+	keyBuilder  *KeyBuilder
+}
+
+// NewStatusHistoryRepository creates a new status history repository.
+func NewStatusHistoryRepository(redisClient *redis.Client) *StatusHistoryRepository {
+	return &StatusHistoryRepository{
+		redisClient: redisClient,
+		keyBuilder:  NewKeyBuilder("kalshi"),
+	}
+}
+
+// SwapStatus stores the latest observed status and returns the previous one, if any.
+func (r *StatusHistoryRepository) SwapStatus(ctx context.Context, ticker string, status entity.MarketStatus) (entity.MarketStatus, bool, error) {
+	result, err := swapStatusScript.Run(ctx, r.redisClient, []string{r.keyBuilder.MarketStatusLatest()}, ticker, string(status)).Result()
+	if err == redis.Nil {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to swap market status: %w", err)
+	}
+
+	previous, ok := result.(string)
+	if !ok {
+		return "", false, nil
+	}
+
+	return entity.MarketStatus(previous), true, nil
+}
+
+// AppendTransition adds a transition to the market's status history.
+func (r *StatusHistoryRepository) AppendTransition(ctx context.Context, transition *entity.StatusTransition) error {
+	data, err := json.Marshal(transition)
+	if err != nil {
+		return fmt.Errorf("failed to marshal status transition: %w", err)
+	}
+
+	key := r.keyBuilder.MarketStatusHistory(transition.Ticker.String())
+
+	pipe := r.redisClient.TxPipeline()
+	pipe.LPush(ctx, key, data)
+	pipe.LTrim(ctx, key, 0, statusHistoryMaxEntries-1)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to append status transition: %w", err)
+	}
+
+	return nil
+}
+
+// GetHistory retrieves the most recent transitions for a market, newest first.
+func (r *StatusHistoryRepository) GetHistory(ctx context.Context, ticker string, limit int) ([]*entity.StatusTransition, error) {
+	values, err := r.redisClient.LRange(ctx, r.keyBuilder.MarketStatusHistory(ticker), 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status history: %w", err)
+	}
+
+	transitions := make([]*entity.StatusTransition, 0, len(values))
+	for _, value := range values {
+		var transition entity.StatusTransition
+		if err := json.Unmarshal([]byte(value), &transition); err != nil {
+			continue
+		}
+		transitions = append(transitions, &transition)
+	}
+
+	return transitions, nil
+}
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TokenRevocationRepository keeps revoked access tokens and per-client watermarks in Redis.
+// Denylisted token IDs expire with the token, so the denylist only holds live tokens.
+type TokenRevocationRepository struct {
+	redisClient *redis.Client
+	keyBuilder  *KeyBuilder
+}
+
+// NewTokenRevocationRepository creates a new token revocation repository.
+func NewTokenRevocationRepository(redisClient *redis.Client) *TokenRevocationRepository {
+	return &TokenRevocationRepository{
+		redisClient: redisClient,
+		keyBuilder:  NewKeyBuilder("kalshi"),
+	}
+}
+
+// RevokeToken denylists a token ID for ttl.
+func (r *TokenRevocationRepository) RevokeToken(ctx context.Context, tokenID string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	if err := r.redisClient.Set(ctx, r.keyBuilder.RevokedToken(tokenID), 1, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	return nil
+}
+
+// IsTokenRevoked checks if a token ID is denylisted.
+func (r *TokenRevocationRepository) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	count, err := r.redisClient.Exists(ctx, r.keyBuilder.RevokedToken(tokenID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	return count > 0, nil
+}
+
+// SetClientWatermark stores the time before which a client's tokens are invalid, in Unix seconds.
+func (r *TokenRevocationRepository) SetClientWatermark(ctx context.Context, clientID string, issuedBefore time.Time) error {
+	if err := r.redisClient.HSet(ctx, r.keyBuilder.TokenWatermarks(), clientID, issuedBefore.Unix()).Err(); err != nil {
+		return fmt.Errorf("failed to set token watermark: %w", err)
+	}
+	return nil
+}
+
+// GetClientWatermark retrieves a client's watermark, or the zero time if none is set.
+func (r *TokenRevocationRepository) GetClientWatermark(ctx context.Context, clientID string) (time.Time, error) {
+	value, err := r.redisClient.HGet(ctx, r.keyBuilder.TokenWatermarks(), clientID).Result()
+	if errors.Is(err, redis.Nil) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get token watermark: %w", err)
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid token watermark for %s: %w", clientID, err)
+	}
+	return time.Unix(seconds, 0), nil
+}
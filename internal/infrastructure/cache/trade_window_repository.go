@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+	"upwork-test/internal/domain/market/entity"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// tradeWindowTTL expires an idle category's trade window once the worker stops refreshing it
+	tradeWindowTTL = 25 * time.Hour
+)
+
+// TradeWindowRepository stores a rolling window of trades per category in Redis.
+// Trades live in a hash keyed by trade ID, indexed by a sorted set scored by trade time.
+type TradeWindowRepository struct {
+	redisClient *redis.Client
+	keyBuilder  *KeyBuilder
+}
+
+// NewTradeWindowRepository creates a new trade window repository.
+func NewTradeWindowRepository(redisClient *redis.Client) *TradeWindowRepository {
+	return &TradeWindowRepository{
+		redisClient: redisClient,
+		keyBuilder:  NewKeyBuilder("kalshi"),
+	}
+}
+
+// Add stores trades, ignoring trade IDs already stored; returns the number added.
+func (r *TradeWindowRepository) Add(ctx context.Context, category string, trades []*entity.Trade) (int, error) {
+	if len(trades) == 0 {
+		return 0, nil
+	}
+
+	tradesKey := r.keyBuilder.AnalyticsTrades(category)
+	indexKey := r.keyBuilder.AnalyticsTradeIndex(category)
+
+	pipe := r.redisClient.TxPipeline()
+	results := make([]*redis.BoolCmd, 0, len(trades))
+
+	for _, trade := range trades {
+		if trade.TradeID == "" {
+			continue
+		}
+
+		data, err := json.Marshal(trade)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal trade: %w", err)
+		}
+
+		results = append(results, pipe.HSetNX(ctx, tradesKey, trade.TradeID, data))
+		pipe.ZAdd(ctx, indexKey, redis.Z{Score: float64(trade.Timestamp.Unix()), Member: trade.TradeID})
+	}
+	pipe.Expire(ctx, tradesKey, tradeWindowTTL)
+	pipe.Expire(ctx, indexKey, tradeWindowTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to store trades: %w", err)
+	}
+
+	added := 0
+	for _, result := range results {
+		if result.Val() {
+			added++
+		}
+	}
+
+	return added, nil
+}
+
+// ListSince retrieves the stored trades created at or after since.
+func (r *TradeWindowRepository) ListSince(ctx context.Context, category string, since time.Time) ([]*entity.Trade, error) {
+	ids, err := r.redisClient.ZRangeByScore(ctx, r.keyBuilder.AnalyticsTradeIndex(category), &redis.ZRangeBy{
+		Min: strconv.FormatInt(since.Unix(), 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trade IDs: %w", err)
+	}
+	if len(ids) == 0 {
+		return []*entity.Trade{}, nil
+	}
+
+	values, err := r.redisClient.HMGet(ctx, r.keyBuilder.AnalyticsTrades(category), ids...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trades: %w", err)
+	}
+
+	trades := make([]*entity.Trade, 0, len(values))
+	for _, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var trade entity.Trade
+		if err := json.Unmarshal([]byte(data), &trade); err != nil {
+			continue
+		}
+		trades = append(trades, &trade)
+	}
+
+	return trades, nil
+}
+
+// Prune discards trades created before the given time.
+func (r *TradeWindowRepository) Prune(ctx context.Context, category string, before time.Time) error {
+	indexKey := r.keyBuilder.AnalyticsTradeIndex(category)
+	max := "(" + strconv.FormatInt(before.Unix(), 10)
+
+	ids, err := r.redisClient.ZRangeByScore(ctx, indexKey, &redis.ZRangeBy{Min: "-inf", Max: max}).Result()
+	if err != nil {
+		return fmt.Errorf("failed to list expired trades: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	pipe := r.redisClient.TxPipeline()
+	pipe.HDel(ctx, r.keyBuilder.AnalyticsTrades(category), ids...)
+	pipe.ZRemRangeByScore(ctx, indexKey, "-inf", max)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to prune trades: %w", err)
+	}
+
+	return nil
+}
+
+// GetWatermarks returns, per market ticker, the time up to which trades have been fetched.
+func (r *TradeWindowRepository) GetWatermarks(ctx context.Context, category string) (map[string]time.Time, error) {
+	values, err := r.redisClient.HGetAll(ctx, r.keyBuilder.AnalyticsWatermarks(category)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trade watermarks: %w", err)
+	}
+
+	watermarks := make(map[string]time.Time, len(values))
+	for ticker, value := range values {
+		seconds, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		watermarks[ticker] = time.Unix(seconds, 0)
+	}
+
+	return watermarks, nil
+}
+
+// SetWatermarks records the time up to which trades have been fetched for markets.
+func (r *TradeWindowRepository) SetWatermarks(ctx context.Context, category string, watermarks map[string]time.Time) error {
+	if len(watermarks) == 0 {
+		return nil
+	}
+
+	fields := make(map[string]interface{}, len(watermarks))
+	for ticker, at := range watermarks {
+		fields[ticker] = at.Unix()
+	}
+
+	key := r.keyBuilder.AnalyticsWatermarks(category)
+
+	pipe := r.redisClient.TxPipeline()
+	pipe.HSet(ctx, key, fields)
+	pipe.Expire(ctx, key, tradeWindowTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to set trade watermarks: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"upwork-test/internal/domain/auth/entity"
+	"upwork-test/internal/domain/auth/valueobject"
+)
+
+// clientSeedFile is the layout of AUTH_CLIENTS_FILE
+type clientSeedFile struct {
+	Clients []clientSeed `json:"clients"`
+}
+
+type clientSeed struct {
+	ID     string          `json:"id"`
+	Name   string          `json:"name"`
+	Tier   string          `json:"tier"`
+	Scopes []string        `json:"scopes"`
+	Status string          `json:"status"`
+	Keys   []clientKeySeed `json:"keys"`
+}
+
+type clientKeySeed struct {
+	ID        string    `json:"id"`
+	Hash      string    `json:"hash"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LoadClientSeeds reads the API clients to register from a seed file. Keys are listed
+// by ID and argon2id hash only, as printed by cmd/apikey.
+func LoadClientSeeds(path string) ([]*entity.Client, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client seed file: %w", err)
+	}
+
+	var file clientSeedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse client seed file: %w", err)
+	}
+
+	now := time.Now()
+	clients := make([]*entity.Client, 0, len(file.Clients))
+	for i, seed := range file.Clients {
+		id := strings.TrimSpace(seed.ID)
+		if err := entity.ValidateClientID(id); err != nil {
+			return nil, fmt.Errorf("client %d in seed file: %w", i, err)
+		}
+
+		status, err := entity.NewClientStatus(seed.Status)
+		if err != nil {
+			return nil, fmt.Errorf("client %s: %w", id, err)
+		}
+
+		// Clients listed without scopes get the defaults; an empty list grants none
+		scopes := seed.Scopes
+		if scopes == nil {
+			scopes = valueobject.DefaultScopes()
+		}
+		for _, scope := range scopes {
+			if err := valueobject.ValidateScope(scope); err != nil {
+				return nil, fmt.Errorf("client %s: %w", id, err)
+			}
+		}
+
+		client := &entity.Client{
+			ID:        id,
+			Name:      seed.Name,
+			Tier:      seed.Tier,
+			Scopes:    scopes,
+			Status:    status,
+			Keys:      make([]entity.ClientKey, 0, len(seed.Keys)),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		for _, key := range seed.Keys {
+			if key.ID == "" || key.Hash == "" {
+				return nil, fmt.Errorf("client %s: every key needs an id and a hash", id)
+			}
+			client.Keys = append(client.Keys, entity.ClientKey{
+				ID:        key.ID,
+				Prefix:    valueobject.MaskedAPIKeyPrefix(key.ID),
+				Hash:      key.Hash,
+				CreatedAt: now,
+				ExpiresAt: key.ExpiresAt,
+			})
+		}
+
+		clients = append(clients, client)
+	}
+
+	return clients, nil
+}
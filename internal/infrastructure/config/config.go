@@ -1,18 +1,31 @@
 package config
 
 import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"strconv"
 	"time"
 )
 
+const (
+	releaseMode = "release"
+	// minSecretLength is the shortest signing secret accepted in release mode, in bytes
+	minSecretLength = 32
+	// keyEncryptionKeyLength is the length of the AES-256 key sealing stored signing keys, in bytes
+	keyEncryptionKeyLength = 32
+)
+
 // Config holds all application configuration
 type Config struct {
 	Server    ServerConfig
 	Redis     RedisConfig
 	Kalshi    KalshiConfig
+	Fees      FeeConfig
 	JWT       JWTConfig
+	Auth      AuthConfig
 	RateLimit RateLimitConfig
 	Cache     CacheConfig
 	Worker    WorkerConfig
@@ -20,11 +33,16 @@ type Config struct {
 }
 
 type ServerConfig struct {
-	Port         string
-	GinMode      string
-	ReadTimeout  time.Duration
-	WriteTimeout time.Duration
-	IdleTimeout  time.Duration
+	Port          string
+	GinMode       string
+	ReadTimeout   time.Duration
+	WriteTimeout  time.Duration
+	IdleTimeout   time.Duration
+	PriceDecimals int
+	CursorSecret  string
+	// CursorSecretGenerated is set when CURSOR_SECRET is unset outside release mode and a
+	// random secret was generated, so cursors only verify on the process that issued them
+	CursorSecretGenerated bool
 }
 
 type RedisConfig struct {
@@ -35,19 +53,38 @@ type RedisConfig struct {
 }
 
 type KalshiConfig struct {
-	BaseURL string
-	APIKey  string
+	BaseURL        string
+	APIKey         string
+	FixtureMode    string
+	FixtureDir     string
+	StrictDecoding bool
+}
+
+type FeeConfig struct {
+	TakerRate float64
+	MakerRate float64
 }
 
 type JWTConfig struct {
-	Secret     string
-	Expiration time.Duration
+	SigningAlgorithm     string
+	KeyEncryptionKey     []byte        // Seals the signing keys stored in Redis (AES-256); nil stores them unencrypted
+	MigratePlaintextKeys bool          // Seals signing keys stored unencrypted instead of refusing them, once
+	KeyRotation          time.Duration // How long each signing key signs before the next takes over
+	Expiration           time.Duration // Access token lifetime
+	RefreshExpiration    time.Duration
+}
+
+type AuthConfig struct {
+	ClientsFile string
 }
 
 type RateLimitConfig struct {
-	Authenticated   int
+	Authenticated   int // Requests per minute of the built-in tiers
 	Unauthenticated int
 	Worker          int
+	TiersFile       string
+	Algorithm       string
+	RoutesFile      string
 }
 
 type CacheConfig struct {
@@ -76,6 +113,9 @@ func Load() (*Config, error) {
 			ReadTimeout:  60 * time.Second,
 			WriteTimeout: 60 * time.Second,
 			IdleTimeout:  120 * time.Second,
+			// Decimal places of a cent shown in responses (0-2)
+			PriceDecimals: getEnvInt("PRICE_DISPLAY_DECIMALS", 2),
+			CursorSecret:  getEnv("CURSOR_SECRET", ""),
 		},
 		Redis: RedisConfig{
 			Host:     getEnv("REDIS_HOST", "localhost"),
@@ -84,17 +124,37 @@ func Load() (*Config, error) {
 			DB:       getEnvInt("REDIS_DB", 0),
 		},
 		Kalshi: KalshiConfig{
-			BaseURL: getEnv("KALSHI_API_BASE_URL", "https://api.elections.kalshi.com"),
-			APIKey:  getEnv("KALSHI_API_KEY", "kalshi"),
+			BaseURL:        getEnv("KALSHI_API_BASE_URL", "https://api.elections.kalshi.com"),
+			APIKey:         getEnv("KALSHI_API_KEY", "kalshi"),
+			FixtureMode:    getEnv("KALSHI_FIXTURE_MODE", ""),
+			FixtureDir:     getEnv("KALSHI_FIXTURE_DIR", "testdata/kalshi"),
+			StrictDecoding: getEnvBool("KALSHI_STRICT_DECODING", false),
+		},
+		Fees: FeeConfig{
+			TakerRate: getEnvFloat("KALSHI_TAKER_FEE_RATE", 0.07),
+			MakerRate: getEnvFloat("KALSHI_MAKER_FEE_RATE", 0.0175),
 		},
 		JWT: JWTConfig{
-			Secret:     getEnv("JWT_SECRET", "secret"),
-			Expiration: time.Duration(getEnvInt("JWT_EXPIRATION_HOURS", 24)) * time.Hour,
+			SigningAlgorithm:     getEnv("JWT_SIGNING_ALGORITHM", "ES256"),
+			MigratePlaintextKeys: getEnvBool("JWT_KEY_ENCRYPTION_MIGRATE", false),
+			KeyRotation:          time.Duration(getEnvInt("JWT_KEY_ROTATION_HOURS", 720)) * time.Hour,
+			Expiration:           time.Duration(getEnvInt("JWT_ACCESS_TOKEN_MINUTES", 15)) * time.Minute,
+			RefreshExpiration:    time.Duration(getEnvInt("JWT_REFRESH_TOKEN_HOURS", 720)) * time.Hour,
+		},
+		Auth: AuthConfig{
+			// JSON file of API clients registered at startup, if not already in Redis
+			ClientsFile: getEnv("AUTH_CLIENTS_FILE", ""),
 		},
 		RateLimit: RateLimitConfig{
 			Authenticated:   getEnvInt("RATE_LIMIT_AUTHENTICATED", 100),
 			Unauthenticated: getEnvInt("RATE_LIMIT_UNAUTHENTICATED", 10),
 			Worker:          getEnvInt("RATE_LIMIT_WORKER", 80),
+			// JSON file of further tiers, assignable to API clients
+			TiersFile: getEnv("RATE_LIMIT_TIERS_FILE", ""),
+			// fixed_window, sliding_log, sliding_window or gcra
+			Algorithm: getEnv("RATE_LIMIT_ALGORITHM", "sliding_window"),
+			// JSON file of route costs and classes, added to or replacing the defaults
+			RoutesFile: getEnv("RATE_LIMIT_ROUTES_FILE", ""),
 		},
 		Cache: CacheConfig{
 			TTLMarkets:  time.Duration(getEnvInt("CACHE_TTL_MARKETS", 300)) * time.Second,
@@ -117,13 +177,75 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("KALSHI_API_KEY is required")
 	}
 
-	if cfg.JWT.Secret == "" {
-		return nil, fmt.Errorf("JWT_SECRET is required")
+	if cfg.JWT.KeyRotation < time.Hour {
+		return nil, fmt.Errorf("JWT_KEY_ROTATION_HOURS must be at least 1")
+	}
+
+	// A zero or negative duration would issue tokens that are already expired, cache
+	// nothing, or spin the worker
+	durations := []struct {
+		name  string
+		value time.Duration
+	}{
+		{name: "JWT_ACCESS_TOKEN_MINUTES", value: cfg.JWT.Expiration},
+		{name: "JWT_REFRESH_TOKEN_HOURS", value: cfg.JWT.RefreshExpiration},
+		{name: "CACHE_TTL_MARKETS", value: cfg.Cache.TTLMarkets},
+		{name: "CACHE_TTL_DETAILS", value: cfg.Cache.TTLDetails},
+		{name: "CACHE_TTL_OVERVIEW", value: cfg.Cache.TTLOverview},
+		{name: "WORKER_INTERVAL_SECONDS", value: time.Duration(cfg.Worker.IntervalSeconds) * time.Second},
+	}
+	for _, duration := range durations {
+		if duration.value <= 0 {
+			return nil, fmt.Errorf("%s must be positive", duration.name)
+		}
+	}
+
+	if encoded := os.Getenv("JWT_KEY_ENCRYPTION_KEY"); encoded != "" {
+		kek, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(kek) != keyEncryptionKeyLength {
+			return nil, fmt.Errorf("JWT_KEY_ENCRYPTION_KEY must be %d random bytes, base64 encoded", keyEncryptionKeyLength)
+		}
+		cfg.JWT.KeyEncryptionKey = kek
+	}
+	if cfg.JWT.MigratePlaintextKeys && cfg.JWT.KeyEncryptionKey == nil {
+		return nil, fmt.Errorf("JWT_KEY_ENCRYPTION_MIGRATE requires JWT_KEY_ENCRYPTION_KEY")
+	}
+
+	// Without a configured secret, local development signs cursors with a random one.
+	// Release mode refuses to start instead (see ValidateSecrets), since every API process
+	// must share the secret for cursors to be accepted by all of them.
+	if cfg.Server.CursorSecret == "" && cfg.Server.GinMode != releaseMode {
+		secret := make([]byte, minSecretLength)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate cursor secret: %w", err)
+		}
+		cfg.Server.CursorSecret = hex.EncodeToString(secret)
+		cfg.Server.CursorSecretGenerated = true
 	}
 
 	return cfg, nil
 }
 
+// ValidateSecrets refuses, in release mode, a missing cursor signing secret or one too short
+// to resist guessing, a missing signing key encryption key, and the one-time migration of
+// unencrypted signing keys. Debug and test modes accept them for local development.
+func (c *Config) ValidateSecrets() error {
+	if c.Server.GinMode != releaseMode {
+		return nil
+	}
+
+	if len(c.Server.CursorSecret) < minSecretLength {
+		return fmt.Errorf("CURSOR_SECRET must be set to a random value of at least %d bytes in release mode", minSecretLength)
+	}
+	if c.JWT.KeyEncryptionKey == nil {
+		return fmt.Errorf("JWT_KEY_ENCRYPTION_KEY must be set in release mode, so signing keys are not stored in Redis unencrypted")
+	}
+	if c.JWT.MigratePlaintextKeys {
+		return fmt.Errorf("JWT_KEY_ENCRYPTION_MIGRATE must not be set in release mode; migrate unencrypted signing keys once in debug mode")
+	}
+	return nil
+}
+
 // getEnv gets an environment variable or returns a default value
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -142,6 +264,26 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvFloat gets an environment variable as a float or returns a default value
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvBool gets an environment variable as a boolean or returns a default value
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
 // RedisAddr returns the Redis connection address
 func (c *RedisConfig) Addr() string {
 	return fmt.Sprintf("%s:%s", c.Host, c.Port)
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKeyEncryptionKey = base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", keyEncryptionKeyLength)))
+
+func TestLoadKeyEncryptionMigration(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+	}{
+		{
+			name: "migration with a key-encryption key",
+			env:  map[string]string{"JWT_KEY_ENCRYPTION_KEY": testKeyEncryptionKey, "JWT_KEY_ENCRYPTION_MIGRATE": "true"},
+		},
+		{
+			name:    "migration without a key-encryption key",
+			env:     map[string]string{"JWT_KEY_ENCRYPTION_MIGRATE": "true"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GIN_MODE", "debug")
+			t.Setenv("JWT_KEY_ENCRYPTION_KEY", "")
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, cfg.JWT.MigratePlaintextKeys)
+		})
+	}
+}
+
+func TestValidateSecrets(t *testing.T) {
+	secret := strings.Repeat("s", minSecretLength)
+	kek := []byte(strings.Repeat("k", keyEncryptionKeyLength))
+
+	tests := []struct {
+		name    string
+		mode    string
+		secret  string
+		kek     []byte
+		migrate bool
+		wantErr string
+	}{
+		{name: "release mode with secrets", mode: releaseMode, secret: secret, kek: kek},
+		{name: "short cursor secret", mode: releaseMode, secret: "short", kek: kek, wantErr: "CURSOR_SECRET"},
+		{name: "no key-encryption key", mode: releaseMode, secret: secret, wantErr: "JWT_KEY_ENCRYPTION_KEY"},
+		{name: "key migration in release mode", mode: releaseMode, secret: secret, kek: kek, migrate: true, wantErr: "JWT_KEY_ENCRYPTION_MIGRATE"},
+		{name: "key migration in debug mode", mode: "debug", kek: kek, migrate: true},
+		{name: "debug mode without secrets", mode: "debug"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server: ServerConfig{GinMode: tt.mode, CursorSecret: tt.secret},
+				JWT:    JWTConfig{KeyEncryptionKey: tt.kek, MigratePlaintextKeys: tt.migrate},
+			}
+
+			err := cfg.ValidateSecrets()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoadDurations(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		value   string
+		wantErr bool
+	}{
+		{name: "defaults", env: "JWT_ACCESS_TOKEN_MINUTES", value: ""},
+		{name: "access token lifetime", env: "JWT_ACCESS_TOKEN_MINUTES", value: "30"},
+		{name: "zero access token lifetime", env: "JWT_ACCESS_TOKEN_MINUTES", value: "0", wantErr: true},
+		{name: "negative access token lifetime", env: "JWT_ACCESS_TOKEN_MINUTES", value: "-5", wantErr: true},
+		{name: "zero refresh token lifetime", env: "JWT_REFRESH_TOKEN_HOURS", value: "0", wantErr: true},
+		{name: "negative refresh token lifetime", env: "JWT_REFRESH_TOKEN_HOURS", value: "-1", wantErr: true},
+		{name: "zero key rotation", env: "JWT_KEY_ROTATION_HOURS", value: "0", wantErr: true},
+		{name: "zero markets TTL", env: "CACHE_TTL_MARKETS", value: "0", wantErr: true},
+		{name: "negative details TTL", env: "CACHE_TTL_DETAILS", value: "-60", wantErr: true},
+		{name: "zero overview TTL", env: "CACHE_TTL_OVERVIEW", value: "0", wantErr: true},
+		{name: "zero worker interval", env: "WORKER_INTERVAL_SECONDS", value: "0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GIN_MODE", "debug")
+			t.Setenv(tt.env, tt.value)
+
+			_, err := Load()
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.env)
+		})
+	}
+}
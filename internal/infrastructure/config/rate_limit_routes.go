@@ -0,0 +1,107 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"upwork-test/internal/domain/ratelimit/valueobject"
+)
+
+// RouteCost is how requests to a route are rate limited. Routes not listed cost 1 in the
+// read class.
+type RouteCost struct {
+	Route     string                 `json:"route"`      // Method and path pattern, e.g. "GET /api/v1/markets/:ticker"
+	Class     valueobject.RouteClass `json:"class"`      // Defaults to read
+	Cost      int                    `json:"cost"`       // Units per request, or per started batch of BatchSize items
+	BatchSize int                    `json:"batch_size"` // Items per cost unit of a batch route; 0 for a flat cost
+}
+
+// rateLimitRouteFile is the layout of RATE_LIMIT_ROUTES_FILE
+type rateLimitRouteFile struct {
+	Routes []RouteCost `json:"routes"`
+}
+
+// defaultRouteCosts weighs requests by the upstream calls they can cost, and keeps
+// authentication out of the read bucket
+func defaultRouteCosts() []RouteCost {
+	return []RouteCost{
+		// A cache miss fetches the market, its order book and its recent trades
+		{Route: http.MethodGet + " /api/v1/markets/:ticker", Class: valueobject.RouteClassRead, Cost: 3},
+		{Route: http.MethodPost + " /api/v1/markets/batch", Class: valueobject.RouteClassRead, Cost: 1, BatchSize: 10},
+		{Route: http.MethodPost + " /api/v1/auth/token", Class: valueobject.RouteClassHandshake, Cost: 1},
+		{Route: http.MethodPost + " /api/v1/auth/refresh", Class: valueobject.RouteClassHandshake, Cost: 1},
+		{Route: http.MethodPost + " /api/v1/auth/revoke", Class: valueobject.RouteClassHandshake, Cost: 1},
+		{Route: http.MethodPost + " /api/v1/oauth/token", Class: valueobject.RouteClassHandshake, Cost: 1},
+		{Route: http.MethodPost + " /api/v1/oauth/introspect", Class: valueobject.RouteClassHandshake, Cost: 1},
+		{Route: http.MethodGet + " /.well-known/jwks.json", Class: valueobject.RouteClassHandshake, Cost: 1},
+	}
+}
+
+// LoadRouteCosts returns the default route costs, with the routes of the routes file, if
+// any, added or replacing them
+func LoadRouteCosts(path string) ([]RouteCost, error) {
+	routes := defaultRouteCosts()
+	if path == "" {
+		return routes, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate limit routes file: %w", err)
+	}
+
+	var file rateLimitRouteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse rate limit routes file: %w", err)
+	}
+
+	index := make(map[string]int, len(routes))
+	for i, route := range routes {
+		index[route.Route] = i
+	}
+	seen := make(map[string]bool, len(file.Routes))
+	for _, route := range file.Routes {
+		if err := validateRouteCost(&route); err != nil {
+			return nil, err
+		}
+		if seen[route.Route] {
+			return nil, fmt.Errorf("rate limit route %s is listed twice", route.Route)
+		}
+		seen[route.Route] = true
+
+		if i, ok := index[route.Route]; ok {
+			routes[i] = route
+			continue
+		}
+		routes = append(routes, route)
+	}
+
+	return routes, nil
+}
+
+// validateRouteCost checks a route cost, defaulting its class
+func validateRouteCost(route *RouteCost) error {
+	method, path, ok := strings.Cut(route.Route, " ")
+	if !ok || method == "" || method != strings.ToUpper(method) || !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("rate limit route %q must be a method and a path, e.g. \"GET /api/v1/markets\"", route.Route)
+	}
+
+	if route.Class == "" {
+		route.Class = valueobject.RouteClassRead
+	}
+	class, err := valueobject.NewRouteClass(route.Class.String())
+	if err != nil {
+		return fmt.Errorf("rate limit route %s: %w", route.Route, err)
+	}
+	route.Class = class
+
+	if route.Cost < 1 {
+		return fmt.Errorf("rate limit route %s: cost must be positive", route.Route)
+	}
+	if route.BatchSize < 0 {
+		return fmt.Errorf("rate limit route %s: batch size cannot be negative", route.Route)
+	}
+	return nil
+}
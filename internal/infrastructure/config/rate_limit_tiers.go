@@ -0,0 +1,84 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+	"upwork-test/internal/domain/ratelimit/valueobject"
+)
+
+// builtinTierWindow is the window of the built-in tiers, whose limits are set by RATE_LIMIT_*
+const builtinTierWindow = time.Minute
+
+// rateLimitTierFile is the layout of RATE_LIMIT_TIERS_FILE
+type rateLimitTierFile struct {
+	Tiers []rateLimitTierDefinition `json:"tiers"`
+}
+
+type rateLimitTierDefinition struct {
+	Name   string `json:"name"`
+	Limit  int    `json:"limit"`
+	Window string `json:"window"` // A Go duration, e.g. "1m"
+	Burst  int    `json:"burst"`  // Defaults to the limit
+}
+
+// LoadRateLimitTiers returns the built-in tiers at their configured limits, followed by the
+// tiers of the tiers file, if any. A tier of the file named after a built-in one replaces it.
+func LoadRateLimitTiers(cfg RateLimitConfig) ([]valueobject.RateLimitTier, error) {
+	definitions := []rateLimitTierDefinition{
+		{Name: valueobject.TierAuthenticated, Limit: cfg.Authenticated},
+		{Name: valueobject.TierUnauthenticated, Limit: cfg.Unauthenticated},
+		{Name: valueobject.TierWorker, Limit: cfg.Worker},
+	}
+
+	if cfg.TiersFile != "" {
+		data, err := os.ReadFile(cfg.TiersFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rate limit tiers file: %w", err)
+		}
+
+		var file rateLimitTierFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse rate limit tiers file: %w", err)
+		}
+
+		builtins := make(map[string]int, len(definitions))
+		for i, definition := range definitions {
+			builtins[definition.Name] = i
+		}
+		seen := make(map[string]bool, len(file.Tiers))
+		for _, definition := range file.Tiers {
+			if seen[definition.Name] {
+				return nil, fmt.Errorf("rate limit tier %s is defined twice", definition.Name)
+			}
+			seen[definition.Name] = true
+
+			if i, ok := builtins[definition.Name]; ok {
+				definitions[i] = definition
+				continue
+			}
+			definitions = append(definitions, definition)
+		}
+	}
+
+	tiers := make([]valueobject.RateLimitTier, 0, len(definitions))
+	for _, definition := range definitions {
+		window := builtinTierWindow
+		if definition.Window != "" {
+			parsed, err := time.ParseDuration(definition.Window)
+			if err != nil {
+				return nil, fmt.Errorf("rate limit tier %s: invalid window %q", definition.Name, definition.Window)
+			}
+			window = parsed
+		}
+
+		tier, err := valueobject.DefineRateLimitTier(definition.Name, definition.Limit, window, definition.Burst)
+		if err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, tier)
+	}
+
+	return tiers, nil
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"upwork-test/internal/domain/ratelimit/valueobject"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tierSummary is what a test checks of a tier
+type tierSummary struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+	Burst  int
+}
+
+func summarizeTiers(tiers []valueobject.RateLimitTier) []tierSummary {
+	summaries := make([]tierSummary, len(tiers))
+	for i, tier := range tiers {
+		summaries[i] = tierSummary{Name: tier.Name(), Limit: tier.MaxRequests(), Window: tier.Window(), Burst: tier.Burst()}
+	}
+	return summaries
+}
+
+// writeTiersFile writes a tiers file and returns its path
+func writeTiersFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "tiers.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadRateLimitTiers(t *testing.T) {
+	builtins := RateLimitConfig{Authenticated: 100, Unauthenticated: 10, Worker: 80}
+
+	tests := []struct {
+		name string
+		file string
+		want []tierSummary
+	}{
+		{
+			name: "built-in tiers only",
+			want: []tierSummary{
+				{Name: "authenticated", Limit: 100, Window: time.Minute, Burst: 100},
+				{Name: "unauthenticated", Limit: 10, Window: time.Minute, Burst: 10},
+				{Name: "worker", Limit: 80, Window: time.Minute, Burst: 80},
+			},
+		},
+		{
+			name: "file tiers follow the built-in ones",
+			file: `{"tiers": [
+				{"name": "partner", "limit": 1000, "window": "1h", "burst": 50},
+				{"name": "internal", "limit": 500}
+			]}`,
+			want: []tierSummary{
+				{Name: "authenticated", Limit: 100, Window: time.Minute, Burst: 100},
+				{Name: "unauthenticated", Limit: 10, Window: time.Minute, Burst: 10},
+				{Name: "worker", Limit: 80, Window: time.Minute, Burst: 80},
+				{Name: "partner", Limit: 1000, Window: time.Hour, Burst: 50},
+				{Name: "internal", Limit: 500, Window: time.Minute, Burst: 500},
+			},
+		},
+		{
+			name: "file tier replaces a built-in one",
+			file: `{"tiers": [{"name": "worker", "limit": 10, "window": "10s"}]}`,
+			want: []tierSummary{
+				{Name: "authenticated", Limit: 100, Window: time.Minute, Burst: 100},
+				{Name: "unauthenticated", Limit: 10, Window: time.Minute, Burst: 10},
+				{Name: "worker", Limit: 10, Window: 10 * time.Second, Burst: 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := builtins
+			if tt.file != "" {
+				cfg.TiersFile = writeTiersFile(t, tt.file)
+			}
+
+			tiers, err := LoadRateLimitTiers(cfg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, summarizeTiers(tiers))
+		})
+	}
+}
+
+func TestLoadRateLimitTiersInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     RateLimitConfig
+		file    string
+		wantErr string
+	}{
+		{
+			name:    "zero built-in limit",
+			cfg:     RateLimitConfig{Authenticated: 100, Unauthenticated: 0, Worker: 80},
+			wantErr: "unauthenticated: limit must be positive",
+		},
+		{name: "malformed file", file: `{"tiers": [`, wantErr: "failed to parse"},
+		{
+			name:    "tier defined twice",
+			file:    `{"tiers": [{"name": "partner", "limit": 5}, {"name": "partner", "limit": 6}]}`,
+			wantErr: "partner is defined twice",
+		},
+		{
+			name:    "invalid window",
+			file:    `{"tiers": [{"name": "partner", "limit": 5, "window": "soon"}]}`,
+			wantErr: `invalid window "soon"`,
+		},
+		{
+			name:    "window under a second",
+			file:    `{"tiers": [{"name": "partner", "limit": 5, "window": "500ms"}]}`,
+			wantErr: "window must be at least 1s",
+		},
+		{
+			name:    "negative burst",
+			file:    `{"tiers": [{"name": "partner", "limit": 5, "burst": -1}]}`,
+			wantErr: "burst must be positive",
+		},
+		{
+			name:    "invalid name",
+			file:    `{"tiers": [{"name": "Partner Tier", "limit": 5}]}`,
+			wantErr: "must be lowercase",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			if cfg == (RateLimitConfig{}) {
+				cfg = RateLimitConfig{Authenticated: 100, Unauthenticated: 10, Worker: 80}
+			}
+			if tt.file != "" {
+				cfg.TiersFile = writeTiersFile(t, tt.file)
+			}
+
+			_, err := LoadRateLimitTiers(cfg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoadRateLimitTiersMissingFile(t *testing.T) {
+	_, err := LoadRateLimitTiers(RateLimitConfig{
+		Authenticated: 100, Unauthenticated: 10, Worker: 80,
+		TiersFile: filepath.Join(t.TempDir(), "missing.json"),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read")
+}
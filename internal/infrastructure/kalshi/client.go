@@ -3,9 +3,12 @@ package kalshi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -16,69 +19,194 @@ const (
 	initialBackoff    = 1 * time.Second
 	maxBackoff        = 10 * time.Second
 	backoffMultiplier = 2.0
+	pageLimit         = 1000 // Kalshi's maximum page size
+	eventPageLimit    = 200  // Kalshi's maximum page size for /events
+	maxPages          = 10   // Guards against runaway cursor loops; longer listings are marked Truncated
+	maxEventPages     = 500  // Lists every event (up to 100,000); only background jobs list them
 )
 
+var (
+	// ErrNotFound is returned when Kalshi responds with 404
+	ErrNotFound = errors.New("resource not found")
+)
+
+// Limiter paces upstream requests; *rate.Limiter satisfies it
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
 // Client represents a Kalshi API client
 type Client struct {
 	baseURL    string
 	apiKey     string
 	httpClient *http.Client
+	monitor    *SchemaMonitor
+	limiter    Limiter
 }
 
 // NewClient creates a new Kalshi API client
 func NewClient(baseURL, apiKey string) *Client {
+	return NewClientWithTransport(baseURL, apiKey, nil)
+}
+
+// NewClientWithTransport creates a Kalshi API client that sends requests through transport.
+// A nil transport uses http.DefaultTransport.
+func NewClientWithTransport(baseURL, apiKey string, transport http.RoundTripper) *Client {
 	return &Client{
 		baseURL: baseURL,
 		apiKey:  apiKey,
 		httpClient: &http.Client{
-			Timeout: defaultTimeout,
+			Timeout:   defaultTimeout,
+			Transport: transport,
 		},
 	}
 }
 
-// GetMarkets fetches markets filtered by category and status
-func (c *Client) GetMarkets(ctx context.Context, category string, status string) (*MarketListResponse, error) {
-	// Step 1: Get series tickers for this category
+// SetSchemaMonitor attaches a monitor that records payload drift for every response
+func (c *Client) SetSchemaMonitor(monitor *SchemaMonitor) {
+	c.monitor = monitor
+}
+
+// SetRateLimiter paces every request sent to Kalshi, including each page of a listing and each retry
+func (c *Client) SetRateLimiter(limiter Limiter) {
+	c.limiter = limiter
+}
+
+// SchemaMonitor returns the attached schema monitor, or nil if none is set
+func (c *Client) SchemaMonitor() *SchemaMonitor {
+	return c.monitor
+}
+
+// GetAllMarkets fetches every market in a category across all of its series, following cursors.
+// A status narrows the listing; an empty status fetches markets of every status.
+func (c *Client) GetAllMarkets(ctx context.Context, category string, status string) (*MarketListResponse, error) {
 	seriesTickers, err := c.getSeriesTickersForCategory(ctx, category)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get series: %w", err)
 	}
-	
-	if len(seriesTickers) == 0 {
-		return &MarketListResponse{Markets: []MarketResponse{}}, nil
-	}
-	
-	// Step 2: Fetch markets for each series individually
-	// Note: Kalshi API doesn't support multiple series_ticker params in one call
-	// We'll fetch markets for each series (limited to first 50 series to avoid timeout)
+
 	upperCategory := strings.ToUpper(category)
-	
 	var allMarkets []MarketResponse
-	maxSeries := 10 // Limit to avoid timeout for categories with many series
-	if len(seriesTickers) > maxSeries {
-		seriesTickers = seriesTickers[:maxSeries]
-	}
-	
+
+	truncated := false
+
 	for _, seriesTicker := range seriesTickers {
-		url := fmt.Sprintf("%s/trade-api/v2/markets?series_ticker=%s&limit=1", c.baseURL, seriesTicker)
+		query := url.Values{}
+		query.Set("series_ticker", seriesTicker)
+		query.Set("limit", strconv.Itoa(pageLimit))
 		if status != "" {
-			url += fmt.Sprintf("&status=%s", status)
+			query.Set("status", status)
 		}
-		
-		var response MarketListResponse
-		if err := c.doRequest(ctx, "GET", url, nil, &response); err != nil {
-			// Log error but continue with other series
-			continue
+
+		cursor := ""
+		for page := 0; ; page++ {
+			if page == maxPages {
+				c.warnTruncated("/markets", query, page)
+				truncated = true
+				break
+			}
+
+			var response MarketListResponse
+			if err := c.doRequest(ctx, "GET", "/markets", c.pageURL("/markets", query, cursor), nil, &response); err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				// Skip the rest of this series but keep the others
+				truncated = true
+				break
+			}
+
+			for i := range response.Markets {
+				response.Markets[i].Category = upperCategory
+			}
+			allMarkets = append(allMarkets, response.Markets...)
+
+			cursor = response.Cursor
+			if cursor == "" || len(response.Markets) == 0 {
+				break
+			}
 		}
-		
-		// Add markets from this series
-		for i := range response.Markets {
-			response.Markets[i].Category = upperCategory
+	}
+
+	return &MarketListResponse{Markets: allMarkets, Truncated: truncated}, nil
+}
+
+// GetMarketsByEvent fetches every market belonging to an event, following cursors
+func (c *Client) GetMarketsByEvent(ctx context.Context, eventTicker string) (*MarketListResponse, error) {
+	var allMarkets []MarketResponse
+	query := url.Values{}
+	query.Set("event_ticker", eventTicker)
+	query.Set("limit", strconv.Itoa(pageLimit))
+
+	cursor := ""
+	for page := 0; ; page++ {
+		if page == maxPages {
+			c.warnTruncated("/markets", query, page)
+			return &MarketListResponse{Markets: allMarkets, Truncated: true}, nil
+		}
+
+		var response MarketListResponse
+		if err := c.doRequest(ctx, "GET", "/markets", c.pageURL("/markets", query, cursor), nil, &response); err != nil {
+			return nil, fmt.Errorf("failed to get markets for event: %w", err)
 		}
+
 		allMarkets = append(allMarkets, response.Markets...)
-	}	
-	
-	return &MarketListResponse{Markets: allMarkets}, nil
+
+		cursor = response.Cursor
+		if cursor == "" || len(response.Markets) == 0 {
+			return &MarketListResponse{Markets: allMarkets}, nil
+		}
+	}
+}
+
+// GetSeries fetches the series of a category
+func (c *Client) GetSeries(ctx context.Context, category string) (*SeriesListResponse, error) {
+	url := fmt.Sprintf("%s/trade-api/v2/series?category=%s", c.baseURL, category)
+
+	var response SeriesListResponse
+	if err := c.doRequest(ctx, "GET", "/series", url, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get series: %w", err)
+	}
+
+	upperCategory := strings.ToUpper(category)
+	series := make([]SeriesResponse, 0, len(response.Series))
+	for _, s := range response.Series {
+		if strings.EqualFold(s.Category, upperCategory) {
+			series = append(series, s)
+		}
+	}
+
+	return &SeriesListResponse{Series: series}, nil
+}
+
+// GetEvents fetches every event with the given status across all categories, following cursors
+func (c *Client) GetEvents(ctx context.Context, status string) (*EventListResponse, error) {
+	var allEvents []EventResponse
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(eventPageLimit))
+	if status != "" {
+		query.Set("status", status)
+	}
+
+	cursor := ""
+	for page := 0; ; page++ {
+		if page == maxEventPages {
+			c.warnTruncated("/events", query, page)
+			return &EventListResponse{Events: allEvents, Truncated: true}, nil
+		}
+
+		var response EventListResponse
+		if err := c.doRequest(ctx, "GET", "/events", c.pageURL("/events", query, cursor), nil, &response); err != nil {
+			return nil, fmt.Errorf("failed to get events: %w", err)
+		}
+
+		allEvents = append(allEvents, response.Events...)
+
+		cursor = response.Cursor
+		if cursor == "" || len(response.Events) == 0 {
+			return &EventListResponse{Events: allEvents}, nil
+		}
+	}
 }
 
 // getSeriesTickersForCategory fetches series and returns tickers for the given category
@@ -86,44 +214,56 @@ func (c *Client) getSeriesTickersForCategory(ctx context.Context, category strin
 	// Fetch series with smaller limit to avoid timeout
 	// The /series endpoint is slow; limiting to 500 keeps response time reasonable
 	url := fmt.Sprintf("%s/trade-api/v2/series?category=%s", c.baseURL, category)
-	
+
 	var response SeriesListResponse
-	if err := c.doRequest(ctx, "GET", url, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GET", "/series", url, nil, &response); err != nil {
 		return nil, err
 	}
-	
+
 	upperCategory := strings.ToUpper(category)
 	seriesTickers := make([]string, 0)
-	
+
 	for _, series := range response.Series {
 		if strings.EqualFold(series.Category, upperCategory) {
 			seriesTickers = append(seriesTickers, series.Ticker)
 		}
 	}
-	
+
 	return seriesTickers, nil
 }
 
 // GetMarket fetches a single market by tickery
 func (c *Client) GetMarket(ctx context.Context, ticker string) (*MarketResponse, error) {
-	url := fmt.Sprintf("%s/trade-api/v2/markets/%s", c.baseURL, ticker)
+	requestURL := fmt.Sprintf("%s/trade-api/v2/markets/%s", c.baseURL, url.PathEscape(ticker))
 
 	var response struct {
 		Market MarketResponse `json:"market"`
 	}
-	if err := c.doRequest(ctx, "GET", url, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GET", "/markets/{ticker}", requestURL, nil, &response); err != nil {
 		return nil, fmt.Errorf("failed to get market: %w", err)
 	}
 
 	return &response.Market, nil
 }
 
+// GetEvent fetches an event together with its markets
+func (c *Client) GetEvent(ctx context.Context, eventTicker string) (*EventDetailResponse, error) {
+	requestURL := fmt.Sprintf("%s/trade-api/v2/events/%s?with_nested_markets=false", c.baseURL, url.PathEscape(eventTicker))
+
+	var response EventDetailResponse
+	if err := c.doRequest(ctx, "GET", "/events/{event_ticker}", requestURL, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	return &response, nil
+}
+
 // GetOrderBook fetches the order book for a market
 func (c *Client) GetOrderBook(ctx context.Context, ticker string) (*OrderBookResponse, error) {
-	url := fmt.Sprintf("%s/trade-api/v2/markets/%s/orderbook", c.baseURL, ticker)
+	requestURL := fmt.Sprintf("%s/trade-api/v2/markets/%s/orderbook", c.baseURL, url.PathEscape(ticker))
 
 	var response OrderBookResponse
-	if err := c.doRequest(ctx, "GET", url, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GET", "/markets/{ticker}/orderbook", requestURL, nil, &response); err != nil {
 		return nil, fmt.Errorf("failed to get orderbook: %w", err)
 	}
 
@@ -132,18 +272,78 @@ func (c *Client) GetOrderBook(ctx context.Context, ticker string) (*OrderBookRes
 
 // GetTrades fetches recent trades for a market
 func (c *Client) GetTrades(ctx context.Context, ticker string, limit int) (*TradesResponse, error) {
-	url := fmt.Sprintf("%s/trade-api/v2/markets/%s/trades?limit=%d", c.baseURL, ticker, limit)
+	requestURL := fmt.Sprintf("%s/trade-api/v2/markets/%s/trades?limit=%d", c.baseURL, url.PathEscape(ticker), limit)
 
 	var response TradesResponse
-	if err := c.doRequest(ctx, "GET", url, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GET", "/markets/{ticker}/trades", requestURL, nil, &response); err != nil {
 		return nil, fmt.Errorf("failed to get trades: %w", err)
 	}
 
 	return &response, nil
 }
 
-// doRequest executes an HTTP request with retry logic and exponential backoff
-func (c *Client) doRequest(ctx context.Context, method, url string, body io.Reader, result interface{}) error {
+// GetCandlesticks fetches a market's candlesticks between start and end, one per periodMinutes (1, 60 or 1440)
+func (c *Client) GetCandlesticks(ctx context.Context, seriesTicker, ticker string, start, end time.Time, periodMinutes int) (*CandlesticksResponse, error) {
+	requestURL := fmt.Sprintf("%s/trade-api/v2/series/%s/markets/%s/candlesticks?start_ts=%d&end_ts=%d&period_interval=%d",
+		c.baseURL, url.PathEscape(seriesTicker), url.PathEscape(ticker), start.Unix(), end.Unix(), periodMinutes)
+
+	var response CandlesticksResponse
+	if err := c.doRequest(ctx, "GET", "/series/{series_ticker}/markets/{ticker}/candlesticks", requestURL, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get candlesticks: %w", err)
+	}
+
+	return &response, nil
+}
+
+// GetTradesSince fetches every trade in a market created at or after since, following cursors
+func (c *Client) GetTradesSince(ctx context.Context, ticker string, since time.Time) (*TradesResponse, error) {
+	var allTrades []TradeResponse
+	query := url.Values{}
+	query.Set("ticker", ticker)
+	query.Set("min_ts", strconv.FormatInt(since.Unix(), 10))
+	query.Set("limit", strconv.Itoa(pageLimit))
+
+	cursor := ""
+	for page := 0; ; page++ {
+		if page == maxPages {
+			c.warnTruncated("/markets/trades", query, page)
+			return &TradesResponse{Trades: allTrades, Truncated: true}, nil
+		}
+
+		var response TradesResponse
+		if err := c.doRequest(ctx, "GET", "/markets/trades", c.pageURL("/markets/trades", query, cursor), nil, &response); err != nil {
+			return nil, fmt.Errorf("failed to get trades: %w", err)
+		}
+
+		allTrades = append(allTrades, response.Trades...)
+
+		cursor = response.Cursor
+		if cursor == "" || len(response.Trades) == 0 {
+			return &TradesResponse{Trades: allTrades}, nil
+		}
+	}
+}
+
+// pageURL builds the URL of one page of a list endpoint, escaping every parameter
+func (c *Client) pageURL(path string, query url.Values, cursor string) string {
+	page := url.Values{}
+	for key, values := range query {
+		page[key] = values
+	}
+	if cursor != "" {
+		page.Set("cursor", cursor)
+	}
+	return c.baseURL + "/trade-api/v2" + path + "?" + page.Encode()
+}
+
+// warnTruncated logs a listing cut off at its page cap; the response is marked Truncated
+func (c *Client) warnTruncated(endpoint string, query url.Values, pages int) {
+	fmt.Printf("Warning: Kalshi %s listing (%s) truncated after %d pages\n", endpoint, query.Encode(), pages)
+}
+
+// doRequest executes an HTTP request with retry logic and exponential backoff.
+// endpoint is the route template used to group schema diagnostics.
+func (c *Client) doRequest(ctx context.Context, method, endpoint, url string, body io.Reader, result interface{}) error {
 	var lastErr error
 	backoff := initialBackoff
 
@@ -162,6 +362,12 @@ func (c *Client) doRequest(ctx context.Context, method, url string, body io.Read
 			}
 		}
 
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return err
+			}
+		}
+
 		req, err := http.NewRequestWithContext(ctx, method, url, body)
 		if err != nil {
 			return fmt.Errorf("failed to create request: %w", err)
@@ -169,7 +375,7 @@ func (c *Client) doRequest(ctx context.Context, method, url string, body io.Read
 
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("Accept", "application/json")
-		
+
 		// Only set Authorization header if API key is provided
 		if c.apiKey != "" {
 			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
@@ -177,6 +383,10 @@ func (c *Client) doRequest(ctx context.Context, method, url string, body io.Read
 
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
+			// A missing fixture will not appear on retry
+			if errors.Is(err, ErrFixtureNotFound) {
+				return err
+			}
 			lastErr = fmt.Errorf("request failed: %w", err)
 			continue
 		}
@@ -199,6 +409,10 @@ func (c *Client) doRequest(ctx context.Context, method, url string, body io.Read
 			continue
 		}
 
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("%w: %s", ErrNotFound, endpoint)
+		}
+
 		if resp.StatusCode >= 400 {
 			// Client error - don't retry
 			var errResp ErrorResponse
@@ -209,9 +423,12 @@ func (c *Client) doRequest(ctx context.Context, method, url string, body io.Read
 		}
 
 		if err := json.Unmarshal(bodyBytes, result); err != nil {
+			c.monitor.RecordDecodeError(method + " " + endpoint)
 			return fmt.Errorf("failed to unmarshal response: %w", err)
 		}
 
+		c.monitor.Inspect(method+" "+endpoint, bodyBytes, result)
+
 		return nil
 	}
 
@@ -0,0 +1,100 @@
+package kalshi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pathRecorder answers every request with an empty JSON object and records its escaped path
+type pathRecorder struct {
+	paths []string
+}
+
+func (r *pathRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.paths = append(r.paths, req.URL.EscapedPath())
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request:    req,
+	}, nil
+}
+
+func TestClientEscapesPathSegments(t *testing.T) {
+	// A ticker with path and query syntax must stay one segment of the intended endpoint
+	const ticker = "KX/../events?x=1#y"
+	const escaped = "KX%2F..%2Fevents%3Fx=1%23y"
+
+	tests := []struct {
+		name     string
+		call     func(ctx context.Context, client *Client) error
+		wantPath string
+	}{
+		{
+			name: "market",
+			call: func(ctx context.Context, client *Client) error {
+				_, err := client.GetMarket(ctx, ticker)
+				return err
+			},
+			wantPath: "/trade-api/v2/markets/" + escaped,
+		},
+		{
+			name: "event",
+			call: func(ctx context.Context, client *Client) error {
+				_, err := client.GetEvent(ctx, ticker)
+				return err
+			},
+			wantPath: "/trade-api/v2/events/" + escaped,
+		},
+		{
+			name: "order book",
+			call: func(ctx context.Context, client *Client) error {
+				_, err := client.GetOrderBook(ctx, ticker)
+				return err
+			},
+			wantPath: "/trade-api/v2/markets/" + escaped + "/orderbook",
+		},
+		{
+			name: "trades",
+			call: func(ctx context.Context, client *Client) error {
+				_, err := client.GetTrades(ctx, ticker, 10)
+				return err
+			},
+			wantPath: "/trade-api/v2/markets/" + escaped + "/trades",
+		},
+		{
+			name: "candlesticks",
+			call: func(ctx context.Context, client *Client) error {
+				_, err := client.GetCandlesticks(ctx, "KX SERIES", ticker, time.Unix(0, 0), time.Unix(3600, 0), 60)
+				return err
+			},
+			wantPath: "/trade-api/v2/series/KX%20SERIES/markets/" + escaped + "/candlesticks",
+		},
+		{
+			name: "plain tickers are unchanged",
+			call: func(ctx context.Context, client *Client) error {
+				_, err := client.GetMarket(ctx, "KXCPIYOY-24NOV-T2.7")
+				return err
+			},
+			wantPath: "/trade-api/v2/markets/KXCPIYOY-24NOV-T2.7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &pathRecorder{}
+			client := NewClientWithTransport("https://api.elections.kalshi.com", "", recorder)
+
+			require.NoError(t, tt.call(context.Background(), client))
+			require.Len(t, recorder.paths, 1)
+			assert.Equal(t, tt.wantPath, recorder.paths[0])
+		})
+	}
+}
@@ -2,16 +2,21 @@ package kalshi
 
 import (
 	"fmt"
+	"time"
 	"upwork-test/internal/domain/market/entity"
 	"upwork-test/internal/domain/market/valueobject"
 )
 
 // Mapper converts Kalshi API models to domain entities
-type Mapper struct{}
+type Mapper struct {
+	monitor *SchemaMonitor
+}
 
-// NewMapper creates a new Mapper
-func NewMapper() *Mapper {
-	return &Mapper{}
+// NewMapper creates a new Mapper. monitor may be nil.
+func NewMapper(monitor *SchemaMonitor) *Mapper {
+	return &Mapper{
+		monitor: monitor,
+	}
 }
 
 // ToMarketEntity converts a MarketResponse to a Market entity
@@ -21,30 +26,11 @@ func (m *Mapper) ToMarketEntity(resp *MarketResponse) (*entity.Market, error) {
 		return nil, fmt.Errorf("invalid ticker: %w", err)
 	}
 
-	yesAsk, err := valueobject.NewPrice(resp.YesAsk)
-	if err != nil {
-		yesAsk, _ = valueobject.NewPrice(0)
-	}
-
-	yesBid, err := valueobject.NewPrice(resp.YesBid)
-	if err != nil {
-		yesBid, _ = valueobject.NewPrice(0)
-	}
-
-	noAsk, err := valueobject.NewPrice(resp.NoAsk)
-	if err != nil {
-		noAsk, _ = valueobject.NewPrice(0)
-	}
-
-	noBid, err := valueobject.NewPrice(resp.NoBid)
-	if err != nil {
-		noBid, _ = valueobject.NewPrice(0)
-	}
-
-	lastPrice, err := valueobject.NewPrice(resp.LastPrice)
-	if err != nil {
-		lastPrice, _ = valueobject.NewPrice(0)
-	}
+	yesAsk := m.priceOrZero("market.yes_ask", resp.YesAsk, resp.YesAskDollars)
+	yesBid := m.priceOrZero("market.yes_bid", resp.YesBid, resp.YesBidDollars)
+	noAsk := m.priceOrZero("market.no_ask", resp.NoAsk, resp.NoAskDollars)
+	noBid := m.priceOrZero("market.no_bid", resp.NoBid, resp.NoBidDollars)
+	lastPrice := m.priceOrZero("market.last_price", resp.LastPrice, resp.LastPriceDollars)
 
 	status := m.mapMarketStatus(resp.Status)
 
@@ -62,13 +48,68 @@ func (m *Mapper) ToMarketEntity(resp *MarketResponse) (*entity.Market, error) {
 	market.NoAsk = noAsk
 	market.NoBid = noBid
 	market.LastPrice = lastPrice
+	market.PreviousYesAsk = m.priceOrZero("market.previous_yes_ask", resp.PreviousYesAsk, resp.PreviousYesAskDollars)
+	market.PreviousYesBid = m.priceOrZero("market.previous_yes_bid", resp.PreviousYesBid, resp.PreviousYesBidDollars)
+	market.PreviousPrice = m.priceOrZero("market.previous_price", resp.PreviousPrice, resp.PreviousPriceDollars)
 	market.Volume = resp.Volume
 	market.Volume24h = resp.Volume24h
 	market.Liquidity = resp.Liquidity
+	market.OpenInterest = resp.OpenInterest
+	market.Subtitle = resp.Subtitle
+	market.EventTicker = resp.EventTicker
+	market.RangedGroupTicker = resp.RangedGroupTicker
+	market.Strike = m.mapStrike(resp)
+	market.Settlement = m.mapSettlement(ticker, status, resp)
 
 	return market, nil
 }
 
+// mapStrike builds the strike for scalar/bracket markets, or nil for plain binary markets
+func (m *Mapper) mapStrike(resp *MarketResponse) *valueobject.Strike {
+	if resp.StrikeType == "" {
+		return nil
+	}
+
+	strike, err := valueobject.NewStrike(resp.StrikeType, resp.FloorStrike, resp.CapStrike, resp.FunctionalStrike)
+	if err != nil {
+		m.monitor.RecordFallbackValue("market.strike:dropped", resp.StrikeType)
+		return nil
+	}
+
+	return &strike
+}
+
+// mapSettlement builds the settlement for a settled market, or nil if it has not settled.
+// Kalshi may report a result while the market is determined or disputed; it is only final once settled.
+func (m *Mapper) mapSettlement(ticker valueobject.Ticker, status entity.MarketStatus, resp *MarketResponse) *entity.Settlement {
+	if resp.Result == "" || !status.IsSettled() {
+		return nil
+	}
+
+	result, err := entity.ParseSettlementResult(resp.Result)
+	if err != nil {
+		m.monitor.RecordFallbackValue("market.result:unknown", resp.Result)
+		return nil
+	}
+
+	// Kalshi omits settlement_value for binary markets; derive it from the result
+	value := resp.SettlementValue
+	if value == 0 && result == entity.SettlementResultYes {
+		value = 100
+	}
+	settlementValue := m.priceOrZero("market.settlement_value", value, "")
+
+	settledAt := resp.SettlementTime
+	if settledAt.IsZero() {
+		settledAt = resp.LatestExpiration
+	}
+	if settledAt.IsZero() {
+		settledAt = resp.CloseTime
+	}
+
+	return entity.NewSettlement(ticker, result, settlementValue, resp.ExpirationValue, settledAt)
+}
+
 // ToMarketEntities converts multiple MarketResponse to Market entities
 func (m *Mapper) ToMarketEntities(responses []MarketResponse) ([]*entity.Market, error) {
 	markets := make([]*entity.Market, 0, len(responses))
@@ -76,6 +117,7 @@ func (m *Mapper) ToMarketEntities(responses []MarketResponse) ([]*entity.Market,
 	for _, resp := range responses {
 		market, err := m.ToMarketEntity(&resp)
 		if err != nil {
+			m.monitor.RecordFallback("market:dropped")
 			continue
 		}
 		markets = append(markets, market)
@@ -84,6 +126,46 @@ func (m *Mapper) ToMarketEntities(responses []MarketResponse) ([]*entity.Market,
 	return markets, nil
 }
 
+// ToEventEntity converts an EventDetailResponse to an Event entity with its markets
+func (m *Mapper) ToEventEntity(resp *EventDetailResponse) (*entity.Event, error) {
+	event := entity.NewEvent(
+		resp.Event.EventTicker,
+		resp.Event.SeriesTicker,
+		resp.Event.Title,
+		resp.Event.Category,
+		resp.Event.MutuallyExclusive,
+	)
+	event.SubTitle = resp.Event.SubTitle
+
+	markets, err := m.ToMarketEntities(resp.Markets)
+	if err != nil {
+		return nil, err
+	}
+	event.Markets = markets
+
+	return event, nil
+}
+
+// ToEventEntities converts event list entries to Event entities without markets
+func (m *Mapper) ToEventEntities(responses []EventResponse) []*entity.Event {
+	events := make([]*entity.Event, 0, len(responses))
+	for _, resp := range responses {
+		event := entity.NewEvent(resp.EventTicker, resp.SeriesTicker, resp.Title, resp.Category, resp.MutuallyExclusive)
+		event.SubTitle = resp.SubTitle
+		events = append(events, event)
+	}
+	return events
+}
+
+// ToSeriesEntities converts series list entries to Series entities
+func (m *Mapper) ToSeriesEntities(responses []SeriesResponse) []*entity.Series {
+	series := make([]*entity.Series, 0, len(responses))
+	for _, resp := range responses {
+		series = append(series, entity.NewSeries(resp.Ticker, resp.Title, resp.Category, resp.Frequency))
+	}
+	return series
+}
+
 // ToOrderBookEntity converts an OrderBookResponse to an OrderBook entity
 func (m *Mapper) ToOrderBookEntity(resp *OrderBookResponse) (*entity.OrderBook, error) {
 	ticker, err := valueobject.NewTicker(resp.Ticker)
@@ -95,6 +177,8 @@ func (m *Mapper) ToOrderBookEntity(resp *OrderBookResponse) (*entity.OrderBook,
 	for _, level := range resp.YesOrders {
 		price, err := valueobject.NewPrice(level.Price)
 		if err != nil {
+			m.monitor.RecordOutOfRange("orderbook.yes_orders.price")
+			m.monitor.RecordFallback("orderbook.level:dropped")
 			continue
 		}
 		bids = append(bids, entity.OrderLevel{
@@ -107,6 +191,8 @@ func (m *Mapper) ToOrderBookEntity(resp *OrderBookResponse) (*entity.OrderBook,
 	for _, level := range resp.NoOrders {
 		price, err := valueobject.NewPrice(level.Price)
 		if err != nil {
+			m.monitor.RecordOutOfRange("orderbook.no_orders.price")
+			m.monitor.RecordFallback("orderbook.level:dropped")
 			continue
 		}
 		asks = append(asks, entity.OrderLevel{
@@ -126,11 +212,17 @@ func (m *Mapper) ToTradeEntities(responses []TradeResponse) ([]*entity.Trade, er
 	for _, resp := range responses {
 		ticker, err := valueobject.NewTicker(resp.Ticker)
 		if err != nil {
+			m.monitor.RecordFallback("trade:dropped")
 			continue
 		}
 
 		price, err := valueobject.NewPrice(resp.Price)
+		if resp.YesPriceDollars != "" {
+			price, err = valueobject.ParseDollars(resp.YesPriceDollars)
+		}
 		if err != nil {
+			m.monitor.RecordOutOfRange("trade.price")
+			m.monitor.RecordFallback("trade:dropped")
 			continue
 		}
 
@@ -151,18 +243,46 @@ func (m *Mapper) ToTradeEntities(responses []TradeResponse) ([]*entity.Trade, er
 	return trades, nil
 }
 
+// ToCandlestickEntities converts candlestick responses to Candlestick entities
+func (m *Mapper) ToCandlestickEntities(resp *CandlesticksResponse) ([]*entity.Candlestick, error) {
+	ticker, err := valueobject.NewTicker(resp.Ticker)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ticker: %w", err)
+	}
+
+	candles := make([]*entity.Candlestick, 0, len(resp.Candlesticks))
+	for _, candle := range resp.Candlesticks {
+		result := &entity.Candlestick{
+			Ticker:       ticker,
+			PeriodEnd:    time.Unix(candle.EndPeriodTs, 0).UTC(),
+			YesBidClose:  m.priceOrZero("candlestick.yes_bid.close", valueOrZero(candle.YesBid.Close), candle.YesBid.CloseDollars),
+			YesAskClose:  m.priceOrZero("candlestick.yes_ask.close", valueOrZero(candle.YesAsk.Close), candle.YesAsk.CloseDollars),
+			Volume:       candle.Volume,
+			OpenInterest: candle.OpenInterest,
+		}
+
+		if candle.Price.Close != nil || candle.Price.CloseDollars != "" {
+			result.HasTrades = true
+			result.Open = m.priceOrZero("candlestick.price.open", valueOrZero(candle.Price.Open), candle.Price.OpenDollars)
+			result.High = m.priceOrZero("candlestick.price.high", valueOrZero(candle.Price.High), candle.Price.HighDollars)
+			result.Low = m.priceOrZero("candlestick.price.low", valueOrZero(candle.Price.Low), candle.Price.LowDollars)
+			result.Close = m.priceOrZero("candlestick.price.close", valueOrZero(candle.Price.Close), candle.Price.CloseDollars)
+		}
+
+		candles = append(candles, result)
+	}
+
+	return candles, nil
+}
+
 // mapMarketStatus converts API status to domain status
 func (m *Mapper) mapMarketStatus(status string) entity.MarketStatus {
-	switch status {
-	case "open":
-		return entity.MarketStatusOpen
-	case "closed":
-		return entity.MarketStatusClosed
-	case "settled":
-		return entity.MarketStatusSettled
-	default:
-		return entity.MarketStatusClosed
+	marketStatus, err := entity.ParseMarketStatus(status)
+	if err != nil {
+		m.monitor.RecordFallbackValue("market.status:unknown", status)
+		return entity.MarketStatusUnknown
 	}
+	return marketStatus
 }
 
 // mapTradeSide converts API side to domain side
@@ -173,6 +293,35 @@ func (m *Mapper) mapTradeSide(side string) entity.TradeSide {
 	case "no", "sell":
 		return entity.TradeSideSell
 	default:
+		m.monitor.RecordFallbackValue("trade.side:unknown", side)
 		return entity.TradeSideBuy
 	}
 }
+
+// priceOrZero converts a price, falling back to zero and recording the drift if it is out of range.
+// The dollar-denominated field carries sub-cent precision and is preferred when Kalshi sends it.
+func (m *Mapper) priceOrZero(field string, value int64, dollars string) valueobject.Price {
+	if dollars != "" {
+		price, err := valueobject.ParseDollars(dollars)
+		if err == nil {
+			return price
+		}
+		m.monitor.RecordOutOfRange(field + "_dollars")
+	}
+
+	price, err := valueobject.NewPrice(value)
+	if err != nil {
+		m.monitor.RecordOutOfRange(field)
+		m.monitor.RecordFallback(field + ":zeroed")
+		price, _ = valueobject.NewPrice(0)
+	}
+	return price
+}
+
+// valueOrZero dereferences an optional integer field
+func valueOrZero(value *int64) int64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
@@ -0,0 +1,262 @@
+package kalshi
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"upwork-test/internal/domain/market/entity"
+	"upwork-test/internal/domain/market/valueobject"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// updateGolden rewrites the golden files from the current mapper output:
+// go test ./internal/infrastructure/kalshi -run TestMapperGolden -update
+var updateGolden = flag.Bool("update", false, "rewrite golden files")
+
+// replayClient returns a client that serves every request from the fixtures in testdata
+func replayClient(t *testing.T) *Client {
+	t.Helper()
+
+	transport, err := NewFixtureTransport(FixtureModeReplay, "testdata", nil)
+	require.NoError(t, err)
+
+	client := NewClientWithTransport("https://api.elections.kalshi.com", "", transport)
+	client.SetSchemaMonitor(NewSchemaMonitor(false))
+	return client
+}
+
+func TestMapperGolden(t *testing.T) {
+	candlesStart := time.Date(2024, 12, 31, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		// fetch replays a Kalshi response and maps it with the client's mapper
+		fetch func(ctx context.Context, client *Client, mapper *Mapper) (interface{}, error)
+	}{
+		{
+			name: "market_bracket",
+			fetch: func(ctx context.Context, client *Client, mapper *Mapper) (interface{}, error) {
+				resp, err := client.GetMarket(ctx, "KXHIGHNY-24DEC31-B45")
+				if err != nil {
+					return nil, err
+				}
+				return mapper.ToMarketEntity(resp)
+			},
+		},
+		{
+			name: "market_settled_binary",
+			fetch: func(ctx context.Context, client *Client, mapper *Mapper) (interface{}, error) {
+				resp, err := client.GetMarket(ctx, "KXFEDDECISION-24DEC-C25")
+				if err != nil {
+					return nil, err
+				}
+				return mapper.ToMarketEntity(resp)
+			},
+		},
+		{
+			name: "market_settled_scalar",
+			fetch: func(ctx context.Context, client *Client, mapper *Mapper) (interface{}, error) {
+				resp, err := client.GetMarket(ctx, "KXCPIYOY-24NOV-T2.7")
+				if err != nil {
+					return nil, err
+				}
+				return mapper.ToMarketEntity(resp)
+			},
+		},
+		{
+			name: "market_void",
+			fetch: func(ctx context.Context, client *Client, mapper *Mapper) (interface{}, error) {
+				resp, err := client.GetMarket(ctx, "KXNBAGAME-24DEC25LALGSW-LAL")
+				if err != nil {
+					return nil, err
+				}
+				return mapper.ToMarketEntity(resp)
+			},
+		},
+		{
+			name: "event",
+			fetch: func(ctx context.Context, client *Client, mapper *Mapper) (interface{}, error) {
+				resp, err := client.GetEvent(ctx, "KXHIGHNY-24DEC31")
+				if err != nil {
+					return nil, err
+				}
+				return mapper.ToEventEntity(resp)
+			},
+		},
+		{
+			name: "orderbook",
+			fetch: func(ctx context.Context, client *Client, mapper *Mapper) (interface{}, error) {
+				resp, err := client.GetOrderBook(ctx, "KXHIGHNY-24DEC31-B45")
+				if err != nil {
+					return nil, err
+				}
+				return mapper.ToOrderBookEntity(resp)
+			},
+		},
+		{
+			name: "trades",
+			fetch: func(ctx context.Context, client *Client, mapper *Mapper) (interface{}, error) {
+				resp, err := client.GetTrades(ctx, "KXHIGHNY-24DEC31-B45", 3)
+				if err != nil {
+					return nil, err
+				}
+				return mapper.ToTradeEntities(resp.Trades)
+			},
+		},
+		{
+			name: "candlesticks",
+			fetch: func(ctx context.Context, client *Client, mapper *Mapper) (interface{}, error) {
+				resp, err := client.GetCandlesticks(ctx, "KXHIGHNY", "KXHIGHNY-24DEC31-B45", candlesStart, candlesStart.Add(2*time.Hour), 60)
+				if err != nil {
+					return nil, err
+				}
+				return mapper.ToCandlestickEntities(resp)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := replayClient(t)
+			mapper := NewMapper(client.SchemaMonitor())
+
+			mapped, err := tt.fetch(context.Background(), client, mapper)
+			require.NoError(t, err)
+
+			// The schema report shows which values were dropped or zeroed along the way
+			report := client.SchemaMonitor().Report("golden")
+			got := goldenJSON(t, map[string]interface{}{
+				"result":       mapped,
+				"fallbacks":    report.Fallbacks,
+				"out_of_range": report.OutOfRange,
+			})
+
+			path := filepath.Join("testdata", "golden", tt.name+".json")
+			if *updateGolden {
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+				require.NoError(t, os.WriteFile(path, append(got, '\n'), 0o644))
+			}
+
+			want, err := os.ReadFile(path)
+			require.NoError(t, err, "run with -update to create the golden file")
+			assert.JSONEq(t, string(want), string(got))
+		})
+	}
+}
+
+// goldenJSON marshals a value for comparison, dropping the last_updated stamps set at mapping time
+func goldenJSON(t *testing.T, value interface{}) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+
+	var generic interface{}
+	require.NoError(t, json.Unmarshal(data, &generic))
+	dropKey(generic, "last_updated")
+
+	data, err = json.MarshalIndent(generic, "", "  ")
+	require.NoError(t, err)
+	return data
+}
+
+// dropKey removes a key from every object nested in a decoded JSON value
+func dropKey(value interface{}, key string) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		delete(typed, key)
+		for _, nested := range typed {
+			dropKey(nested, key)
+		}
+	case []interface{}:
+		for _, nested := range typed {
+			dropKey(nested, key)
+		}
+	}
+}
+
+func TestMapperSettlement(t *testing.T) {
+	closeTime := time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC)
+	expiration := time.Date(2025, 1, 7, 15, 0, 0, 0, time.UTC)
+	settledAt := time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		resp MarketResponse
+		// want is nil when the market should have no settlement
+		want *entity.Settlement
+	}{
+		{
+			name: "binary yes derives the payout",
+			resp: MarketResponse{Status: "settled", Result: "yes", SettlementTime: settledAt},
+			want: &entity.Settlement{Result: entity.SettlementResultYes, SettlementValue: cents(t, 100), SettledAt: settledAt},
+		},
+		{
+			name: "binary no",
+			resp: MarketResponse{Status: "finalized", Result: "no", SettlementTime: settledAt},
+			want: &entity.Settlement{Result: entity.SettlementResultNo, SettlementValue: cents(t, 0), SettledAt: settledAt},
+		},
+		{
+			name: "scalar keeps the settlement value",
+			resp: MarketResponse{
+				Status: "settled", Result: "no", SettlementValue: 40, ExpirationValue: "2.7",
+				StrikeType: "functional", FunctionalStrike: "(x - 2.5) / 0.5", SettlementTime: settledAt,
+			},
+			want: &entity.Settlement{Result: entity.SettlementResultNo, SettlementValue: cents(t, 40), ExpirationValue: "2.7", SettledAt: settledAt},
+		},
+		{
+			name: "void",
+			resp: MarketResponse{Status: "settled", Result: "void", LatestExpiration: expiration},
+			want: &entity.Settlement{Result: entity.SettlementResultVoid, SettlementValue: cents(t, 0), SettledAt: expiration},
+		},
+		{
+			name: "falls back to the close time",
+			resp: MarketResponse{Status: "settled", Result: "yes"},
+			want: &entity.Settlement{Result: entity.SettlementResultYes, SettlementValue: cents(t, 100), SettledAt: closeTime},
+		},
+		{
+			name: "determined is not final",
+			resp: MarketResponse{Status: "determined", Result: "yes"},
+		},
+		{
+			name: "settled without result",
+			resp: MarketResponse{Status: "settled"},
+		},
+		{
+			name: "unknown result",
+			resp: MarketResponse{Status: "settled", Result: "all_yes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.resp.Ticker = "KXTEST-24DEC31"
+			tt.resp.CloseTime = closeTime
+
+			market, err := NewMapper(nil).ToMarketEntity(&tt.resp)
+			require.NoError(t, err)
+
+			if tt.want == nil {
+				assert.Nil(t, market.Settlement)
+				return
+			}
+			tt.want.Ticker = market.Ticker
+			assert.Equal(t, tt.want, market.Settlement)
+		})
+	}
+}
+
+// cents returns a whole-cent price
+func cents(t *testing.T, value int64) valueobject.Price {
+	t.Helper()
+
+	price, err := valueobject.NewPrice(value)
+	require.NoError(t, err)
+	return price
+}
@@ -6,39 +6,52 @@ import "time"
 type MarketListResponse struct {
 	Markets []MarketResponse `json:"markets"`
 	Cursor  string           `json:"cursor,omitempty"`
+
+	// Truncated is set by the client when it stopped following cursors before the last page
+	Truncated bool `json:"-"`
 }
 
 // MarketResponse represents a market in the API response
 type MarketResponse struct {
-	Ticker            string    `json:"ticker"`
-	EventTicker       string    `json:"event_ticker"`
-	Title             string    `json:"title"`
-	Subtitle          string    `json:"subtitle"`
-	OpenTime          time.Time `json:"open_time"`
-	CloseTime         time.Time `json:"close_time"`
-	Status            string    `json:"status"`
-	Volume            int64     `json:"volume"`
-	Volume24h         int64     `json:"volume_24h"`
-	Liquidity         int64     `json:"liquidity"`
-	YesAsk            int64     `json:"yes_ask"`
-	YesBid            int64     `json:"yes_bid"`
-	NoAsk             int64     `json:"no_ask"`
-	NoBid             int64     `json:"no_bid"`
-	LastPrice         int64     `json:"last_price"`
-	PreviousYesAsk    int64     `json:"previous_yes_ask"`
-	PreviousYesBid    int64     `json:"previous_yes_bid"`
-	PreviousPrice     int64     `json:"previous_price"`
-	Result            string    `json:"result,omitempty"`
-	CanCloseEarly     bool      `json:"can_close_early"`
-	ExpirationValue   string    `json:"expiration_value,omitempty"`
-	LatestExpiration  time.Time `json:"latest_expiration_time,omitempty"`
-	FloorStrike       int64     `json:"floor_strike,omitempty"`
-	CapStrike         int64     `json:"cap_strike,omitempty"`
-	StrikeType        string    `json:"strike_type,omitempty"`
-	SettlementValue   int64     `json:"settlement_value,omitempty"`
-	FunctionalStrike  string    `json:"functional_strike,omitempty"`
-	RangedGroupTicker string    `json:"ranged_group_ticker,omitempty"`
-	Category          string    `json:"-"` // Derived field, not from API
+	Ticker                string    `json:"ticker"`
+	EventTicker           string    `json:"event_ticker"`
+	Title                 string    `json:"title"`
+	Subtitle              string    `json:"subtitle"`
+	OpenTime              time.Time `json:"open_time"`
+	CloseTime             time.Time `json:"close_time"`
+	Status                string    `json:"status"`
+	Volume                int64     `json:"volume"`
+	Volume24h             int64     `json:"volume_24h"`
+	Liquidity             int64     `json:"liquidity"`
+	OpenInterest          int64     `json:"open_interest"`
+	YesAsk                int64     `json:"yes_ask"`
+	YesBid                int64     `json:"yes_bid"`
+	NoAsk                 int64     `json:"no_ask"`
+	NoBid                 int64     `json:"no_bid"`
+	LastPrice             int64     `json:"last_price"`
+	YesAskDollars         string    `json:"yes_ask_dollars,omitempty"`
+	YesBidDollars         string    `json:"yes_bid_dollars,omitempty"`
+	NoAskDollars          string    `json:"no_ask_dollars,omitempty"`
+	NoBidDollars          string    `json:"no_bid_dollars,omitempty"`
+	LastPriceDollars      string    `json:"last_price_dollars,omitempty"`
+	PreviousYesAsk        int64     `json:"previous_yes_ask"`
+	PreviousYesBid        int64     `json:"previous_yes_bid"`
+	PreviousPrice         int64     `json:"previous_price"`
+	PreviousYesAskDollars string    `json:"previous_yes_ask_dollars,omitempty"`
+	PreviousYesBidDollars string    `json:"previous_yes_bid_dollars,omitempty"`
+	PreviousPriceDollars  string    `json:"previous_price_dollars,omitempty"`
+	Result                string    `json:"result,omitempty"`
+	CanCloseEarly         bool      `json:"can_close_early"`
+	ExpirationValue       string    `json:"expiration_value,omitempty"`
+	LatestExpiration      time.Time `json:"latest_expiration_time,omitempty"`
+	FloorStrike           *float64  `json:"floor_strike,omitempty"`
+	CapStrike             *float64  `json:"cap_strike,omitempty"`
+	StrikeType            string    `json:"strike_type,omitempty"`
+	SettlementValue       int64     `json:"settlement_value,omitempty"`
+	SettlementTime        time.Time `json:"settlement_ts,omitempty"`
+	FunctionalStrike      string    `json:"functional_strike,omitempty"`
+	RangedGroupTicker     string    `json:"ranged_group_ticker,omitempty"`
+	Category              string    `json:"-"` // Derived field, not from API
 }
 
 // OrderBookResponse represents the order book for a market
@@ -59,18 +72,51 @@ type OrderBookLevel struct {
 type TradesResponse struct {
 	Trades []TradeResponse `json:"trades"`
 	Cursor string          `json:"cursor,omitempty"`
+
+	// Truncated is set by the client when it stopped following cursors before the last page
+	Truncated bool `json:"-"`
 }
 
 // TradeResponse represents a trade in the API response
 type TradeResponse struct {
-	TradeID   string    `json:"trade_id"`
-	Ticker    string    `json:"ticker"`
-	Price     int64     `json:"price"`
-	Quantity  int64     `json:"quantity"`
-	Side      string    `json:"side"`   // "yes" or "no"
-	Action    string    `json:"action"` // "buy" or "sell"
-	CreatedAt time.Time `json:"created_at"`
-	Taker     string    `json:"taker_side"` // "yes" or "no"
+	TradeID         string    `json:"trade_id"`
+	Ticker          string    `json:"ticker"`
+	Price           int64     `json:"price"`
+	YesPriceDollars string    `json:"yes_price_dollars,omitempty"`
+	Quantity        int64     `json:"quantity"`
+	Side            string    `json:"side"`   // "yes" or "no"
+	Action          string    `json:"action"` // "buy" or "sell"
+	CreatedAt       time.Time `json:"created_at"`
+	Taker           string    `json:"taker_side"` // "yes" or "no"
+}
+
+// CandlesticksResponse represents the response from GET /series/{series_ticker}/markets/{ticker}/candlesticks
+type CandlesticksResponse struct {
+	Ticker       string                `json:"ticker"`
+	Candlesticks []CandlestickResponse `json:"candlesticks"`
+}
+
+// CandlestickResponse represents one period of a market's candlestick history
+type CandlestickResponse struct {
+	EndPeriodTs  int64             `json:"end_period_ts"`
+	Price        CandlestickPrices `json:"price"`
+	YesBid       CandlestickPrices `json:"yes_bid"`
+	YesAsk       CandlestickPrices `json:"yes_ask"`
+	Volume       int64             `json:"volume"`
+	OpenInterest int64             `json:"open_interest"`
+}
+
+// CandlestickPrices holds the OHLC prices of one series in a candlestick.
+// Traded prices are null for periods without trades.
+type CandlestickPrices struct {
+	Open         *int64 `json:"open"`
+	High         *int64 `json:"high"`
+	Low          *int64 `json:"low"`
+	Close        *int64 `json:"close"`
+	OpenDollars  string `json:"open_dollars,omitempty"`
+	HighDollars  string `json:"high_dollars,omitempty"`
+	LowDollars   string `json:"low_dollars,omitempty"`
+	CloseDollars string `json:"close_dollars,omitempty"`
 }
 
 // ErrorResponse represents an error response from the Kalshi API
@@ -97,12 +143,24 @@ type SeriesResponse struct {
 // EventListResponse represents the response from GET /events
 type EventListResponse struct {
 	Events []EventResponse `json:"events"`
+	Cursor string          `json:"cursor,omitempty"`
+
+	// Truncated is set by the client when it stopped following cursors before the last page
+	Truncated bool `json:"-"`
 }
 
 // EventResponse represents an event from the Kalshi API
 type EventResponse struct {
-	EventTicker  string `json:"event_ticker"`
-	SeriesTicker string `json:"series_ticker"`
-	Title        string `json:"title"`
-	Category     string `json:"category"`
+	EventTicker       string `json:"event_ticker"`
+	SeriesTicker      string `json:"series_ticker"`
+	Title             string `json:"title"`
+	SubTitle          string `json:"sub_title,omitempty"`
+	Category          string `json:"category"`
+	MutuallyExclusive bool   `json:"mutually_exclusive"`
+}
+
+// EventDetailResponse represents the response from GET /events/{event_ticker}
+type EventDetailResponse struct {
+	Event   EventResponse    `json:"event"`
+	Markets []MarketResponse `json:"markets"`
 }
@@ -0,0 +1,292 @@
+package kalshi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FixtureFormatVersion is the version of the on-disk fixture format.
+// Bump it when the Fixture layout changes so stale recordings are rejected.
+const FixtureFormatVersion = 1
+
+// FixtureMode controls how the fixture transport treats outgoing requests
+type FixtureMode string
+
+const (
+	// FixtureModeOff sends requests upstream without touching fixtures
+	FixtureModeOff FixtureMode = ""
+	// FixtureModeRecord sends requests upstream and saves every response as a fixture
+	FixtureModeRecord FixtureMode = "record"
+	// FixtureModeReplay serves responses from fixtures and never contacts Kalshi
+	FixtureModeReplay FixtureMode = "replay"
+)
+
+var (
+	// ErrFixtureNotFound is returned in replay mode when no fixture matches a request
+	ErrFixtureNotFound = errors.New("fixture not found")
+	// ErrFixtureVersion is returned when a fixture was written with another format version
+	ErrFixtureVersion = errors.New("unsupported fixture version")
+
+	// scrubbedHeaders are never written to fixture files
+	scrubbedHeaders = []string{
+		"Authorization",
+		"Cookie",
+		"Set-Cookie",
+		"Kalshi-Access-Key",
+		"Kalshi-Access-Signature",
+		"Kalshi-Access-Timestamp",
+	}
+
+	// unsafePathChars matches characters that should not appear in fixture file names
+	unsafePathChars = regexp.MustCompile(`[^A-Za-z0-9\-_.]+`)
+)
+
+// Fixture is a recorded Kalshi request/response pair
+type Fixture struct {
+	Version    int             `json:"version"`
+	RecordedAt time.Time       `json:"recorded_at"`
+	Request    FixtureRequest  `json:"request"`
+	Response   FixtureResponse `json:"response"`
+}
+
+// FixtureRequest describes the recorded request with credentials removed
+type FixtureRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+// FixtureResponse holds the recorded upstream response
+type FixtureResponse struct {
+	StatusCode int             `json:"status_code"`
+	Header     http.Header     `json:"header,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	BodyText   string          `json:"body_text,omitempty"` // Used when the body is not valid JSON
+}
+
+// FixtureTransport is an http.RoundTripper that records Kalshi responses to
+// fixture files or replays them deterministically.
+type FixtureTransport struct {
+	mode FixtureMode
+	dir  string
+	next http.RoundTripper
+	mu   sync.Mutex
+}
+
+// NewFixtureTransport creates a transport for the given mode.
+// next is used for upstream calls in record mode; nil means http.DefaultTransport.
+func NewFixtureTransport(mode FixtureMode, dir string, next http.RoundTripper) (*FixtureTransport, error) {
+	switch mode {
+	case FixtureModeOff, FixtureModeRecord, FixtureModeReplay:
+	default:
+		return nil, fmt.Errorf("invalid fixture mode: %s", mode)
+	}
+
+	if mode != FixtureModeOff && dir == "" {
+		return nil, fmt.Errorf("fixture directory is required in %s mode", mode)
+	}
+
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &FixtureTransport{
+		mode: mode,
+		dir:  dir,
+		next: next,
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *FixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch t.mode {
+	case FixtureModeReplay:
+		return t.replay(req)
+	case FixtureModeRecord:
+		return t.record(req)
+	default:
+		return t.next.RoundTrip(req)
+	}
+}
+
+// replay serves a previously recorded response
+func (t *FixtureTransport) replay(req *http.Request) (*http.Response, error) {
+	data, err := os.ReadFile(t.fixturePath(req))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s %s", ErrFixtureNotFound, req.Method, fixtureKey(req))
+		}
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to decode fixture: %w", err)
+	}
+
+	if fixture.Version != FixtureFormatVersion {
+		return nil, fmt.Errorf("%w: got %d, want %d", ErrFixtureVersion, fixture.Version, FixtureFormatVersion)
+	}
+
+	body := []byte(fixture.Response.Body)
+	if len(body) == 0 {
+		body = []byte(fixture.Response.BodyText)
+	}
+
+	header := fixture.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fixture.Response.StatusCode, http.StatusText(fixture.Response.StatusCode)),
+		StatusCode:    fixture.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// record forwards the request upstream and writes the response to disk
+func (t *FixtureTransport) record(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response for recording: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	fixture := Fixture{
+		Version:    FixtureFormatVersion,
+		RecordedAt: time.Now().UTC(),
+		Request: FixtureRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  canonicalQuery(req),
+			Header: scrubHeader(req.Header),
+		},
+		Response: FixtureResponse{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header),
+		},
+	}
+
+	if json.Valid(body) {
+		fixture.Response.Body = json.RawMessage(body)
+	} else {
+		fixture.Response.BodyText = string(body)
+	}
+
+	if err := t.write(t.fixturePath(req), &fixture); err != nil {
+		// Recording is best-effort; the caller still gets the live response
+		fmt.Printf("Warning: failed to record Kalshi fixture: %v\n", err)
+	}
+
+	return resp, nil
+}
+
+// write saves a fixture atomically so concurrent replays never see partial files
+func (t *FixtureTransport) write(path string, fixture *Fixture) error {
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal fixture: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// fixturePath returns the file a request is recorded to, grouped by format version.
+// The name keeps the path readable and appends a hash of the canonical request.
+func (t *FixtureTransport) fixturePath(req *http.Request) string {
+	key := fixtureKey(req)
+	sum := sha256.Sum256([]byte(req.Method + " " + key))
+
+	name := strings.Trim(unsafePathChars.ReplaceAllString(strings.TrimPrefix(req.URL.Path, "/trade-api/v2/"), "_"), "_")
+	if name == "" {
+		name = "root"
+	}
+
+	return filepath.Join(
+		t.dir,
+		fmt.Sprintf("v%d", FixtureFormatVersion),
+		fmt.Sprintf("%s_%s_%s.json", strings.ToLower(req.Method), name, hex.EncodeToString(sum[:])[:12]),
+	)
+}
+
+// fixtureKey identifies a request independently of host and query parameter order
+func fixtureKey(req *http.Request) string {
+	query := canonicalQuery(req)
+	if query == "" {
+		return req.URL.Path
+	}
+	return req.URL.Path + "?" + query
+}
+
+// canonicalQuery returns the query string with parameters sorted
+func canonicalQuery(req *http.Request) string {
+	values := req.URL.Query()
+	if len(values) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		sort.Strings(values[key])
+	}
+
+	return values.Encode()
+}
+
+// scrubHeader copies a header without credentials
+func scrubHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	scrubbed := header.Clone()
+	for _, name := range scrubbedHeaders {
+		scrubbed.Del(name)
+	}
+
+	return scrubbed
+}
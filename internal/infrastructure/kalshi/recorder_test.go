@@ -0,0 +1,248 @@
+package kalshi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upstream starts a server that answers every request with body and a session cookie
+func upstream(t *testing.T, contentType, body string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Header().Set("X-Request-Id", "req-1")
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// send issues a GET through transport with Kalshi credentials attached
+func send(t *testing.T, transport http.RoundTripper, url string) (*http.Response, error) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer secret-key")
+	req.Header.Set("Cookie", "session=secret")
+	req.Header.Set("Kalshi-Access-Key", "key-id")
+	req.Header.Set("Kalshi-Access-Signature", "signature")
+	req.Header.Set("Kalshi-Access-Timestamp", "1735653600000")
+
+	return transport.RoundTrip(req)
+}
+
+// readFixtures decodes every fixture in dir, failing on leftover temporary files
+func readFixtures(t *testing.T, dir string) []Fixture {
+	t.Helper()
+
+	var fixtures []Fixture
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		require.True(t, strings.HasSuffix(path, ".json"), "unexpected file %s", path)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		var fixture Fixture
+		require.NoError(t, json.Unmarshal(data, &fixture), "fixture %s is not valid JSON", path)
+		fixtures = append(fixtures, fixture)
+		return nil
+	})
+	require.NoError(t, err)
+	return fixtures
+}
+
+func TestNewFixtureTransport(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    FixtureMode
+		dir     string
+		wantErr bool
+	}{
+		{name: "off without directory", mode: FixtureModeOff},
+		{name: "record", mode: FixtureModeRecord, dir: "fixtures"},
+		{name: "replay", mode: FixtureModeReplay, dir: "fixtures"},
+		{name: "record without directory", mode: FixtureModeRecord, wantErr: true},
+		{name: "replay without directory", mode: FixtureModeReplay, wantErr: true},
+		{name: "unknown mode", mode: "rewind", dir: "fixtures", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFixtureTransport(tt.mode, tt.dir, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestFixtureTransportRecordScrubsCredentials(t *testing.T) {
+	server := upstream(t, "application/json", `{"market":{"ticker":"KXTEST-1"}}`)
+	dir := t.TempDir()
+
+	transport, err := NewFixtureTransport(FixtureModeRecord, dir, nil)
+	require.NoError(t, err)
+
+	resp, err := send(t, transport, server.URL+"/trade-api/v2/markets/KXTEST-1")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	// The caller still gets the live response, credentials included
+	assert.JSONEq(t, `{"market":{"ticker":"KXTEST-1"}}`, string(body))
+	assert.Equal(t, "session=secret", resp.Header.Get("Set-Cookie"))
+
+	fixtures := readFixtures(t, dir)
+	require.Len(t, fixtures, 1)
+	fixture := fixtures[0]
+
+	assert.Equal(t, FixtureFormatVersion, fixture.Version)
+	assert.Equal(t, "/trade-api/v2/markets/KXTEST-1", fixture.Request.Path)
+	assert.Equal(t, "application/json", fixture.Request.Header.Get("Accept"))
+	assert.Equal(t, "req-1", fixture.Response.Header.Get("X-Request-Id"))
+	for _, name := range scrubbedHeaders {
+		assert.Empty(t, fixture.Request.Header.Values(name), "request header %s was recorded", name)
+		assert.Empty(t, fixture.Response.Header.Values(name), "response header %s was recorded", name)
+	}
+}
+
+func TestFixtureTransportRecordThenReplay(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		recordPath  string
+		replayPath  string
+	}{
+		{
+			name:        "json body",
+			contentType: "application/json",
+			body:        `{"trades":[{"trade_id":"t1","price":42}]}`,
+			recordPath:  "/trade-api/v2/markets/trades?ticker=KXTEST-1&limit=100",
+			// Parameter order does not change the fixture a request maps to
+			replayPath: "/trade-api/v2/markets/trades?limit=100&ticker=KXTEST-1",
+		},
+		{
+			name:        "text body",
+			contentType: "text/plain",
+			body:        "upstream maintenance",
+			recordPath:  "/trade-api/v2/exchange/status",
+			replayPath:  "/trade-api/v2/exchange/status",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := upstream(t, tt.contentType, tt.body)
+			dir := t.TempDir()
+
+			recorder, err := NewFixtureTransport(FixtureModeRecord, dir, nil)
+			require.NoError(t, err)
+			resp, err := send(t, recorder, server.URL+tt.recordPath)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			replayer, err := NewFixtureTransport(FixtureModeReplay, dir, nil)
+			require.NoError(t, err)
+			// Replay never contacts the upstream, whatever host the client is configured with
+			resp, err = send(t, replayer, "http://replay.invalid"+tt.replayPath)
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, tt.contentType, resp.Header.Get("Content-Type"))
+			assert.Empty(t, resp.Header.Get("Set-Cookie"))
+			if json.Valid([]byte(tt.body)) {
+				assert.JSONEq(t, tt.body, string(body))
+			} else {
+				assert.Equal(t, tt.body, string(body))
+			}
+		})
+	}
+}
+
+func TestFixtureTransportReplayErrors(t *testing.T) {
+	server := upstream(t, "application/json", `{"series":[]}`)
+	dir := t.TempDir()
+
+	recorder, err := NewFixtureTransport(FixtureModeRecord, dir, nil)
+	require.NoError(t, err)
+	resp, err := send(t, recorder, server.URL+"/trade-api/v2/series")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	replayer, err := NewFixtureTransport(FixtureModeReplay, dir, nil)
+	require.NoError(t, err)
+
+	t.Run("missing fixture", func(t *testing.T) {
+		_, err := send(t, replayer, server.URL+"/trade-api/v2/series?category=Economics")
+		assert.ErrorIs(t, err, ErrFixtureNotFound)
+	})
+
+	t.Run("other format version", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/trade-api/v2/series", nil)
+		require.NoError(t, err)
+		path := replayer.fixturePath(req)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var fixture Fixture
+		require.NoError(t, json.Unmarshal(data, &fixture))
+		fixture.Version = FixtureFormatVersion + 1
+		data, err = json.Marshal(fixture)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, data, 0o644))
+
+		_, err = send(t, replayer, server.URL+"/trade-api/v2/series")
+		assert.ErrorIs(t, err, ErrFixtureVersion)
+	})
+}
+
+func TestFixtureTransportConcurrentRecordingIsAtomic(t *testing.T) {
+	server := upstream(t, "application/json", `{"markets":[{"ticker":"KXTEST-1"},{"ticker":"KXTEST-2"}]}`)
+	dir := t.TempDir()
+
+	transport, err := NewFixtureTransport(FixtureModeRecord, dir, nil)
+	require.NoError(t, err)
+
+	// Every goroutine records the same request, so they all replace one file
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := send(t, transport, server.URL+"/trade-api/v2/markets?limit=2")
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// readFixtures fails on partial JSON or a leftover .tmp file
+	fixtures := readFixtures(t, dir)
+	require.Len(t, fixtures, 1)
+	assert.Equal(t, "limit=2", fixtures[0].Request.Query)
+}
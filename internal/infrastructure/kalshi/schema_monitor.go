@@ -0,0 +1,363 @@
+package kalshi
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"upwork-test/internal/domain/diagnostics/entity"
+)
+
+const (
+	// maxFallbackValues caps the distinct upstream values recorded per fallback reason; further
+	// ones are counted together as "other", so a misbehaving upstream cannot grow the report
+	maxFallbackValues = 20
+	// maxFallbackValueLength truncates each recorded upstream value, in bytes
+	maxFallbackValueLength = 32
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// endpointStats accumulates drift counters for one endpoint
+type endpointStats struct {
+	responses     int64
+	decodeErrors  int64
+	unknownFields map[string]int64
+	missingFields map[string]int64
+}
+
+// SchemaMonitor records upstream payload drift and mapping fallbacks.
+// Fallback counters are always collected; field-level inspection of response
+// bodies only runs when strict decoding is enabled since it decodes every body twice.
+// All methods are safe to call on a nil monitor.
+type SchemaMonitor struct {
+	strict      bool
+	mu          sync.Mutex
+	endpoints   map[string]*endpointStats
+	fallbacks   map[string]int64
+	values      map[string]map[string]bool // Distinct upstream values recorded per fallback reason
+	outOfRange  map[string]int64
+	startedAt   time.Time
+	lastDriftAt time.Time
+}
+
+// NewSchemaMonitor creates a new SchemaMonitor
+func NewSchemaMonitor(strict bool) *SchemaMonitor {
+	return &SchemaMonitor{
+		strict:     strict,
+		endpoints:  make(map[string]*endpointStats),
+		fallbacks:  make(map[string]int64),
+		values:     make(map[string]map[string]bool),
+		outOfRange: make(map[string]int64),
+		startedAt:  time.Now(),
+	}
+}
+
+// Strict returns true if response bodies are inspected field by field
+func (m *SchemaMonitor) Strict() bool {
+	return m != nil && m.strict
+}
+
+// Inspect compares a response body against the struct it is decoded into and
+// records fields that Kalshi sent but we do not model, and required fields it omitted.
+func (m *SchemaMonitor) Inspect(endpoint string, body []byte, target interface{}) {
+	if m == nil {
+		return
+	}
+
+	unknown := make(map[string]struct{})
+	missing := make(map[string]struct{})
+
+	if m.strict {
+		var payload interface{}
+		if err := json.Unmarshal(body, &payload); err == nil {
+			compareSchema("", payload, reflect.TypeOf(target), unknown, missing)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := m.endpoint(endpoint)
+	stats.responses++
+
+	for field := range unknown {
+		stats.unknownFields[field]++
+	}
+	for field := range missing {
+		stats.missingFields[field]++
+	}
+
+	if len(unknown) > 0 || len(missing) > 0 {
+		m.lastDriftAt = time.Now()
+	}
+}
+
+// RecordDecodeError records a response that could not be decoded at all
+func (m *SchemaMonitor) RecordDecodeError(endpoint string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.endpoint(endpoint).decodeErrors++
+	m.lastDriftAt = time.Now()
+}
+
+// RecordFallback records that the mapper substituted a default for an upstream value
+func (m *SchemaMonitor) RecordFallback(reason string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.fallbacks[reason]++
+	m.lastDriftAt = time.Now()
+}
+
+// RecordFallbackValue records a fallback caused by an upstream value, such as an unknown
+// status. The value is kept in the reason, for up to maxFallbackValues values per reason.
+func (m *SchemaMonitor) RecordFallbackValue(reason string, value string) {
+	if m == nil {
+		return
+	}
+
+	if len(value) > maxFallbackValueLength {
+		value = strings.ToValidUTF8(value[:maxFallbackValueLength], "")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	seen := m.values[reason]
+	if seen == nil {
+		seen = make(map[string]bool)
+		m.values[reason] = seen
+	}
+	if !seen[value] {
+		if len(seen) >= maxFallbackValues {
+			value = "other"
+		} else {
+			seen[value] = true
+		}
+	}
+
+	m.fallbacks[reason+":"+value]++
+	m.lastDriftAt = time.Now()
+}
+
+// RecordOutOfRange records an upstream value outside the range the domain accepts
+func (m *SchemaMonitor) RecordOutOfRange(field string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.outOfRange[field]++
+	m.lastDriftAt = time.Now()
+}
+
+// Report returns a snapshot of the collected diagnostics
+func (m *SchemaMonitor) Report(source string) *entity.SchemaReport {
+	report := &entity.SchemaReport{
+		Source:      source,
+		Endpoints:   []entity.EndpointDrift{},
+		Fallbacks:   map[string]int64{},
+		OutOfRange:  map[string]int64{},
+		GeneratedAt: time.Now(),
+	}
+	if m == nil {
+		return report
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	report.StrictDecoding = m.strict
+	report.StartedAt = m.startedAt
+	report.LastDriftAt = m.lastDriftAt
+
+	for name, stats := range m.endpoints {
+		report.Endpoints = append(report.Endpoints, entity.EndpointDrift{
+			Endpoint:      name,
+			Responses:     stats.responses,
+			DecodeErrors:  stats.decodeErrors,
+			UnknownFields: copyCounts(stats.unknownFields),
+			MissingFields: copyCounts(stats.missingFields),
+		})
+	}
+	sort.Slice(report.Endpoints, func(i, j int) bool {
+		return report.Endpoints[i].Endpoint < report.Endpoints[j].Endpoint
+	})
+
+	report.Fallbacks = copyCounts(m.fallbacks)
+	report.OutOfRange = copyCounts(m.outOfRange)
+
+	return report
+}
+
+// endpoint returns the stats for an endpoint, creating them if needed. Caller must hold mu.
+func (m *SchemaMonitor) endpoint(name string) *endpointStats {
+	stats, ok := m.endpoints[name]
+	if !ok {
+		stats = &endpointStats{
+			unknownFields: make(map[string]int64),
+			missingFields: make(map[string]int64),
+		}
+		m.endpoints[name] = stats
+	}
+	return stats
+}
+
+// compareSchema walks a decoded JSON value alongside the Go type it maps to
+func compareSchema(path string, value interface{}, t reflect.Type, unknown, missing map[string]struct{}) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if t == timeType {
+			return
+		}
+
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		seen := make(map[string]bool, len(object))
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, omitEmpty, skip := jsonFieldName(field)
+			if skip {
+				continue
+			}
+
+			// Embedded structs without a tag are flattened by encoding/json
+			if field.Anonymous && field.Tag.Get("json") == "" {
+				compareSchema(path, value, field.Type, unknown, missing)
+				for key := range object {
+					if hasJSONField(field.Type, key) {
+						seen[strings.ToLower(key)] = true
+					}
+				}
+				continue
+			}
+
+			fieldValue, present := lookupField(object, name)
+			if !present {
+				if !omitEmpty {
+					missing[joinPath(path, name)] = struct{}{}
+				}
+				continue
+			}
+
+			seen[strings.ToLower(name)] = true
+			compareSchema(joinPath(path, name), fieldValue, field.Type, unknown, missing)
+		}
+
+		for key := range object {
+			if !seen[strings.ToLower(key)] {
+				unknown[joinPath(path, key)] = struct{}{}
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		if t == rawMessageType {
+			return
+		}
+
+		items, ok := value.([]interface{})
+		if !ok {
+			return
+		}
+		for _, item := range items {
+			compareSchema(path+"[]", item, t.Elem(), unknown, missing)
+		}
+	}
+}
+
+// jsonFieldName returns the JSON name of a struct field and whether it is optional
+func jsonFieldName(field reflect.StructField) (name string, omitEmpty bool, skip bool) {
+	if field.PkgPath != "" && !field.Anonymous {
+		return "", false, true
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+
+	for _, option := range parts[1:] {
+		if option == "omitempty" || option == "omitzero" {
+			omitEmpty = true
+		}
+	}
+
+	return name, omitEmpty, false
+}
+
+// hasJSONField reports whether a struct type declares a JSON field with the given key
+func hasJSONField(t reflect.Type, key string) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		name, _, skip := jsonFieldName(t.Field(i))
+		if !skip && strings.EqualFold(name, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupField finds a key the way encoding/json does: exact match first, then case-insensitive
+func lookupField(object map[string]interface{}, name string) (interface{}, bool) {
+	if value, ok := object[name]; ok {
+		return value, true
+	}
+	for key, value := range object {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// joinPath builds a dotted field path
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// copyCounts returns a copy of a counter map
+func copyCounts(counts map[string]int64) map[string]int64 {
+	result := make(map[string]int64, len(counts))
+	for key, value := range counts {
+		result[key] = value
+	}
+	return result
+}
@@ -9,21 +9,40 @@ import (
 	"upwork-test/internal/domain/ratelimit/valueobject"
 	"upwork-test/internal/infrastructure/cache"
 
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
-// RedisRateLimiter implements the rate limiting using Redis sliding window.
+// RedisRateLimiter implements rate limiting in Redis, with one atomic Lua script per algorithm.
 type RedisRateLimiter struct {
 	client     *redis.Client
 	keyBuilder *cache.KeyBuilder
+	algorithm  valueobject.RateLimitAlgorithm
+	script     *redis.Script
 }
 
-// NewRedisRateLimiter creates a new Redis-backed rate limiter.
-func NewRedisRateLimiter(client *redis.Client) *RedisRateLimiter {
+// NewRedisRateLimiter creates a new Redis-backed rate limiter counting requests with algorithm.
+func NewRedisRateLimiter(client *redis.Client, algorithm valueobject.RateLimitAlgorithm) (*RedisRateLimiter, error) {
+	script, ok := rateLimitScripts[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", valueobject.ErrUnsupportedAlgorithm, algorithm)
+	}
+
 	return &RedisRateLimiter{
 		client:     client,
 		keyBuilder: cache.NewKeyBuilder("kalshi"),
+		algorithm:  algorithm,
+		script:     script,
+	}, nil
+}
+
+// LoadScript sends the algorithm's script to Redis ahead of the first request, so requests
+// only ever send its SHA
+func (r *RedisRateLimiter) LoadScript(ctx context.Context) error {
+	if err := r.script.Load(ctx, r.client).Err(); err != nil {
+		return fmt.Errorf("failed to load rate limit script: %w", err)
 	}
+	return nil
 }
 
 // Get retrieves the current rate limit state for a user.
@@ -77,46 +96,30 @@ func (r *RedisRateLimiter) Save(ctx context.Context, rateLimit *entity.RateLimit
 	return nil
 }
 
-// IncrementAndCheck atomically increments and checks if request is allowed.
-// This uses a Lua script to ensure atomicity.
-func (r *RedisRateLimiter) IncrementAndCheck(ctx context.Context, userID string, tier valueobject.RateLimitTier) (bool, int, time.Time, error) {
-	// Use tier name as the window identifier to separate different tiers
-	key := r.keyBuilder.RateLimitCounter(userID, tier.Name())
-	maxRequests := tier.MaxRequests()
-	windowSeconds := int(tier.Window().Seconds())
-
-	script := redis.NewScript(`
-		local key = KEYS[1]
-		local max_requests = tonumber(ARGV[1])
-		local window_seconds = tonumber(ARGV[2])
-		
-		local count = redis.call('INCR', key)
-		local ttl = redis.call('TTL', key)
-		
-		if ttl == -1 then
-			redis.call('EXPIRE', key, window_seconds)
-			ttl = window_seconds
-		end
-		
-		return {count, ttl}
-	`)
-
-	result, err := script.Run(ctx, r.client, []string{key}, maxRequests, windowSeconds).Result()
+// IncrementAndCheck atomically checks if a request is allowed under the limiter's algorithm
+// and, if it is, counts it with its cost. Denied requests are not counted.
+func (r *RedisRateLimiter) IncrementAndCheck(ctx context.Context, userID string, tier valueobject.RateLimitTier, class valueobject.RouteClass, cost int) (bool, int, time.Time, error) {
+	// Each tier, route class and algorithm has its own key, as the algorithms store different types
+	key := r.keyBuilder.RateLimitCounter(userID, tier.Name()+":"+class.String()+":"+r.algorithm.String())
+
+	// Only the sliding log needs an ID, to tell apart requests made in the same microsecond
+	requestID := ""
+	if r.algorithm == valueobject.AlgorithmSlidingLog {
+		requestID = uuid.NewString()
+	}
+
+	// Run sends EVALSHA, and only sends the script itself if Redis doesn't have it
+	result, err := r.script.Run(ctx, r.client, []string{key}, tier.MaxRequests(), tier.Window().Microseconds(), cost, tier.Burst(), requestID).Int64Slice()
 	if err != nil {
 		return false, 0, time.Time{}, fmt.Errorf("failed to increment rate limit: %w", err)
 	}
-
-	resultSlice := result.([]interface{})
-	count := int(resultSlice[0].(int64))
-	ttl := int(resultSlice[1].(int64))
-
-	allowed := count <= maxRequests
-	remaining := maxRequests - count
-	if remaining < 0 {
-		remaining = 0
+	if len(result) != 3 {
+		return false, 0, time.Time{}, fmt.Errorf("unexpected rate limit script result: %v", result)
 	}
 
-	resetTime := time.Now().Add(time.Duration(ttl) * time.Second)
+	allowed := result[0] == 1
+	remaining := max(int(result[1]), 0)
+	resetTime := time.Now().Add(time.Duration(result[2]) * time.Microsecond)
 
 	return allowed, remaining, resetTime, nil
 }
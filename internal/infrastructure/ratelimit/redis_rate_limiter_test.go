@@ -0,0 +1,256 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+	"upwork-test/internal/domain/ratelimit/valueobject"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLimiter returns a limiter for algorithm on the test Redis, with its script loaded
+func newTestLimiter(t *testing.T, algorithm valueobject.RateLimitAlgorithm) *RedisRateLimiter {
+	t.Helper()
+
+	limiter, err := NewRedisRateLimiter(testRedisClient(t), algorithm)
+	require.NoError(t, err)
+	require.NoError(t, limiter.LoadScript(context.Background()))
+	return limiter
+}
+
+// rateLimitStep is one request of a test client and its expected outcome
+type rateLimitStep struct {
+	cost          int
+	wantAllowed   bool
+	wantRemaining int
+}
+
+func TestRedisRateLimiterAllowsAndDenies(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []rateLimitStep
+	}{
+		{
+			name: "requests up to the limit",
+			steps: []rateLimitStep{
+				{cost: 1, wantAllowed: true, wantRemaining: 4},
+				{cost: 1, wantAllowed: true, wantRemaining: 3},
+				{cost: 1, wantAllowed: true, wantRemaining: 2},
+				{cost: 1, wantAllowed: true, wantRemaining: 1},
+				{cost: 1, wantAllowed: true, wantRemaining: 0},
+				{cost: 1, wantAllowed: false, wantRemaining: 0},
+				// Denied requests are not counted
+				{cost: 1, wantAllowed: false, wantRemaining: 0},
+			},
+		},
+		{
+			name: "costly requests",
+			steps: []rateLimitStep{
+				{cost: 3, wantAllowed: true, wantRemaining: 2},
+				{cost: 3, wantAllowed: false, wantRemaining: 2},
+				{cost: 2, wantAllowed: true, wantRemaining: 0},
+				{cost: 1, wantAllowed: false, wantRemaining: 0},
+			},
+		},
+		{
+			name: "request costing more than the limit",
+			steps: []rateLimitStep{
+				{cost: 6, wantAllowed: false, wantRemaining: 5},
+				{cost: 5, wantAllowed: true, wantRemaining: 0},
+			},
+		},
+	}
+
+	tier, err := valueobject.DefineRateLimitTier("test", 5, time.Minute, 0)
+	require.NoError(t, err)
+
+	for _, algorithm := range valueobject.RateLimitAlgorithms() {
+		t.Run(algorithm.String(), func(t *testing.T) {
+			limiter := newTestLimiter(t, algorithm)
+			ctx := context.Background()
+
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					userID := "test-" + uuid.NewString()
+					for i, step := range tt.steps {
+						allowed, remaining, resetTime, err := limiter.IncrementAndCheck(ctx, userID, tier, valueobject.RouteClassRead, step.cost)
+						require.NoError(t, err)
+						assert.Equal(t, step.wantAllowed, allowed, "request %d allowed", i)
+						assert.Equal(t, step.wantRemaining, remaining, "request %d remaining", i)
+
+						// Allowed requests report when the limit resets, denied ones when to retry;
+						// neither is more than a window away
+						wait := time.Until(resetTime)
+						assert.Greater(t, wait, time.Duration(0), "request %d reset", i)
+						assert.LessOrEqual(t, wait, tier.Window(), "request %d reset", i)
+					}
+
+					// Other clients have their own limit
+					allowed, _, _, err := limiter.IncrementAndCheck(ctx, userID+"-other", tier, valueobject.RouteClassRead, 1)
+					require.NoError(t, err)
+					assert.True(t, allowed)
+				})
+			}
+		})
+	}
+}
+
+func TestRedisRateLimiterRetryAfter(t *testing.T) {
+	tests := []struct {
+		algorithm valueobject.RateLimitAlgorithm
+		// exact is set when a request retried at the reported time is allowed. The sliding
+		// window only reports the earliest time, as the previous window still weighs then.
+		exact bool
+	}{
+		{algorithm: valueobject.AlgorithmFixedWindow, exact: true},
+		{algorithm: valueobject.AlgorithmSlidingLog, exact: true},
+		{algorithm: valueobject.AlgorithmSlidingWindow},
+		{algorithm: valueobject.AlgorithmGCRA, exact: true},
+	}
+
+	tier, err := valueobject.DefineRateLimitTier("test", 2, time.Second, 0)
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm.String(), func(t *testing.T) {
+			limiter := newTestLimiter(t, tt.algorithm)
+			ctx := context.Background()
+			userID := "test-" + uuid.NewString()
+
+			for i := 0; i < 2; i++ {
+				allowed, _, _, err := limiter.IncrementAndCheck(ctx, userID, tier, valueobject.RouteClassRead, 1)
+				require.NoError(t, err)
+				require.True(t, allowed)
+			}
+			allowed, _, retryAt, err := limiter.IncrementAndCheck(ctx, userID, tier, valueobject.RouteClassRead, 1)
+			require.NoError(t, err)
+			require.False(t, allowed)
+			require.LessOrEqual(t, time.Until(retryAt), tier.Window())
+
+			// Retrying at the reported time, with a margin for rounding, is allowed; the
+			// sliding window may report a later time again, but never past the next window
+			deadline := time.Now().Add(2 * tier.Window())
+			for {
+				time.Sleep(time.Until(retryAt) + 20*time.Millisecond)
+				allowed, _, retryAt, err = limiter.IncrementAndCheck(ctx, userID, tier, valueobject.RouteClassRead, 1)
+				require.NoError(t, err)
+				if allowed || tt.exact {
+					break
+				}
+				require.True(t, time.Now().Before(deadline), "still denied after two windows")
+			}
+			assert.True(t, allowed)
+		})
+	}
+}
+
+// benchmarkCase is a load on one client, under a tier that allows or denies its requests
+type benchmarkCase struct {
+	name     string
+	limit    int
+	parallel bool
+}
+
+var benchmarkCases = []benchmarkCase{
+	// Requests within the limit are counted
+	{name: "allowed", limit: 1_000_000_000},
+	// Requests over the limit are only checked
+	{name: "denied", limit: 1},
+	// Concurrent requests of one client contend for its key
+	{name: "allowed-parallel", limit: 1_000_000_000, parallel: true},
+}
+
+func BenchmarkFixedWindow(b *testing.B) {
+	benchmarkAlgorithm(b, valueobject.AlgorithmFixedWindow)
+}
+
+func BenchmarkSlidingLog(b *testing.B) {
+	benchmarkAlgorithm(b, valueobject.AlgorithmSlidingLog)
+}
+
+func BenchmarkSlidingWindow(b *testing.B) {
+	benchmarkAlgorithm(b, valueobject.AlgorithmSlidingWindow)
+}
+
+func BenchmarkGCRA(b *testing.B) {
+	benchmarkAlgorithm(b, valueobject.AlgorithmGCRA)
+}
+
+// benchmarkAlgorithm runs every benchmark case against the Redis at REDIS_HOST and REDIS_PORT
+// (localhost:6379 by default), skipping when it cannot be reached. Every run uses fresh keys,
+// which expire after a minute.
+func benchmarkAlgorithm(b *testing.B, algorithm valueobject.RateLimitAlgorithm) {
+	client := testRedisClient(b)
+	ctx := context.Background()
+
+	limiter, err := NewRedisRateLimiter(client, algorithm)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := limiter.LoadScript(ctx); err != nil {
+		b.Fatal(err)
+	}
+
+	for _, benchmark := range benchmarkCases {
+		b.Run(benchmark.name, func(b *testing.B) {
+			tier, err := valueobject.DefineRateLimitTier("bench", benchmark.limit, time.Minute, 0)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			userID := "bench-" + uuid.NewString()
+			b.ReportAllocs()
+			if benchmark.parallel {
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						if _, _, _, err := limiter.IncrementAndCheck(ctx, userID, tier, valueobject.RouteClassRead, 1); err != nil {
+							b.Error(err)
+							return
+						}
+					}
+				})
+				return
+			}
+			for i := 0; i < b.N; i++ {
+				if _, _, _, err := limiter.IncrementAndCheck(ctx, userID, tier, valueobject.RouteClassRead, 1); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// testRedisClient connects to the Redis at REDIS_HOST and REDIS_PORT (localhost:6379 by
+// default), skipping the test or benchmark if it is unreachable. Callers use fresh keys, so
+// no database is flushed.
+func testRedisClient(tb testing.TB) *redis.Client {
+	tb.Helper()
+
+	addr := net.JoinHostPort(getEnv("REDIS_HOST", "localhost"), getEnv("REDIS_PORT", "6379"))
+	client := redis.NewClient(&redis.Options{
+		Addr:        addr,
+		Password:    os.Getenv("REDIS_PASSWORD"),
+		DialTimeout: time.Second,
+		// Fail fast when Redis is not running, so the test is skipped quickly
+		DialerRetries: 1,
+	})
+	tb.Cleanup(func() { client.Close() })
+
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		tb.Skipf("Redis unreachable at %s: %v", addr, err)
+	}
+	return client
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
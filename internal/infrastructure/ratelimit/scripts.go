@@ -0,0 +1,144 @@
+package ratelimit
+
+import (
+	"upwork-test/internal/domain/ratelimit/valueobject"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Every script takes the tier's limit, its window in microseconds, the request's cost, the
+// tier's burst and a unique request ID as ARGV, and returns whether the request was allowed,
+// the remaining capacity and, in microseconds, when the limit resets (allowed) or when the
+// request could be retried (denied). Denied requests are not counted. Scripts that track time
+// read it from Redis, so all API processes share one clock.
+
+// timePrelude reads the arguments and the Redis clock, in microseconds
+const timePrelude = `
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local cost = tonumber(ARGV[3])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+`
+
+// fixedWindowScript counts requests in a counter that expires with the window
+var fixedWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local cost = tonumber(ARGV[3])
+
+local count = tonumber(redis.call('GET', KEYS[1]) or '0')
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl < 0 then
+	ttl = math.ceil(window / 1000)
+end
+
+if count + cost > limit then
+	return {0, limit - count, ttl * 1000}
+end
+
+count = redis.call('INCRBY', KEYS[1], cost)
+if redis.call('PTTL', KEYS[1]) < 0 then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+end
+
+return {1, limit - count, ttl * 1000}
+`)
+
+// slidingLogScript keeps a sorted set of the requests of the last window, scored by time.
+// A request of cost n adds n entries.
+var slidingLogScript = redis.NewScript(timePrelude + `
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+
+if count + cost > limit then
+	-- Retry once enough of the oldest entries have left the window
+	local retry = window
+	if cost <= limit then
+		local index = count + cost - limit - 1
+		local entry = redis.call('ZRANGE', KEYS[1], index, index, 'WITHSCORES')
+		if entry[2] then
+			retry = tonumber(entry[2]) + window - now
+		end
+	end
+	return {0, limit - count, retry}
+end
+
+for i = 1, cost do
+	redis.call('ZADD', KEYS[1], now, ARGV[5] .. ':' .. i)
+end
+redis.call('PEXPIRE', KEYS[1], math.ceil(window / 1000))
+
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+return {1, limit - count - cost, tonumber(oldest[2]) + window - now}
+`)
+
+// slidingWindowScript keeps the counts of the current and previous fixed windows in a hash,
+// and weighs the previous count by how much of the previous window the last window overlaps
+var slidingWindowScript = redis.NewScript(timePrelude + `
+local current = math.floor(now / window)
+local elapsed = now - current * window
+
+local state = redis.call('HMGET', KEYS[1], 'w', 'c', 'p')
+local stored = tonumber(state[1])
+local count = tonumber(state[2]) or 0
+local previous = tonumber(state[3]) or 0
+if stored ~= current then
+	if stored == current - 1 then
+		previous = count
+	else
+		previous = 0
+	end
+	count = 0
+end
+
+local weight = (window - elapsed) / window
+local estimate = previous * weight + count
+
+if estimate + cost > limit then
+	-- Retry once the previous window weighs little enough, or at the next window at the earliest
+	local retry = window - elapsed
+	if previous > 0 and count + cost <= limit then
+		retry = math.ceil((weight - (limit - count - cost) / previous) * window)
+	end
+	return {0, math.floor(limit - estimate), retry}
+end
+
+count = count + cost
+redis.call('HSET', KEYS[1], 'w', current, 'c', count, 'p', previous)
+redis.call('PEXPIRE', KEYS[1], math.ceil(2 * window / 1000))
+
+return {1, math.floor(limit - previous * weight - count), window - elapsed}
+`)
+
+// gcraScript stores the theoretical arrival time (TAT) of the next request. Each unit of cost
+// pushes it one emission interval (window / limit) later, and a request is allowed unless that
+// would put it more than burst intervals ahead of now.
+var gcraScript = redis.NewScript(timePrelude + `
+local burst = tonumber(ARGV[4])
+local interval = window / limit
+local capacity = interval * burst
+
+local tat = tonumber(redis.call('GET', KEYS[1])) or now
+if tat < now then
+	tat = now
+end
+
+local next_tat = tat + interval * cost
+local allow_at = next_tat - capacity
+if allow_at > now then
+	return {0, math.floor((now - tat + capacity) / interval), math.ceil(allow_at - now)}
+end
+
+redis.call('SET', KEYS[1], next_tat, 'PX', math.ceil((next_tat - now) / 1000))
+
+return {1, math.floor((now - next_tat + capacity) / interval), math.ceil(next_tat - now)}
+`)
+
+// rateLimitScripts maps each algorithm to its script
+var rateLimitScripts = map[valueobject.RateLimitAlgorithm]*redis.Script{
+	valueobject.AlgorithmFixedWindow:   fixedWindowScript,
+	valueobject.AlgorithmSlidingLog:    slidingLogScript,
+	valueobject.AlgorithmSlidingWindow: slidingWindowScript,
+	valueobject.AlgorithmGCRA:          gcraScript,
+}